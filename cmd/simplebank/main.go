@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/RobinHood3082/simplebank/internal/app"
 	"github.com/RobinHood3082/simplebank/internal/gapi"
@@ -17,7 +18,9 @@ import (
 	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/internal/token"
 	"github.com/RobinHood3082/simplebank/mail"
+	"github.com/RobinHood3082/simplebank/risk"
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
 	"github.com/RobinHood3082/simplebank/worker"
 	"github.com/go-playground/validator/v10"
 	"github.com/golang-migrate/migrate/v4"
@@ -77,16 +80,41 @@ func main() {
 		return
 	}
 
+	// the asymmetric makers sign with the keys rotated by runTokenKeyRotator
+	tokenKeys := token.NewKeySet(2 * config.TokenKeyRefreshInterval)
+	var tokenKeyAlgorithm string
+
 	var tokenMaker token.Maker
 	switch config.TokenType {
 	case "jwt":
-		tokenMaker, err = token.NewJWTMaker(config.TokenSymmetricKey)
+		tokenMaker, err = token.NewJWTMaker(config.TokenSymmetricKey, config.TokenIssuer, config.TokenAudience)
 		if err != nil {
 			log.Fatal("cannot create token maker:", err)
 			return
 		}
 	case "paseto":
-		tokenMaker, err = token.NewPasetoMaker(config.TokenSymmetricKey)
+		tokenMaker, err = token.NewPasetoMaker(config.TokenSymmetricKey, config.TokenIssuer, config.TokenAudience)
+		if err != nil {
+			log.Fatal("cannot create token maker:", err)
+			return
+		}
+	case "paseto_public":
+		tokenKeyAlgorithm = token.EdDSAAlgorithm
+		tokenMaker, err = token.NewAsymmetricPasetoMaker(tokenKeys, config.TokenIssuer, config.TokenAudience)
+		if err != nil {
+			log.Fatal("cannot create token maker:", err)
+			return
+		}
+	case "jwt_eddsa":
+		tokenKeyAlgorithm = token.EdDSAAlgorithm
+		tokenMaker, err = token.NewAsymmetricJWTMaker(tokenKeys, token.EdDSAAlgorithm, config.TokenIssuer, config.TokenAudience)
+		if err != nil {
+			log.Fatal("cannot create token maker:", err)
+			return
+		}
+	case "jwt_es256":
+		tokenKeyAlgorithm = token.ES256Algorithm
+		tokenMaker, err = token.NewAsymmetricJWTMaker(tokenKeys, token.ES256Algorithm, config.TokenIssuer, config.TokenAudience)
 		if err != nil {
 			log.Fatal("cannot create token maker:", err)
 			return
@@ -96,14 +124,72 @@ func main() {
 		return
 	}
 
+	var passwordHasher util.PasswordHasher
+	switch config.PasswordHashAlgorithm {
+	case util.Argon2idAlgorithm:
+		passwordHasher, err = util.NewArgon2idHasher(config.Argon2Memory, config.Argon2Time, config.Argon2Parallelism)
+		if err != nil {
+			log.Fatal("cannot create password hasher:", err)
+			return
+		}
+	case util.BcryptAlgorithm:
+		passwordHasher, err = util.NewBcryptHasher(config.BcryptCost)
+		if err != nil {
+			log.Fatal("cannot create password hasher:", err)
+			return
+		}
+	default:
+		log.Fatal("unknown password hash algorithm")
+		return
+	}
+
 	logger := slog.Default()
 	store := persistence.NewStore(conn)
 
+	err = store.RefreshCurrencies(ctx)
+	if err != nil {
+		log.Fatal("cannot load currencies:", err)
+		return
+	}
+
+	err = store.RefreshRoles(ctx)
+	if err != nil {
+		log.Fatal("cannot load roles:", err)
+		return
+	}
+
+	tokenKeyRotation := persistence.RotateTokenSigningKeysParams{
+		Keys:             tokenKeys,
+		Algorithm:        tokenKeyAlgorithm,
+		EncryptionKey:    []byte(config.TokenSymmetricKey),
+		RotationInterval: config.TokenKeyRotationInterval,
+		// a key signs until its successor is made, up to a refresh later, and activated two refreshes after that;
+		// the last token it signs lives as long as a refresh token
+		KeyLifetime: config.TokenKeyRotationInterval + 3*config.TokenKeyRefreshInterval + max(config.AccessTokenDuration, config.RefreshTokenDuration),
+	}
+
+	if tokenKeyAlgorithm != "" {
+		err = store.RotateTokenSigningKeys(ctx, tokenKeyRotation)
+		if err != nil {
+			log.Fatal("cannot load token signing keys:", err)
+			return
+		}
+	}
+
+	riskEngine := newRiskEngine(config)
+
 	waitGroup, ctx := errgroup.WithContext(ctx)
 
-	runTaskProcessor(ctx, waitGroup, redisOpt, store, config)
-	runGatewayServer(ctx, waitGroup, store, logger, tokenMaker, config, taskDistributor)
-	runGRPCServer(ctx, waitGroup, store, logger, tokenMaker, config, taskDistributor)
+	runCurrencyRefresher(ctx, waitGroup, store, config)
+	runRoleRefresher(ctx, waitGroup, store, config)
+	runFailedLoginPruner(ctx, waitGroup, store, config)
+	if tokenKeyAlgorithm != "" {
+		runTokenKeyRotator(ctx, waitGroup, store, config, tokenKeyRotation)
+	}
+	runSpendingSummaryScheduler(ctx, waitGroup, config, taskDistributor)
+	runTaskProcessor(ctx, waitGroup, redisOpt, store, config, taskDistributor, riskEngine)
+	runGatewayServer(ctx, waitGroup, store, logger, tokenMaker, passwordHasher, config, taskDistributor, riskEngine)
+	runGRPCServer(ctx, waitGroup, store, logger, tokenMaker, passwordHasher, config, taskDistributor, riskEngine)
 
 	err = waitGroup.Wait()
 	if err != nil {
@@ -129,19 +215,171 @@ func runDBMigrations(migrationURL string, dbSource string) error {
 	return nil
 }
 
+func runCurrencyRefresher(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	store persistence.Store,
+	config util.Config,
+) {
+	waitGroup.Go(
+		func() error {
+			ticker := time.NewTicker(config.CurrencyRefreshInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					err := store.RefreshCurrencies(ctx)
+					if err != nil && ctx.Err() == nil {
+						log.Println("cannot refresh currencies:", err)
+					}
+				}
+			}
+		},
+	)
+}
+
+// runRoleRefresher periodically reloads the role permissions to pick up changes made in the database
+func runRoleRefresher(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	store persistence.Store,
+	config util.Config,
+) {
+	waitGroup.Go(
+		func() error {
+			ticker := time.NewTicker(config.RoleRefreshInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					err := store.RefreshRoles(ctx)
+					if err != nil && ctx.Err() == nil {
+						log.Println("cannot refresh roles:", err)
+					}
+				}
+			}
+		},
+	)
+}
+
+// runFailedLoginPruner periodically forgets the failed logins that no longer count towards any throttle
+func runFailedLoginPruner(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	store persistence.Store,
+	config util.Config,
+) {
+	policy := persistence.LoginPolicy{
+		MaxFailedAttempts:      config.LoginMaxFailedAttempts,
+		MaxFailedAttemptsPerIP: config.LoginMaxFailedAttemptsPerIP,
+		FailureWindow:          config.LoginFailureWindow,
+		BaseDelay:              config.LoginBaseDelay,
+		LockoutDuration:        config.LoginLockoutDuration,
+	}
+
+	waitGroup.Go(
+		func() error {
+			ticker := time.NewTicker(config.LoginFailureWindow)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					err := store.PruneFailedLogins(ctx, policy)
+					if err != nil && ctx.Err() == nil {
+						log.Println("cannot prune failed logins:", err)
+					}
+				}
+			}
+		},
+	)
+}
+
+// runTokenKeyRotator periodically reloads the token signing keys and makes a new key when the newest one
+// is due for rotation
+func runTokenKeyRotator(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	store persistence.Store,
+	config util.Config,
+	rotation persistence.RotateTokenSigningKeysParams,
+) {
+	waitGroup.Go(
+		func() error {
+			ticker := time.NewTicker(config.TokenKeyRefreshInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					err := store.RotateTokenSigningKeys(ctx, rotation)
+					if err != nil && ctx.Err() == nil {
+						log.Println("cannot rotate token signing keys:", err)
+					}
+				}
+			}
+		},
+	)
+}
+
+// runSpendingSummaryScheduler periodically queues the job that categorizes new transfers
+// and refreshes the spending summaries. Every instance runs it; the distributor merges duplicate requests.
+func runSpendingSummaryScheduler(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	taskDistributor worker.TaskDistributor,
+) {
+	waitGroup.Go(
+		func() error {
+			ticker := time.NewTicker(config.SpendingSummaryRefreshInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					err := taskDistributor.DistributeSpendingSummaryRefresh(ctx)
+					if err != nil && ctx.Err() == nil {
+						log.Println("cannot queue spending summary refresh:", err)
+					}
+				}
+			}
+		},
+	)
+}
+
 func runTaskProcessor(
 	ctx context.Context,
 	waitGroup *errgroup.Group,
 	redisOpt asynq.RedisClientOpt,
 	store persistence.Store,
 	config util.Config,
+	taskDistributor worker.TaskDistributor,
+	riskEngine *risk.Engine,
 ) {
 	mailer := mail.NewGmailSender(
 		config.EmailSenderName,
 		config.EmailSenderAddress,
 		config.EmailSenderPassword,
 	)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	webhookSender := webhook.NewHTTPSender(config.WebhookTimeout)
+	beneficiaryLimit := persistence.BeneficiaryLimit{
+		CoolingOffPeriod: config.BeneficiaryCoolingOffPeriod,
+		Units:            config.NewBeneficiaryTransferLimit,
+	}
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, webhookSender, taskDistributor, riskEngine, beneficiaryLimit)
 	log.Println("task processor starting")
 
 	err := taskProcessor.Start()
@@ -166,10 +404,12 @@ func runGRPCServer(
 	store persistence.Store,
 	logger *slog.Logger,
 	tokenMaker token.Maker,
+	passwordHasher util.PasswordHasher,
 	config util.Config,
 	taskDistributor worker.TaskDistributor,
+	riskEngine *risk.Engine,
 ) {
-	server := gapi.NewServer(store, logger, tokenMaker, config, taskDistributor)
+	server := gapi.NewServer(store, logger, tokenMaker, passwordHasher, config, taskDistributor, riskEngine)
 
 	grpcServer := grpc.NewServer()
 	pb.RegisterSimpleBankServer(grpcServer, server)
@@ -215,19 +455,24 @@ func runGatewayServer(
 	store persistence.Store,
 	logger *slog.Logger,
 	tokenMaker token.Maker,
+	passwordHasher util.PasswordHasher,
 	config util.Config,
 	taskDistributor worker.TaskDistributor,
+	riskEngine *risk.Engine,
 ) {
-	server := gapi.NewServer(store, logger, tokenMaker, config, taskDistributor)
+	server := gapi.NewServer(store, logger, tokenMaker, passwordHasher, config, taskDistributor, riskEngine)
 
+	// google.api.HttpBody responses, such as file downloads, are written as they are rather than as JSON
 	jsonOption := runtime.WithMarshalerOption(
 		runtime.MIMEWildcard,
-		&runtime.JSONPb{
-			MarshalOptions: protojson.MarshalOptions{
-				UseProtoNames: true,
-			},
-			UnmarshalOptions: protojson.UnmarshalOptions{
-				DiscardUnknown: true,
+		&runtime.HTTPBodyMarshaler{
+			Marshaler: &runtime.JSONPb{
+				MarshalOptions: protojson.MarshalOptions{
+					UseProtoNames: true,
+				},
+				UnmarshalOptions: protojson.UnmarshalOptions{
+					DiscardUnknown: true,
+				},
 			},
 		},
 	)
@@ -240,6 +485,19 @@ func runGatewayServer(
 		return
 	}
 
+	// registered after the generated routes so it takes precedence over the non-streaming handler
+	err = grpcMux.HandlePath(http.MethodGet, "/api/v1/export_statement", server.ExportStatementHandler(grpcMux))
+	if err != nil {
+		log.Fatal("cannot register export statement handler:", err)
+		return
+	}
+
+	err = grpcMux.HandlePath(http.MethodGet, "/.well-known/jwks.json", server.JWKSHandler())
+	if err != nil {
+		log.Fatal("cannot register JWKS handler:", err)
+		return
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
 
@@ -289,8 +547,8 @@ func runGatewayServer(
 	)
 }
 
-func runHTTPServer(store persistence.Store, logger *slog.Logger, validate *validator.Validate, tokenMaker token.Maker, config util.Config) error {
-	server := app.NewServer(store, logger, validate, tokenMaker, config)
+func runHTTPServer(store persistence.Store, logger *slog.Logger, validate *validator.Validate, tokenMaker token.Maker, passwordHasher util.PasswordHasher, config util.Config, taskDistributor worker.TaskDistributor) error {
+	server := app.NewServer(store, logger, validate, tokenMaker, passwordHasher, config, taskDistributor, newRiskEngine(config))
 
 	err := server.Start(config.HTTPServerAddress)
 	if err != nil {
@@ -300,3 +558,13 @@ func runHTTPServer(store persistence.Store, logger *slog.Logger, validate *valid
 
 	return nil
 }
+
+// newRiskEngine sets up the built-in rules transfers are checked with before they are made
+func newRiskEngine(config util.Config) *risk.Engine {
+	return risk.NewEngine(
+		risk.Velocity{MaxTransfersPerHour: config.RiskMaxTransfersPerHour},
+		risk.NewCounterparty{Threshold: config.RiskNewCounterpartyThreshold},
+		risk.CredentialChange{Window: config.RiskCredentialChangeHold},
+		risk.NewLoginIP{Window: config.RiskNewLoginIPWindow},
+	)
+}
@@ -8,8 +8,10 @@ CREATE TABLE "users" (
   "hashed_password" varchar NOT NULL,
   "full_name" varchar NOT NULL,
   "email" varchar UNIQUE NOT NULL,
+  "handle" varchar UNIQUE,
   "is_email_verified" boolean NOT NULL DEFAULT false,
   "password_changed_at" timestamptz NOT NULL DEFAULT (0001-01-01 00:00:00Z),
+  "email_changed_at" timestamptz NOT NULL DEFAULT (0001-01-01 00:00:00Z),
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -23,8 +25,17 @@ CREATE TABLE "verify_emails" (
   "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
 );
 
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "exponent" int NOT NULL,
+  "is_enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
 CREATE TABLE "accounts" (
   "id" bigserial PRIMARY KEY,
+  "account_number" varchar UNIQUE NOT NULL,
   "owner" varchar NOT NULL,
   "balance" bigint NOT NULL,
   "currency" varchar NOT NULL,
@@ -35,7 +46,9 @@ CREATE TABLE "entries" (
   "id" bigserial PRIMARY KEY,
   "account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "pocket_id" bigint,
+  "transfer_id" bigint
 );
 
 CREATE TABLE "transfers" (
@@ -43,13 +56,13 @@ CREATE TABLE "transfers" (
   "from_account_id" bigint NOT NULL,
   "to_account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "reference" varchar NOT NULL DEFAULT ''
 );
 
 CREATE TABLE "sessions" (
   "id" varchar PRIMARY KEY,
   "username" varchar NOT NULL,
-  "refresh_token" varchar NOT NULL,
   "user_agent" varchar NOT NULL,
   "client_ip" varchar NOT NULL,
   "is_blocked" boolean NOT NULL DEFAULT false,
@@ -57,6 +70,308 @@ CREATE TABLE "sessions" (
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
+CREATE TABLE "account_members" (
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "username")
+);
+
+CREATE TABLE "account_invitations" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "invited_by" varchar NOT NULL,
+  "secret_code_hash" varchar NOT NULL DEFAULT '',
+  "is_accepted" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '7 days')
+);
+
+CREATE TABLE "webhooks" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "webhook_id" bigint NOT NULL,
+  "event_id" varchar NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_code" int,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "last_attempt_at" timestamptz,
+  "replay_of" bigint
+);
+
+CREATE TABLE "pockets" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "balance" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "beneficiaries" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "payment_requests" (
+  "id" bigserial PRIMARY KEY,
+  "requester" varchar NOT NULL,
+  "payer" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '7 days')
+);
+
+CREATE TABLE "categories" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "category_rules" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "counterparty_account_id" bigint,
+  "keyword" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_categories" (
+  "transfer_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "rule_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("transfer_id", "username")
+);
+
+CREATE TABLE "payment_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "message_id" varchar NOT NULL,
+  "message_name" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "confirmed_at" timestamptz,
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "payment_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "payment_information_id" varchar NOT NULL,
+  "instruction_id" varchar NOT NULL,
+  "end_to_end_id" varchar NOT NULL,
+  "from_account_number" varchar NOT NULL,
+  "from_account_id" bigint,
+  "to_account_number" varchar NOT NULL,
+  "to_account_id" bigint,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "reference" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "reason_code" varchar NOT NULL DEFAULT '',
+  "reason" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint
+);
+
+CREATE TABLE "transfer_risk_assessments" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "reference" varchar NOT NULL DEFAULT '',
+  "requested_by" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "decision" varchar NOT NULL,
+  "findings" jsonb NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "disputes" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "account_id" bigint NOT NULL,
+  "opened_by" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open',
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "credit_entry_id" bigint,
+  "chargeback_entry_id" bigint,
+  "reversal_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz
+);
+
+CREATE TABLE "dispute_attachments" (
+  "id" bigserial PRIMARY KEY,
+  "dispute_id" bigint NOT NULL,
+  "file_name" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "content" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "dispute_events" (
+  "id" bigserial PRIMARY KEY,
+  "dispute_id" bigint NOT NULL,
+  "status" varchar NOT NULL,
+  "actor" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "approval_requests" (
+  "id" bigserial PRIMARY KEY,
+  "operation" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "requested_by" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "reviewed_by" varchar,
+  "review_note" varchar NOT NULL DEFAULT '',
+  "reviewed_at" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_limits" (
+  "account_id" bigint PRIMARY KEY,
+  "max_transfers_per_hour" bigint,
+  "new_counterparty_threshold" bigint,
+  "new_beneficiary_transfer_limit" bigint,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "refresh_tokens" (
+  "id" uuid PRIMARY KEY,
+  "session_id" uuid NOT NULL,
+  "token_hash" varchar NOT NULL,
+  "rotated_at" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "totp_enrollments" (
+  "username" varchar PRIMARY KEY,
+  "secret" varchar NOT NULL,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "confirmed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "mfa_challenges" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "attempts" int NOT NULL DEFAULT 0,
+  "expires_at" timestamptz NOT NULL,
+  "completed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "password_resets" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "email" varchar NOT NULL,
+  "secret_code_hash" varchar NOT NULL,
+  "is_used" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+CREATE TABLE "login_throttles" (
+  "username" varchar PRIMARY KEY,
+  "failed_attempts" int NOT NULL DEFAULT 0,
+  "last_failed_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  "last_attempt_at" timestamptz
+);
+
+CREATE TABLE "failed_logins" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "token_signing_keys" (
+  "kid" varchar PRIMARY KEY,
+  "algorithm" varchar NOT NULL,
+  "sealed_private_key" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL
+);
+
+CREATE TABLE "api_keys" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "prefix" varchar UNIQUE NOT NULL,
+  "secret_hash" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "allowed_ips" varchar[] NOT NULL DEFAULT '{}',
+  "last_used_at" timestamptz,
+  "last_used_ip" varchar,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "roles" (
+  "name" varchar PRIMARY KEY,
+  "description" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "role_permissions" (
+  "role" varchar NOT NULL,
+  "permission" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "permission")
+);
+
 CREATE INDEX ON "verify_emails" ("username");
 
 CREATE UNIQUE INDEX ON "verify_emails" ("username", "email");
@@ -67,20 +382,252 @@ CREATE UNIQUE INDEX ON "accounts" ("owner", "currency");
 
 CREATE INDEX ON "entries" ("account_id");
 
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
 CREATE INDEX ON "transfers" ("from_account_id");
 
 CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "transfers" ("from_account_id", "to_account_id", "created_at");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at");
+
+CREATE INDEX "transfers_reference_trgm_idx" ON "transfers" USING GIN ("reference" gin_trgm_ops);
+
+CREATE INDEX ON "account_members" ("username");
+
+CREATE INDEX ON "account_invitations" ("account_id");
+
+CREATE INDEX ON "pockets" ("account_id");
+
+CREATE UNIQUE INDEX ON "pockets" ("account_id", "name");
+
+CREATE INDEX ON "beneficiaries" ("owner");
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("owner", "nickname");
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("owner", "account_id");
+
+CREATE INDEX ON "payment_requests" ("requester");
+
+CREATE INDEX ON "payment_requests" ("payer");
+
+CREATE INDEX ON "webhooks" ("owner");
+
+CREATE INDEX ON "webhook_deliveries" ("webhook_id");
+
+CREATE UNIQUE INDEX "webhook_event_key" ON "webhook_deliveries" ("webhook_id", "event_id") WHERE "replay_of" IS NULL;
+
+CREATE UNIQUE INDEX ON "categories" ("owner", "name");
+
+CREATE INDEX ON "category_rules" ("owner");
+
+CREATE INDEX ON "payment_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "payment_batches" ("owner", "message_id");
+
+CREATE INDEX ON "payment_batch_items" ("batch_id");
+
+CREATE INDEX ON "transfer_risk_assessments" ("from_account_id");
+
+CREATE INDEX ON "transfer_risk_assessments" ("status");
+
+CREATE UNIQUE INDEX ON "disputes" ("transfer_id");
+
+CREATE INDEX ON "disputes" ("opened_by");
+
+CREATE INDEX ON "disputes" ("status");
+
+CREATE INDEX ON "dispute_attachments" ("dispute_id");
+
+CREATE INDEX ON "dispute_events" ("dispute_id");
+
+CREATE INDEX ON "approval_requests" ("status");
+
+CREATE INDEX ON "approval_requests" ("requested_by");
+
+CREATE INDEX ON "refresh_tokens" ("session_id");
+
+CREATE INDEX ON "recovery_codes" ("username");
+
+CREATE INDEX ON "mfa_challenges" ("username");
+
+CREATE INDEX ON "password_resets" ("username");
+
+CREATE INDEX ON "failed_logins" ("client_ip", "created_at");
+
+CREATE INDEX ON "failed_logins" ("created_at");
+
+CREATE INDEX ON "token_signing_keys" ("expires_at");
+
+CREATE INDEX ON "api_keys" ("owner");
+
+COMMENT ON COLUMN "users"."handle" IS 'user-chosen payment handle, stored lowercase without the leading @';
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."exponent" IS 'number of decimal places of the minor unit, fixed once accounts hold amounts in it';
+
+COMMENT ON COLUMN "currencies"."is_enabled" IS 'disabled currencies cannot be used to open new accounts';
+
+COMMENT ON COLUMN "accounts"."account_number" IS 'customer-facing identifier: bank code, mod-97 check digits and a random 10 digit body';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or zero';
 
+COMMENT ON COLUMN "entries"."pocket_id" IS 'set when the entry moves money into or out of a pocket';
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'set when the entry is one side of a transfer';
+
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+COMMENT ON COLUMN "transfers"."reference" IS 'free text set by the sender, e.g. an invoice number';
+
+COMMENT ON COLUMN "account_members"."role" IS 'owner, can-transfer or view-only';
+
+COMMENT ON COLUMN "account_invitations"."secret_code_hash" IS 'hex encoded SHA-256 of the emailed code, empty until the invitation email is sent';
+
+COMMENT ON COLUMN "pockets"."balance" IS 'ring-fenced part of the parent account balance';
+
+COMMENT ON COLUMN "payment_requests"."account_id" IS 'the requester''s account that receives the money';
+
+COMMENT ON COLUMN "payment_requests"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "payment_requests"."status" IS 'pending, accepted, declined or expired';
+
+COMMENT ON COLUMN "webhooks"."secret" IS 'HMAC-SHA256 signing key, shown to the owner once';
+
+COMMENT ON COLUMN "webhooks"."event_types" IS 'event types the webhook is subscribed to';
+
+COMMENT ON COLUMN "webhook_deliveries"."payload" IS 'the exact event body that is signed and sent';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "webhook_deliveries"."response_code" IS 'HTTP status of the last attempt, null if no response was received';
+
+COMMENT ON COLUMN "webhook_deliveries"."replay_of" IS 'the delivery this one replays, null for the original delivery of an event';
+
+COMMENT ON COLUMN "category_rules"."counterparty_account_id" IS 'matches transfers to or from this account';
+
+COMMENT ON COLUMN "category_rules"."keyword" IS 'matches transfers whose reference contains it, ignoring case';
+
+COMMENT ON TABLE "transfer_categories" IS 'the category each member of the accounts involved gave a transfer';
+
+COMMENT ON COLUMN "transfer_categories"."rule_id" IS 'the rule that categorized the transfer, NULL when the user chose the category';
+
+COMMENT ON COLUMN "payment_batches"."message_id" IS 'GrpHdr/MsgId of the pain.001 file, a file is only accepted once';
+
+COMMENT ON COLUMN "payment_batches"."message_name" IS 'the pain.001 version of the file, e.g. pain.001.001.09';
+
+COMMENT ON COLUMN "payment_batches"."status" IS 'pending until confirmed, then confirmed until every valid item has been executed, then completed';
+
+COMMENT ON COLUMN "payment_batch_items"."from_account_number" IS 'as given in the file, from_account_id is set when it was found';
+
+COMMENT ON COLUMN "payment_batch_items"."to_account_number" IS 'as given in the file, to_account_id is set when it was found';
+
+COMMENT ON COLUMN "payment_batch_items"."amount" IS 'in minor units, 0 when the amount in the file could not be read';
+
+COMMENT ON COLUMN "payment_batch_items"."status" IS 'valid or invalid when imported, valid items become executed or failed';
+
+COMMENT ON COLUMN "payment_batch_items"."reason_code" IS 'ISO 20022 status reason code of an invalid or failed item';
+
+COMMENT ON COLUMN "transfer_risk_assessments"."amount" IS 'in minor units';
+
+COMMENT ON COLUMN "transfer_risk_assessments"."decision" IS 'allow, review or block';
+
+COMMENT ON COLUMN "transfer_risk_assessments"."findings" IS 'the rules that did not allow the transfer and why';
+
+COMMENT ON COLUMN "transfer_risk_assessments"."status" IS 'executed when the transfer was made right away, blocked transfers are held until approved or rejected by a banker';
+
+COMMENT ON COLUMN "disputes"."account_id" IS 'the account the disputed transfer was sent from';
+
+COMMENT ON COLUMN "disputes"."status" IS 'open, investigating, provisional_credit, resolved_for_customer or resolved_against_customer';
+
+COMMENT ON COLUMN "disputes"."amount" IS 'in minor units, the amount of the disputed transfer';
+
+COMMENT ON COLUMN "disputes"."credit_entry_id" IS 'the entry crediting the customer, provisionally or once resolved in their favour';
+
+COMMENT ON COLUMN "disputes"."chargeback_entry_id" IS 'the entry taking the money back from the recipient once resolved for the customer';
+
+COMMENT ON COLUMN "disputes"."reversal_entry_id" IS 'the entry taking back a provisional credit once resolved against the customer';
+
+COMMENT ON COLUMN "dispute_events"."status" IS 'the status the dispute moved to';
+
+COMMENT ON COLUMN "dispute_events"."actor" IS 'the user who opened the dispute or the banker who moved it';
+
+COMMENT ON COLUMN "approval_requests"."operation" IS 'add_account_balance, update_dispute_status, update_user_role or update_account_limits';
+
+COMMENT ON COLUMN "approval_requests"."payload" IS 'the arguments of the operation';
+
+COMMENT ON COLUMN "approval_requests"."status" IS 'pending, approved, rejected or expired. Approved operations have been executed.';
+
+COMMENT ON COLUMN "approval_requests"."reviewed_by" IS 'a banker other than the one who requested the operation';
+
+COMMENT ON COLUMN "account_limits"."max_transfers_per_hour" IS 'overrides RISK_MAX_TRANSFERS_PER_HOUR, the default applies when null';
+
+COMMENT ON COLUMN "account_limits"."new_counterparty_threshold" IS 'overrides RISK_NEW_COUNTERPARTY_THRESHOLD, in minor units of the account currency';
+
+COMMENT ON COLUMN "account_limits"."new_beneficiary_transfer_limit" IS 'overrides NEW_BENEFICIARY_TRANSFER_LIMIT, in minor units of the account currency';
+
+COMMENT ON COLUMN "account_limits"."updated_by" IS 'the banker who approved the override';
+
+COMMENT ON COLUMN "refresh_tokens"."id" IS 'the ID of the refresh token payload';
+
+COMMENT ON COLUMN "refresh_tokens"."token_hash" IS 'hex encoded SHA-256 of the refresh token';
+
+COMMENT ON COLUMN "refresh_tokens"."rotated_at" IS 'set when the token is renewed, using it again revokes the session';
+
+COMMENT ON COLUMN "totp_enrollments"."secret" IS 'base32 encoded TOTP secret';
+
+COMMENT ON COLUMN "totp_enrollments"."last_used_step" IS 'the time step of the last accepted code, codes cannot be used twice';
+
+COMMENT ON COLUMN "totp_enrollments"."confirmed_at" IS 'set once a code has been verified, TOTP is only enabled from then on';
+
+COMMENT ON COLUMN "recovery_codes"."code_hash" IS 'hex encoded SHA-256 of the recovery code';
+
+COMMENT ON COLUMN "mfa_challenges"."token_hash" IS 'hex encoded SHA-256 of the challenge token returned by login';
+
+COMMENT ON COLUMN "mfa_challenges"."attempts" IS 'wrong codes entered, the challenge fails after too many';
+
+COMMENT ON COLUMN "password_resets"."email" IS 'the address the code was sent to';
+
+COMMENT ON COLUMN "password_resets"."secret_code_hash" IS 'hex encoded SHA-256 of the emailed code';
+
+COMMENT ON COLUMN "login_throttles"."username" IS 'not a reference to users, unknown usernames are throttled the same way';
+
+COMMENT ON COLUMN "login_throttles"."failed_attempts" IS 'consecutive failed logins, reset by a successful login, a lockout or an unlock';
+
+COMMENT ON COLUMN "login_throttles"."last_attempt_at" IS 'when a login of the username was last let through, so concurrent logins cannot skip the wait';
+
+COMMENT ON COLUMN "failed_logins"."client_ip" IS 'the client address without its port, or the /64 network of an IPv6 client';
+
+COMMENT ON COLUMN "token_signing_keys"."algorithm" IS 'EdDSA or ES256';
+
+COMMENT ON COLUMN "token_signing_keys"."sealed_private_key" IS 'PKCS #8 private key encrypted with the token symmetric key';
+
+COMMENT ON COLUMN "token_signing_keys"."expires_at" IS 'when the last token the key signed has expired';
+
+COMMENT ON COLUMN "api_keys"."prefix" IS 'the start of the key, shown to tell keys apart';
+
+COMMENT ON COLUMN "api_keys"."secret_hash" IS 'hex encoded SHA-256 of the whole key';
+
+COMMENT ON COLUMN "api_keys"."allowed_ips" IS 'addresses and CIDR ranges the key can be used from, any address when empty';
+
+COMMENT ON COLUMN "api_keys"."last_used_at" IS 'updated at most once a minute';
+
+COMMENT ON COLUMN "role_permissions"."permission" IS 'permissions ending in .any apply to the resources of every user';
+
 ALTER TABLE "verify_emails" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
 
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
 
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
 ALTER TABLE "entries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
@@ -89,4 +636,116 @@ ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts"
 
 ALTER TABLE "sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
 
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_invitations" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_invitations" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_invitations" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("pocket_id") REFERENCES "pockets" ("id");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "pockets" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("requester") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("payer") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "webhooks" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("webhook_id") REFERENCES "webhooks" ("id");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("replay_of") REFERENCES "webhook_deliveries" ("id");
+
+ALTER TABLE "categories" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "category_rules" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "category_rules" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+
+ALTER TABLE "category_rules" ADD FOREIGN KEY ("counterparty_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("rule_id") REFERENCES "category_rules" ("id");
+
+ALTER TABLE "payment_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "payment_batches" ("id");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("opened_by") REFERENCES "users" ("username");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("credit_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("chargeback_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("reversal_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "dispute_attachments" ADD FOREIGN KEY ("dispute_id") REFERENCES "disputes" ("id");
+
+ALTER TABLE "dispute_events" ADD FOREIGN KEY ("dispute_id") REFERENCES "disputes" ("id");
+
+ALTER TABLE "dispute_events" ADD FOREIGN KEY ("actor") REFERENCES "users" ("username");
+
+ALTER TABLE "approval_requests" ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "approval_requests" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "account_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_limits" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "refresh_tokens" ADD FOREIGN KEY ("session_id") REFERENCES "sessions" ("id");
+
+ALTER TABLE "totp_enrollments" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "mfa_challenges" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "password_resets" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "role_permissions" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
+
+ALTER TABLE "users" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
+
 ALTER TABLE "accounts" ADD FOREIGN KEY ("balance") REFERENCES "entries" ("account_id");
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtAccount struct {
+	ID string `xml:"Id>Othr>Id"`
+}
+
+type camtBalance struct {
+	XMLName   xml.Name   `xml:"Bal"`
+	Type      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      string     `xml:"Dt>DtTm"`
+}
+
+type camtRelatedParties struct {
+	DebtorAccount   *camtAccount `xml:"DbtrAcct,omitempty"`
+	CreditorAccount *camtAccount `xml:"CdtrAcct,omitempty"`
+}
+
+type camtTransaction struct {
+	EndToEndID     string              `xml:"Refs>EndToEndId"`
+	RelatedParties *camtRelatedParties `xml:"RltdPties,omitempty"`
+	Remittance     string              `xml:"RmtInf>Ustrd,omitempty"`
+}
+
+type camtEntry struct {
+	XMLName     xml.Name         `xml:"Ntry"`
+	Reference   string           `xml:"NtryRef"`
+	Amount      camtAmount       `xml:"Amt"`
+	Indicator   string           `xml:"CdtDbtInd"`
+	Status      string           `xml:"Sts>Cd"`
+	BookingDate string           `xml:"BookgDt>DtTm"`
+	ValueDate   string           `xml:"ValDt>DtTm"`
+	Code        string           `xml:"BkTxCd>Prtry>Cd"`
+	Transaction *camtTransaction `xml:"NtryDtls>TxDtls,omitempty"`
+}
+
+type camt053Writer struct {
+	w   io.Writer
+	enc *xml.Encoder
+}
+
+func newCamt053Writer(w io.Writer, statement Statement) (Writer, error) {
+	_, err := fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<Document xmlns="%s"><BkToCstmrStmt>`+
+		`<GrpHdr><MsgId>%s</MsgId><CreDtTm>%s</CreDtTm></GrpHdr>`+
+		`<Stmt><Id>%s</Id><CreDtTm>%s</CreDtTm>`+
+		`<FrToDt><FrDtTm>%s</FrDtTm><ToDtTm>%s</ToDtTm></FrToDt>`+
+		`<Acct><Id><Othr><Id>%s</Id></Othr></Id><Ccy>%s</Ccy></Acct>`+"\n",
+		camt053Namespace,
+		escapeXML(statement.ID),
+		camtTime(statement.CreatedAt),
+		escapeXML(statement.ID),
+		camtTime(statement.CreatedAt),
+		camtTime(statement.From),
+		camtTime(statement.To),
+		escapeXML(statement.AccountNumber),
+		escapeXML(statement.Currency),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &camt053Writer{w: w, enc: xml.NewEncoder(w)}
+
+	// opening booked and closing booked balances, both known before any entry is written
+	for _, balance := range []camtBalance{
+		newCamtBalance("OPBD", statement.OpeningBalance, statement.From),
+		newCamtBalance("CLBD", statement.ClosingBalance, statement.To),
+	} {
+		if err := writer.enc.Encode(balance); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.enc.Flush(); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (writer *camt053Writer) WriteEntry(entry Entry) error {
+	amount, indicator := camtSignedAmount(entry.Amount)
+
+	ntry := camtEntry{
+		Reference:   strconv.FormatInt(entry.ID, 10),
+		Amount:      amount,
+		Indicator:   indicator,
+		Status:      "BOOK",
+		BookingDate: camtTime(entry.BookedAt),
+		ValueDate:   camtTime(entry.BookedAt),
+		Code:        "INTERNAL",
+	}
+
+	if entry.TransferID != 0 {
+		ntry.Code = "TRANSFER"
+		ntry.Transaction = &camtTransaction{
+			EndToEndID: strconv.FormatInt(entry.TransferID, 10),
+			Remittance: entry.Reference,
+		}
+		if entry.CounterpartyAccountNumber != "" {
+			counterparty := &camtAccount{ID: entry.CounterpartyAccountNumber}
+			if entry.Amount.Amount < 0 {
+				ntry.Transaction.RelatedParties = &camtRelatedParties{CreditorAccount: counterparty}
+			} else {
+				ntry.Transaction.RelatedParties = &camtRelatedParties{DebtorAccount: counterparty}
+			}
+		}
+	}
+
+	if err := writer.enc.Encode(ntry); err != nil {
+		return err
+	}
+	return writer.enc.Flush()
+}
+
+func (writer *camt053Writer) Close() error {
+	_, err := io.WriteString(writer.w, "\n</Stmt></BkToCstmrStmt></Document>\n")
+	return err
+}
+
+func newCamtBalance(code string, balance util.Money, at time.Time) camtBalance {
+	amount, indicator := camtSignedAmount(balance)
+	return camtBalance{
+		Type:      code,
+		Amount:    amount,
+		Indicator: indicator,
+		Date:      camtTime(at),
+	}
+}
+
+// camtSignedAmount splits a signed amount into the unsigned amount and credit or debit indicator camt.053 uses
+func camtSignedAmount(money util.Money) (camtAmount, string) {
+	indicator := "CRDT"
+	if money.Amount < 0 {
+		indicator = "DBIT"
+		money.Amount = -money.Amount
+	}
+	return camtAmount{Currency: money.Currency, Value: money.Decimal()}, indicator
+}
+
+func camtTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
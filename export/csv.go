@@ -0,0 +1,65 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"date",
+	"entry_id",
+	"transfer_id",
+	"amount",
+	"currency",
+	"counterparty_account_number",
+	"reference",
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, statement Statement) (Writer, error) {
+	writer := &csvWriter{w: csv.NewWriter(w)}
+	if err := writer.w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (writer *csvWriter) WriteEntry(entry Entry) error {
+	transferID := ""
+	if entry.TransferID != 0 {
+		transferID = strconv.FormatInt(entry.TransferID, 10)
+	}
+
+	return writer.w.Write([]string{
+		entry.BookedAt.UTC().Format(time.RFC3339),
+		strconv.FormatInt(entry.ID, 10),
+		transferID,
+		entry.Amount.Decimal(),
+		entry.Amount.Currency,
+		entry.CounterpartyAccountNumber,
+		sanitizeCSVField(entry.Reference),
+	})
+}
+
+func (writer *csvWriter) Close() error {
+	writer.w.Flush()
+	return writer.w.Error()
+}
+
+// sanitizeCSVField stops spreadsheets from running user-written text as a formula
+func sanitizeCSVField(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
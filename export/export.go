@@ -0,0 +1,106 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+// Format is a file format an account statement can be exported in
+type Format string
+
+const (
+	FormatCSV = Format("csv")
+	// FormatOFX is Open Financial Exchange 2.2, the XML based version
+	FormatOFX = Format("ofx")
+	// FormatCamt053 is the ISO 20022 bank to customer statement, camt.053.001.08
+	FormatCamt053 = Format("camt053")
+)
+
+var ErrUnsupportedFormat = errors.New("export format is not supported")
+
+// ContentType returns the MIME type of files in the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatOFX:
+		return "application/x-ofx"
+	case FormatCamt053:
+		return "application/xml"
+	}
+	return "application/octet-stream"
+}
+
+// Extension returns the usual file name extension of the format, without the dot
+func (f Format) Extension() string {
+	switch f {
+	case FormatCamt053:
+		return "xml"
+	}
+	return string(f)
+}
+
+// Statement describes the account and period an export covers
+type Statement struct {
+	ID            string
+	AccountNumber string
+	Currency      string
+	// From is inclusive and To exclusive
+	From time.Time
+	To   time.Time
+	// OpeningBalance is the balance at From and ClosingBalance the balance at To
+	OpeningBalance util.Money
+	ClosingBalance util.Money
+	CreatedAt      time.Time
+}
+
+// Entry is a single booking on the account
+type Entry struct {
+	ID int64
+	// Amount is negative for money leaving the account
+	Amount   util.Money
+	BookedAt time.Time
+	// TransferID is 0 for entries that did not come from a transfer, e.g. pocket moves
+	TransferID                int64
+	CounterpartyAccountNumber string
+	Reference                 string
+}
+
+// Writer streams a statement in a file format. The header is written when the writer is created,
+// each entry as it is written, and the trailer on Close, so entries never have to be held in memory.
+type Writer interface {
+	WriteEntry(entry Entry) error
+	// Close writes the end of the file. It does not close the underlying io.Writer.
+	Close() error
+}
+
+// NewWriter starts writing the statement to w in the given format
+func NewWriter(format Format, w io.Writer, statement Statement) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, statement)
+	case FormatOFX:
+		return newOFXWriter(w, statement)
+	case FormatCamt053:
+		return newCamt053Writer(w, statement)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+// description is the text shown for an entry by formats that have a single description field
+func (e Entry) description() string {
+	switch {
+	case e.Reference != "":
+		return e.Reference
+	case e.TransferID == 0:
+		return "Internal movement"
+	case e.Amount.Amount < 0:
+		return "Transfer to " + e.CounterpartyAccountNumber
+	default:
+		return "Transfer from " + e.CounterpartyAccountNumber
+	}
+}
@@ -0,0 +1,170 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func testStatement() (Statement, []Entry) {
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	statement := Statement{
+		ID:             "STMT-1",
+		AccountNumber:  "SMPL491234567890",
+		Currency:       util.USD,
+		From:           from,
+		To:             from.AddDate(0, 1, 0),
+		OpeningBalance: util.Money{Amount: 10000, Currency: util.USD},
+		ClosingBalance: util.Money{Amount: 8950, Currency: util.USD},
+		CreatedAt:      from.AddDate(0, 1, 2),
+	}
+
+	entries := []Entry{
+		{
+			ID:                        1,
+			Amount:                    util.Money{Amount: -1550, Currency: util.USD},
+			BookedAt:                  from.Add(26 * time.Hour),
+			TransferID:                7,
+			CounterpartyAccountNumber: "SMPL220000000001",
+			Reference:                 "=rent <march> & more",
+		},
+		{
+			ID:                        2,
+			Amount:                    util.Money{Amount: 500, Currency: util.USD},
+			BookedAt:                  from.Add(50 * time.Hour),
+			TransferID:                8,
+			CounterpartyAccountNumber: "SMPL330000000002",
+		},
+	}
+
+	return statement, entries
+}
+
+func writeStatement(t *testing.T, format Format) []byte {
+	statement, entries := testStatement()
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(format, &buf, statement)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.NoError(t, writer.WriteEntry(entry))
+	}
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+// requireWellFormedXML decodes every token of the document, which fails on malformed XML
+func requireWellFormedXML(t *testing.T, data []byte) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestCSV(t *testing.T) {
+	records, err := csv.NewReader(bytes.NewReader(writeStatement(t, FormatCSV))).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		csvHeader,
+		{"2026-03-02T02:00:00Z", "1", "7", "-15.50", "USD", "SMPL220000000001", "'=rent <march> & more"},
+		{"2026-03-03T02:00:00Z", "2", "8", "5.00", "USD", "SMPL330000000002", ""},
+	}, records)
+}
+
+func TestOFX(t *testing.T) {
+	data := writeStatement(t, FormatOFX)
+	requireWellFormedXML(t, data)
+
+	var doc struct {
+		Currency     string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>CURDEF"`
+		BankID       string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>BANKID"`
+		AccountID    string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>ACCTID"`
+		Transactions []struct {
+			Type   string `xml:"TRNTYPE"`
+			Posted string `xml:"DTPOSTED"`
+			Amount string `xml:"TRNAMT"`
+			FITID  string `xml:"FITID"`
+			Memo   string `xml:"MEMO"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>STMTTRN"`
+		LedgerBalance string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>LEDGERBAL>BALAMT"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+
+	require.Equal(t, util.USD, doc.Currency)
+	require.Equal(t, "SMPL", doc.BankID)
+	require.Equal(t, "SMPL491234567890", doc.AccountID)
+	require.Len(t, doc.Transactions, 2)
+	require.Equal(t, "XFER", doc.Transactions[0].Type)
+	require.Equal(t, "20260302020000.000[0:UTC]", doc.Transactions[0].Posted)
+	require.Equal(t, "-15.50", doc.Transactions[0].Amount)
+	require.Equal(t, "1", doc.Transactions[0].FITID)
+	require.Equal(t, "=rent <march> & more", doc.Transactions[0].Memo)
+	require.Equal(t, "Transfer from SMPL330000000002", doc.Transactions[1].Memo)
+	require.Equal(t, "89.50", doc.LedgerBalance)
+}
+
+func TestCamt053(t *testing.T) {
+	data := writeStatement(t, FormatCamt053)
+	requireWellFormedXML(t, data)
+
+	type amount struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	}
+	var doc struct {
+		XMLName  xml.Name
+		Account  string `xml:"BkToCstmrStmt>Stmt>Acct>Id>Othr>Id"`
+		Balances []struct {
+			Type      string `xml:"Tp>CdOrPrtry>Cd"`
+			Amount    amount `xml:"Amt"`
+			Indicator string `xml:"CdtDbtInd"`
+		} `xml:"BkToCstmrStmt>Stmt>Bal"`
+		Entries []struct {
+			Reference       string `xml:"NtryRef"`
+			Amount          amount `xml:"Amt"`
+			Indicator       string `xml:"CdtDbtInd"`
+			EndToEndID      string `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+			CreditorAccount string `xml:"NtryDtls>TxDtls>RltdPties>CdtrAcct>Id>Othr>Id"`
+			DebtorAccount   string `xml:"NtryDtls>TxDtls>RltdPties>DbtrAcct>Id>Othr>Id"`
+			Remittance      string `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+		} `xml:"BkToCstmrStmt>Stmt>Ntry"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+
+	require.Equal(t, camt053Namespace, doc.XMLName.Space)
+	require.Equal(t, "SMPL491234567890", doc.Account)
+
+	require.Len(t, doc.Balances, 2)
+	require.Equal(t, "OPBD", doc.Balances[0].Type)
+	require.Equal(t, amount{Currency: util.USD, Value: "100.00"}, doc.Balances[0].Amount)
+	require.Equal(t, "CLBD", doc.Balances[1].Type)
+	require.Equal(t, "CRDT", doc.Balances[1].Indicator)
+
+	require.Len(t, doc.Entries, 2)
+	require.Equal(t, "1", doc.Entries[0].Reference)
+	require.Equal(t, amount{Currency: util.USD, Value: "15.50"}, doc.Entries[0].Amount)
+	require.Equal(t, "DBIT", doc.Entries[0].Indicator)
+	require.Equal(t, "7", doc.Entries[0].EndToEndID)
+	require.Equal(t, "SMPL220000000001", doc.Entries[0].CreditorAccount)
+	require.Equal(t, "=rent <march> & more", doc.Entries[0].Remittance)
+	require.Equal(t, "CRDT", doc.Entries[1].Indicator)
+	require.Equal(t, "SMPL330000000002", doc.Entries[1].DebtorAccount)
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	statement, _ := testStatement()
+	_, err := NewWriter(Format("pdf"), io.Discard, statement)
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+// ofxNameLength is the longest NAME an OFX transaction may carry
+const ofxNameLength = 32
+
+type ofxTransaction struct {
+	XMLName xml.Name `xml:"STMTTRN"`
+	Type    string   `xml:"TRNTYPE"`
+	Posted  string   `xml:"DTPOSTED"`
+	Amount  string   `xml:"TRNAMT"`
+	FITID   string   `xml:"FITID"`
+	Name    string   `xml:"NAME,omitempty"`
+	Memo    string   `xml:"MEMO,omitempty"`
+}
+
+type ofxWriter struct {
+	w         io.Writer
+	enc       *xml.Encoder
+	statement Statement
+}
+
+func newOFXWriter(w io.Writer, statement Statement) (Writer, error) {
+	bankID := statement.AccountNumber
+	if len(bankID) > util.BankCodeLength {
+		bankID = bankID[:util.BankCodeLength]
+	}
+
+	_, err := fmt.Fprintf(w, "%s<OFX>"+
+		"<SIGNONMSGSRSV1><SONRS>"+
+		"<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>"+
+		"<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE>"+
+		"</SONRS></SIGNONMSGSRSV1>"+
+		"<BANKMSGSRSV1><STMTTRNRS><TRNUID>%s</TRNUID>"+
+		"<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>"+
+		"<STMTRS><CURDEF>%s</CURDEF>"+
+		"<BANKACCTFROM><BANKID>%s</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>"+
+		"<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n",
+		ofxHeader,
+		ofxTime(statement.CreatedAt),
+		escapeXML(statement.ID),
+		escapeXML(statement.Currency),
+		escapeXML(bankID),
+		escapeXML(statement.AccountNumber),
+		ofxTime(statement.From),
+		ofxTime(statement.To),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ofxWriter{w: w, enc: xml.NewEncoder(w), statement: statement}, nil
+}
+
+func (writer *ofxWriter) WriteEntry(entry Entry) error {
+	transaction := ofxTransaction{
+		Type:   "CREDIT",
+		Posted: ofxTime(entry.BookedAt),
+		Amount: entry.Amount.Decimal(),
+		FITID:  strconv.FormatInt(entry.ID, 10),
+		Name:   truncate(entry.CounterpartyAccountNumber, ofxNameLength),
+		Memo:   entry.description(),
+	}
+	if entry.Amount.Amount < 0 {
+		transaction.Type = "DEBIT"
+	}
+	if entry.TransferID != 0 {
+		transaction.Type = "XFER"
+	}
+
+	if err := writer.enc.Encode(transaction); err != nil {
+		return err
+	}
+	return writer.enc.Flush()
+}
+
+func (writer *ofxWriter) Close() error {
+	_, err := fmt.Fprintf(writer.w, "\n</BANKTRANLIST>"+
+		"<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>"+
+		"</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n",
+		writer.statement.ClosingBalance.Decimal(),
+		ofxTime(writer.statement.To),
+	)
+	return err
+}
+
+// ofxTime formats a time as an OFX datetime in UTC, e.g. 20261019143000.000[0:UTC]
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:UTC]"
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
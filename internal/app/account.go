@@ -1,18 +1,77 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type accountResponse struct {
+	AccountNumber string             `json:"account_number"`
+	Owner         string             `json:"owner"`
+	Balance       util.Money         `json:"balance"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+func newAccountResponse(account persistence.Account) accountResponse {
+	return accountResponse{
+		AccountNumber: account.AccountNumber,
+		Owner:         account.Owner,
+		Balance:       account.BalanceMoney(),
+		CreatedAt:     account.CreatedAt,
+	}
+}
+
+type pocketResponse struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Balance   util.Money         `json:"balance"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// getAccountResponse rolls the account's pockets up into a total and an available balance
+type getAccountResponse struct {
+	accountResponse
+	TotalBalance     util.Money       `json:"total_balance"`
+	AvailableBalance util.Money       `json:"available_balance"`
+	Pockets          []pocketResponse `json:"pockets"`
+}
+
+func newGetAccountResponse(account persistence.Account, pockets []persistence.Pocket) (getAccountResponse, error) {
+	available, err := account.AvailableBalance(pockets)
+	if err != nil {
+		return getAccountResponse{}, err
+	}
+
+	rsp := getAccountResponse{
+		accountResponse:  newAccountResponse(account),
+		TotalBalance:     account.BalanceMoney(),
+		AvailableBalance: available,
+		Pockets:          make([]pocketResponse, 0, len(pockets)),
+	}
+	for _, pocket := range pockets {
+		rsp.Pockets = append(rsp.Pockets, pocketResponse{
+			ID:        pocket.ID,
+			Name:      pocket.Name,
+			Balance:   util.Money{Amount: pocket.Balance, Currency: account.Currency},
+			CreatedAt: pocket.CreatedAt,
+		})
+	}
+
+	return rsp, nil
+}
+
 type createAccountRequest struct {
-	Currency string `json:"currency" validate:"required,currency"`
+	Currency string `json:"currency" validate:"required,account_currency"`
 }
 
 func (server *Server) createAccount(w http.ResponseWriter, r *http.Request) {
@@ -23,14 +82,22 @@ func (server *Server) createAccount(w http.ResponseWriter, r *http.Request) {
 	}
 
 	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
-	arg := persistence.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Balance:  0,
-		Currency: req.Currency,
+	arg := persistence.CreateAccountTxParams{
+		CreateAccountParams: persistence.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Balance:  0,
+			Currency: req.Currency,
+		},
+		BankCode: server.config.BankCode,
 	}
 
-	account, err := server.store.CreateAccount(r.Context(), arg)
+	txResult, err := server.store.CreateAccountTx(r.Context(), arg)
 	if err != nil {
+		if errors.Is(err, persistence.ErrCurrencyDisabled) {
+			server.writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
 			case pgerrcode.UniqueViolation, pgerrcode.ForeignKeyViolation:
@@ -43,30 +110,43 @@ func (server *Server) createAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	account := txResult.Account
 	server.logger.Info("Account created", "account", account)
-	err = server.writeJSON(w, http.StatusOK, account, nil)
+
+	data := webhook.AccountData{
+		AccountNumber: account.AccountNumber,
+		Owner:         account.Owner,
+		Balance:       account.BalanceMoney(),
+		CreatedAt:     account.CreatedAt.Time,
+	}
+
+	err = server.taskDistributor.DistributeWebhookEvent(r.Context(), webhook.EventAccountCreated, data, account.ID)
+	if err != nil {
+		server.logger.Error("failed to distribute webhook event", "error", err)
+	}
+
+	err = server.writeJSON(w, http.StatusOK, newAccountResponse(account), nil)
 	if err != nil {
 		server.writeError(w, http.StatusInternalServerError, err)
 	}
 }
 
 type getAccountRequest struct {
-	ID int64 `validate:"required,min=1"`
+	AccountNumber string `validate:"required,account_number"`
 }
 
+// getAccount looks an account up by its account number, internal account IDs are not accepted
 func (server *Server) getAccount(w http.ResponseWriter, r *http.Request) {
-	var req getAccountRequest
-	if _, err := fmt.Sscanf(r.PathValue("id"), "%d", &req.ID); err != nil {
-		server.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid account ID"))
-		return
+	req := getAccountRequest{
+		AccountNumber: r.PathValue("account_number"),
 	}
 
 	if err := server.validate.Struct(req); err != nil {
-		server.writeError(w, http.StatusBadRequest, fmt.Errorf("ID must be greater than 0"))
+		server.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid account number"))
 		return
 	}
 
-	account, err := server.store.GetAccount(r.Context(), req.ID)
+	account, err := server.store.GetAccountByNumber(r.Context(), util.NormalizeAccountNumber(req.AccountNumber))
 	if err != nil {
 		server.logger.Error(err.Error())
 		if err == pgx.ErrNoRows {
@@ -78,13 +158,23 @@ func (server *Server) getAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		server.writeError(w, http.StatusForbidden, fmt.Errorf("account doesn't belong to the authenticated user"))
+	if !server.authorizeAccount(w, r, account.ID, util.AccountViewOnlyRole) {
 		return
 	}
 
-	err = server.writeJSON(w, http.StatusOK, account, nil)
+	pockets, err := server.store.ListPockets(r.Context(), account.ID)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp, err := newGetAccountResponse(account, pockets)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
 	if err != nil {
 		server.writeError(w, http.StatusInternalServerError, err)
 	}
@@ -118,20 +208,50 @@ func (server *Server) listAccounts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
-	arg := persistence.ListAccountsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
-		Owner:  authPayload.Username,
+	arg := persistence.ListMemberAccountsParams{
+		Username: authPayload.Username,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
 	}
 
-	accounts, err := server.store.ListAccounts(r.Context(), arg)
+	accounts, err := server.store.ListMemberAccounts(r.Context(), arg)
 	if err != nil {
 		server.writeError(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	err = server.writeJSON(w, http.StatusOK, accounts, nil)
+	rsp := make([]accountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		rsp = append(rsp, newAccountResponse(account))
+	}
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
 	if err != nil {
 		server.writeError(w, http.StatusInternalServerError, err)
 	}
 }
+
+// authorizeAccount checks that the authenticated user is a member of the account holding at least the required role
+func (server *Server) authorizeAccount(w http.ResponseWriter, r *http.Request, accountID int64, requiredRole string) bool {
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+	member, err := server.store.GetAccountMember(r.Context(), persistence.GetAccountMemberParams{
+		AccountID: accountID,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			server.writeError(w, http.StatusForbidden, fmt.Errorf("account doesn't belong to the authenticated user"))
+			return false
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return false
+	}
+
+	if !util.AccountRoleAllows(member.Role, requiredRole) {
+		server.writeError(w, http.StatusForbidden, fmt.Errorf("account role %s is not allowed to perform this action", member.Role))
+		return false
+	}
+
+	return true
+}
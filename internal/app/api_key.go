@@ -0,0 +1,215 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+)
+
+// routeScopes is the scope an API key needs for each route. Routes that are not listed,
+// such as managing sessions or API keys, cannot be used with an API key.
+var routeScopes = map[string]string{
+	"POST /accounts":                 util.ScopeAccountsWrite,
+	"GET /accounts/{account_number}": util.ScopeAccountsRead,
+	"GET /accounts":                  util.ScopeAccountsRead,
+	"POST /transfers":                util.ScopeTransfersWrite,
+	"GET /transfers":                 util.ScopeTransfersRead,
+	"GET /entries":                   util.ScopeTransfersRead,
+	"GET /recipients/lookup":         util.ScopeTransfersRead,
+}
+
+// authenticateApiKey authenticates a request made with an API key, it returns the status code to reply with on failure
+func (server *Server) authenticateApiKey(r *http.Request, key string) (*token.Payload, int, error) {
+	result, err := server.store.AuthenticateApiKey(r.Context(), persistence.AuthenticateApiKeyParams{
+		Key:      key,
+		ClientIp: r.RemoteAddr,
+	})
+	if err != nil {
+		if errors.Is(err, persistence.ErrInvalidApiKey) {
+			return nil, http.StatusUnauthorized, err
+		}
+		if errors.Is(err, persistence.ErrApiKeyAddressDenied) {
+			return nil, http.StatusForbidden, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	scope, ok := routeScopes[r.Pattern]
+	if !ok {
+		return nil, http.StatusForbidden, errors.New("this route cannot be used with an API key")
+	}
+
+	if !slices.Contains(result.ApiKey.Scopes, scope) {
+		return nil, http.StatusForbidden, fmt.Errorf("API key is missing the %s scope", scope)
+	}
+
+	return &token.Payload{
+		Username: result.Owner.Username,
+		Role:     result.Owner.Role,
+		IssuedAt: result.ApiKey.CreatedAt.Time,
+	}, 0, nil
+}
+
+type apiKeyResponse struct {
+	ID         int64      `json:"id"`
+	Owner      string     `json:"owner"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	AllowedIps []string   `json:"allowed_ips"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIp string     `json:"last_used_ip,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func newApiKeyResponse(apiKey persistence.ApiKey) apiKeyResponse {
+	rsp := apiKeyResponse{
+		ID:         apiKey.ID,
+		Owner:      apiKey.Owner,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		AllowedIps: apiKey.AllowedIps,
+		CreatedAt:  apiKey.CreatedAt.Time,
+	}
+
+	if apiKey.LastUsedAt.Valid {
+		rsp.LastUsedAt = &apiKey.LastUsedAt.Time
+		rsp.LastUsedIp = apiKey.LastUsedIp.String
+	}
+
+	if apiKey.RevokedAt.Valid {
+		rsp.RevokedAt = &apiKey.RevokedAt.Time
+	}
+
+	return rsp
+}
+
+type createApiKeyRequest struct {
+	Name       string   `json:"name" validate:"required,max=100"`
+	Scopes     []string `json:"scopes" validate:"api_key_scopes"`
+	AllowedIps []string `json:"allowed_ips" validate:"ip_allowlist"`
+}
+
+type createApiKeyResponse struct {
+	ApiKey apiKeyResponse `json:"api_key"`
+	// Key is only returned when the key is created
+	Key string `json:"key"`
+}
+
+func (server *Server) createApiKey(w http.ResponseWriter, r *http.Request) {
+	var req createApiKeyRequest
+	if err := server.bindData(w, r, &req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+
+	key, prefix, err := util.GenerateAPIKey()
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+
+	allowedIps := req.AllowedIps
+	if allowedIps == nil {
+		allowedIps = []string{}
+	}
+
+	apiKey, err := server.store.CreateApiKey(r.Context(), persistence.CreateApiKeyParams{
+		Owner:      authPayload.Username,
+		Name:       req.Name,
+		Prefix:     prefix,
+		SecretHash: util.HashToken(key),
+		Scopes:     slices.Compact(scopes),
+		AllowedIps: allowedIps,
+	})
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp := createApiKeyResponse{
+		ApiKey: newApiKeyResponse(apiKey),
+		Key:    key,
+	}
+
+	err = server.writeJSON(w, http.StatusCreated, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func (server *Server) listApiKeys(w http.ResponseWriter, r *http.Request) {
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+	apiKeys, err := server.store.ListApiKeys(r.Context(), authPayload.Username)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp := make([]apiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		rsp = append(rsp, newApiKeyResponse(apiKey))
+	}
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// revokeApiKey revokes an API key of the user, admins can revoke every key. Other users' keys are reported as not found.
+func (server *Server) revokeApiKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		server.writeError(w, http.StatusBadRequest, fmt.Errorf("ID must be greater than 0"))
+		return
+	}
+
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+	apiKey, err := server.store.GetApiKey(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			server.writeError(w, http.StatusNotFound, fmt.Errorf("API key not found"))
+			return
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !util.RoleHasPermission(authPayload.Role, util.PermissionApiKeyRevokeAny) && apiKey.Owner != authPayload.Username {
+		server.writeError(w, http.StatusNotFound, fmt.Errorf("API key not found"))
+		return
+	}
+
+	apiKey, err = server.store.RevokeApiKey(r.Context(), apiKey.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			server.writeError(w, http.StatusConflict, fmt.Errorf("API key is already revoked"))
+			return
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = server.writeJSON(w, http.StatusOK, newApiKeyResponse(apiKey), nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// getJWKS serves the public keys tokens can be verified with. Verifiers may cache the keys
+// for as long as a new key takes to be loaded by every instance.
+func (server *Server) getJWKS(w http.ResponseWriter, r *http.Request) {
+	headers := http.Header{}
+	headers.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(server.config.TokenKeyRefreshInterval.Seconds())))
+
+	err := server.writeJSON(w, http.StatusOK, server.tokenMaker.PublicKeys(), headers)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+)
+
+// errInvalidCredentials is returned for unknown usernames and wrong passwords alike,
+// so logins cannot be used to find out who has an account
+var errInvalidCredentials = errors.New("invalid username or password")
+
+func (server *Server) loginPolicy() persistence.LoginPolicy {
+	return persistence.LoginPolicy{
+		MaxFailedAttempts:      server.config.LoginMaxFailedAttempts,
+		MaxFailedAttemptsPerIP: server.config.LoginMaxFailedAttemptsPerIP,
+		FailureWindow:          server.config.LoginFailureWindow,
+		BaseDelay:              server.config.LoginBaseDelay,
+		LockoutDuration:        server.config.LoginLockoutDuration,
+	}
+}
+
+// writeLoginThrottled rejects a login while the username is locked or has to wait after failed logins.
+// It reports whether it wrote the response.
+func (server *Server) writeLoginThrottled(w http.ResponseWriter, r *http.Request, username string) bool {
+	retryAfter, err := server.store.CheckLoginAttempt(r.Context(), persistence.CheckLoginAttemptParams{
+		Username: username,
+		ClientIp: r.RemoteAddr,
+		Policy:   server.loginPolicy(),
+	})
+	if err == nil {
+		return false
+	}
+
+	if !errors.Is(err, persistence.ErrLoginLocked) && !errors.Is(err, persistence.ErrTooManyLoginAttempts) {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return true
+	}
+
+	seconds := int64(retryAfter.Round(time.Second) / time.Second)
+	headers := http.Header{}
+	headers.Set("Retry-After", strconv.FormatInt(seconds, 10))
+
+	rsp := ErrorResponse{Message: fmt.Sprintf("%s, try again in %s", err, retryAfter.Round(time.Second))}
+	if err := server.writeJSON(w, http.StatusTooManyRequests, rsp, headers); err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+	return true
+}
+
+// writeFailedLogin counts a failed login, emails the user when it locks their account,
+// and responds the same way whether or not the user exists
+func (server *Server) writeFailedLogin(w http.ResponseWriter, r *http.Request, username string, userExists bool) {
+	if err := server.countFailedLogin(r, username, userExists); err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.writeError(w, http.StatusUnauthorized, errInvalidCredentials)
+}
+
+// countFailedLogin counts a wrong password or two-factor code towards the lockout of the username,
+// and emails the user when it locks their account
+func (server *Server) countFailedLogin(r *http.Request, username string, userExists bool) error {
+	txResult, err := server.store.RecordFailedLoginTx(r.Context(), persistence.RecordFailedLoginTxParams{
+		Username: username,
+		ClientIp: r.RemoteAddr,
+		Policy:   server.loginPolicy(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if txResult.Locked && userExists {
+		taskPayload := &worker.PayloadSendLoginLockedEmail{
+			Username:    username,
+			LockedUntil: txResult.Throttle.LockedUntil.Time,
+		}
+
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.Queue(worker.QueueCritical),
+		}
+
+		_ = server.taskDistributor.DistributeTask(r.Context(), worker.TaskSendLoginLockedEmail, taskPayload, opts...)
+	}
+
+	return nil
+}
+
+type unlockUserResponse struct {
+	WasLocked bool `json:"was_locked"`
+}
+
+// unlockUser lifts the lockout of a user and forgets their failed logins
+func (server *Server) unlockUser(w http.ResponseWriter, r *http.Request) {
+	user, err := server.store.GetUser(r.Context(), r.PathValue("username"))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			server.writeError(w, http.StatusNotFound, fmt.Errorf("user not found"))
+			return
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var rsp unlockUserResponse
+	throttle, err := server.store.GetLoginThrottle(r.Context(), user.Username)
+	switch {
+	case err == nil:
+		rsp.WasLocked = throttle.LockedUntil.Valid && time.Now().Before(throttle.LockedUntil.Time)
+	case !errors.Is(err, pgx.ErrNoRows):
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if _, err := server.store.DeleteLoginThrottle(r.Context(), user.Username); err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
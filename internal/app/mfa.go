@@ -0,0 +1,168 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type totpEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type mfaChallengeResponse struct {
+	MfaRequired       bool                    `json:"mfa_required"`
+	MfaToken          string                  `json:"mfa_token"`
+	MfaTokenExpiresAt time.Time               `json:"mfa_token_expires_at"`
+	TotpEnrollment    *totpEnrollmentResponse `json:"totp_enrollment,omitempty"`
+	User              userResponse            `json:"user"`
+}
+
+// writeMfaChallenge starts the second step of a login when the user has enabled TOTP, or has a role without
+// util.PermissionMfaOptional and has to enroll before they can login. It reports whether it wrote the response.
+func (server *Server) writeMfaChallenge(w http.ResponseWriter, r *http.Request, user persistence.User) bool {
+	enrollment, err := server.store.GetTotpEnrollment(r.Context(), user.Username)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return true
+	}
+
+	enabled := err == nil && enrollment.ConfirmedAt.Valid
+	if !enabled && !util.RoleHasPermission(user.Role, util.PermissionMfaOptional) {
+		return false
+	}
+
+	rsp := mfaChallengeResponse{
+		MfaRequired: true,
+		User:        newUserResponse(user),
+	}
+
+	if !enabled {
+		// an enrollment that was started before is kept, so a QR code that was scanned already keeps working
+		if errors.Is(err, pgx.ErrNoRows) {
+			secret, err := util.GenerateTOTPSecret()
+			if err != nil {
+				server.writeError(w, http.StatusInternalServerError, err)
+				return true
+			}
+
+			enrollment, err = server.store.CreateTotpEnrollment(r.Context(), persistence.CreateTotpEnrollmentParams{
+				Username: user.Username,
+				Secret:   secret,
+			})
+			if err != nil {
+				server.writeError(w, http.StatusInternalServerError, err)
+				return true
+			}
+		}
+
+		rsp.TotpEnrollment = &totpEnrollmentResponse{
+			Secret:          enrollment.Secret,
+			ProvisioningURI: util.TOTPProvisioningURI(server.config.TOTPIssuer, user.Username, enrollment.Secret),
+		}
+	}
+
+	rsp.MfaToken, err = util.GenerateToken()
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return true
+	}
+
+	challenge, err := server.store.CreateMfaChallenge(r.Context(), persistence.CreateMfaChallengeParams{
+		Username:  user.Username,
+		TokenHash: util.HashToken(rsp.MfaToken),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(server.config.MFAChallengeDuration), Valid: true},
+	})
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return true
+	}
+	rsp.MfaTokenExpiresAt = challenge.ExpiresAt.Time
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+
+	return true
+}
+
+type verifyMfaRequest struct {
+	MfaToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type verifyMfaResponse struct {
+	loginUserResponse
+	// RecoveryCodes are set when the login completed an enrollment
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// verifyMfa completes a login that requires two-factor authentication and starts its session.
+// Wrong codes count as failed logins of the user.
+func (server *Server) verifyMfa(w http.ResponseWriter, r *http.Request) {
+	var req verifyMfaRequest
+	if err := server.bindData(w, r, &req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	txResult, err := server.store.VerifyMfaTx(r.Context(), persistence.VerifyMfaTxParams{
+		Token: req.MfaToken,
+		Code:  req.Code,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			server.writeError(w, http.StatusUnauthorized, errors.New("invalid MFA token"))
+		case errors.Is(err, persistence.ErrInvalidMfaCode):
+			if err := server.countFailedLogin(r, txResult.Challenge.Username, true); err != nil {
+				server.writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			server.writeError(w, http.StatusUnauthorized, persistence.ErrInvalidMfaCode)
+		case errors.Is(err, persistence.ErrLoginLocked):
+			server.writeError(w, http.StatusTooManyRequests, err)
+		case errors.Is(err, persistence.ErrMfaChallengeCompleted),
+			errors.Is(err, persistence.ErrMfaChallengeExpired),
+			errors.Is(err, persistence.ErrTooManyMfaAttempts),
+			errors.Is(err, persistence.ErrTotpNotEnabled):
+			server.writeError(w, http.StatusUnauthorized, err)
+		default:
+			server.writeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	user, err := server.store.GetUser(r.Context(), txResult.Challenge.Username)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if _, err := server.store.DeleteLoginThrottle(r.Context(), user.Username); err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	login, err := server.startSession(r, user)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.logger.Info("User logged in", "Username", user.Username)
+	err = server.writeJSON(w, http.StatusOK, verifyMfaResponse{
+		loginUserResponse: login,
+		RecoveryCodes:     txResult.RecoveryCodes,
+	}, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
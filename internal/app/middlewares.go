@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
 )
 
 // LogRequest logs all incoming requests
@@ -38,7 +41,8 @@ const (
 // AutorizationPayloadKey is the key for the authorization payload in the request context
 type AutorizationPayloadKey string
 
-// Authenticate checks if the request is authenticated
+// Authenticate checks if the request is authenticated and the user's role grants the permission
+// routePermissions requires for the route
 func (server *Server) Authenticate(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		server.logger.Info("Authenticating request")
@@ -61,9 +65,32 @@ func (server *Server) Authenticate(next http.HandlerFunc) http.HandlerFunc {
 		}
 
 		accessToken := fields[1]
-		payload, err := server.tokenMaker.VerifyToken(accessToken)
-		if err != nil {
-			server.writeError(w, http.StatusUnauthorized, err)
+		var payload *token.Payload
+		if util.IsAPIKey(accessToken) {
+			var code int
+			var err error
+			payload, code, err = server.authenticateApiKey(r, accessToken)
+			if err != nil {
+				server.writeError(w, code, err)
+				return
+			}
+		} else {
+			var err error
+			payload, err = server.tokenMaker.VerifyToken(accessToken)
+			if err != nil {
+				server.writeError(w, http.StatusUnauthorized, err)
+				return
+			}
+		}
+
+		permission, ok := routePermissions[r.Pattern]
+		if !ok {
+			server.writeError(w, http.StatusForbidden, errors.New("permission denied"))
+			return
+		}
+
+		if permission != authenticatedOnly && !util.RoleHasPermission(payload.Role, permission) {
+			server.writeError(w, http.StatusForbidden, fmt.Errorf("permission denied: role %s does not grant %s", payload.Role, permission))
 			return
 		}
 
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+)
+
+type requestPasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// requestPasswordReset emails a password reset code to the user with the email address. It responds the same
+// whether or not a user has the address, so it cannot be used to find out who has an account.
+func (server *Server) requestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req requestPasswordResetRequest
+	if err := server.bindData(w, r, &req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := server.store.GetUserByEmail(r.Context(), req.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	taskPayload := &worker.PayloadSendPasswordResetEmail{
+		Username: user.Username,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTask(r.Context(), worker.TaskSendPasswordResetEmail, taskPayload, opts...)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type resetPasswordRequest struct {
+	ResetID     int64  `json:"reset_id" validate:"required,min=1"`
+	SecretCode  string `json:"secret_code" validate:"required,min=32,max=128"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
+
+// resetPassword sets a new password with the code from a password reset email and revokes every session of the user
+func (server *Server) resetPassword(w http.ResponseWriter, r *http.Request) {
+	var req resetPasswordRequest
+	if err := server.bindData(w, r, &req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	hashedPassword, err := server.passwordHasher.HashPassword(req.NewPassword)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	txResult, err := server.store.ResetPasswordTx(r.Context(), persistence.ResetPasswordTxParams{
+		ResetID:        req.ResetID,
+		SecretCode:     req.SecretCode,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if errors.Is(err, persistence.ErrInvalidPasswordReset) {
+			server.writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.logger.Info("Password reset", "Username", txResult.User.Username, "RevokedSessions", txResult.RevokedSessions)
+	w.WriteHeader(http.StatusNoContent)
+}
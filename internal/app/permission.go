@@ -0,0 +1,28 @@
+package app
+
+import (
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+// authenticatedOnly marks routes that every authenticated user can use, whatever their role
+const authenticatedOnly = ""
+
+// routePermissions is the permission a user's role needs to use each authenticated route, Authenticate enforces it.
+// Authenticated routes that are not listed cannot be used by anyone.
+var routePermissions = map[string]string{
+	"GET /sessions":                         authenticatedOnly,
+	"DELETE /sessions/{id}":                 authenticatedOnly,
+	"POST /sessions/revoke_others":          authenticatedOnly,
+	"POST /users/{username}/block_sessions": util.PermissionSessionBlock,
+	"POST /users/{username}/unlock":         util.PermissionUserUnlock,
+	"POST /api_keys":                        util.PermissionApiKeyManage,
+	"GET /api_keys":                         util.PermissionApiKeyManage,
+	"POST /api_keys/{id}/revoke":            util.PermissionApiKeyManage,
+	"POST /accounts":                        util.PermissionAccountCreate,
+	"GET /accounts/{account_number}":        util.PermissionAccountRead,
+	"GET /accounts":                         util.PermissionAccountRead,
+	"POST /transfers":                       util.PermissionTransferCreate,
+	"GET /transfers":                        util.PermissionAccountRead,
+	"GET /entries":                          util.PermissionAccountRead,
+	"GET /recipients/lookup":                util.PermissionTransferCreate,
+}
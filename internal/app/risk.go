@@ -0,0 +1,48 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/risk"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type heldTransferResponse struct {
+	ID                int64              `json:"id"`
+	Status            string             `json:"status"`
+	FromAccountNumber string             `json:"from_account_number"`
+	ToAccountNumber   string             `json:"to_account_number"`
+	Amount            util.Money         `json:"amount"`
+	Reference         string             `json:"reference"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+func newHeldTransferResponse(assessment persistence.TransferRiskAssessment, fromAccount, toAccount persistence.Account) heldTransferResponse {
+	return heldTransferResponse{
+		ID:                assessment.ID,
+		Status:            assessment.Status,
+		FromAccountNumber: fromAccount.AccountNumber,
+		ToAccountNumber:   toAccount.AccountNumber,
+		Amount:            util.Money{Amount: assessment.Amount, Currency: assessment.Currency},
+		Reference:         assessment.Reference,
+		CreatedAt:         assessment.CreatedAt,
+	}
+}
+
+// assessTransfer gathers what is known about a transfer and the user sending it, and runs the risk engine on it
+func (server *Server) assessTransfer(w http.ResponseWriter, r *http.Request, username string, fromAccountID, toAccountID int64, amount util.Money) (risk.Assessment, bool) {
+	assessment, err := server.riskEngine.AssessTransfer(r.Context(), server.store, risk.Transfer{
+		Username:      username,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+	})
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return assessment, false
+	}
+
+	return assessment, true
+}
@@ -14,16 +14,34 @@ func (server *Server) getRoutes() {
 	authenticatedChain := append(standardChain, server.Authenticate)
 
 	router.Get("/health", standardChain.Then(server.healthCheck))
+	router.Get("/.well-known/jwks.json", standardChain.Then(server.getJWKS))
 
 	router.Post("/users", standardChain.Then(server.createUser))
 	router.Post("/users/login", standardChain.Then(server.loginUser))
+	router.Post("/users/login/verify_mfa", standardChain.Then(server.verifyMfa))
+	router.Post("/users/logout", standardChain.Then(server.logoutUser))
+	router.Post("/users/request_password_reset", standardChain.Then(server.requestPasswordReset))
+	router.Post("/users/reset_password", standardChain.Then(server.resetPassword))
 	router.Post("/tokens/renew_access", standardChain.Then(server.renewAccessToken))
 
+	router.Get("/sessions", authenticatedChain.Then(server.listSessions))
+	router.Delete("/sessions/{id}", authenticatedChain.Then(server.revokeSession))
+	router.Post("/sessions/revoke_others", authenticatedChain.Then(server.revokeOtherSessions))
+	router.Post("/users/{username}/block_sessions", authenticatedChain.Then(server.blockUserSessions))
+	router.Post("/users/{username}/unlock", authenticatedChain.Then(server.unlockUser))
+
+	router.Post("/api_keys", authenticatedChain.Then(server.createApiKey))
+	router.Get("/api_keys", authenticatedChain.Then(server.listApiKeys))
+	router.Post("/api_keys/{id}/revoke", authenticatedChain.Then(server.revokeApiKey))
+
 	router.Post("/accounts", authenticatedChain.Then(server.createAccount))
-	router.Get("/accounts/{id}", authenticatedChain.Then(server.getAccount))
+	router.Get("/accounts/{account_number}", authenticatedChain.Then(server.getAccount))
 	router.Get("/accounts", authenticatedChain.Then(server.listAccounts))
 
 	router.Post("/transfers", authenticatedChain.Then(server.createTransfer))
+	router.Get("/transfers", authenticatedChain.Then(server.searchTransfers))
+	router.Get("/entries", authenticatedChain.Then(server.searchEntries))
+	router.Get("/recipients/lookup", authenticatedChain.Then(server.lookupRecipient))
 
 	server.router = router
 }
@@ -0,0 +1,302 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// searchRequest holds the query parameters shared by transfer and entry searches
+type searchRequest struct {
+	AccountID     int64  `validate:"excluded_with=AccountNumber,gte=0"`
+	AccountNumber string `validate:"omitempty,account_number"`
+	Direction     string `validate:"omitempty,oneof=incoming outgoing"`
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	MinAmount     string `validate:"omitempty,numeric"`
+	MaxAmount     string `validate:"omitempty,numeric"`
+	Currency      string `validate:"required_with=MinAmount MaxAmount,omitempty,currency"`
+	Sort          string `validate:"omitempty,oneof=newest oldest amount_desc amount_asc"`
+	PageID        int32  `validate:"required,min=1"`
+	PageSize      int32  `validate:"required,min=5,max=10"`
+}
+
+// searchFilters are the parsed filters of a searchRequest
+type searchFilters struct {
+	accountIDs    []int64
+	createdAfter  pgtype.Timestamptz
+	createdBefore pgtype.Timestamptz
+	minAmount     pgtype.Int8
+	maxAmount     pgtype.Int8
+}
+
+func (server *Server) readSearchRequest(qs url.Values, req *searchRequest) error {
+	if err := server.readInt64(qs, "account_id", 0, &req.AccountID); err != nil {
+		return err
+	}
+	req.AccountNumber = server.readString(qs, "account_number", "")
+	req.Direction = server.readString(qs, "direction", "")
+	if err := server.readTime(qs, "created_after", &req.CreatedAfter); err != nil {
+		return err
+	}
+	if err := server.readTime(qs, "created_before", &req.CreatedBefore); err != nil {
+		return err
+	}
+	req.MinAmount = server.readString(qs, "min_amount", "")
+	req.MaxAmount = server.readString(qs, "max_amount", "")
+	req.Currency = server.readString(qs, "currency", "")
+	req.Sort = server.readString(qs, "sort", util.SearchSortNewestFirst)
+	if err := server.readInt32(qs, "page_id", 1, &req.PageID); err != nil {
+		return err
+	}
+	return server.readInt32(qs, "page_size", 5, &req.PageSize)
+}
+
+// parseSearchFilters converts the search request into query filters, writing an error response when it is invalid.
+// The searched accounts are the requested one, or every account of the user in the search currency.
+func (server *Server) parseSearchFilters(w http.ResponseWriter, r *http.Request, req searchRequest) (searchFilters, bool) {
+	var filters searchFilters
+
+	if !req.CreatedAfter.IsZero() {
+		filters.createdAfter = pgtype.Timestamptz{Time: req.CreatedAfter, Valid: true}
+	}
+
+	if !req.CreatedBefore.IsZero() {
+		if filters.createdAfter.Valid && !req.CreatedAfter.Before(req.CreatedBefore) {
+			server.writeError(w, http.StatusBadRequest, fmt.Errorf("created_before must be after created_after"))
+			return filters, false
+		}
+		filters.createdBefore = pgtype.Timestamptz{Time: req.CreatedBefore, Valid: true}
+	}
+
+	if req.MinAmount != "" {
+		amount, err := util.ParseMoney(req.MinAmount, req.Currency)
+		if err != nil {
+			server.writeError(w, http.StatusBadRequest, err)
+			return filters, false
+		}
+		filters.minAmount = pgtype.Int8{Int64: amount.Amount, Valid: true}
+	}
+
+	if req.MaxAmount != "" {
+		amount, err := util.ParseMoney(req.MaxAmount, req.Currency)
+		if err != nil {
+			server.writeError(w, http.StatusBadRequest, err)
+			return filters, false
+		}
+		if filters.minAmount.Valid && amount.Amount < filters.minAmount.Int64 {
+			server.writeError(w, http.StatusBadRequest, fmt.Errorf("max_amount must not be less than min_amount"))
+			return filters, false
+		}
+		filters.maxAmount = pgtype.Int8{Int64: amount.Amount, Valid: true}
+	}
+
+	// amounts are only comparable within a currency
+	currency := ""
+	if filters.minAmount.Valid || filters.maxAmount.Valid {
+		currency = req.Currency
+	}
+
+	if req.AccountID == 0 && req.AccountNumber == "" {
+		authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+		accounts, err := server.store.ListAllMemberAccounts(r.Context(), authPayload.Username)
+		if err != nil {
+			server.writeError(w, http.StatusInternalServerError, err)
+			return filters, false
+		}
+
+		filters.accountIDs = []int64{}
+		for _, account := range accounts {
+			if currency == "" || account.Currency == currency {
+				filters.accountIDs = append(filters.accountIDs, account.ID)
+			}
+		}
+		return filters, true
+	}
+
+	var account persistence.Account
+	var err error
+	if req.AccountNumber != "" {
+		account, err = server.store.GetAccountByNumber(r.Context(), util.NormalizeAccountNumber(req.AccountNumber))
+	} else {
+		account, err = server.store.GetAccount(r.Context(), req.AccountID)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			server.writeError(w, http.StatusForbidden, fmt.Errorf("account doesn't belong to the authenticated user"))
+			return filters, false
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return filters, false
+	}
+
+	if !server.authorizeAccount(w, r, account.ID, util.AccountViewOnlyRole) {
+		return filters, false
+	}
+
+	if currency != "" && account.Currency != currency {
+		server.writeError(w, http.StatusBadRequest, fmt.Errorf("account currency mismatch: expected %s, got %s", account.Currency, currency))
+		return filters, false
+	}
+
+	filters.accountIDs = []int64{account.ID}
+	return filters, true
+}
+
+type searchTransfersRequest struct {
+	searchRequest
+	CounterpartyAccountID     int64  `validate:"excluded_with=CounterpartyAccountNumber,gte=0"`
+	CounterpartyAccountNumber string `validate:"omitempty,account_number"`
+	Reference                 string `validate:"max=140"`
+}
+
+// searchTransfers finds the transfers into or out of the user's accounts that match every given filter
+func (server *Server) searchTransfers(w http.ResponseWriter, r *http.Request) {
+	var req searchTransfersRequest
+
+	qs := r.URL.Query()
+	if err := server.readSearchRequest(qs, &req.searchRequest); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := server.readInt64(qs, "counterparty_account_id", 0, &req.CounterpartyAccountID); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req.CounterpartyAccountNumber = server.readString(qs, "counterparty_account_number", "")
+	req.Reference = server.readString(qs, "reference", "")
+
+	if err := server.validate.Struct(req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	filters, valid := server.parseSearchFilters(w, r, req.searchRequest)
+	if !valid {
+		return
+	}
+
+	arg := persistence.SearchTransfersParams{
+		Direction:     req.Direction,
+		AccountIds:    filters.accountIDs,
+		CreatedAfter:  filters.createdAfter,
+		CreatedBefore: filters.createdBefore,
+		MinAmount:     filters.minAmount,
+		MaxAmount:     filters.maxAmount,
+		Sort:          req.Sort,
+		Limit:         req.PageSize,
+		Offset:        (req.PageID - 1) * req.PageSize,
+	}
+
+	rsp := []transferResponse{}
+
+	if req.CounterpartyAccountID != 0 || req.CounterpartyAccountNumber != "" {
+		var counterparty persistence.Account
+		var err error
+		if req.CounterpartyAccountNumber != "" {
+			counterparty, err = server.store.GetAccountByNumber(r.Context(), util.NormalizeAccountNumber(req.CounterpartyAccountNumber))
+		} else {
+			counterparty, err = server.store.GetAccount(r.Context(), req.CounterpartyAccountID)
+		}
+		if err != nil {
+			// an unknown counterparty has no transfers; reporting it would let account numbers be probed
+			if errors.Is(err, pgx.ErrNoRows) {
+				if err := server.writeJSON(w, http.StatusOK, rsp, nil); err != nil {
+					server.writeError(w, http.StatusInternalServerError, err)
+				}
+				return
+			}
+
+			server.writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		arg.CounterpartyAccountID = pgtype.Int8{Int64: counterparty.ID, Valid: true}
+	}
+
+	if req.Reference != "" {
+		arg.Reference = pgtype.Text{String: persistence.ContainsPattern(req.Reference), Valid: true}
+	}
+
+	transfers, err := server.store.SearchTransfers(r.Context(), arg)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	for _, transfer := range transfers {
+		rsp = append(rsp, transferResponse{
+			ID:                transfer.ID,
+			FromAccountNumber: transfer.FromAccountNumber,
+			ToAccountNumber:   transfer.ToAccountNumber,
+			Amount:            util.Money{Amount: transfer.Amount, Currency: transfer.Currency},
+			Reference:         transfer.Reference,
+			CreatedAt:         transfer.CreatedAt,
+		})
+	}
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// searchEntries finds the entries of the user's accounts that match every given filter.
+// Amount filters apply to the absolute amount, so they work the same for both directions.
+func (server *Server) searchEntries(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	if err := server.readSearchRequest(r.URL.Query(), &req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := server.validate.Struct(req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	filters, valid := server.parseSearchFilters(w, r, req)
+	if !valid {
+		return
+	}
+
+	entries, err := server.store.SearchEntries(r.Context(), persistence.SearchEntriesParams{
+		AccountIds:    filters.accountIDs,
+		Direction:     req.Direction,
+		CreatedAfter:  filters.createdAfter,
+		CreatedBefore: filters.createdBefore,
+		MinAmount:     filters.minAmount,
+		MaxAmount:     filters.maxAmount,
+		Sort:          req.Sort,
+		Limit:         req.PageSize,
+		Offset:        (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp := make([]entryResponse, 0, len(entries))
+	for _, entry := range entries {
+		rsp = append(rsp, entryResponse{
+			ID:            entry.ID,
+			AccountNumber: entry.AccountNumber,
+			Amount:        util.Money{Amount: entry.Amount, Currency: entry.Currency},
+			CreatedAt:     entry.CreatedAt,
+		})
+	}
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -10,27 +10,42 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/internal/token"
 	"github.com/RobinHood3082/simplebank/pkg/router"
+	"github.com/RobinHood3082/simplebank/risk"
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
 	"github.com/go-playground/validator/v10"
 )
 
 // Server serves HTTP requests for our banking service
 type Server struct {
-	store      persistence.Store
-	router     *router.Router
-	logger     *slog.Logger
-	validate   *validator.Validate
-	tokenMaker token.Maker
-	config     util.Config
+	store           persistence.Store
+	router          *router.Router
+	logger          *slog.Logger
+	validate        *validator.Validate
+	tokenMaker      token.Maker
+	passwordHasher  util.PasswordHasher
+	config          util.Config
+	taskDistributor worker.TaskDistributor
+	riskEngine      *risk.Engine
+	// dummyPasswordHash is checked for unknown usernames, so they take as long to reject as wrong passwords
+	dummyPasswordHash string
 }
 
 // NewServer creates a new HTTP server and set up routing
-func NewServer(store persistence.Store, logger *slog.Logger, validate *validator.Validate, tokenMaker token.Maker, config util.Config) *Server {
-	server := &Server{store: store, logger: logger, validate: validate, tokenMaker: tokenMaker, config: config}
+func NewServer(store persistence.Store, logger *slog.Logger, validate *validator.Validate, tokenMaker token.Maker, passwordHasher util.PasswordHasher, config util.Config, taskDistributor worker.TaskDistributor, riskEngine *risk.Engine) *Server {
+	server := &Server{store: store, logger: logger, validate: validate, tokenMaker: tokenMaker, passwordHasher: passwordHasher, config: config, taskDistributor: taskDistributor, riskEngine: riskEngine}
+
+	var err error
+	server.dummyPasswordHash, err = passwordHasher.HashPassword(util.RandomString(32))
+	if err != nil {
+		logger.Error("Cannot hash the dummy password, unknown usernames are rejected faster than wrong passwords", "Error", err)
+	}
+
 	server.getRoutes()
 	return server
 }
@@ -183,6 +198,24 @@ func (server *Server) readInt32(qs url.Values, key string, defaultValue int, des
 	return nil
 }
 
+// The readTime() helper reads an RFC 3339 timestamp from the query string. If no matching
+// key could be found it leaves dest as the zero time. If the value couldn't be parsed, then
+// we return an error.
+func (server *Server) readTime(qs url.Values, key string, dest *time.Time) error {
+	s := qs.Get(key)
+	if s == "" {
+		*dest = time.Time{}
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s, expected an RFC 3339 timestamp", key)
+	}
+
+	*dest = t
+	return nil
+}
+
 // The readInt64() helper reads a string value from the query string and converts it to an
 // int64 before returning. If no matching key could be found it returns the provided
 // default value. If the value couldn't be converted to an integer, then we return an
@@ -0,0 +1,229 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type sessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIp  string    `json:"client_ip"`
+	IsBlocked bool      `json:"is_blocked"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newSessionResponse(session persistence.Session) sessionResponse {
+	return sessionResponse{
+		ID:        uuid.UUID(session.ID.Bytes),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		ExpiresAt: session.ExpiresAt.Time,
+		CreatedAt: session.CreatedAt.Time,
+	}
+}
+
+func (server *Server) listSessions(w http.ResponseWriter, r *http.Request) {
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+	sessions, err := server.store.ListSessions(r.Context(), authPayload.Username)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp := make([]sessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		rsp = append(rsp, newSessionResponse(session))
+	}
+
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func (server *Server) revokeSession(w http.ResponseWriter, r *http.Request) {
+	session, ok := server.authorizeSession(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	session, err := server.store.BlockSession(r.Context(), persistence.BlockSessionParams{
+		ID:       session.ID,
+		Username: session.Username,
+	})
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = server.writeJSON(w, http.StatusOK, newSessionResponse(session), nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+type revokeOtherSessionsRequest struct {
+	SessionID string `json:"session_id" validate:"required,uuid"`
+}
+
+type revokeOtherSessionsResponse struct {
+	RevokedSessions int64 `json:"revoked_sessions"`
+}
+
+func (server *Server) revokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	var req revokeOtherSessionsRequest
+	if err := server.bindData(w, r, &req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	session, ok := server.authorizeSession(w, r, req.SessionID)
+	if !ok {
+		return
+	}
+
+	if session.IsBlocked {
+		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("session is blocked"))
+		return
+	}
+
+	revoked, err := server.store.BlockOtherSessions(r.Context(), persistence.BlockOtherSessionsParams{
+		Username: session.Username,
+		ID:       session.ID,
+	})
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = server.writeJSON(w, http.StatusOK, revokeOtherSessionsResponse{RevokedSessions: revoked}, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+type logoutUserRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// logoutUser blocks the session of a refresh token, it needs no access token
+func (server *Server) logoutUser(w http.ResponseWriter, r *http.Request) {
+	var req logoutUserRequest
+	if err := server.bindData(w, r, &req); err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken)
+	if err != nil {
+		server.writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	refreshToken, err := server.store.GetRefreshToken(r.Context(), pgtype.UUID{Bytes: refreshPayload.ID, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			server.writeError(w, http.StatusNotFound, err)
+			return
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !util.CheckToken(req.RefreshToken, refreshToken.TokenHash) {
+		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("mismatched refresh token"))
+		return
+	}
+
+	session, err := server.store.GetSession(r.Context(), refreshToken.SessionID)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if session.Username != refreshPayload.Username {
+		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("mismatched refresh token"))
+		return
+	}
+
+	_, err = server.store.BlockSession(r.Context(), persistence.BlockSessionParams{
+		ID:       session.ID,
+		Username: session.Username,
+	})
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type blockUserSessionsResponse struct {
+	BlockedSessions int64 `json:"blocked_sessions"`
+}
+
+// blockUserSessions blocks every session of a user
+func (server *Server) blockUserSessions(w http.ResponseWriter, r *http.Request) {
+	user, err := server.store.GetUser(r.Context(), r.PathValue("username"))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			server.writeError(w, http.StatusNotFound, fmt.Errorf("user not found"))
+			return
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	blocked, err := server.store.BlockUserSessions(r.Context(), user.Username)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = server.writeJSON(w, http.StatusOK, blockUserSessionsResponse{BlockedSessions: blocked}, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// authorizeSession checks that the session belongs to the authenticated user,
+// other users' sessions are reported as not found
+func (server *Server) authorizeSession(w http.ResponseWriter, r *http.Request, sessionID string) (persistence.Session, bool) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		server.writeError(w, http.StatusBadRequest, fmt.Errorf("session ID must be a valid UUID"))
+		return persistence.Session{}, false
+	}
+
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+	session, err := server.store.GetSession(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			server.writeError(w, http.StatusNotFound, fmt.Errorf("session not found"))
+			return session, false
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return session, false
+	}
+
+	if session.Username != authPayload.Username {
+		server.writeError(w, http.StatusNotFound, fmt.Errorf("session not found"))
+		return session, false
+	}
+
+	return session, true
+}
@@ -1,10 +1,13 @@
 package app
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -13,9 +16,13 @@ type renewAccessTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+// renewAccessTokenResponse carries a new refresh token as well, the one that was renewed can no longer be used
 type renewAccessTokenResponse struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	SessionID             uuid.UUID `json:"session_id"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
 func (server *Server) renewAccessToken(w http.ResponseWriter, r *http.Request) {
@@ -31,40 +38,48 @@ func (server *Server) renewAccessToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := server.store.GetSession(r.Context(), pgtype.UUID{Bytes: refreshPayload.ID, Valid: true})
+	var refreshToken string
+	var newRefreshPayload *token.Payload
+	txResult, err := server.store.RotateRefreshTokenTx(r.Context(), persistence.RotateRefreshTokenTxParams{
+		ID:           pgtype.UUID{Bytes: refreshPayload.ID, Valid: true},
+		RefreshToken: req.RefreshToken,
+		Username:     refreshPayload.Username,
+		IssueRefreshToken: func(session persistence.Session, user persistence.User) (persistence.IssuedRefreshToken, error) {
+			var err error
+			refreshToken, newRefreshPayload, err = server.tokenMaker.CreateToken(
+				user.Username,
+				user.Role,
+				time.Until(session.ExpiresAt.Time),
+			)
+			if err != nil {
+				return persistence.IssuedRefreshToken{}, err
+			}
+
+			return persistence.IssuedRefreshToken{
+				ID:        pgtype.UUID{Bytes: newRefreshPayload.ID, Valid: true},
+				Token:     refreshToken,
+				ExpiresAt: newRefreshPayload.ExpiredAt,
+			}, nil
+		},
+	})
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
 			server.writeError(w, http.StatusNotFound, err)
-			return
+		case errors.Is(err, persistence.ErrRefreshTokenReused),
+			errors.Is(err, persistence.ErrRefreshTokenMismatch),
+			errors.Is(err, persistence.ErrSessionBlocked),
+			errors.Is(err, persistence.ErrSessionExpired):
+			server.writeError(w, http.StatusUnauthorized, err)
+		default:
+			server.writeError(w, http.StatusInternalServerError, err)
 		}
-
-		server.writeError(w, http.StatusInternalServerError, err)
-		return
-	}
-
-	if session.IsBlocked {
-		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("session is blocked"))
-		return
-	}
-
-	if session.Username != refreshPayload.Username {
-		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("refresh token is not valid for this user"))
-		return
-	}
-
-	if session.RefreshToken != req.RefreshToken {
-		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("mismatched refresh token"))
-		return
-	}
-
-	if time.Now().After(session.ExpiresAt.Time) {
-		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("session has expired"))
 		return
 	}
 
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		refreshPayload.Username,
-		refreshPayload.Role,
+		txResult.User.Username,
+		txResult.User.Role,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -73,8 +88,11 @@ func (server *Server) renewAccessToken(w http.ResponseWriter, r *http.Request) {
 	}
 
 	rsp := renewAccessTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+		SessionID:             txResult.Session.ID.Bytes,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: newRefreshPayload.ExpiredAt,
 	}
 
 	err = server.writeJSON(w, http.StatusOK, rsp, nil)
@@ -1,19 +1,84 @@
 package app
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/risk"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type createTransferRequest struct {
-	FromAccountID int64  `json:"from_account_id" validate:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" validate:"required,min=1"`
-	Amount        int64  `json:"amount" validate:"required,gt=0"`
-	Currency      string `json:"currency" validate:"required,currency"`
+	FromAccountID     int64  `json:"from_account_id" validate:"required_without=FromAccountNumber,excluded_with=FromAccountNumber,gte=0"`
+	FromAccountNumber string `json:"from_account_number" validate:"omitempty,account_number"`
+	ToAccountID       int64  `json:"to_account_id" validate:"required_without_all=ToAccountNumber BeneficiaryID Recipient,excluded_with=ToAccountNumber BeneficiaryID Recipient,gte=0"`
+	ToAccountNumber   string `json:"to_account_number" validate:"omitempty,account_number,excluded_with=BeneficiaryID Recipient"`
+	BeneficiaryID     int64  `json:"beneficiary_id" validate:"excluded_with=Recipient,gte=0"`
+	Recipient         string `json:"recipient" validate:"omitempty,recipient"`
+	Amount            string `json:"amount" validate:"required,numeric"`
+	Currency          string `json:"currency" validate:"required,currency"`
+	Reference         string `json:"reference" validate:"max=140"`
+}
+
+type transferResponse struct {
+	ID                int64              `json:"id"`
+	FromAccountNumber string             `json:"from_account_number"`
+	ToAccountNumber   string             `json:"to_account_number"`
+	Amount            util.Money         `json:"amount"`
+	Reference         string             `json:"reference"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+type entryResponse struct {
+	ID            int64              `json:"id"`
+	AccountNumber string             `json:"account_number"`
+	Amount        util.Money         `json:"amount"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type transferTxResponse struct {
+	Transfer    transferResponse `json:"transfer"`
+	FromAccount accountResponse  `json:"from_account"`
+	ToAccount   accountResponse  `json:"to_account"`
+	FromEntry   entryResponse    `json:"from_entry"`
+	ToEntry     entryResponse    `json:"to_entry"`
+}
+
+func newTransferResponse(transfer persistence.Transfer, fromAccount, toAccount persistence.Account) transferResponse {
+	return transferResponse{
+		ID:                transfer.ID,
+		FromAccountNumber: fromAccount.AccountNumber,
+		ToAccountNumber:   toAccount.AccountNumber,
+		Amount:            util.Money{Amount: transfer.Amount, Currency: fromAccount.Currency},
+		Reference:         transfer.Reference,
+		CreatedAt:         transfer.CreatedAt,
+	}
+}
+
+func newEntryResponse(entry persistence.Entry, account persistence.Account) entryResponse {
+	return entryResponse{
+		ID:            entry.ID,
+		AccountNumber: account.AccountNumber,
+		Amount:        util.Money{Amount: entry.Amount, Currency: account.Currency},
+		CreatedAt:     entry.CreatedAt,
+	}
+}
+
+func newTransferTxResponse(result persistence.TransferTxResult) transferTxResponse {
+	return transferTxResponse{
+		Transfer:    newTransferResponse(result.Transfer, result.FromAccount, result.ToAccount),
+		FromAccount: newAccountResponse(result.FromAccount),
+		ToAccount:   newAccountResponse(result.ToAccount),
+		FromEntry:   newEntryResponse(result.FromEntry, result.FromAccount),
+		ToEntry:     newEntryResponse(result.ToEntry, result.ToAccount),
+	}
 }
 
 func (server *Server) createTransfer(w http.ResponseWriter, r *http.Request) {
@@ -23,36 +88,138 @@ func (server *Server) createTransfer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fromAccount, valid := server.validAccount(w, r, req.FromAccountID, req.Currency)
+	amount, err := util.ParseMoney(req.Amount, req.Currency)
+	if err != nil {
+		server.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !amount.IsPositive() {
+		server.writeError(w, http.StatusBadRequest, fmt.Errorf("amount must be positive"))
+		return
+	}
+
+	fromAccountID := req.FromAccountID
+	if req.FromAccountNumber != "" {
+		account, valid := server.validAccountNumber(w, r, req.FromAccountNumber)
+		if !valid {
+			return
+		}
+
+		fromAccountID = account.ID
+	}
+
+	fromAccount, valid := server.validAccount(w, r, fromAccountID, req.Currency)
 	if !valid {
 		return
 	}
 
-	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		server.writeError(w, http.StatusUnauthorized, fmt.Errorf("account doesn't belong to the authenticated user"))
+	if !server.authorizeAccount(w, r, fromAccountID, util.AccountCanTransferRole) {
 		return
 	}
 
-	_, valid = server.validAccount(w, r, req.ToAccountID, req.Currency)
+	toAccountID := req.ToAccountID
+	if req.ToAccountNumber != "" {
+		account, valid := server.validAccountNumber(w, r, req.ToAccountNumber)
+		if !valid {
+			return
+		}
+
+		toAccountID = account.ID
+	}
+
+	if req.Recipient != "" {
+		recipient, valid := server.validRecipient(w, r, req.Recipient, req.Currency)
+		if !valid {
+			return
+		}
+
+		toAccountID = recipient.Account.ID
+	}
+
+	if req.BeneficiaryID != 0 {
+		beneficiary, valid := server.validBeneficiary(w, r, req.BeneficiaryID, req.Currency)
+		if !valid {
+			return
+		}
+
+		toAccountID = beneficiary.AccountID
+	}
+
+	toAccount, valid := server.validAccount(w, r, toAccountID, req.Currency)
 	if !valid {
 		return
 	}
 
-	arg := persistence.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+	assessment, valid := server.assessTransfer(w, r, authPayload.Username, fromAccountID, toAccountID, amount)
+	if !valid {
+		return
 	}
 
-	Transfer, err := server.store.TransferTx(r.Context(), arg)
+	findings, err := json.Marshal(assessment.Findings)
 	if err != nil {
 		server.writeError(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	server.logger.Info("Transfer created", "Transfer", Transfer)
-	err = server.writeJSON(w, http.StatusOK, Transfer, nil)
+	arg := persistence.AssessedTransferTxParams{
+		TransferTxParams: persistence.TransferTxParams{
+			FromAccountID:    fromAccountID,
+			ToAccountID:      toAccountID,
+			Amount:           amount,
+			Reference:        req.Reference,
+			SentBy:           authPayload.Username,
+			BeneficiaryLimit: server.beneficiaryLimit(),
+		},
+		TransferAssessment: persistence.TransferAssessment{
+			RequestedBy: authPayload.Username,
+			ClientIp:    r.RemoteAddr,
+			Decision:    string(assessment.Decision),
+			Findings:    findings,
+		},
+		Hold: assessment.Decision == risk.Block,
+	}
+
+	result, err := server.store.AssessedTransferTx(r.Context(), arg)
+	if err != nil {
+		if errors.Is(err, persistence.ErrInsufficientFunds) || errors.Is(err, persistence.ErrBeneficiaryLimitExceeded) {
+			server.writeError(w, http.StatusForbidden, err)
+			return
+		}
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// blocked transfers wait for a banker to approve or reject them
+	if assessment.Decision == risk.Block {
+		server.logger.Info("Transfer held for review", "Assessment", assessment, "ID", result.Assessment.ID)
+
+		err = server.writeJSON(w, http.StatusAccepted, newHeldTransferResponse(result.Assessment, fromAccount, toAccount), nil)
+		if err != nil {
+			server.writeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	transfer := result.Transfer
+	server.logger.Info("Transfer created", "Transfer", transfer, "Assessment", assessment)
+
+	data := webhook.TransferData{
+		ID:                transfer.Transfer.ID,
+		FromAccountNumber: transfer.FromAccount.AccountNumber,
+		ToAccountNumber:   transfer.ToAccount.AccountNumber,
+		Amount:            amount,
+		Reference:         transfer.Transfer.Reference,
+		CreatedAt:         transfer.Transfer.CreatedAt.Time,
+	}
+
+	err = server.taskDistributor.DistributeWebhookEvent(r.Context(), webhook.EventTransferCreated, data, fromAccountID, toAccountID)
+	if err != nil {
+		server.logger.Error("failed to distribute webhook event", "error", err)
+	}
+
+	err = server.writeJSON(w, http.StatusOK, newTransferTxResponse(transfer), nil)
 	if err != nil {
 		server.writeError(w, http.StatusInternalServerError, err)
 	}
@@ -78,3 +245,108 @@ func (server *Server) validAccount(w http.ResponseWriter, r *http.Request, accou
 
 	return account, true
 }
+
+// validAccountNumber looks up the account with the given account number
+func (server *Server) validAccountNumber(w http.ResponseWriter, r *http.Request, number string) (persistence.Account, bool) {
+	account, err := server.store.GetAccountByNumber(r.Context(), util.NormalizeAccountNumber(number))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			server.writeError(w, http.StatusNotFound, fmt.Errorf("account %s not found", util.MaskAccountNumber(number)))
+			return account, false
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return account, false
+	}
+
+	return account, true
+}
+
+// validRecipient resolves a username, verified email or @handle to the recipient's account in the currency
+func (server *Server) validRecipient(w http.ResponseWriter, r *http.Request, recipient string, currency string) (persistence.Recipient, bool) {
+	result, err := server.store.ResolveRecipient(r.Context(), recipient, currency)
+	if err != nil {
+		if errors.Is(err, persistence.ErrRecipientNotFound) {
+			server.writeError(w, http.StatusNotFound, err)
+			return result, false
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return result, false
+	}
+
+	return result, true
+}
+
+type lookupRecipientRequest struct {
+	Recipient string `validate:"required,recipient"`
+	Currency  string `validate:"required,currency"`
+}
+
+type lookupRecipientResponse struct {
+	DisplayName string `json:"display_name"`
+	Currency    string `json:"currency"`
+}
+
+// lookupRecipient lets a sender confirm who a recipient is before sending, without revealing their account
+func (server *Server) lookupRecipient(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	req := lookupRecipientRequest{
+		Recipient: server.readString(qs, "recipient", ""),
+		Currency:  server.readString(qs, "currency", ""),
+	}
+
+	if err := server.validate.Struct(req); err != nil {
+		server.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid recipient or currency"))
+		return
+	}
+
+	recipient, valid := server.validRecipient(w, r, req.Recipient, req.Currency)
+	if !valid {
+		return
+	}
+
+	rsp := lookupRecipientResponse{
+		DisplayName: util.MaskName(recipient.User.FullName),
+		Currency:    recipient.Account.Currency,
+	}
+
+	err := server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// validBeneficiary checks that the beneficiary belongs to the authenticated user and receives the given currency
+func (server *Server) validBeneficiary(w http.ResponseWriter, r *http.Request, beneficiaryID int64, currency string) (persistence.Beneficiary, bool) {
+	beneficiary, err := server.store.GetBeneficiary(r.Context(), beneficiaryID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			server.writeError(w, http.StatusNotFound, fmt.Errorf("beneficiary with ID %d not found", beneficiaryID))
+			return beneficiary, false
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return beneficiary, false
+	}
+
+	authPayload := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+	if beneficiary.Owner != authPayload.Username {
+		server.writeError(w, http.StatusNotFound, fmt.Errorf("beneficiary with ID %d not found", beneficiaryID))
+		return beneficiary, false
+	}
+
+	if beneficiary.Currency != currency {
+		server.writeError(w, http.StatusBadRequest, fmt.Errorf("beneficiary currency mismatch: expected %s, got %s", beneficiary.Currency, currency))
+		return beneficiary, false
+	}
+
+	return beneficiary, true
+}
+
+func (server *Server) beneficiaryLimit() persistence.BeneficiaryLimit {
+	return persistence.BeneficiaryLimit{
+		CoolingOffPeriod: server.config.BeneficiaryCoolingOffPeriod,
+		Units:            server.config.NewBeneficiaryTransferLimit,
+	}
+}
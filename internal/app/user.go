@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/RobinHood3082/simplebank/internal/persistence"
-	"github.com/RobinHood3082/simplebank/util"
 	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
@@ -24,6 +23,7 @@ type userResponse struct {
 	Username          string             `json:"username"`
 	FullName          string             `json:"full_name"`
 	Email             string             `json:"email"`
+	Handle            string             `json:"handle,omitempty"`
 	PasswordChangedAt pgtype.Timestamptz `json:"password_changed_at"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 }
@@ -33,6 +33,7 @@ func newUserResponse(user persistence.User) userResponse {
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
+		Handle:            user.Handle.String,
 		PasswordChangedAt: user.PasswordChangedAt,
 		CreatedAt:         user.CreatedAt,
 	}
@@ -45,7 +46,7 @@ func (server *Server) createUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hashedPassword, err := util.HashPassword(req.Password)
+	hashedPassword, err := server.passwordHasher.HashPassword(req.Password)
 	if err != nil {
 		server.writeError(w, http.StatusInternalServerError, err)
 		return
@@ -95,6 +96,9 @@ type loginUserResponse struct {
 	User                  userResponse `json:"user"`
 }
 
+// loginUser checks the password of a user and starts a session, unless two-factor authentication
+// is required, in which case verifyMfa starts it. Failed logins are throttled per username and client
+// address, and lock the username after too many.
 func (server *Server) loginUser(w http.ResponseWriter, r *http.Request) {
 	var req loginUserRequest
 	if err := server.bindData(w, r, &req); err != nil {
@@ -102,10 +106,15 @@ func (server *Server) loginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if server.writeLoginThrottled(w, r, req.Username) {
+		return
+	}
+
 	user, err := server.store.GetUser(r.Context(), req.Username)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			server.writeError(w, http.StatusNotFound, err)
+			_ = server.passwordHasher.CheckPassword(req.Password, server.dummyPasswordHash)
+			server.writeFailedLogin(w, r, req.Username, false)
 			return
 		}
 
@@ -113,19 +122,45 @@ func (server *Server) loginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := util.CheckPassword(req.Password, user.HashedPassword); err != nil {
-		server.writeError(w, http.StatusUnauthorized, err)
+	if err := server.passwordHasher.CheckPassword(req.Password, user.HashedPassword); err != nil {
+		server.writeFailedLogin(w, r, user.Username, true)
 		return
 	}
 
+	server.rehashPassword(r.Context(), user, req.Password)
+
+	// failed logins are only forgotten once the login is complete, verifyMfa does it for logins with a second step
+	if server.writeMfaChallenge(w, r, user) {
+		return
+	}
+
+	if _, err := server.store.DeleteLoginThrottle(r.Context(), user.Username); err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp, err := server.startSession(r, user)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.logger.Info("User logged in", "Username", user.Username)
+	err = server.writeJSON(w, http.StatusOK, rsp, nil)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// startSession creates the access and refresh tokens of a user who has logged in, and their session
+func (server *Server) startSession(r *http.Request, user persistence.User) (loginUserResponse, error) {
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
-		server.writeError(w, http.StatusInternalServerError, err)
-		return
+		return loginUserResponse{}, err
 	}
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
@@ -134,36 +169,30 @@ func (server *Server) loginUser(w http.ResponseWriter, r *http.Request) {
 		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
-		server.writeError(w, http.StatusInternalServerError, err)
-		return
+		return loginUserResponse{}, err
 	}
 
-	session, err := server.store.CreateSession(r.Context(), persistence.CreateSessionParams{
-		ID:           pgtype.UUID{Bytes: refreshPayload.ID, Valid: true},
-		Username:     user.Username,
+	txResult, err := server.store.CreateSessionTx(r.Context(), persistence.CreateSessionTxParams{
+		CreateSessionParams: persistence.CreateSessionParams{
+			ID:        pgtype.UUID{Bytes: refreshPayload.ID, Valid: true},
+			Username:  user.Username,
+			UserAgent: r.UserAgent(),
+			ClientIp:  r.RemoteAddr,
+			IsBlocked: false,
+			ExpiresAt: pgtype.Timestamptz{Time: refreshPayload.ExpiredAt, Valid: true},
+		},
 		RefreshToken: refreshToken,
-		UserAgent:    r.UserAgent(),
-		ClientIp:     r.RemoteAddr,
-		IsBlocked:    false,
-		ExpiresAt:    pgtype.Timestamptz{Time: refreshPayload.ExpiredAt, Valid: true},
 	})
 	if err != nil {
-		server.writeError(w, http.StatusInternalServerError, err)
-		return
+		return loginUserResponse{}, err
 	}
 
-	rsp := loginUserResponse{
-		SessionID:             session.ID.Bytes,
+	return loginUserResponse{
+		SessionID:             txResult.Session.ID.Bytes,
 		AccessToken:           accessToken,
 		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
 		RefreshToken:          refreshToken,
 		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
 		User:                  newUserResponse(user),
-	}
-
-	server.logger.Info("User logged in", "User", user)
-	err = server.writeJSON(w, http.StatusOK, rsp, nil)
-	if err != nil {
-		server.writeError(w, http.StatusInternalServerError, err)
-	}
+	}, nil
 }
@@ -1,19 +1,79 @@
 package app
 
 import (
-	"github.com/RobinHood3082/simplebank/util"
+	simplebankvalidator "github.com/RobinHood3082/simplebank/pkg/validator"
 	"github.com/go-playground/validator/v10"
 )
 
 var validCurrency validator.Func = func(fl validator.FieldLevel) bool {
 	if currency, ok := fl.Field().Interface().(string); ok {
-		return util.IsCurrencySupported(currency)
+		return simplebankvalidator.ValidateCurrency(currency) == nil
+	}
+	return false
+}
+
+var validAccountCurrency validator.Func = func(fl validator.FieldLevel) bool {
+	if currency, ok := fl.Field().Interface().(string); ok {
+		return simplebankvalidator.ValidateAccountCurrency(currency) == nil
+	}
+	return false
+}
+
+var validRecipient validator.Func = func(fl validator.FieldLevel) bool {
+	if recipient, ok := fl.Field().Interface().(string); ok {
+		return simplebankvalidator.ValidateRecipient(recipient) == nil
+	}
+	return false
+}
+
+var validAccountNumber validator.Func = func(fl validator.FieldLevel) bool {
+	if number, ok := fl.Field().Interface().(string); ok {
+		return simplebankvalidator.ValidateAccountNumber(number) == nil
+	}
+	return false
+}
+
+var validApiKeyScopes validator.Func = func(fl validator.FieldLevel) bool {
+	if scopes, ok := fl.Field().Interface().([]string); ok {
+		return simplebankvalidator.ValidateApiKeyScopes(scopes) == nil
+	}
+	return false
+}
+
+var validIPAllowlist validator.Func = func(fl validator.FieldLevel) bool {
+	if allowlist, ok := fl.Field().Interface().([]string); ok {
+		return simplebankvalidator.ValidateIPAllowlist(allowlist) == nil
 	}
 	return false
 }
 
 func SetupValidation(validate *validator.Validate) error {
 	err := validate.RegisterValidation("currency", validCurrency)
+	if err != nil {
+		return err
+	}
+
+	err = validate.RegisterValidation("recipient", validRecipient)
+	if err != nil {
+		return err
+	}
+
+	err = validate.RegisterValidation("account_number", validAccountNumber)
+	if err != nil {
+		return err
+	}
+
+	err = validate.RegisterValidation("account_currency", validAccountCurrency)
+	if err != nil {
+		return err
+	}
+
+	err = validate.RegisterValidation("api_key_scopes", validApiKeyScopes)
+	if err != nil {
+		return err
+	}
+
+	err = validate.RegisterValidation("ip_allowlist", validIPAllowlist)
 
 	return err
 }
@@ -0,0 +1,39 @@
+CREATE TABLE "account_members" (
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "username")
+);
+
+CREATE TABLE "account_invitations" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "invited_by" varchar NOT NULL,
+  "secret_code" varchar NOT NULL,
+  "is_accepted" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '7 days')
+);
+
+CREATE INDEX ON "account_members" ("username");
+
+CREATE INDEX ON "account_invitations" ("account_id");
+
+COMMENT ON COLUMN "account_members"."role" IS 'owner, can-transfer or view-only';
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_invitations" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_invitations" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_invitations" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+-- every existing account is owned by its creator
+INSERT INTO "account_members" ("account_id", "username", "role")
+SELECT "id", "owner", 'owner' FROM "accounts";
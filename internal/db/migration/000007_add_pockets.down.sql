@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "pocket_id";
+
+DROP TABLE IF EXISTS "pockets";
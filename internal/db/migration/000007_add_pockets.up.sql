@@ -0,0 +1,22 @@
+CREATE TABLE "pockets" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "balance" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "pocket_balance_non_negative" CHECK ("balance" >= 0)
+);
+
+CREATE INDEX ON "pockets" ("account_id");
+
+ALTER TABLE "pockets" ADD CONSTRAINT "account_pocket_name_key" UNIQUE ("account_id", "name");
+
+COMMENT ON COLUMN "pockets"."balance" IS 'ring-fenced part of the parent account balance';
+
+ALTER TABLE "pockets" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "entries" ADD COLUMN "pocket_id" bigint;
+
+COMMENT ON COLUMN "entries"."pocket_id" IS 'set when the entry moves money into or out of a pocket';
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("pocket_id") REFERENCES "pockets" ("id");
@@ -0,0 +1,20 @@
+CREATE TABLE "beneficiaries" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "beneficiaries" ("owner");
+
+ALTER TABLE "beneficiaries" ADD CONSTRAINT "owner_nickname_key" UNIQUE ("owner", "nickname");
+
+ALTER TABLE "beneficiaries" ADD CONSTRAINT "owner_beneficiary_account_key" UNIQUE ("owner", "account_id");
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE INDEX ON "transfers" ("from_account_id", "to_account_id", "created_at");
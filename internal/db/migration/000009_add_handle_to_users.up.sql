@@ -0,0 +1,3 @@
+ALTER TABLE "users" ADD COLUMN "handle" varchar UNIQUE;
+
+COMMENT ON COLUMN "users"."handle" IS 'user-chosen payment handle, stored lowercase without the leading @';
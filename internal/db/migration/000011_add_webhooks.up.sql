@@ -0,0 +1,48 @@
+CREATE TABLE "webhooks" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "webhook_id" bigint NOT NULL,
+  "event_id" varchar NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_code" int,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "last_attempt_at" timestamptz,
+  "replay_of" bigint
+);
+
+CREATE INDEX ON "webhooks" ("owner");
+
+CREATE INDEX ON "webhook_deliveries" ("webhook_id");
+
+CREATE UNIQUE INDEX "webhook_event_key" ON "webhook_deliveries" ("webhook_id", "event_id") WHERE "replay_of" IS NULL;
+
+COMMENT ON COLUMN "webhooks"."secret" IS 'HMAC-SHA256 signing key, shown to the owner once';
+
+COMMENT ON COLUMN "webhooks"."event_types" IS 'event types the webhook is subscribed to';
+
+COMMENT ON COLUMN "webhook_deliveries"."payload" IS 'the exact event body that is signed and sent';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "webhook_deliveries"."response_code" IS 'HTTP status of the last attempt, null if no response was received';
+
+COMMENT ON COLUMN "webhook_deliveries"."replay_of" IS 'the delivery this one replays, null for the original delivery of an event';
+
+ALTER TABLE "webhooks" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("webhook_id") REFERENCES "webhooks" ("id");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("replay_of") REFERENCES "webhook_deliveries" ("id");
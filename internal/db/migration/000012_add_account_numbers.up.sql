@@ -0,0 +1,45 @@
+ALTER TABLE "accounts" ADD COLUMN "account_number" varchar;
+
+-- Existing accounts get numbers under the default bank code SMPL.
+-- The layout and check digits match util.NewAccountNumber.
+CREATE FUNCTION pg_temp.mod97(s text) RETURNS int AS $$
+DECLARE
+  r int := 0;
+  c text;
+BEGIN
+  FOREACH c IN ARRAY regexp_split_to_array(s, '')
+  LOOP
+    IF c ~ '[0-9]' THEN
+      r := (r * 10 + c::int) % 97;
+    ELSE
+      r := (r * 100 + ascii(c) - 55) % 97;
+    END IF;
+  END LOOP;
+  RETURN r;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+DO $$
+DECLARE
+  account_id bigint;
+  body text;
+  number text;
+BEGIN
+  FOR account_id IN SELECT "id" FROM "accounts" ORDER BY "id"
+  LOOP
+    LOOP
+      body := lpad(floor(random() * 10000000000)::bigint::text, 10, '0');
+      number := 'SMPL' || lpad((98 - pg_temp.mod97(body || 'SMPL00'))::text, 2, '0') || body;
+      EXIT WHEN NOT EXISTS (SELECT 1 FROM "accounts" WHERE "account_number" = number);
+    END LOOP;
+
+    UPDATE "accounts" SET "account_number" = number WHERE "id" = account_id;
+  END LOOP;
+END;
+$$;
+
+ALTER TABLE "accounts" ALTER COLUMN "account_number" SET NOT NULL;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_account_number_key" UNIQUE ("account_number");
+
+COMMENT ON COLUMN "accounts"."account_number" IS 'customer-facing identifier: bank code, mod-97 check digits and a random 10 digit body';
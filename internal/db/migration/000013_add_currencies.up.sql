@@ -0,0 +1,21 @@
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "exponent" int NOT NULL,
+  "is_enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."exponent" IS 'number of decimal places of the minor unit, fixed once accounts hold amounts in it';
+
+COMMENT ON COLUMN "currencies"."is_enabled" IS 'disabled currencies cannot be used to open new accounts';
+
+INSERT INTO "currencies" ("code", "name", "exponent") VALUES
+  ('USD', 'US Dollar', 2),
+  ('EUR', 'Euro', 2),
+  ('GBP', 'Pound Sterling', 2),
+  ('BDT', 'Bangladeshi Taka', 2);
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
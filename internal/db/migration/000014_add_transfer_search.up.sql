@@ -0,0 +1,13 @@
+ALTER TABLE "transfers" ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN "transfers"."reference" IS 'free text set by the sender, e.g. an invoice number';
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at");
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX "transfers_reference_trgm_idx" ON "transfers" USING GIN ("reference" gin_trgm_ops);
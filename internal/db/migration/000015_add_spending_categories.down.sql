@@ -0,0 +1,9 @@
+DROP MATERIALIZED VIEW IF EXISTS "spending_summaries";
+
+DROP TABLE IF EXISTS "transfer_categories";
+
+DROP TABLE IF EXISTS "category_rules";
+
+DROP TABLE IF EXISTS "categories";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "transfer_id";
@@ -0,0 +1,90 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint REFERENCES "transfers" ("id");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'set when the entry is one side of a transfer';
+
+-- A transfer and its two entries are created in the same transaction, so they share created_at.
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE
+  e."pocket_id" IS NULL
+  AND e."created_at" = t."created_at"
+  AND (
+    (e."account_id" = t."from_account_id" AND e."amount" = -t."amount")
+    OR (e."account_id" = t."to_account_id" AND e."amount" = t."amount")
+  );
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+CREATE TABLE "categories" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "categories" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "categories" ADD CONSTRAINT "owner_category_name_key" UNIQUE ("owner", "name");
+
+CREATE TABLE "category_rules" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "counterparty_account_id" bigint,
+  "keyword" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "category_rule_has_condition" CHECK ("counterparty_account_id" IS NOT NULL OR "keyword" IS NOT NULL)
+);
+
+COMMENT ON COLUMN "category_rules"."counterparty_account_id" IS 'matches transfers to or from this account';
+
+COMMENT ON COLUMN "category_rules"."keyword" IS 'matches transfers whose reference contains it, ignoring case';
+
+ALTER TABLE "category_rules" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "category_rules" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+
+ALTER TABLE "category_rules" ADD FOREIGN KEY ("counterparty_account_id") REFERENCES "accounts" ("id");
+
+CREATE INDEX ON "category_rules" ("owner");
+
+CREATE TABLE "transfer_categories" (
+  "transfer_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "rule_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("transfer_id", "username")
+);
+
+COMMENT ON TABLE "transfer_categories" IS 'the category each member of the accounts involved gave a transfer';
+
+COMMENT ON COLUMN "transfer_categories"."rule_id" IS 'the rule that categorized the transfer, NULL when the user chose the category';
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+
+ALTER TABLE "transfer_categories" ADD FOREIGN KEY ("rule_id") REFERENCES "category_rules" ("id");
+
+-- spending_summaries totals the money moving in and out of each account per member, month and category.
+-- Category 0 collects uncategorized transfers. Only transfer entries count, since pocket moves cancel out within the account.
+CREATE MATERIALIZED VIEW "spending_summaries" AS
+SELECT
+  m."username",
+  e."account_id",
+  date_trunc('month', e."created_at" AT TIME ZONE 'UTC')::date AS "month",
+  COALESCE(tc."category_id", 0)::bigint AS "category_id",
+  COALESCE(SUM(e."amount") FILTER (WHERE e."amount" > 0), 0)::bigint AS "inflow",
+  COALESCE(SUM(-e."amount") FILTER (WHERE e."amount" < 0), 0)::bigint AS "outflow"
+FROM "entries" e
+JOIN "account_members" m ON m."account_id" = e."account_id"
+LEFT JOIN "transfer_categories" tc ON tc."transfer_id" = e."transfer_id" AND tc."username" = m."username"
+WHERE e."transfer_id" IS NOT NULL
+GROUP BY 1, 2, 3, 4;
+
+-- REFRESH MATERIALIZED VIEW CONCURRENTLY needs a unique index
+CREATE UNIQUE INDEX ON "spending_summaries" ("username", "account_id", "month", "category_id");
@@ -0,0 +1,61 @@
+CREATE TABLE "payment_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "message_id" varchar NOT NULL,
+  "message_name" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "confirmed_at" timestamptz,
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "payment_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "payment_information_id" varchar NOT NULL,
+  "instruction_id" varchar NOT NULL,
+  "end_to_end_id" varchar NOT NULL,
+  "from_account_number" varchar NOT NULL,
+  "from_account_id" bigint,
+  "to_account_number" varchar NOT NULL,
+  "to_account_id" bigint,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "reference" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "reason_code" varchar NOT NULL DEFAULT '',
+  "reason" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint
+);
+
+CREATE INDEX ON "payment_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "payment_batches" ("owner", "message_id");
+
+CREATE INDEX ON "payment_batch_items" ("batch_id");
+
+COMMENT ON COLUMN "payment_batches"."message_id" IS 'GrpHdr/MsgId of the pain.001 file, a file is only accepted once';
+
+COMMENT ON COLUMN "payment_batches"."message_name" IS 'the pain.001 version of the file, e.g. pain.001.001.09';
+
+COMMENT ON COLUMN "payment_batches"."status" IS 'pending until confirmed, then confirmed until every valid item has been executed, then completed';
+
+COMMENT ON COLUMN "payment_batch_items"."from_account_number" IS 'as given in the file, from_account_id is set when it was found';
+
+COMMENT ON COLUMN "payment_batch_items"."to_account_number" IS 'as given in the file, to_account_id is set when it was found';
+
+COMMENT ON COLUMN "payment_batch_items"."amount" IS 'in minor units, 0 when the amount in the file could not be read';
+
+COMMENT ON COLUMN "payment_batch_items"."status" IS 'valid or invalid when imported, valid items become executed or failed';
+
+COMMENT ON COLUMN "payment_batch_items"."reason_code" IS 'ISO 20022 status reason code of an invalid or failed item';
+
+ALTER TABLE "payment_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "payment_batches" ("id");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
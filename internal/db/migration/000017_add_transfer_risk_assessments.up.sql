@@ -0,0 +1,41 @@
+ALTER TABLE "users" ADD COLUMN "email_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z';
+
+CREATE TABLE "transfer_risk_assessments" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "reference" varchar NOT NULL DEFAULT '',
+  "requested_by" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "decision" varchar NOT NULL,
+  "findings" jsonb NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_risk_assessments" ("from_account_id");
+
+CREATE INDEX ON "transfer_risk_assessments" ("status");
+
+COMMENT ON COLUMN "transfer_risk_assessments"."amount" IS 'in minor units';
+
+COMMENT ON COLUMN "transfer_risk_assessments"."decision" IS 'allow, review or block';
+
+COMMENT ON COLUMN "transfer_risk_assessments"."findings" IS 'the rules that did not allow the transfer and why';
+
+COMMENT ON COLUMN "transfer_risk_assessments"."status" IS 'executed when the transfer was made right away, blocked transfers are held until approved or rejected by a banker';
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_risk_assessments" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
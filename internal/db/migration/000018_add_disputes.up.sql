@@ -0,0 +1,78 @@
+CREATE TABLE "disputes" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "account_id" bigint NOT NULL,
+  "opened_by" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open',
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "credit_entry_id" bigint,
+  "chargeback_entry_id" bigint,
+  "reversal_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz
+);
+
+CREATE TABLE "dispute_attachments" (
+  "id" bigserial PRIMARY KEY,
+  "dispute_id" bigint NOT NULL,
+  "file_name" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "content" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "dispute_events" (
+  "id" bigserial PRIMARY KEY,
+  "dispute_id" bigint NOT NULL,
+  "status" varchar NOT NULL,
+  "actor" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "disputes" ("transfer_id");
+
+CREATE INDEX ON "disputes" ("opened_by");
+
+CREATE INDEX ON "disputes" ("status");
+
+CREATE INDEX ON "dispute_attachments" ("dispute_id");
+
+CREATE INDEX ON "dispute_events" ("dispute_id");
+
+COMMENT ON COLUMN "disputes"."account_id" IS 'the account the disputed transfer was sent from';
+
+COMMENT ON COLUMN "disputes"."status" IS 'open, investigating, provisional_credit, resolved_for_customer or resolved_against_customer';
+
+COMMENT ON COLUMN "disputes"."amount" IS 'in minor units, the amount of the disputed transfer';
+
+COMMENT ON COLUMN "disputes"."credit_entry_id" IS 'the entry crediting the customer, provisionally or once resolved in their favour';
+
+COMMENT ON COLUMN "disputes"."chargeback_entry_id" IS 'the entry taking the money back from the recipient once resolved for the customer';
+
+COMMENT ON COLUMN "disputes"."reversal_entry_id" IS 'the entry taking back a provisional credit once resolved against the customer';
+
+COMMENT ON COLUMN "dispute_events"."status" IS 'the status the dispute moved to';
+
+COMMENT ON COLUMN "dispute_events"."actor" IS 'the user who opened the dispute or the banker who moved it';
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("opened_by") REFERENCES "users" ("username");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("credit_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("chargeback_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("reversal_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "dispute_attachments" ADD FOREIGN KEY ("dispute_id") REFERENCES "disputes" ("id");
+
+ALTER TABLE "dispute_events" ADD FOREIGN KEY ("dispute_id") REFERENCES "disputes" ("id");
+
+ALTER TABLE "dispute_events" ADD FOREIGN KEY ("actor") REFERENCES "users" ("username");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "account_limits";
+
+DROP TABLE IF EXISTS "approval_requests";
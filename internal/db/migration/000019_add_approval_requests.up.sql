@@ -0,0 +1,49 @@
+CREATE TABLE "approval_requests" (
+  "id" bigserial PRIMARY KEY,
+  "operation" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "requested_by" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "reviewed_by" varchar,
+  "review_note" varchar NOT NULL DEFAULT '',
+  "reviewed_at" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_limits" (
+  "account_id" bigint PRIMARY KEY,
+  "max_transfers_per_hour" bigint,
+  "new_counterparty_threshold" bigint,
+  "new_beneficiary_transfer_limit" bigint,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "approval_requests" ("status");
+
+CREATE INDEX ON "approval_requests" ("requested_by");
+
+COMMENT ON COLUMN "approval_requests"."operation" IS 'add_account_balance, update_dispute_status, update_user_role or update_account_limits';
+
+COMMENT ON COLUMN "approval_requests"."payload" IS 'the arguments of the operation';
+
+COMMENT ON COLUMN "approval_requests"."status" IS 'pending, approved, rejected or expired. Approved operations have been executed.';
+
+COMMENT ON COLUMN "approval_requests"."reviewed_by" IS 'a banker other than the one who requested the operation';
+
+COMMENT ON COLUMN "account_limits"."max_transfers_per_hour" IS 'overrides RISK_MAX_TRANSFERS_PER_HOUR, the default applies when null';
+
+COMMENT ON COLUMN "account_limits"."new_counterparty_threshold" IS 'overrides RISK_NEW_COUNTERPARTY_THRESHOLD, in minor units of the account currency';
+
+COMMENT ON COLUMN "account_limits"."new_beneficiary_transfer_limit" IS 'overrides NEW_BENEFICIARY_TRANSFER_LIMIT, in minor units of the account currency';
+
+COMMENT ON COLUMN "account_limits"."updated_by" IS 'the banker who approved the override';
+
+ALTER TABLE "approval_requests" ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "approval_requests" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "account_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_limits" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
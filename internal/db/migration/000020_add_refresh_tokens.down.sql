@@ -0,0 +1,4 @@
+-- only hashes of refresh tokens are stored, so sessions cannot be renewed after going down
+ALTER TABLE "sessions" ADD COLUMN "refresh_token" varchar NOT NULL DEFAULT '';
+
+DROP TABLE IF EXISTS "refresh_tokens";
@@ -0,0 +1,25 @@
+CREATE TABLE "refresh_tokens" (
+  "id" uuid PRIMARY KEY,
+  "session_id" uuid NOT NULL,
+  "token_hash" varchar NOT NULL,
+  "rotated_at" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "refresh_tokens" ("session_id");
+
+COMMENT ON COLUMN "refresh_tokens"."id" IS 'the ID of the refresh token payload';
+
+COMMENT ON COLUMN "refresh_tokens"."token_hash" IS 'hex encoded SHA-256 of the refresh token';
+
+COMMENT ON COLUMN "refresh_tokens"."rotated_at" IS 'set when the token is renewed, using it again revokes the session';
+
+ALTER TABLE "refresh_tokens" ADD FOREIGN KEY ("session_id") REFERENCES "sessions" ("id");
+
+-- the refresh tokens of existing sessions keep working until they are renewed
+INSERT INTO "refresh_tokens" ("id", "session_id", "token_hash", "expires_at", "created_at")
+SELECT "id", "id", encode(sha256(convert_to("refresh_token", 'UTF8')), 'hex'), "expires_at", "created_at"
+FROM "sessions";
+
+ALTER TABLE "sessions" DROP COLUMN "refresh_token";
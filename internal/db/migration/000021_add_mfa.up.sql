@@ -0,0 +1,47 @@
+CREATE TABLE "totp_enrollments" (
+  "username" varchar PRIMARY KEY,
+  "secret" varchar NOT NULL,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "confirmed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "mfa_challenges" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "attempts" int NOT NULL DEFAULT 0,
+  "expires_at" timestamptz NOT NULL,
+  "completed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "recovery_codes" ("username");
+
+CREATE INDEX ON "mfa_challenges" ("username");
+
+COMMENT ON COLUMN "totp_enrollments"."secret" IS 'base32 encoded TOTP secret';
+
+COMMENT ON COLUMN "totp_enrollments"."last_used_step" IS 'the time step of the last accepted code, codes cannot be used twice';
+
+COMMENT ON COLUMN "totp_enrollments"."confirmed_at" IS 'set once a code has been verified, TOTP is only enabled from then on';
+
+COMMENT ON COLUMN "recovery_codes"."code_hash" IS 'hex encoded SHA-256 of the recovery code';
+
+COMMENT ON COLUMN "mfa_challenges"."token_hash" IS 'hex encoded SHA-256 of the challenge token returned by login';
+
+COMMENT ON COLUMN "mfa_challenges"."attempts" IS 'wrong codes entered, the challenge fails after too many';
+
+ALTER TABLE "totp_enrollments" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "mfa_challenges" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -0,0 +1,21 @@
+CREATE TABLE "login_throttles" (
+  "username" varchar PRIMARY KEY,
+  "failed_attempts" int NOT NULL DEFAULT 0,
+  "last_failed_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz
+);
+
+CREATE TABLE "failed_logins" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "failed_logins" ("client_ip", "created_at");
+
+COMMENT ON COLUMN "login_throttles"."username" IS 'not a reference to users, unknown usernames are throttled the same way';
+
+COMMENT ON COLUMN "login_throttles"."failed_attempts" IS 'consecutive failed logins, reset by a successful login, a lockout or an unlock';
+
+COMMENT ON COLUMN "failed_logins"."client_ip" IS 'the client address without its port';
@@ -0,0 +1,15 @@
+CREATE TABLE "token_signing_keys" (
+  "kid" varchar PRIMARY KEY,
+  "algorithm" varchar NOT NULL,
+  "sealed_private_key" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL
+);
+
+CREATE INDEX ON "token_signing_keys" ("expires_at");
+
+COMMENT ON COLUMN "token_signing_keys"."algorithm" IS 'EdDSA or ES256';
+
+COMMENT ON COLUMN "token_signing_keys"."sealed_private_key" IS 'PKCS #8 private key encrypted with the token symmetric key';
+
+COMMENT ON COLUMN "token_signing_keys"."expires_at" IS 'when the last token the key signed has expired';
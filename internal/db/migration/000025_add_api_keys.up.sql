@@ -0,0 +1,25 @@
+CREATE TABLE "api_keys" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "prefix" varchar UNIQUE NOT NULL,
+  "secret_hash" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "allowed_ips" varchar[] NOT NULL DEFAULT '{}',
+  "last_used_at" timestamptz,
+  "last_used_ip" varchar,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "api_keys" ("owner");
+
+COMMENT ON COLUMN "api_keys"."prefix" IS 'the start of the key, shown to tell keys apart';
+
+COMMENT ON COLUMN "api_keys"."secret_hash" IS 'hex encoded SHA-256 of the whole key';
+
+COMMENT ON COLUMN "api_keys"."allowed_ips" IS 'addresses and CIDR ranges the key can be used from, any address when empty';
+
+COMMENT ON COLUMN "api_keys"."last_used_at" IS 'updated at most once a minute';
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
@@ -0,0 +1,103 @@
+CREATE TABLE "roles" (
+  "name" varchar PRIMARY KEY,
+  "description" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "role_permissions" (
+  "role" varchar NOT NULL,
+  "permission" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "permission")
+);
+
+COMMENT ON COLUMN "role_permissions"."permission" IS 'permissions ending in .any apply to the resources of every user';
+
+INSERT INTO "roles" ("name", "description") VALUES
+  ('depositor', 'customer managing their own accounts'),
+  ('banker', 'staff operating the bank'),
+  ('admin', 'staff operating the bank and administering its users'),
+  ('auditor', 'read-only access to every account, dispute and approval');
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('depositor', 'account.create'),
+  ('depositor', 'account.read'),
+  ('depositor', 'account.deposit'),
+  ('depositor', 'account.manage'),
+  ('depositor', 'transfer.create'),
+  ('depositor', 'beneficiary.manage'),
+  ('depositor', 'payment.manage'),
+  ('depositor', 'category.manage'),
+  ('depositor', 'webhook.manage'),
+  ('depositor', 'dispute.open'),
+  ('depositor', 'dispute.read'),
+  ('depositor', 'user.update'),
+  ('depositor', 'api_key.manage'),
+  ('depositor', 'mfa.optional'),
+  ('banker', 'account.create'),
+  ('banker', 'account.create.any'),
+  ('banker', 'account.read'),
+  ('banker', 'account.read.any'),
+  ('banker', 'account.deposit'),
+  ('banker', 'account.deposit.any'),
+  ('banker', 'account.manage'),
+  ('banker', 'transfer.create'),
+  ('banker', 'beneficiary.manage'),
+  ('banker', 'payment.manage'),
+  ('banker', 'category.manage'),
+  ('banker', 'webhook.manage'),
+  ('banker', 'dispute.open'),
+  ('banker', 'dispute.read'),
+  ('banker', 'dispute.read.any'),
+  ('banker', 'dispute.resolve'),
+  ('banker', 'currency.manage'),
+  ('banker', 'risk.review'),
+  ('banker', 'approval.review'),
+  ('banker', 'audit.read'),
+  ('banker', 'user.update'),
+  ('banker', 'user.update.any'),
+  ('banker', 'user.role.assign'),
+  ('banker', 'user.unlock'),
+  ('banker', 'session.block.any'),
+  ('banker', 'api_key.manage'),
+  ('banker', 'role.read'),
+  ('banker', 'account.limits.update'),
+  ('admin', 'account.create'),
+  ('admin', 'account.create.any'),
+  ('admin', 'account.read'),
+  ('admin', 'account.read.any'),
+  ('admin', 'account.deposit'),
+  ('admin', 'account.deposit.any'),
+  ('admin', 'account.manage'),
+  ('admin', 'transfer.create'),
+  ('admin', 'beneficiary.manage'),
+  ('admin', 'payment.manage'),
+  ('admin', 'category.manage'),
+  ('admin', 'webhook.manage'),
+  ('admin', 'dispute.open'),
+  ('admin', 'dispute.read'),
+  ('admin', 'dispute.read.any'),
+  ('admin', 'dispute.resolve'),
+  ('admin', 'currency.manage'),
+  ('admin', 'risk.review'),
+  ('admin', 'approval.review'),
+  ('admin', 'audit.read'),
+  ('admin', 'user.update'),
+  ('admin', 'user.update.any'),
+  ('admin', 'user.role.assign'),
+  ('admin', 'user.unlock'),
+  ('admin', 'session.block.any'),
+  ('admin', 'api_key.manage'),
+  ('admin', 'role.read'),
+  ('admin', 'account.limits.update'),
+  ('admin', 'api_key.revoke.any'),
+  ('auditor', 'account.read'),
+  ('auditor', 'account.read.any'),
+  ('auditor', 'dispute.read'),
+  ('auditor', 'dispute.read.any'),
+  ('auditor', 'audit.read'),
+  ('auditor', 'role.read');
+
+ALTER TABLE "role_permissions" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
+
+ALTER TABLE "users" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "failed_logins"."client_ip" IS 'the client address without its port';
+
+DROP INDEX IF EXISTS "failed_logins_created_at_idx";
+
+ALTER TABLE "login_throttles" DROP COLUMN IF EXISTS "last_attempt_at";
@@ -0,0 +1,7 @@
+ALTER TABLE "login_throttles" ADD COLUMN "last_attempt_at" timestamptz;
+
+COMMENT ON COLUMN "login_throttles"."last_attempt_at" IS 'when a login of the username was last let through, so concurrent logins cannot skip the wait';
+
+CREATE INDEX ON "failed_logins" ("created_at");
+
+COMMENT ON COLUMN "failed_logins"."client_ip" IS 'the client address without its port, or the /64 network of an IPv6 client';
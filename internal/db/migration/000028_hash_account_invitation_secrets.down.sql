@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "account_invitations"."secret_code_hash" IS NULL;
+
+ALTER TABLE "account_invitations" ALTER COLUMN "secret_code_hash" DROP DEFAULT;
+
+ALTER TABLE "account_invitations" RENAME COLUMN "secret_code_hash" TO "secret_code";
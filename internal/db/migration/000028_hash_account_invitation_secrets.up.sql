@@ -0,0 +1,7 @@
+ALTER TABLE "account_invitations" RENAME COLUMN "secret_code" TO "secret_code_hash";
+
+UPDATE "account_invitations" SET "secret_code_hash" = encode(sha256(convert_to("secret_code_hash", 'UTF8')), 'hex');
+
+ALTER TABLE "account_invitations" ALTER COLUMN "secret_code_hash" SET DEFAULT '';
+
+COMMENT ON COLUMN "account_invitations"."secret_code_hash" IS 'hex encoded SHA-256 of the emailed code, empty until the invitation email is sent';
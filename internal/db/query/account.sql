@@ -1,14 +1,22 @@
 -- name: CreateAccount :one
 INSERT INTO accounts (
-    owner, balance, currency
+    owner, balance, currency, account_number
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 ) RETURNING *; 
 
 -- name: GetAccount :one
 SELECT * from accounts 
 WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountByNumber :one
+SELECT * from accounts
+WHERE account_number = $1 LIMIT 1;
+
+-- name: GetAccountByOwnerAndCurrency :one
+SELECT * from accounts
+WHERE owner = $1 AND currency = $2 LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * from accounts 
 WHERE id = $1 LIMIT 1
@@ -0,0 +1,33 @@
+-- name: CreateAccountInvitation :one
+INSERT INTO account_invitations (
+    account_id,
+    username,
+    role,
+    invited_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetAccountInvitation :one
+SELECT * FROM account_invitations
+WHERE id = $1 LIMIT 1;
+
+-- name: SetAccountInvitationSecret :one
+UPDATE account_invitations
+SET
+    secret_code_hash = @secret_code_hash
+WHERE
+    id = @id
+    AND is_accepted = FALSE
+RETURNING *;
+
+-- name: AcceptAccountInvitation :one
+UPDATE account_invitations
+SET
+    is_accepted = TRUE
+WHERE
+    id = @id
+    AND secret_code_hash = @secret_code_hash
+    AND is_accepted = FALSE
+    AND expires_at > NOW()
+RETURNING *;
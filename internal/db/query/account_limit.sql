@@ -0,0 +1,22 @@
+-- name: GetAccountLimit :one
+SELECT * FROM account_limits
+WHERE account_id = $1 LIMIT 1;
+
+-- name: UpsertAccountLimit :one
+INSERT INTO account_limits (
+    account_id,
+    max_transfers_per_hour,
+    new_counterparty_threshold,
+    new_beneficiary_transfer_limit,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id) DO UPDATE
+SET
+    max_transfers_per_hour = EXCLUDED.max_transfers_per_hour,
+    new_counterparty_threshold = EXCLUDED.new_counterparty_threshold,
+    new_beneficiary_transfer_limit = EXCLUDED.new_beneficiary_transfer_limit,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
@@ -0,0 +1,39 @@
+-- name: CreateAccountMember :one
+INSERT INTO account_members (
+    account_id,
+    username,
+    role
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT * FROM account_members
+WHERE account_id = $1 AND username = $2
+LIMIT 1;
+
+-- name: ListAccountMembers :many
+SELECT * FROM account_members
+WHERE account_id = $1
+ORDER BY created_at;
+
+-- name: CountAccountOwners :one
+SELECT COUNT(*) FROM account_members
+WHERE account_id = $1 AND role = 'owner';
+
+-- name: DeleteAccountMember :exec
+DELETE FROM account_members
+WHERE account_id = $1 AND username = $2;
+
+-- name: ListMemberAccounts :many
+SELECT accounts.* FROM accounts
+JOIN account_members ON account_members.account_id = accounts.id
+WHERE account_members.username = $1
+ORDER BY accounts.id ASC
+LIMIT $2 OFFSET $3;
+
+-- name: ListAllMemberAccounts :many
+SELECT accounts.* FROM accounts
+JOIN account_members ON account_members.account_id = accounts.id
+WHERE account_members.username = $1
+ORDER BY accounts.id ASC;
@@ -0,0 +1,39 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+  owner,
+  name,
+  prefix,
+  secret_hash,
+  scopes,
+  allowed_ips
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetApiKey :one
+SELECT * FROM api_keys
+WHERE id = $1 LIMIT 1;
+
+-- name: GetApiKeyByPrefix :one
+SELECT * FROM api_keys
+WHERE prefix = $1 LIMIT 1;
+
+-- name: ListApiKeys :many
+SELECT * FROM api_keys
+WHERE owner = $1
+ORDER BY id;
+
+-- name: TouchApiKey :exec
+-- TouchApiKey records the use of a key, at most once a minute so busy keys do not write on every request
+UPDATE api_keys
+SET
+  last_used_at = now(),
+  last_used_ip = $2
+WHERE id = $1
+  AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute');
+
+-- name: RevokeApiKey :one
+UPDATE api_keys
+SET revoked_at = now()
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING *;
@@ -0,0 +1,49 @@
+-- name: CreateApprovalRequest :one
+INSERT INTO approval_requests (
+    operation,
+    payload,
+    requested_by,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetApprovalRequest :one
+SELECT * FROM approval_requests
+WHERE id = $1 LIMIT 1;
+
+-- name: GetApprovalRequestForUpdate :one
+SELECT * FROM approval_requests
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListApprovalRequests :many
+-- ListApprovalRequests lists approval requests oldest first. A NULL status matches every request.
+SELECT * FROM approval_requests
+WHERE sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status')
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ReviewApprovalRequest :one
+-- ReviewApprovalRequest records the decision of a banker on a pending request
+UPDATE approval_requests
+SET
+    status = @status,
+    reviewed_by = @reviewed_by,
+    review_note = @review_note,
+    reviewed_at = now()
+WHERE
+    id = @id
+    AND status = 'pending'
+RETURNING *;
+
+-- name: ExpireApprovalRequest :one
+-- ExpireApprovalRequest expires a request that is still pending past its expiry time
+UPDATE approval_requests
+SET status = 'expired'
+WHERE
+    id = $1
+    AND status = 'pending'
+    AND expires_at <= now()
+RETURNING *;
@@ -0,0 +1,38 @@
+-- name: CreateBeneficiary :one
+INSERT INTO beneficiaries (
+    owner,
+    nickname,
+    account_id,
+    currency
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetBeneficiary :one
+SELECT * FROM beneficiaries
+WHERE id = $1 LIMIT 1;
+
+-- name: GetBeneficiaryByAccount :one
+SELECT * FROM beneficiaries
+WHERE owner = $1 AND account_id = $2 LIMIT 1;
+
+-- name: ListBeneficiaries :many
+SELECT
+    sqlc.embed(b),
+    a.account_number
+FROM beneficiaries b
+JOIN accounts a ON a.id = b.account_id
+WHERE b.owner = $1
+ORDER BY b.id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateBeneficiary :one
+UPDATE beneficiaries
+SET nickname = @nickname
+WHERE id = @id AND owner = @owner
+RETURNING *;
+
+-- name: DeleteBeneficiary :execrows
+DELETE FROM beneficiaries
+WHERE id = @id AND owner = @owner;
@@ -0,0 +1,107 @@
+-- name: CreateCategory :one
+INSERT INTO categories (
+    owner,
+    name
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetCategory :one
+SELECT * FROM categories
+WHERE id = $1 LIMIT 1;
+
+-- name: ListCategories :many
+SELECT * FROM categories
+WHERE owner = $1
+ORDER BY name;
+
+-- name: CreateCategoryRule :one
+INSERT INTO category_rules (
+    owner,
+    category_id,
+    counterparty_account_id,
+    keyword
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListCategoryRules :many
+SELECT
+    sqlc.embed(r),
+    a.account_number AS counterparty_account_number
+FROM category_rules r
+LEFT JOIN accounts a ON a.id = r.counterparty_account_id
+WHERE r.owner = $1
+ORDER BY r.id;
+
+-- name: GetTransferCategory :one
+SELECT * FROM transfer_categories
+WHERE transfer_id = $1 AND username = $2
+LIMIT 1;
+
+-- name: SetTransferCategory :one
+-- SetTransferCategory records the category a user chose for a transfer, replacing any category given by a rule
+INSERT INTO transfer_categories (
+    transfer_id,
+    username,
+    category_id
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (transfer_id, username) DO UPDATE
+SET category_id = EXCLUDED.category_id, rule_id = NULL, created_at = now()
+RETURNING *;
+
+-- name: ApplyCategoryRules :execrows
+-- ApplyCategoryRules categorizes the transfers that members of the accounts involved have not categorized yet.
+-- A rule matches when its counterparty and keyword, if set, both match; the oldest matching rule wins.
+INSERT INTO transfer_categories (
+    transfer_id,
+    username,
+    category_id,
+    rule_id
+)
+SELECT DISTINCT ON (t.id, m.username)
+    t.id,
+    m.username,
+    r.category_id,
+    r.id
+FROM transfers t
+JOIN account_members m ON m.account_id IN (t.from_account_id, t.to_account_id)
+JOIN category_rules r ON r.owner = m.username
+WHERE
+    (
+        r.counterparty_account_id IS NULL
+        OR r.counterparty_account_id = CASE WHEN m.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END
+    )
+    AND (r.keyword IS NULL OR strpos(lower(t.reference), lower(r.keyword)) > 0)
+    AND NOT EXISTS (
+        SELECT 1 FROM transfer_categories c
+        WHERE c.transfer_id = t.id AND c.username = m.username
+    )
+ORDER BY t.id, m.username, r.id
+ON CONFLICT (transfer_id, username) DO NOTHING;
+
+-- name: RefreshSpendingSummaries :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY spending_summaries;
+
+-- name: ListSpendingSummaries :many
+-- ListSpendingSummaries totals the summaries of the user's accounts per month, category and currency.
+-- Category 0 holds uncategorized transfers.
+SELECT
+    s.month,
+    s.category_id,
+    COALESCE(c.name, '')::varchar AS category_name,
+    a.currency,
+    SUM(s.inflow)::bigint AS inflow,
+    SUM(s.outflow)::bigint AS outflow
+FROM spending_summaries s
+JOIN accounts a ON a.id = s.account_id
+LEFT JOIN categories c ON c.id = s.category_id
+WHERE
+    s.username = @username
+    AND s.account_id = ANY(@account_ids::bigint[])
+    AND s.month >= @from_month::date
+    AND s.month < @to_month::date
+GROUP BY s.month, s.category_id, c.name, a.currency
+ORDER BY s.month, s.category_id, a.currency;
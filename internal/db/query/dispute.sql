@@ -0,0 +1,93 @@
+-- name: CreateDispute :one
+INSERT INTO disputes (
+    transfer_id,
+    account_id,
+    opened_by,
+    reason,
+    amount,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetDispute :one
+SELECT * FROM disputes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetDisputeForUpdate :one
+SELECT * FROM disputes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListDisputes :many
+-- ListDisputes lists disputes oldest first. Filters left NULL match every dispute.
+SELECT
+    sqlc.embed(d),
+    a.account_number
+FROM disputes d
+JOIN accounts a ON a.id = d.account_id
+WHERE
+    (sqlc.narg('status')::varchar IS NULL OR d.status = sqlc.narg('status'))
+    AND (sqlc.narg('opened_by')::varchar IS NULL OR d.opened_by = sqlc.narg('opened_by'))
+ORDER BY d.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateDisputeStatus :one
+UPDATE disputes
+SET
+    status = @status,
+    credit_entry_id = COALESCE(sqlc.narg(credit_entry_id), credit_entry_id),
+    chargeback_entry_id = COALESCE(sqlc.narg(chargeback_entry_id), chargeback_entry_id),
+    reversal_entry_id = COALESCE(sqlc.narg(reversal_entry_id), reversal_entry_id),
+    resolved_at = COALESCE(sqlc.narg(resolved_at), resolved_at),
+    updated_at = now()
+WHERE
+    id = @id
+RETURNING *;
+
+-- name: CreateDisputeAttachment :one
+INSERT INTO dispute_attachments (
+    dispute_id,
+    file_name,
+    content_type,
+    content
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetDisputeAttachment :one
+SELECT * FROM dispute_attachments
+WHERE id = $1 LIMIT 1;
+
+-- name: ListDisputeAttachments :many
+-- ListDisputeAttachments lists the attachments of a dispute without their content
+SELECT
+    id,
+    dispute_id,
+    file_name,
+    content_type,
+    octet_length(content)::bigint AS size,
+    created_at
+FROM dispute_attachments
+WHERE dispute_id = $1
+ORDER BY id;
+
+-- name: CreateDisputeEvent :one
+INSERT INTO dispute_events (
+    dispute_id,
+    status,
+    actor,
+    note
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetDisputeEvent :one
+SELECT * FROM dispute_events
+WHERE id = $1 LIMIT 1;
+
+-- name: ListDisputeEvents :many
+SELECT * FROM dispute_events
+WHERE dispute_id = $1
+ORDER BY id;
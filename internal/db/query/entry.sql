@@ -1,9 +1,10 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -15,4 +16,74 @@ SELECT * FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
-OFFSET $3;
\ No newline at end of file
+OFFSET $3;
+
+-- name: CreatePocketEntry :one
+INSERT INTO entries (
+  account_id,
+  pocket_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: SearchEntries :many
+-- SearchEntries finds entries of the given accounts. Filters left NULL match every entry.
+-- Amount filters and sorting use the absolute amount, so they work the same for both directions.
+SELECT
+    e.*,
+    a.account_number,
+    a.currency
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    e.account_id = ANY(@account_ids::bigint[])
+    AND (
+        @direction::varchar = ''
+        OR (@direction::varchar = 'incoming' AND e.amount > 0)
+        OR (@direction::varchar = 'outgoing' AND e.amount < 0)
+    )
+    AND (sqlc.narg('created_after')::timestamptz IS NULL OR e.created_at >= sqlc.narg('created_after'))
+    AND (sqlc.narg('created_before')::timestamptz IS NULL OR e.created_at < sqlc.narg('created_before'))
+    AND (sqlc.narg('min_amount')::bigint IS NULL OR abs(e.amount) >= sqlc.narg('min_amount'))
+    AND (sqlc.narg('max_amount')::bigint IS NULL OR abs(e.amount) <= sqlc.narg('max_amount'))
+ORDER BY
+    CASE WHEN @sort::varchar = 'amount_asc' THEN abs(e.amount) END ASC,
+    CASE WHEN @sort::varchar = 'amount_desc' THEN abs(e.amount) END DESC,
+    CASE WHEN @sort::varchar = 'oldest' THEN e.created_at END ASC,
+    e.created_at DESC,
+    e.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListStatementEntries :many
+-- ListStatementEntries pages through the entries of an account booked in [from_time, to_time), in booking order,
+-- together with the transfer each one is part of. Pass the last entry ID seen as after_id to get the next page.
+SELECT
+    e.id,
+    e.amount,
+    e.created_at,
+    e.transfer_id,
+    COALESCE(t.reference, '')::varchar AS reference,
+    COALESCE(c.account_number, '')::varchar AS counterparty_account_number
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts c ON c.id = CASE WHEN e.amount < 0 THEN t.to_account_id ELSE t.from_account_id END
+WHERE
+    e.account_id = @account_id
+    AND e.created_at >= @from_time
+    AND e.created_at < @to_time
+    AND e.id > @after_id
+ORDER BY e.id
+LIMIT sqlc.arg('limit');
+
+-- name: GetStatementBalances :one
+-- GetStatementBalances works the balances of an account at from_time and to_time back from its current balance
+-- through the entries booked since. Both are read in one statement so they agree with each other.
+SELECT
+    (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= @from_time), 0))::bigint AS opening_balance,
+    (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= @to_time), 0))::bigint AS closing_balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= @from_time
+WHERE a.id = @account_id
+GROUP BY a.id;
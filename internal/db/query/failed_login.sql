@@ -0,0 +1,15 @@
+-- name: CreateFailedLogin :one
+INSERT INTO failed_logins (
+  username,
+  client_ip
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: CountFailedLoginsFromIP :one
+SELECT count(*) FROM failed_logins
+WHERE client_ip = @client_ip AND created_at > @since;
+
+-- name: DeleteFailedLoginsBefore :execrows
+DELETE FROM failed_logins
+WHERE created_at < @before;
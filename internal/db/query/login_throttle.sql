@@ -0,0 +1,56 @@
+-- name: GetLoginThrottle :one
+SELECT * FROM login_throttles
+WHERE username = $1 LIMIT 1;
+
+-- name: AddFailedLoginAttempt :one
+-- AddFailedLoginAttempt counts a failed login, attempts made before window_start are forgotten
+INSERT INTO login_throttles (
+  username,
+  failed_attempts,
+  last_failed_at
+) VALUES (
+  @username, 1, now()
+)
+ON CONFLICT (username) DO UPDATE
+SET
+  failed_attempts = CASE
+    WHEN login_throttles.last_failed_at > @window_start THEN login_throttles.failed_attempts + 1
+    ELSE 1
+  END,
+  last_failed_at = now()
+RETURNING *;
+
+-- name: LockLogin :one
+UPDATE login_throttles
+SET
+  failed_attempts = 0,
+  locked_until = $2
+WHERE username = $1
+RETURNING *;
+
+-- name: DeleteLoginThrottle :execrows
+DELETE FROM login_throttles
+WHERE username = $1;
+
+-- name: ClaimLoginThrottle :one
+-- ClaimLoginThrottle locks the throttle of a username until the end of the transaction, creating it if needed
+INSERT INTO login_throttles (
+  username
+) VALUES (
+  $1
+)
+ON CONFLICT (username) DO UPDATE
+SET username = EXCLUDED.username
+RETURNING *;
+
+-- name: StartLoginAttempt :exec
+UPDATE login_throttles
+SET last_attempt_at = now()
+WHERE username = $1;
+
+-- name: DeleteStaleLoginThrottles :execrows
+-- DeleteStaleLoginThrottles forgets usernames that are not locked and have not tried to login since window_start
+DELETE FROM login_throttles
+WHERE last_failed_at < @window_start
+  AND (locked_until IS NULL OR locked_until < now())
+  AND (last_attempt_at IS NULL OR last_attempt_at < @window_start);
@@ -0,0 +1,25 @@
+-- name: CreateMfaChallenge :one
+INSERT INTO mfa_challenges (
+  username,
+  token_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetMfaChallengeForUpdate :one
+SELECT * FROM mfa_challenges
+WHERE token_hash = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddMfaChallengeAttempt :one
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: CompleteMfaChallenge :one
+UPDATE mfa_challenges
+SET completed_at = now()
+WHERE id = $1 AND completed_at IS NULL
+RETURNING *;
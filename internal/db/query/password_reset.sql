@@ -0,0 +1,28 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+  username,
+  email,
+  secret_code_hash
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: UsePasswordReset :one
+UPDATE password_resets
+SET
+  is_used = TRUE
+WHERE
+  id = @id
+  AND secret_code_hash = @secret_code_hash
+  AND is_used = FALSE
+  AND expires_at > NOW()
+RETURNING *;
+
+-- name: InvalidatePasswordResets :exec
+-- InvalidatePasswordResets marks every pending reset of a user as used, so older emails stop working
+UPDATE password_resets
+SET
+  is_used = TRUE
+WHERE
+  username = $1
+  AND is_used = FALSE;
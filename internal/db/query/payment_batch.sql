@@ -0,0 +1,80 @@
+-- name: CreatePaymentBatch :one
+INSERT INTO payment_batches (
+    owner,
+    message_id,
+    message_name
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetPaymentBatch :one
+SELECT * FROM payment_batches
+WHERE id = $1 LIMIT 1;
+
+-- name: ConfirmPaymentBatch :one
+UPDATE payment_batches
+SET
+    status = 'confirmed',
+    confirmed_at = now()
+WHERE
+    id = $1
+    AND status = 'pending'
+RETURNING *;
+
+-- name: CompletePaymentBatch :one
+-- CompletePaymentBatch marks a confirmed batch completed once none of its items is left to execute
+UPDATE payment_batches
+SET
+    status = 'completed',
+    completed_at = now()
+WHERE
+    id = $1
+    AND status = 'confirmed'
+    AND NOT EXISTS (
+        SELECT 1 FROM payment_batch_items
+        WHERE batch_id = payment_batches.id AND status = 'valid'
+    )
+RETURNING *;
+
+-- name: CreatePaymentBatchItem :one
+INSERT INTO payment_batch_items (
+    batch_id,
+    payment_information_id,
+    instruction_id,
+    end_to_end_id,
+    from_account_number,
+    from_account_id,
+    to_account_number,
+    to_account_id,
+    amount,
+    currency,
+    reference,
+    status,
+    reason_code,
+    reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
+
+-- name: GetPaymentBatchItemForUpdate :one
+SELECT * FROM payment_batch_items
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPaymentBatchItems :many
+SELECT * FROM payment_batch_items
+WHERE batch_id = $1
+ORDER BY id;
+
+-- name: ResolvePaymentBatchItem :one
+-- ResolvePaymentBatchItem records the outcome of executing a valid item, an item is only ever executed once
+UPDATE payment_batch_items
+SET
+    status = @status,
+    reason_code = @reason_code,
+    reason = @reason,
+    transfer_id = sqlc.narg(transfer_id)
+WHERE
+    id = @id
+    AND status = 'valid'
+RETURNING *;
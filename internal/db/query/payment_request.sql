@@ -0,0 +1,57 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (
+    requester,
+    payer,
+    account_id,
+    amount,
+    currency,
+    note
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPaymentRequest :one
+SELECT * FROM payment_requests
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPaymentRequestForUpdate :one
+SELECT * FROM payment_requests
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListIncomingPaymentRequests :many
+SELECT * FROM payment_requests
+WHERE payer = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ListOutgoingPaymentRequests :many
+SELECT * FROM payment_requests
+WHERE requester = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ResolvePaymentRequest :one
+UPDATE payment_requests
+SET
+    status = @status,
+    transfer_id = sqlc.narg(transfer_id),
+    updated_at = now()
+WHERE
+    id = @id
+    AND status = 'pending'
+    AND expires_at > now()
+RETURNING *;
+
+-- name: ExpirePaymentRequest :one
+UPDATE payment_requests
+SET
+    status = 'expired',
+    updated_at = now()
+WHERE
+    id = $1
+    AND status = 'pending'
+    AND expires_at <= now()
+RETURNING *;
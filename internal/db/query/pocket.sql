@@ -0,0 +1,32 @@
+-- name: CreatePocket :one
+INSERT INTO pockets (
+    account_id,
+    name
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetPocket :one
+SELECT * FROM pockets
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPocketForUpdate :one
+SELECT * FROM pockets
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPockets :many
+SELECT * FROM pockets
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: GetPocketedBalance :one
+SELECT COALESCE(SUM(balance), 0)::bigint AS pocketed_balance
+FROM pockets
+WHERE account_id = $1;
+
+-- name: AddPocketBalance :one
+UPDATE pockets
+SET balance = balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
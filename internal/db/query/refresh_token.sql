@@ -0,0 +1,25 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+  id,
+  session_id,
+  token_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetRefreshToken :one
+SELECT * FROM refresh_tokens
+WHERE id = $1 LIMIT 1;
+
+-- name: GetRefreshTokenForUpdate :one
+SELECT * FROM refresh_tokens
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: RotateRefreshToken :one
+-- RotateRefreshToken marks a refresh token as renewed, it fails if the token was renewed already
+UPDATE refresh_tokens
+SET rotated_at = now()
+WHERE id = $1 AND rotated_at IS NULL
+RETURNING *;
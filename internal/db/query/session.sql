@@ -2,13 +2,12 @@
 INSERT INTO sessions (
   id,
   username,
-  refresh_token,
   user_agent,
   client_ip,
   is_blocked,
   expires_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetSession :one
@@ -16,3 +15,35 @@ SELECT *
 FROM sessions 
 WHERE id = $1 
 LIMIT 1;
+
+
+-- name: ListRecentLogins :many
+SELECT client_ip, created_at
+FROM sessions
+WHERE username = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: ListSessions :many
+-- ListSessions lists the sessions of a user that have not expired yet, newest first
+SELECT *
+FROM sessions
+WHERE username = $1 AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND username = $2
+RETURNING *;
+
+-- name: BlockOtherSessions :execrows
+-- BlockOtherSessions blocks every session of a user except the one given
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND id <> $2 AND is_blocked = false;
+
+-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND is_blocked = false;
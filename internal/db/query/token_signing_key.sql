@@ -0,0 +1,19 @@
+-- name: CreateTokenSigningKey :one
+INSERT INTO token_signing_keys (
+  kid,
+  algorithm,
+  sealed_private_key,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListTokenSigningKeys :many
+-- ListTokenSigningKeys lists the unexpired keys, oldest first
+SELECT * FROM token_signing_keys
+WHERE expires_at > now()
+ORDER BY created_at;
+
+-- name: DeleteExpiredTokenSigningKeys :execrows
+DELETE FROM token_signing_keys
+WHERE expires_at <= now();
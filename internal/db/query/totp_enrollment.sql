@@ -0,0 +1,38 @@
+-- name: CreateTotpEnrollment :one
+-- CreateTotpEnrollment starts an enrollment or restarts one that was never confirmed with a new secret,
+-- a confirmed enrollment is left alone
+INSERT INTO totp_enrollments (
+  username,
+  secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET
+  secret = EXCLUDED.secret,
+  last_used_step = 0,
+  created_at = now()
+WHERE totp_enrollments.confirmed_at IS NULL
+RETURNING *;
+
+-- name: GetTotpEnrollment :one
+SELECT * FROM totp_enrollments
+WHERE username = $1 LIMIT 1;
+
+-- name: GetTotpEnrollmentForUpdate :one
+SELECT * FROM totp_enrollments
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UseTotpStep :one
+-- UseTotpStep records the time step of an accepted code and confirms the enrollment if it was not yet
+UPDATE totp_enrollments
+SET
+  last_used_step = $2,
+  confirmed_at = COALESCE(confirmed_at, now())
+WHERE username = $1
+RETURNING *;
+
+-- name: DeleteTotpEnrollment :exec
+DELETE FROM totp_enrollments
+WHERE username = $1;
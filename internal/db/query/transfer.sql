@@ -2,9 +2,10 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  reference
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: GetTransfer :one
@@ -18,4 +19,64 @@ WHERE
     to_account_id = $2
 ORDER BY id
 LIMIT $3
-OFFSET $4;
\ No newline at end of file
+OFFSET $4;
+
+-- name: GetTransferredAmountSince :one
+-- GetTransferredAmountSince sums what was sent to an account from every account the user can transfer from
+SELECT COALESCE(SUM(t.amount), 0)::bigint AS transferred_amount
+FROM transfers t
+JOIN account_members m ON m.account_id = t.from_account_id
+WHERE
+    m.username = @username
+    AND m.role IN ('owner', 'can-transfer')
+    AND t.to_account_id = @to_account_id
+    AND t.created_at >= @since;
+
+-- name: CountTransfersSince :one
+SELECT COUNT(*) FROM transfers
+WHERE
+    from_account_id = @from_account_id
+    AND created_at >= @since;
+
+-- name: HasTransferredTo :one
+SELECT EXISTS (
+    SELECT 1 FROM transfers
+    WHERE
+        from_account_id = @from_account_id
+        AND to_account_id = @to_account_id
+);
+
+-- name: SearchTransfers :many
+-- SearchTransfers finds transfers into or out of the given accounts. Filters left NULL match every transfer.
+-- reference is an ILIKE pattern.
+SELECT
+    t.*,
+    f.account_number AS from_account_number,
+    r.account_number AS to_account_number,
+    f.currency
+FROM transfers t
+JOIN accounts f ON f.id = t.from_account_id
+JOIN accounts r ON r.id = t.to_account_id
+WHERE
+    (
+        (@direction::varchar IN ('', 'outgoing') AND t.from_account_id = ANY(@account_ids::bigint[]))
+        OR (@direction::varchar IN ('', 'incoming') AND t.to_account_id = ANY(@account_ids::bigint[]))
+    )
+    AND (
+        sqlc.narg('counterparty_account_id')::bigint IS NULL
+        OR (t.from_account_id = ANY(@account_ids::bigint[]) AND t.to_account_id = sqlc.narg('counterparty_account_id'))
+        OR (t.to_account_id = ANY(@account_ids::bigint[]) AND t.from_account_id = sqlc.narg('counterparty_account_id'))
+    )
+    AND (sqlc.narg('created_after')::timestamptz IS NULL OR t.created_at >= sqlc.narg('created_after'))
+    AND (sqlc.narg('created_before')::timestamptz IS NULL OR t.created_at < sqlc.narg('created_before'))
+    AND (sqlc.narg('min_amount')::bigint IS NULL OR t.amount >= sqlc.narg('min_amount'))
+    AND (sqlc.narg('max_amount')::bigint IS NULL OR t.amount <= sqlc.narg('max_amount'))
+    AND (sqlc.narg('reference')::varchar IS NULL OR t.reference ILIKE sqlc.narg('reference'))
+ORDER BY
+    CASE WHEN @sort::varchar = 'amount_asc' THEN t.amount END ASC,
+    CASE WHEN @sort::varchar = 'amount_desc' THEN t.amount END DESC,
+    CASE WHEN @sort::varchar = 'oldest' THEN t.created_at END ASC,
+    t.created_at DESC,
+    t.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
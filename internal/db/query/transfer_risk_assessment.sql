@@ -0,0 +1,51 @@
+-- name: CreateTransferRiskAssessment :one
+INSERT INTO transfer_risk_assessments (
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    reference,
+    requested_by,
+    client_ip,
+    decision,
+    findings,
+    status,
+    transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetTransferRiskAssessment :one
+SELECT * FROM transfer_risk_assessments
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferRiskAssessmentForUpdate :one
+SELECT * FROM transfer_risk_assessments
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferRiskAssessments :many
+SELECT
+    sqlc.embed(r),
+    f.account_number AS from_account_number,
+    t.account_number AS to_account_number
+FROM transfer_risk_assessments r
+JOIN accounts f ON f.id = r.from_account_id
+JOIN accounts t ON t.id = r.to_account_id
+WHERE r.status = $1
+ORDER BY r.id
+LIMIT $2
+OFFSET $3;
+
+-- name: ReviewTransferRiskAssessment :one
+-- ReviewTransferRiskAssessment records the decision of a banker on a held transfer
+UPDATE transfer_risk_assessments
+SET
+    status = @status,
+    transfer_id = sqlc.narg(transfer_id),
+    reviewed_by = @reviewed_by,
+    reviewed_at = now()
+WHERE
+    id = @id
+    AND status = 'held'
+RETURNING *;
@@ -12,6 +12,24 @@ INSERT INTO users (
 SELECT * FROM users 
 WHERE username = $1 LIMIT 1;
 
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1 LIMIT 1;
+
+-- name: GetUserByHandle :one
+SELECT * FROM users
+WHERE handle = $1 LIMIT 1;
+
+-- name: RehashPassword :execrows
+-- RehashPassword replaces the hash of a password with a new hash of the same password,
+-- unless the password was changed in the meantime
+UPDATE users
+SET
+    hashed_password = sqlc.arg(new_hashed_password)
+WHERE
+    username = sqlc.arg(username)
+    AND hashed_password = sqlc.arg(hashed_password);
+
 -- name: UpdateUser :one
 UPDATE users
 SET
@@ -19,7 +37,15 @@ SET
     password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
     full_name = COALESCE(sqlc.narg(full_name), full_name),
     email = COALESCE(sqlc.narg(email), email),
-    is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
+    email_changed_at = COALESCE(sqlc.narg(email_changed_at), email_changed_at),
+    is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+    handle = COALESCE(sqlc.narg(handle), handle)
 WHERE
     username = sqlc.arg(username)
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE username = $1
+RETURNING *;
@@ -0,0 +1,35 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+    owner,
+    url,
+    secret,
+    event_types
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks
+WHERE owner = $1 AND is_active
+ORDER BY id;
+
+-- name: ListWebhooksForEvent :many
+SELECT w.* FROM webhooks w
+WHERE
+    w.is_active
+    AND @event_type::varchar = ANY(w.event_types)
+    AND EXISTS (
+        SELECT 1 FROM account_members m
+        WHERE m.username = w.owner
+        AND m.account_id = ANY(@account_ids::bigint[])
+    )
+ORDER BY w.id;
+
+-- name: DeactivateWebhook :execrows
+UPDATE webhooks
+SET is_active = false
+WHERE id = $1 AND owner = $2 AND is_active;
@@ -0,0 +1,37 @@
+-- name: CreateWebhookDelivery :one
+-- Creating the original delivery of an event twice returns the existing row,
+-- so a retried fan-out does not send the event again
+INSERT INTO webhook_deliveries (
+    webhook_id,
+    event_id,
+    event_type,
+    payload,
+    replay_of
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (webhook_id, event_id) WHERE replay_of IS NULL
+DO UPDATE SET event_id = EXCLUDED.event_id
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: RecordWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET
+    status = @status,
+    attempts = attempts + 1,
+    response_code = sqlc.narg(response_code),
+    last_error = @last_error,
+    last_attempt_at = now()
+WHERE id = @id
+RETURNING *;
@@ -0,0 +1,114 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
+	"github.com/RobinHood3082/simplebank/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateAccountRef checks that an account is identified by either its ID or its account number, e.g. account_id
+// or account_number for field "account". The check digits are verified here, so a mistyped number is never looked up.
+func validateAccountRef(field string, id int64, number string) *errdetails.BadRequest_FieldViolation {
+	if number == "" {
+		if err := validator.ValidateAccountId(id); err != nil {
+			return fieldViolation(field+"_id", err)
+		}
+		return nil
+	}
+
+	if id != 0 {
+		return fieldViolation(field+"_number", fmt.Errorf("cannot be given together with %s_id", field))
+	}
+
+	if err := validator.ValidateAccountNumber(number); err != nil {
+		return fieldViolation(field+"_number", err)
+	}
+	return nil
+}
+
+// getAccountRef loads an account by its account number when one is given, otherwise by its ID
+func (server *Server) getAccountRef(ctx context.Context, id int64, number string) (persistence.Account, error) {
+	var account persistence.Account
+	var err error
+	if number != "" {
+		account, err = server.store.GetAccountByNumber(ctx, util.NormalizeAccountNumber(number))
+	} else {
+		account, err = server.store.GetAccount(ctx, id)
+	}
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account not found")
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account")
+	}
+
+	return account, nil
+}
+
+// getAccountNumber looks up the account number of an account, which is returned to clients in place of its ID
+func (server *Server) getAccountNumber(ctx context.Context, id int64) (string, error) {
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		return "", server.internalError("failed to get account", err)
+	}
+
+	return account.AccountNumber, nil
+}
+
+// authorizeAccountRef loads an account by ID or account number and checks that the user is a member
+// holding at least the required role. Unknown accounts are reported like other users' accounts,
+// so account numbers cannot be probed.
+func (server *Server) authorizeAccountRef(ctx context.Context, id int64, number string, username string, requiredRole string) (persistence.Account, error) {
+	account, err := server.getAccountRef(ctx, id, number)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return account, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+		}
+		return account, err
+	}
+
+	_, err = server.authorizeAccountMember(ctx, account.ID, username, requiredRole)
+	if err != nil {
+		return account, err
+	}
+
+	return account, nil
+}
+
+// notifyBalanceAdded emails the owner of an account about a deposit and fires the webhook event for it
+func (server *Server) notifyBalanceAdded(ctx context.Context, account persistence.Account, amount util.Money) {
+	taskPayload := worker.PayloadSendBalanceAddedEmail{
+		Username:     account.Owner,
+		AccountID:    util.MaskAccountNumber(account.AccountNumber),
+		AddedBalance: amount,
+		NewBalance:   account.BalanceMoney(),
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	_ = server.taskDistributor.DistributeTask(ctx, worker.TaskSendBalanceAddedEmail, taskPayload, opts...)
+
+	data := webhook.BalanceAddedData{
+		AccountNumber: account.AccountNumber,
+		Amount:        amount,
+		Balance:       account.BalanceMoney(),
+	}
+
+	_ = server.taskDistributor.DistributeWebhookEvent(ctx, webhook.EventAccountBalanceAdded, data, account.ID)
+}
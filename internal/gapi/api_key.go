@@ -0,0 +1,129 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rpcScopes is the scope an API key needs to call each RPC. RPCs that are not listed,
+// such as managing sessions, MFA or API keys, cannot be called with an API key. Approving or
+// rejecting approval requests and held transfers is left out as it would skip the reviewer's MFA.
+var rpcScopes = map[string]string{
+	pb.SimpleBank_GetAccount_FullMethodName:                  util.ScopeAccountsRead,
+	pb.SimpleBank_ListAccountMembers_FullMethodName:          util.ScopeAccountsRead,
+	pb.SimpleBank_ExportStatement_FullMethodName:             util.ScopeAccountsRead,
+	pb.SimpleBank_CreateAccount_FullMethodName:               util.ScopeAccountsWrite,
+	pb.SimpleBank_AddAccountBalance_FullMethodName:           util.ScopeAccountsWrite,
+	pb.SimpleBank_InviteAccountMember_FullMethodName:         util.ScopeAccountsWrite,
+	pb.SimpleBank_RemoveAccountMember_FullMethodName:         util.ScopeAccountsWrite,
+	pb.SimpleBank_CreatePocket_FullMethodName:                util.ScopeAccountsWrite,
+	pb.SimpleBank_MovePocketFunds_FullMethodName:             util.ScopeAccountsWrite,
+	pb.SimpleBank_SearchTransfers_FullMethodName:             util.ScopeTransfersRead,
+	pb.SimpleBank_SearchEntries_FullMethodName:               util.ScopeTransfersRead,
+	pb.SimpleBank_LookupRecipient_FullMethodName:             util.ScopeTransfersRead,
+	pb.SimpleBank_GetSpendingAnalytics_FullMethodName:        util.ScopeTransfersRead,
+	pb.SimpleBank_ListTransferRiskAssessments_FullMethodName: util.ScopeTransfersRead,
+	pb.SimpleBank_ListBeneficiaries_FullMethodName:           util.ScopeBeneficiariesRead,
+	pb.SimpleBank_CreateBeneficiary_FullMethodName:           util.ScopeBeneficiariesWrite,
+	pb.SimpleBank_UpdateBeneficiary_FullMethodName:           util.ScopeBeneficiariesWrite,
+	pb.SimpleBank_DeleteBeneficiary_FullMethodName:           util.ScopeBeneficiariesWrite,
+	pb.SimpleBank_ListPaymentRequests_FullMethodName:         util.ScopePaymentsRead,
+	pb.SimpleBank_GetPaymentBatch_FullMethodName:             util.ScopePaymentsRead,
+	pb.SimpleBank_GetPaymentBatchStatusReport_FullMethodName: util.ScopePaymentsRead,
+	pb.SimpleBank_CreatePaymentRequest_FullMethodName:        util.ScopePaymentsWrite,
+	pb.SimpleBank_AcceptPaymentRequest_FullMethodName:        util.ScopePaymentsWrite,
+	pb.SimpleBank_DeclinePaymentRequest_FullMethodName:       util.ScopePaymentsWrite,
+	pb.SimpleBank_ImportPaymentBatch_FullMethodName:          util.ScopePaymentsWrite,
+	pb.SimpleBank_ConfirmPaymentBatch_FullMethodName:         util.ScopePaymentsWrite,
+	pb.SimpleBank_ListCategories_FullMethodName:              util.ScopeCategoriesRead,
+	pb.SimpleBank_ListCategoryRules_FullMethodName:           util.ScopeCategoriesRead,
+	pb.SimpleBank_CreateCategory_FullMethodName:              util.ScopeCategoriesWrite,
+	pb.SimpleBank_CreateCategoryRule_FullMethodName:          util.ScopeCategoriesWrite,
+	pb.SimpleBank_SetTransferCategory_FullMethodName:         util.ScopeCategoriesWrite,
+	pb.SimpleBank_GetDispute_FullMethodName:                  util.ScopeDisputesRead,
+	pb.SimpleBank_ListDisputes_FullMethodName:                util.ScopeDisputesRead,
+	pb.SimpleBank_GetDisputeAttachment_FullMethodName:        util.ScopeDisputesRead,
+	pb.SimpleBank_OpenDispute_FullMethodName:                 util.ScopeDisputesWrite,
+	pb.SimpleBank_UpdateDisputeStatus_FullMethodName:         util.ScopeDisputesWrite,
+	pb.SimpleBank_ListWebhooks_FullMethodName:                util.ScopeWebhooksRead,
+	pb.SimpleBank_ListWebhookDeliveries_FullMethodName:       util.ScopeWebhooksRead,
+	pb.SimpleBank_CreateWebhook_FullMethodName:               util.ScopeWebhooksWrite,
+	pb.SimpleBank_DeleteWebhook_FullMethodName:               util.ScopeWebhooksWrite,
+	pb.SimpleBank_ReplayWebhookDelivery_FullMethodName:       util.ScopeWebhooksWrite,
+	pb.SimpleBank_ListCurrencies_FullMethodName:              util.ScopeCurrenciesRead,
+	pb.SimpleBank_CreateCurrency_FullMethodName:              util.ScopeCurrenciesWrite,
+	pb.SimpleBank_EnableCurrency_FullMethodName:              util.ScopeCurrenciesWrite,
+	pb.SimpleBank_DisableCurrency_FullMethodName:             util.ScopeCurrenciesWrite,
+	pb.SimpleBank_ListApprovalRequests_FullMethodName:        util.ScopeApprovalsRead,
+	pb.SimpleBank_GetApprovalRequest_FullMethodName:          util.ScopeApprovalsRead,
+}
+
+// rpcMethod returns the full name of the RPC being served, both for gRPC calls and calls through the HTTP gateway
+func rpcMethod(ctx context.Context) string {
+	if method, ok := grpc.Method(ctx); ok && method != "" {
+		return method
+	}
+
+	method, _ := runtime.RPCMethod(ctx)
+	return method
+}
+
+// authorizeApiKey authenticates a request made with an API key. The key acts as its owner,
+// with the owner's current role, but only for RPCs within its scopes.
+func (server *Server) authorizeApiKey(ctx context.Context, key string) (*token.Payload, error) {
+	result, err := server.store.AuthenticateApiKey(ctx, persistence.AuthenticateApiKeyParams{
+		Key:      key,
+		ClientIp: server.extractMetadata(ctx).ClientIp,
+	})
+	if err != nil {
+		if errors.Is(err, persistence.ErrInvalidApiKey) || errors.Is(err, persistence.ErrApiKeyAddressDenied) {
+			return nil, err
+		}
+		return nil, errors.New("failed to authenticate API key")
+	}
+
+	scope, ok := rpcScopes[rpcMethod(ctx)]
+	if !ok {
+		return nil, errors.New("this method cannot be called with an API key")
+	}
+
+	if !slices.Contains(result.ApiKey.Scopes, scope) {
+		return nil, fmt.Errorf("API key is missing the %s scope", scope)
+	}
+
+	return &token.Payload{
+		Username: result.Owner.Username,
+		Role:     result.Owner.Role,
+		IssuedAt: result.ApiKey.CreatedAt.Time,
+	}, nil
+}
+
+// authorizeApiKeyRevoker checks that the API key belongs to the user, admins can revoke every key.
+// Other users' keys are reported as not found.
+func (server *Server) authorizeApiKeyRevoker(ctx context.Context, apiKeyID int64, username string, role string) (persistence.ApiKey, error) {
+	apiKey, err := server.store.GetApiKey(ctx, apiKeyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apiKey, status.Errorf(codes.NotFound, "API key not found")
+		}
+		return apiKey, status.Errorf(codes.Internal, "failed to get API key")
+	}
+
+	if !util.RoleHasPermission(role, util.PermissionApiKeyRevokeAny) && apiKey.Owner != username {
+		return apiKey, status.Errorf(codes.NotFound, "API key not found")
+	}
+
+	return apiKey, nil
+}
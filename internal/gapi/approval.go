@@ -0,0 +1,88 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exceedsApprovalThreshold checks if an amount is over a threshold configured in whole units of its currency
+func (server *Server) exceedsApprovalThreshold(threshold int64, amount util.Money) (bool, error) {
+	limit, err := util.MoneyFromUnits(amount.Currency, threshold, 0)
+	if err != nil {
+		return false, server.internalError("invalid approval threshold", err)
+	}
+
+	return amount.Amount > limit.Amount, nil
+}
+
+// requestApproval holds an operation until a user allowed to review approvals, other than the user, approves it,
+// and schedules its expiry in case nobody does
+func (server *Server) requestApproval(ctx context.Context, operation string, payload any, username string) (persistence.ApprovalRequest, error) {
+	txResult, err := server.store.CreateApprovalRequestTx(ctx, persistence.CreateApprovalRequestTxParams{
+		Operation:   operation,
+		Payload:     payload,
+		RequestedBy: username,
+		ExpiresAt:   time.Now().Add(server.config.ApprovalRequestTTL),
+		AfterCreate: func(request persistence.ApprovalRequest) error {
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessAt(request.ExpiresAt.Time),
+				asynq.Queue(worker.QueueDefault),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskExpireApprovalRequest, &worker.PayloadExpireApprovalRequest{
+				ApprovalRequestID: request.ID,
+			}, opts...)
+		},
+	})
+	if err != nil {
+		return txResult.Request, server.internalError("failed to create approval request", err)
+	}
+
+	return txResult.Request, nil
+}
+
+// authorizeApprovalReviewer checks that a user of the role could have requested the operation held by the approval request,
+// so role changes cannot be used to hand out more permissions than the reviewer holds or to demote users above the reviewer
+func (server *Server) authorizeApprovalReviewer(ctx context.Context, requestID int64, role string) error {
+	request, err := server.store.GetApprovalRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status.Errorf(codes.NotFound, "approval request not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get approval request")
+	}
+
+	if request.Operation != util.ApprovalUpdateUserRole {
+		return nil
+	}
+
+	var change persistence.UserRoleChange
+	if err := json.Unmarshal(request.Payload, &change); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode approval request payload")
+	}
+
+	user, err := server.store.GetUser(ctx, change.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status.Errorf(codes.FailedPrecondition, "user %s no longer exists", change.Username)
+		}
+		return status.Errorf(codes.Internal, "failed to get user")
+	}
+
+	if !util.RoleCanChangeRole(role, user.Role, change.Role) {
+		return status.Errorf(codes.PermissionDenied, "cannot approve changing role %s to %s, role %s does not grant every permission of both", user.Role, change.Role, role)
+	}
+
+	return nil
+}
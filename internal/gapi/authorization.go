@@ -6,8 +6,13 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -15,7 +20,9 @@ const (
 	authorizationTypeBearer = "bearer"
 )
 
-func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+// authorizeUser authenticates the caller and checks that their role grants the permission rpcPermissions
+// requires for the RPC being served
+func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, errors.New("metadata is not provided")
@@ -38,23 +45,144 @@ func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []strin
 	}
 
 	accessToken := fields[1]
-	payload, err := server.tokenMaker.VerifyToken(accessToken)
-	if err != nil {
-		return nil, errors.New("invalid access token")
+	var payload *token.Payload
+	var err error
+	if util.IsAPIKey(accessToken) {
+		payload, err = server.authorizeApiKey(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		payload, err = server.tokenMaker.VerifyToken(accessToken)
+		if err != nil {
+			return nil, errors.New("invalid access token")
+		}
 	}
 
-	if !hasPermission(payload.Role, accessibleRoles) {
+	permission, ok := rpcPermissions[rpcMethod(ctx)]
+	if !ok {
 		return nil, fmt.Errorf("permission denied")
 	}
 
+	if permission != authenticatedOnly && !util.RoleHasPermission(payload.Role, permission) {
+		return nil, fmt.Errorf("permission denied: role %s does not grant %s", payload.Role, permission)
+	}
+
 	return payload, nil
 }
 
-func hasPermission(userRole string, accessibleRoles []string) bool {
-	for _, role := range accessibleRoles {
-		if userRole == role {
-			return true
+// authorizeAccountMember checks that the user is a member of the account holding at least the required role
+func (server *Server) authorizeAccountMember(ctx context.Context, accountID int64, username string, requiredRole string) (persistence.AccountMember, error) {
+	member, err := server.store.GetAccountMember(ctx, persistence.GetAccountMemberParams{
+		AccountID: accountID,
+		Username:  username,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return member, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+		}
+		return member, status.Errorf(codes.Internal, "failed to get account member")
+	}
+
+	if !util.AccountRoleAllows(member.Role, requiredRole) {
+		return member, status.Errorf(codes.PermissionDenied, "account role %s is not allowed to perform this action", member.Role)
+	}
+
+	return member, nil
+}
+
+// authorizePaymentRequestPayer checks that the payment request was sent to the user,
+// requests sent to others are reported as not found
+func (server *Server) authorizePaymentRequestPayer(ctx context.Context, paymentRequestID int64, username string) (persistence.PaymentRequest, error) {
+	paymentRequest, err := server.store.GetPaymentRequest(ctx, paymentRequestID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return paymentRequest, status.Errorf(codes.NotFound, "payment request not found")
+		}
+		return paymentRequest, status.Errorf(codes.Internal, "failed to get payment request")
+	}
+
+	if paymentRequest.Payer != username {
+		return paymentRequest, status.Errorf(codes.NotFound, "payment request not found")
+	}
+
+	return paymentRequest, nil
+}
+
+// authorizePaymentBatchOwner checks that the payment batch was imported by the user,
+// other users' batches are reported as not found
+func (server *Server) authorizePaymentBatchOwner(ctx context.Context, paymentBatchID int64, username string) (persistence.PaymentBatch, error) {
+	paymentBatch, err := server.store.GetPaymentBatch(ctx, paymentBatchID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return paymentBatch, status.Errorf(codes.NotFound, "payment batch not found")
+		}
+		return paymentBatch, status.Errorf(codes.Internal, "failed to get payment batch")
+	}
+
+	if paymentBatch.Owner != username {
+		return paymentBatch, status.Errorf(codes.NotFound, "payment batch not found")
+	}
+
+	return paymentBatch, nil
+}
+
+// authorizeWebhookOwner checks that the webhook belongs to the user and has not been deleted,
+// other webhooks are reported as not found
+func (server *Server) authorizeWebhookOwner(ctx context.Context, webhookID int64, username string) (persistence.Webhook, error) {
+	webhook, err := server.store.GetWebhook(ctx, webhookID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return webhook, status.Errorf(codes.NotFound, "webhook not found")
+		}
+		return webhook, status.Errorf(codes.Internal, "failed to get webhook")
+	}
+
+	if webhook.Owner != username || !webhook.IsActive {
+		return webhook, status.Errorf(codes.NotFound, "webhook not found")
+	}
+
+	return webhook, nil
+}
+
+// authorizeCategoryOwner checks that the category belongs to the user,
+// other users' categories are reported as not found
+func (server *Server) authorizeCategoryOwner(ctx context.Context, categoryID int64, username string) (persistence.Category, error) {
+	category, err := server.store.GetCategory(ctx, categoryID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return category, status.Errorf(codes.NotFound, "category not found")
+		}
+		return category, status.Errorf(codes.Internal, "failed to get category")
+	}
+
+	if category.Owner != username {
+		return category, status.Errorf(codes.NotFound, "category not found")
+	}
+
+	return category, nil
+}
+
+// authorizeTransferMember checks that the user is a member of either account of the transfer,
+// other transfers are reported as not found
+func (server *Server) authorizeTransferMember(ctx context.Context, transferID int64, username string) (persistence.Transfer, error) {
+	transfer, err := server.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return transfer, status.Errorf(codes.NotFound, "transfer not found")
+		}
+		return transfer, status.Errorf(codes.Internal, "failed to get transfer")
+	}
+
+	for _, accountID := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+		_, err = server.authorizeAccountMember(ctx, accountID, username, util.AccountViewOnlyRole)
+		if err == nil {
+			return transfer, nil
+		}
+		if status.Code(err) != codes.PermissionDenied {
+			return transfer, err
 		}
 	}
-	return false
+
+	return transfer, status.Errorf(codes.NotFound, "transfer not found")
 }
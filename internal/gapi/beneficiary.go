@@ -0,0 +1,10 @@
+package gapi
+
+import "github.com/RobinHood3082/simplebank/internal/persistence"
+
+func (server *Server) beneficiaryLimit() persistence.BeneficiaryLimit {
+	return persistence.BeneficiaryLimit{
+		CoolingOffPeriod: server.config.BeneficiaryCoolingOffPeriod,
+		Units:            server.config.NewBeneficiaryTransferLimit,
+	}
+}
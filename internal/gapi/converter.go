@@ -1,8 +1,14 @@
 package gapi
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/RobinHood3082/simplebank/internal/pb"
 	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/risk"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -11,17 +17,370 @@ func convertUser(user persistence.User) *pb.User {
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
+		Handle:            user.Handle.String,
+		Role:              user.Role,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt.Time),
 		CreatedAt:         timestamppb.New(user.CreatedAt.Time),
 	}
 }
 
+func convertSession(session persistence.Session) *pb.Session {
+	return &pb.Session{
+		Id:        uuid.UUID(session.ID.Bytes).String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		ExpiresAt: timestamppb.New(session.ExpiresAt.Time),
+		CreatedAt: timestamppb.New(session.CreatedAt.Time),
+	}
+}
+
 func convertAccount(account persistence.Account) *pb.Account {
 	return &pb.Account{
-		Id:        account.ID,
-		Owner:     account.Owner,
-		Balance:   account.Balance,
-		Currency:  account.Currency,
-		CreatedAt: timestamppb.New(account.CreatedAt.Time),
+		Owner:         account.Owner,
+		Balance:       convertMoney(account.BalanceMoney()),
+		Currency:      account.Currency,
+		CreatedAt:     timestamppb.New(account.CreatedAt.Time),
+		AccountNumber: account.AccountNumber,
+	}
+}
+
+func convertMoney(money util.Money) *pb.Money {
+	units, nanos := money.Units()
+	return &pb.Money{
+		CurrencyCode: money.Currency,
+		Units:        units,
+		Nanos:        nanos,
+	}
+}
+
+func parseMoney(money *pb.Money) (util.Money, error) {
+	return util.MoneyFromUnits(money.GetCurrencyCode(), money.GetUnits(), money.GetNanos())
+}
+
+func convertSearchTransfer(transfer persistence.SearchTransfersRow) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                transfer.ID,
+		FromAccountNumber: transfer.FromAccountNumber,
+		ToAccountNumber:   transfer.ToAccountNumber,
+		Amount:            convertMoney(util.Money{Amount: transfer.Amount, Currency: transfer.Currency}),
+		Reference:         transfer.Reference,
+		CreatedAt:         timestamppb.New(transfer.CreatedAt.Time),
+	}
+}
+
+func convertTransfer(transfer persistence.Transfer, fromAccount, toAccount persistence.Account) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                transfer.ID,
+		FromAccountNumber: fromAccount.AccountNumber,
+		ToAccountNumber:   toAccount.AccountNumber,
+		Amount:            convertMoney(util.Money{Amount: transfer.Amount, Currency: fromAccount.Currency}),
+		Reference:         transfer.Reference,
+		CreatedAt:         timestamppb.New(transfer.CreatedAt.Time),
+	}
+}
+
+func convertSearchEntry(entry persistence.SearchEntriesRow) *pb.Entry {
+	return &pb.Entry{
+		Id:            entry.ID,
+		AccountNumber: entry.AccountNumber,
+		Amount:        convertMoney(util.Money{Amount: entry.Amount, Currency: entry.Currency}),
+		CreatedAt:     timestamppb.New(entry.CreatedAt.Time),
+	}
+}
+
+func convertAccountMember(member persistence.AccountMember, account persistence.Account) *pb.AccountMember {
+	return &pb.AccountMember{
+		AccountNumber: account.AccountNumber,
+		Username:      member.Username,
+		Role:          member.Role,
+		CreatedAt:     timestamppb.New(member.CreatedAt.Time),
+	}
+}
+
+func convertPocket(pocket persistence.Pocket, account persistence.Account) *pb.Pocket {
+	return &pb.Pocket{
+		Id:            pocket.ID,
+		AccountNumber: account.AccountNumber,
+		Name:          pocket.Name,
+		Balance:       convertMoney(util.Money{Amount: pocket.Balance, Currency: account.Currency}),
+		CreatedAt:     timestamppb.New(pocket.CreatedAt.Time),
+	}
+}
+
+func convertBeneficiary(beneficiary persistence.Beneficiary, accountNumber string, coolingOffPeriod time.Duration) *pb.Beneficiary {
+	return &pb.Beneficiary{
+		Id:               beneficiary.ID,
+		Nickname:         beneficiary.Nickname,
+		AccountNumber:    accountNumber,
+		Currency:         beneficiary.Currency,
+		CreatedAt:        timestamppb.New(beneficiary.CreatedAt.Time),
+		CoolingOffEndsAt: timestamppb.New(beneficiary.CoolingOffEndsAt(coolingOffPeriod)),
+	}
+}
+
+func convertPaymentRequest(paymentRequest persistence.PaymentRequest) *pb.PaymentRequest {
+	// the worker expires requests asynchronously, report them as expired as soon as they are due
+	status := paymentRequest.Status
+	if status == util.PaymentRequestPending && time.Now().After(paymentRequest.ExpiresAt.Time) {
+		status = util.PaymentRequestExpired
+	}
+
+	return &pb.PaymentRequest{
+		Id:         paymentRequest.ID,
+		Requester:  paymentRequest.Requester,
+		Payer:      paymentRequest.Payer,
+		Amount:     convertMoney(util.Money{Amount: paymentRequest.Amount, Currency: paymentRequest.Currency}),
+		Note:       paymentRequest.Note,
+		Status:     status,
+		TransferId: paymentRequest.TransferID.Int64,
+		CreatedAt:  timestamppb.New(paymentRequest.CreatedAt.Time),
+		UpdatedAt:  timestamppb.New(paymentRequest.UpdatedAt.Time),
+		ExpiresAt:  timestamppb.New(paymentRequest.ExpiresAt.Time),
+	}
+}
+
+func convertPaymentBatch(paymentBatch persistence.PaymentBatch) *pb.PaymentBatch {
+	rsp := &pb.PaymentBatch{
+		Id:        paymentBatch.ID,
+		MessageId: paymentBatch.MessageID,
+		Status:    paymentBatch.Status,
+		CreatedAt: timestamppb.New(paymentBatch.CreatedAt.Time),
+	}
+
+	if paymentBatch.ConfirmedAt.Valid {
+		rsp.ConfirmedAt = timestamppb.New(paymentBatch.ConfirmedAt.Time)
+	}
+
+	if paymentBatch.CompletedAt.Valid {
+		rsp.CompletedAt = timestamppb.New(paymentBatch.CompletedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertPaymentBatchItem(item persistence.PaymentBatchItem) *pb.PaymentBatchItem {
+	return &pb.PaymentBatchItem{
+		Id:                   item.ID,
+		PaymentInformationId: item.PaymentInformationID,
+		InstructionId:        item.InstructionID,
+		EndToEndId:           item.EndToEndID,
+		FromAccountNumber:    item.FromAccountNumber,
+		ToAccountNumber:      item.ToAccountNumber,
+		Amount:               convertMoney(util.Money{Amount: item.Amount, Currency: item.Currency}),
+		Reference:            item.Reference,
+		Status:               item.Status,
+		ReasonCode:           item.ReasonCode,
+		Reason:               item.Reason,
+		TransferId:           item.TransferID.Int64,
+	}
+}
+
+func convertTransferRiskAssessment(assessment persistence.TransferRiskAssessment, fromAccountNumber, toAccountNumber string) *pb.TransferRiskAssessment {
+	rsp := &pb.TransferRiskAssessment{
+		Id:                assessment.ID,
+		FromAccountNumber: fromAccountNumber,
+		ToAccountNumber:   toAccountNumber,
+		Amount:            convertMoney(util.Money{Amount: assessment.Amount, Currency: assessment.Currency}),
+		Reference:         assessment.Reference,
+		RequestedBy:       assessment.RequestedBy,
+		ClientIp:          assessment.ClientIp,
+		Decision:          assessment.Decision,
+		Status:            assessment.Status,
+		TransferId:        assessment.TransferID.Int64,
+		ReviewedBy:        assessment.ReviewedBy.String,
+		CreatedAt:         timestamppb.New(assessment.CreatedAt.Time),
+	}
+
+	// findings are always stored as a JSON array of risk.Finding
+	var findings []risk.Finding
+	_ = json.Unmarshal(assessment.Findings, &findings)
+	for _, finding := range findings {
+		rsp.Findings = append(rsp.Findings, &pb.RiskFinding{
+			Rule:     finding.Rule,
+			Decision: string(finding.Decision),
+			Reason:   finding.Reason,
+		})
+	}
+
+	if assessment.ReviewedAt.Valid {
+		rsp.ReviewedAt = timestamppb.New(assessment.ReviewedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertDispute(dispute persistence.Dispute, accountNumber string) *pb.Dispute {
+	rsp := &pb.Dispute{
+		Id:                dispute.ID,
+		TransferId:        dispute.TransferID,
+		AccountNumber:     accountNumber,
+		OpenedBy:          dispute.OpenedBy,
+		Reason:            dispute.Reason,
+		Status:            dispute.Status,
+		Amount:            convertMoney(util.Money{Amount: dispute.Amount, Currency: dispute.Currency}),
+		CreditEntryId:     dispute.CreditEntryID.Int64,
+		ChargebackEntryId: dispute.ChargebackEntryID.Int64,
+		ReversalEntryId:   dispute.ReversalEntryID.Int64,
+		CreatedAt:         timestamppb.New(dispute.CreatedAt.Time),
+		UpdatedAt:         timestamppb.New(dispute.UpdatedAt.Time),
+	}
+
+	if dispute.ResolvedAt.Valid {
+		rsp.ResolvedAt = timestamppb.New(dispute.ResolvedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertDisputeAttachment(attachment persistence.ListDisputeAttachmentsRow) *pb.DisputeAttachment {
+	return &pb.DisputeAttachment{
+		Id:          attachment.ID,
+		DisputeId:   attachment.DisputeID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   timestamppb.New(attachment.CreatedAt.Time),
+	}
+}
+
+func convertDisputeEvent(event persistence.DisputeEvent) *pb.DisputeEvent {
+	return &pb.DisputeEvent{
+		Id:        event.ID,
+		Status:    event.Status,
+		Actor:     event.Actor,
+		Note:      event.Note,
+		CreatedAt: timestamppb.New(event.CreatedAt.Time),
+	}
+}
+
+func convertAccountLimits(account persistence.Account, limit persistence.AccountLimit) *pb.AccountLimits {
+	rsp := &pb.AccountLimits{
+		AccountNumber: account.AccountNumber,
+		UpdatedBy:     limit.UpdatedBy,
+		UpdatedAt:     timestamppb.New(limit.UpdatedAt.Time),
+	}
+
+	if limit.MaxTransfersPerHour.Valid {
+		rsp.MaxTransfersPerHour = &limit.MaxTransfersPerHour.Int64
+	}
+	if limit.NewCounterpartyThreshold.Valid {
+		rsp.NewCounterpartyThreshold = convertMoney(util.Money{Amount: limit.NewCounterpartyThreshold.Int64, Currency: account.Currency})
+	}
+	if limit.NewBeneficiaryTransferLimit.Valid {
+		rsp.NewBeneficiaryTransferLimit = convertMoney(util.Money{Amount: limit.NewBeneficiaryTransferLimit.Int64, Currency: account.Currency})
+	}
+
+	return rsp
+}
+
+func convertApprovalRequest(request persistence.ApprovalRequest) *pb.ApprovalRequest {
+	rsp := &pb.ApprovalRequest{
+		Id:          request.ID,
+		Operation:   request.Operation,
+		Payload:     string(request.Payload),
+		RequestedBy: request.RequestedBy,
+		Status:      request.Status,
+		ReviewedBy:  request.ReviewedBy.String,
+		ReviewNote:  request.ReviewNote,
+		ExpiresAt:   timestamppb.New(request.ExpiresAt.Time),
+		CreatedAt:   timestamppb.New(request.CreatedAt.Time),
+	}
+
+	if request.ReviewedAt.Valid {
+		rsp.ReviewedAt = timestamppb.New(request.ReviewedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertCurrency(currency persistence.Currency) *pb.Currency {
+	return &pb.Currency{
+		Code:      currency.Code,
+		Name:      currency.Name,
+		Exponent:  currency.Exponent,
+		IsEnabled: currency.IsEnabled,
+		CreatedAt: timestamppb.New(currency.CreatedAt.Time),
+	}
+}
+
+func convertCategory(category persistence.Category) *pb.Category {
+	return &pb.Category{
+		Id:        category.ID,
+		Name:      category.Name,
+		CreatedAt: timestamppb.New(category.CreatedAt.Time),
+	}
+}
+
+func convertCategoryRule(rule persistence.CategoryRule, counterpartyAccountNumber string) *pb.CategoryRule {
+	return &pb.CategoryRule{
+		Id:                        rule.ID,
+		CategoryId:                rule.CategoryID,
+		CounterpartyAccountNumber: counterpartyAccountNumber,
+		Keyword:                   rule.Keyword.String,
+		CreatedAt:                 timestamppb.New(rule.CreatedAt.Time),
+	}
+}
+
+func convertWebhook(webhook persistence.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:         webhook.ID,
+		Url:        webhook.Url,
+		EventTypes: webhook.EventTypes,
+		CreatedAt:  timestamppb.New(webhook.CreatedAt.Time),
+	}
+}
+
+func convertWebhookDelivery(delivery persistence.WebhookDelivery) *pb.WebhookDelivery {
+	rsp := &pb.WebhookDelivery{
+		Id:           delivery.ID,
+		WebhookId:    delivery.WebhookID,
+		EventId:      delivery.EventID,
+		EventType:    delivery.EventType,
+		Payload:      string(delivery.Payload),
+		Status:       delivery.Status,
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode.Int32,
+		LastError:    delivery.LastError,
+		CreatedAt:    timestamppb.New(delivery.CreatedAt.Time),
+		ReplayOf:     delivery.ReplayOf.Int64,
+	}
+
+	if delivery.LastAttemptAt.Valid {
+		rsp.LastAttemptAt = timestamppb.New(delivery.LastAttemptAt.Time)
+	}
+
+	return rsp
+}
+
+func convertApiKey(apiKey persistence.ApiKey) *pb.ApiKey {
+	rsp := &pb.ApiKey{
+		Id:         apiKey.ID,
+		Owner:      apiKey.Owner,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		AllowedIps: apiKey.AllowedIps,
+		CreatedAt:  timestamppb.New(apiKey.CreatedAt.Time),
+	}
+
+	if apiKey.LastUsedAt.Valid {
+		rsp.LastUsedAt = timestamppb.New(apiKey.LastUsedAt.Time)
+		rsp.LastUsedIp = apiKey.LastUsedIp.String
+	}
+
+	if apiKey.RevokedAt.Valid {
+		rsp.RevokedAt = timestamppb.New(apiKey.RevokedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertRole(role persistence.Role, permissions []string) *pb.Role {
+	return &pb.Role{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   timestamppb.New(role.CreatedAt.Time),
 	}
 }
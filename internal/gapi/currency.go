@@ -0,0 +1,41 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// setCurrencyEnabled enables or disables a currency for new accounts on behalf of a banker.
+// The local catalogue is updated right away; other instances pick the change up on their next refresh.
+func (server *Server) setCurrencyEnabled(ctx context.Context, code string, enabled bool) (persistence.Currency, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return persistence.Currency{}, unauthenticatedError(err)
+	}
+
+	if err := validator.ValidateCurrencyCode(code); err != nil {
+		return persistence.Currency{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("code", err)})
+	}
+
+	currency, err := server.store.UpdateCurrencyEnabled(ctx, persistence.UpdateCurrencyEnabledParams{
+		Code:      code,
+		IsEnabled: enabled,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return persistence.Currency{}, status.Errorf(codes.NotFound, "currency not found")
+		}
+		return persistence.Currency{}, server.internalError("failed to update currency", err)
+	}
+
+	util.PutCurrency(currency.Catalogue())
+
+	return currency, nil
+}
@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// distributeDisputeEmail queues the email telling both parties of the disputed transfer about a step in the case
+func (server *Server) distributeDisputeEmail(ctx context.Context, event persistence.DisputeEvent) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	return server.taskDistributor.DistributeTask(ctx, worker.TaskSendDisputeEmail, &worker.PayloadSendDisputeEmail{
+		DisputeEventID: event.ID,
+	}, opts...)
+}
+
+// authorizeDisputeViewer checks that the dispute was opened by the user, bankers and auditors can see every dispute.
+// Other users' disputes are reported as not found.
+func (server *Server) authorizeDisputeViewer(ctx context.Context, disputeID int64, username string, role string) (persistence.Dispute, error) {
+	dispute, err := server.store.GetDispute(ctx, disputeID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return dispute, status.Errorf(codes.NotFound, "dispute not found")
+		}
+		return dispute, status.Errorf(codes.Internal, "failed to get dispute")
+	}
+
+	if !util.RoleHasPermission(role, util.PermissionDisputeReadAny) && dispute.OpenedBy != username {
+		return dispute, status.Errorf(codes.NotFound, "dispute not found")
+	}
+
+	return dispute, nil
+}
+
+// disputeMoveBooksEntries checks if moving the dispute to the status books ledger entries
+func disputeMoveBooksEntries(dispute persistence.Dispute, to string) bool {
+	switch to {
+	case util.DisputeProvisionalCredit, util.DisputeResolvedForCustomer:
+		return true
+	case util.DisputeResolvedAgainstCustomer:
+		return dispute.CreditEntryID.Valid
+	}
+	return false
+}
@@ -1,9 +1,12 @@
 package gapi
 
 import (
+	"time"
+
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
@@ -24,6 +27,25 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 	return details.Err()
 }
 
+// internalError logs what went wrong and only tells the client what failed, so database errors
+// and other internals do not reach clients
+func (server *Server) internalError(message string, err error) error {
+	server.logger.Error(message, "error", err)
+	return status.Errorf(codes.Internal, "%s", message)
+}
+
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
 }
+
+// resourceExhaustedError tells the client how long to wait before trying again
+func resourceExhaustedError(err error, retryAfter time.Duration) error {
+	retryAfter = retryAfter.Round(time.Second)
+	statusExhausted := status.Newf(codes.ResourceExhausted, "%s, try again in %s", err, retryAfter)
+
+	details, detailsErr := statusExhausted.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if detailsErr != nil {
+		return statusExhausted.Err()
+	}
+	return details.Err()
+}
@@ -0,0 +1,104 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ExportStatementHandler serves ExportStatement on the HTTP gateway. The generated in-process gateway
+// handler does not support server streaming, so this one writes each chunk to the response as it is sent.
+func (server *Server) ExportStatementHandler(mux *runtime.ServeMux) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+
+		ctx, err := runtime.AnnotateIncomingContext(
+			r.Context(),
+			mux,
+			r,
+			"/pb.SimpleBank/ExportStatement",
+			runtime.WithHTTPPathPattern("/api/v1/export_statement"),
+		)
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		req := &pb.ExportStatementRequest{}
+		err = runtime.PopulateQueryParameters(req, r.URL.Query(), utilities.NewDoubleArray(nil))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		stream := &httpBodyStream{ctx: ctx, w: w}
+		err = server.ExportStatement(req, stream)
+		if err != nil && !stream.started {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+		}
+	}
+}
+
+// httpBodyStream adapts an HTTP response to the server side of a HttpBody stream
+type httpBodyStream struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	header  metadata.MD
+	started bool
+}
+
+func (s *httpBodyStream) Send(body *httpbody.HttpBody) error {
+	if !s.started {
+		s.w.Header().Set("Content-Type", body.GetContentType())
+		if values := s.header.Get(contentDispositionHeader); len(values) > 0 {
+			s.w.Header().Set("Content-Disposition", values[0])
+		}
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	if _, err := s.w.Write(body.GetData()); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func (s *httpBodyStream) SetHeader(md metadata.MD) error {
+	if s.started {
+		return status.Error(codes.Internal, "headers already sent")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *httpBodyStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *httpBodyStream) SetTrailer(metadata.MD) {}
+
+func (s *httpBodyStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *httpBodyStream) SendMsg(m any) error {
+	body, ok := m.(*httpbody.HttpBody)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected message type %T", m)
+	}
+	return s.Send(body)
+}
+
+func (s *httpBodyStream) RecvMsg(any) error {
+	return status.Error(codes.Unimplemented, "export statement stream has no requests")
+}
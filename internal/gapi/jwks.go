@@ -0,0 +1,25 @@
+package gapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// JWKSHandler serves the public keys tokens can be verified with at /.well-known/jwks.json on the HTTP gateway.
+// Verifiers may cache the keys for as long as a new key takes to be loaded by every instance.
+func (server *Server) JWKSHandler() runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		js, err := json.Marshal(server.tokenMaker.PublicKeys())
+		if err != nil {
+			http.Error(w, "failed to marshal public keys", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(server.config.TokenKeyRefreshInterval.Seconds())))
+		_, _ = w.Write(js)
+	}
+}
@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/worker"
+	"github.com/hibiken/asynq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errInvalidCredentials is returned for unknown usernames and wrong passwords alike,
+// so logins cannot be used to find out who has an account
+var errInvalidCredentials = status.Errorf(codes.Unauthenticated, "invalid username or password")
+
+func (server *Server) loginPolicy() persistence.LoginPolicy {
+	return persistence.LoginPolicy{
+		MaxFailedAttempts:      server.config.LoginMaxFailedAttempts,
+		MaxFailedAttemptsPerIP: server.config.LoginMaxFailedAttemptsPerIP,
+		FailureWindow:          server.config.LoginFailureWindow,
+		BaseDelay:              server.config.LoginBaseDelay,
+		LockoutDuration:        server.config.LoginLockoutDuration,
+	}
+}
+
+// checkLoginAttempt rejects a login while the username is locked or has to wait after failed logins
+func (server *Server) checkLoginAttempt(ctx context.Context, username string, clientIP string) error {
+	retryAfter, err := server.store.CheckLoginAttempt(ctx, persistence.CheckLoginAttemptParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   server.loginPolicy(),
+	})
+	if err != nil {
+		if errors.Is(err, persistence.ErrLoginLocked) || errors.Is(err, persistence.ErrTooManyLoginAttempts) {
+			return resourceExhaustedError(err, retryAfter)
+		}
+		return status.Errorf(codes.Internal, "failed to check login attempt")
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts a failed login and emails the user when it locks their account.
+// It returns the error to respond with.
+func (server *Server) recordFailedLogin(ctx context.Context, username string, clientIP string, userExists bool) error {
+	if err := server.countFailedLogin(ctx, username, clientIP, userExists); err != nil {
+		return err
+	}
+
+	return errInvalidCredentials
+}
+
+// countFailedLogin counts a wrong password or two-factor code towards the lockout of the username,
+// and emails the user when it locks their account
+func (server *Server) countFailedLogin(ctx context.Context, username string, clientIP string, userExists bool) error {
+	txResult, err := server.store.RecordFailedLoginTx(ctx, persistence.RecordFailedLoginTxParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   server.loginPolicy(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to record failed login")
+	}
+
+	if txResult.Locked && userExists {
+		taskPayload := &worker.PayloadSendLoginLockedEmail{
+			Username:    username,
+			LockedUntil: txResult.Throttle.LockedUntil.Time,
+		}
+
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.Queue(worker.QueueCritical),
+		}
+
+		_ = server.taskDistributor.DistributeTask(ctx, worker.TaskSendLoginLockedEmail, taskPayload, opts...)
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package gapi
 
 import (
 	"context"
+	"strings"
 
+	"github.com/RobinHood3082/simplebank/util"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
@@ -11,6 +13,7 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
+	contentDispositionHeader   = "content-disposition"
 )
 
 type Metadata struct {
@@ -20,6 +23,7 @@ type Metadata struct {
 
 func (server *Server) extractMetadata(ctx context.Context) *Metadata {
 	mtdt := &Metadata{}
+	var forwardedFor []string
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
 			mtdt.UserAgent = userAgents[0]
@@ -29,14 +33,33 @@ func (server *Server) extractMetadata(ctx context.Context) *Metadata {
 			mtdt.UserAgent = userAgents[0]
 		}
 
-		if clientIPs := md.Get(xForwardedForHeader); len(clientIPs) > 0 {
-			mtdt.ClientIp = clientIPs[0]
+		for _, value := range md.Get(xForwardedForHeader) {
+			for _, addr := range strings.Split(value, ",") {
+				forwardedFor = append(forwardedFor, strings.TrimSpace(addr))
+			}
 		}
 	}
 
+	// the gateway has no peer, it appends the address of its own client to X-Forwarded-For instead
 	if p, ok := peer.FromContext(ctx); ok {
-		mtdt.ClientIp = p.Addr.String()
+		forwardedFor = append(forwardedFor, p.Addr.String())
 	}
 
+	mtdt.ClientIp = server.clientAddress(forwardedFor)
 	return mtdt
 }
+
+// clientAddress picks the client out of a chain of addresses, the last of which made the connection.
+// The chain is walked back only through trusted proxies, the addresses before them can be set by anyone.
+func (server *Server) clientAddress(chain []string) string {
+	for i := len(chain) - 1; i >= 0; i-- {
+		if i == 0 || !server.isTrustedProxy(chain[i]) {
+			return chain[i]
+		}
+	}
+	return ""
+}
+
+func (server *Server) isTrustedProxy(addr string) bool {
+	return len(server.config.TrustedProxies) > 0 && util.IPAllowed(server.config.TrustedProxies, addr)
+}
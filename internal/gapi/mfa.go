@@ -0,0 +1,95 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// mfaChallenge starts the second step of a login when the user has enabled TOTP, or has a role without
+// util.PermissionMfaOptional and has to enroll before they can login. It returns nil when the password is enough to login.
+func (server *Server) mfaChallenge(ctx context.Context, user persistence.User) (*pb.LoginUserResponse, error) {
+	enrollment, err := server.store.GetTotpEnrollment(ctx, user.Username)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to get TOTP enrollment")
+	}
+
+	enabled := err == nil && enrollment.ConfirmedAt.Valid
+	if !enabled && util.RoleHasPermission(user.Role, util.PermissionMfaOptional) {
+		return nil, nil
+	}
+
+	rsp := &pb.LoginUserResponse{
+		User:        convertUser(user),
+		MfaRequired: true,
+	}
+
+	if !enabled {
+		// an enrollment that was started before is kept, so a QR code that was scanned already keeps working
+		if errors.Is(err, pgx.ErrNoRows) {
+			enrollment, err = server.enrollTotp(ctx, user.Username)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rsp.TotpEnrollment = server.convertTotpEnrollment(enrollment)
+	}
+
+	mfaToken, err := util.GenerateToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create MFA token")
+	}
+
+	challenge, err := server.store.CreateMfaChallenge(ctx, persistence.CreateMfaChallengeParams{
+		Username:  user.Username,
+		TokenHash: util.HashToken(mfaToken),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(server.config.MFAChallengeDuration), Valid: true},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create MFA challenge")
+	}
+
+	rsp.MfaToken = mfaToken
+	rsp.MfaTokenExpiresAt = timestamppb.New(challenge.ExpiresAt.Time)
+
+	return rsp, nil
+}
+
+// enrollTotp starts an enrollment with a new secret, ErrTotpAlreadyEnabled is reported
+// as a failed precondition
+func (server *Server) enrollTotp(ctx context.Context, username string) (persistence.TotpEnrollment, error) {
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return persistence.TotpEnrollment{}, status.Errorf(codes.Internal, "failed to generate TOTP secret")
+	}
+
+	enrollment, err := server.store.CreateTotpEnrollment(ctx, persistence.CreateTotpEnrollmentParams{
+		Username: username,
+		Secret:   secret,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return enrollment, status.Errorf(codes.FailedPrecondition, "%s", persistence.ErrTotpAlreadyEnabled)
+		}
+		return enrollment, status.Errorf(codes.Internal, "failed to create TOTP enrollment")
+	}
+
+	return enrollment, nil
+}
+
+func (server *Server) convertTotpEnrollment(enrollment persistence.TotpEnrollment) *pb.TOTPEnrollment {
+	return &pb.TOTPEnrollment{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: util.TOTPProvisioningURI(server.config.TOTPIssuer, enrollment.Username, enrollment.Secret),
+	}
+}
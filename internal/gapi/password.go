@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+)
+
+// rehashPassword replaces a password hash made with an outdated algorithm or parameters once the password
+// has been checked. A failure is only logged, the old hash keeps working.
+func (server *Server) rehashPassword(ctx context.Context, user persistence.User, password string) {
+	if !server.passwordHasher.NeedsRehash(user.HashedPassword) {
+		return
+	}
+
+	hashedPassword, err := server.passwordHasher.HashPassword(password)
+	if err != nil {
+		server.logger.Error("failed to rehash password", "username", user.Username, "error", err)
+		return
+	}
+
+	_, err = server.store.RehashPassword(ctx, persistence.RehashPasswordParams{
+		NewHashedPassword: hashedPassword,
+		Username:          user.Username,
+		HashedPassword:    user.HashedPassword,
+	})
+	if err != nil {
+		server.logger.Error("failed to rehash password", "username", user.Username, "error", err)
+	}
+}
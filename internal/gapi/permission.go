@@ -0,0 +1,81 @@
+package gapi
+
+import (
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+// authenticatedOnly marks RPCs that every authenticated user can call, whatever their role
+const authenticatedOnly = ""
+
+// rpcPermissions is the permission a user's role needs to call each RPC, authorizeUser enforces it.
+// RPCs that authorize their callers and are not listed cannot be called by anyone.
+// Checks that depend on the resource, such as reading the accounts of other users, are made by the RPC.
+var rpcPermissions = map[string]string{
+	pb.SimpleBank_UpdateUser_FullMethodName:                  util.PermissionUserUpdate,
+	pb.SimpleBank_UpdateUserRole_FullMethodName:              util.PermissionUserRoleAssign,
+	pb.SimpleBank_UnlockUser_FullMethodName:                  util.PermissionUserUnlock,
+	pb.SimpleBank_ListRoles_FullMethodName:                   util.PermissionRoleRead,
+	pb.SimpleBank_ListSessions_FullMethodName:                authenticatedOnly,
+	pb.SimpleBank_RevokeSession_FullMethodName:               authenticatedOnly,
+	pb.SimpleBank_RevokeAllOtherSessions_FullMethodName:      authenticatedOnly,
+	pb.SimpleBank_BlockUserSessions_FullMethodName:           util.PermissionSessionBlock,
+	pb.SimpleBank_EnrollTOTP_FullMethodName:                  authenticatedOnly,
+	pb.SimpleBank_ConfirmTOTP_FullMethodName:                 authenticatedOnly,
+	pb.SimpleBank_DisableTOTP_FullMethodName:                 authenticatedOnly,
+	pb.SimpleBank_CreateApiKey_FullMethodName:                util.PermissionApiKeyManage,
+	pb.SimpleBank_ListApiKeys_FullMethodName:                 util.PermissionApiKeyManage,
+	pb.SimpleBank_RevokeApiKey_FullMethodName:                util.PermissionApiKeyManage,
+	pb.SimpleBank_CreateAccount_FullMethodName:               util.PermissionAccountCreate,
+	pb.SimpleBank_GetAccount_FullMethodName:                  util.PermissionAccountRead,
+	pb.SimpleBank_ListAccountMembers_FullMethodName:          util.PermissionAccountRead,
+	pb.SimpleBank_ExportStatement_FullMethodName:             util.PermissionAccountRead,
+	pb.SimpleBank_SearchTransfers_FullMethodName:             util.PermissionAccountRead,
+	pb.SimpleBank_SearchEntries_FullMethodName:               util.PermissionAccountRead,
+	pb.SimpleBank_GetSpendingAnalytics_FullMethodName:        util.PermissionAccountRead,
+	pb.SimpleBank_AddAccountBalance_FullMethodName:           util.PermissionAccountDeposit,
+	pb.SimpleBank_InviteAccountMember_FullMethodName:         util.PermissionAccountManage,
+	pb.SimpleBank_RemoveAccountMember_FullMethodName:         util.PermissionAccountManage,
+	pb.SimpleBank_CreatePocket_FullMethodName:                util.PermissionAccountManage,
+	pb.SimpleBank_MovePocketFunds_FullMethodName:             util.PermissionAccountManage,
+	pb.SimpleBank_LookupRecipient_FullMethodName:             util.PermissionTransferCreate,
+	pb.SimpleBank_CreateBeneficiary_FullMethodName:           util.PermissionBeneficiaryManage,
+	pb.SimpleBank_ListBeneficiaries_FullMethodName:           util.PermissionBeneficiaryManage,
+	pb.SimpleBank_UpdateBeneficiary_FullMethodName:           util.PermissionBeneficiaryManage,
+	pb.SimpleBank_DeleteBeneficiary_FullMethodName:           util.PermissionBeneficiaryManage,
+	pb.SimpleBank_CreatePaymentRequest_FullMethodName:        util.PermissionPaymentManage,
+	pb.SimpleBank_ListPaymentRequests_FullMethodName:         util.PermissionPaymentManage,
+	pb.SimpleBank_AcceptPaymentRequest_FullMethodName:        util.PermissionPaymentManage,
+	pb.SimpleBank_DeclinePaymentRequest_FullMethodName:       util.PermissionPaymentManage,
+	pb.SimpleBank_ImportPaymentBatch_FullMethodName:          util.PermissionPaymentManage,
+	pb.SimpleBank_GetPaymentBatch_FullMethodName:             util.PermissionPaymentManage,
+	pb.SimpleBank_ConfirmPaymentBatch_FullMethodName:         util.PermissionPaymentManage,
+	pb.SimpleBank_GetPaymentBatchStatusReport_FullMethodName: util.PermissionPaymentManage,
+	pb.SimpleBank_CreateCategory_FullMethodName:              util.PermissionCategoryManage,
+	pb.SimpleBank_ListCategories_FullMethodName:              util.PermissionCategoryManage,
+	pb.SimpleBank_CreateCategoryRule_FullMethodName:          util.PermissionCategoryManage,
+	pb.SimpleBank_ListCategoryRules_FullMethodName:           util.PermissionCategoryManage,
+	pb.SimpleBank_SetTransferCategory_FullMethodName:         util.PermissionCategoryManage,
+	pb.SimpleBank_CreateWebhook_FullMethodName:               util.PermissionWebhookManage,
+	pb.SimpleBank_ListWebhooks_FullMethodName:                util.PermissionWebhookManage,
+	pb.SimpleBank_DeleteWebhook_FullMethodName:               util.PermissionWebhookManage,
+	pb.SimpleBank_ListWebhookDeliveries_FullMethodName:       util.PermissionWebhookManage,
+	pb.SimpleBank_ReplayWebhookDelivery_FullMethodName:       util.PermissionWebhookManage,
+	pb.SimpleBank_OpenDispute_FullMethodName:                 util.PermissionDisputeOpen,
+	pb.SimpleBank_GetDispute_FullMethodName:                  util.PermissionDisputeRead,
+	pb.SimpleBank_ListDisputes_FullMethodName:                util.PermissionDisputeRead,
+	pb.SimpleBank_GetDisputeAttachment_FullMethodName:        util.PermissionDisputeRead,
+	pb.SimpleBank_UpdateDisputeStatus_FullMethodName:         util.PermissionDisputeResolve,
+	pb.SimpleBank_ListCurrencies_FullMethodName:              authenticatedOnly,
+	pb.SimpleBank_CreateCurrency_FullMethodName:              util.PermissionCurrencyManage,
+	pb.SimpleBank_EnableCurrency_FullMethodName:              util.PermissionCurrencyManage,
+	pb.SimpleBank_DisableCurrency_FullMethodName:             util.PermissionCurrencyManage,
+	pb.SimpleBank_ListTransferRiskAssessments_FullMethodName: util.PermissionAuditRead,
+	pb.SimpleBank_ApproveHeldTransfer_FullMethodName:         util.PermissionRiskReview,
+	pb.SimpleBank_RejectHeldTransfer_FullMethodName:          util.PermissionRiskReview,
+	pb.SimpleBank_ListApprovalRequests_FullMethodName:        util.PermissionAuditRead,
+	pb.SimpleBank_GetApprovalRequest_FullMethodName:          util.PermissionAuditRead,
+	pb.SimpleBank_ApproveApprovalRequest_FullMethodName:      util.PermissionApprovalReview,
+	pb.SimpleBank_RejectApprovalRequest_FullMethodName:       util.PermissionApprovalReview,
+	pb.SimpleBank_UpdateAccountLimits_FullMethodName:         util.PermissionAccountLimitsUpdate,
+}
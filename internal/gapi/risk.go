@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/risk"
+	"github.com/RobinHood3082/simplebank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// assessTransfer runs the risk engine on money the user is about to send. Only direct transfers can be held
+// for a banker to review, so a transfer the engine blocks is refused here, any other decision is returned
+// to be recorded with the transfer.
+func (server *Server) assessTransfer(ctx context.Context, username string, fromAccountID, toAccountID int64, amount util.Money) (persistence.TransferAssessment, error) {
+	var transferAssessment persistence.TransferAssessment
+
+	assessment, err := server.riskEngine.AssessTransfer(ctx, server.store, risk.Transfer{
+		Username:      username,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+	})
+	if err != nil {
+		return transferAssessment, status.Errorf(codes.Internal, "failed to assess transfer")
+	}
+
+	if assessment.Decision == risk.Block {
+		server.logger.Info("Transfer blocked", "Assessment", assessment, "Username", username)
+		return transferAssessment, status.Errorf(codes.FailedPrecondition, "transfer was blocked by risk checks, please contact the bank")
+	}
+
+	findings, err := json.Marshal(assessment.Findings)
+	if err != nil {
+		return transferAssessment, status.Errorf(codes.Internal, "failed to record transfer assessment")
+	}
+
+	transferAssessment = persistence.TransferAssessment{
+		RequestedBy: username,
+		ClientIp:    server.extractMetadata(ctx).ClientIp,
+		Decision:    string(assessment.Decision),
+		Findings:    findings,
+	}
+
+	return transferAssessment, nil
+}
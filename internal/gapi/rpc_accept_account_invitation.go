@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AcceptAccountInvitation(ctx context.Context, req *pb.AcceptAccountInvitationRequest) (*pb.AcceptAccountInvitationResponse, error) {
+	violations := validateAcceptAccountInvitationRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	txResult, err := server.store.AcceptAccountInvitationTx(
+		ctx,
+		persistence.AcceptAccountInvitationTxParams{
+			InvitationID: req.GetInvitationId(),
+			SecretCode:   req.GetSecretCode(),
+		},
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "invitation not found, already accepted or expired")
+		}
+
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return nil, status.Errorf(codes.AlreadyExists, "user is already a member of the account")
+			}
+		}
+		return nil, server.internalError("failed to accept invitation", err)
+	}
+
+	account, err := server.store.GetAccount(ctx, txResult.Member.AccountID)
+	if err != nil {
+		return nil, server.internalError("failed to get account", err)
+	}
+
+	rsp := &pb.AcceptAccountInvitationResponse{
+		Member: convertAccountMember(txResult.Member, account),
+	}
+
+	return rsp, nil
+}
+
+func validateAcceptAccountInvitationRequest(req *pb.AcceptAccountInvitationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateInvitationId(req.GetInvitationId()); err != nil {
+		violations = append(violations, fieldViolation("invitation_id", err))
+	}
+
+	if err := validator.ValidateSecretCode(req.GetSecretCode()); err != nil {
+		violations = append(violations, fieldViolation("secret_code", err))
+	}
+
+	return violations
+}
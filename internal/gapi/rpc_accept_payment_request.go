@@ -0,0 +1,110 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
+	"github.com/RobinHood3082/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AcceptPaymentRequest(ctx context.Context, req *pb.AcceptPaymentRequestRequest) (*pb.AcceptPaymentRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAcceptPaymentRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	paymentRequest, err := server.authorizePaymentRequestPayer(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccount, err := server.authorizeAccountRef(ctx, req.GetFromAccountId(), req.GetFromAccountNumber(), authPayload.Username, util.AccountCanTransferRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Currency != paymentRequest.Currency {
+		return nil, status.Errorf(codes.InvalidArgument, "account currency mismatch: expected %s, got %s", paymentRequest.Currency, fromAccount.Currency)
+	}
+
+	amount := util.Money{Amount: paymentRequest.Amount, Currency: paymentRequest.Currency}
+	assessment, err := server.assessTransfer(ctx, authPayload.Username, fromAccount.ID, paymentRequest.AccountID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := persistence.AcceptPaymentRequestTxParams{
+		PaymentRequestID: paymentRequest.ID,
+		FromAccountID:    fromAccount.ID,
+		Assessment:       assessment,
+		BeneficiaryLimit: server.beneficiaryLimit(),
+		AfterAccept: func(paymentRequest persistence.PaymentRequest) error {
+			taskPayload := &worker.PayloadSendPaymentRequestEmail{
+				PaymentRequestID: paymentRequest.ID,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskSendPaymentRequestEmail, taskPayload, opts...)
+		},
+	}
+
+	txResult, err := server.store.AcceptPaymentRequestTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, persistence.ErrPaymentRequestNotPending) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, persistence.ErrInsufficientFunds) || errors.Is(err, persistence.ErrBeneficiaryLimitExceeded) {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot accept payment request: %s", err)
+		}
+		return nil, server.internalError("failed to accept payment request", err)
+	}
+
+	transfer := txResult.Transfer.Transfer
+	data := webhook.TransferData{
+		ID:                transfer.ID,
+		FromAccountNumber: txResult.Transfer.FromAccount.AccountNumber,
+		ToAccountNumber:   txResult.Transfer.ToAccount.AccountNumber,
+		Amount:            amount,
+		Reference:         transfer.Reference,
+		CreatedAt:         transfer.CreatedAt.Time,
+	}
+
+	_ = server.taskDistributor.DistributeWebhookEvent(ctx, webhook.EventTransferCreated, data, transfer.FromAccountID, transfer.ToAccountID)
+
+	return &pb.AcceptPaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(txResult.PaymentRequest),
+	}, nil
+}
+
+func validateAcceptPaymentRequestRequest(req *pb.AcceptPaymentRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentRequestId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if violation := validateAccountRef("from_account", req.GetFromAccountId(), req.GetFromAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
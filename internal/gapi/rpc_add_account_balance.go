@@ -2,74 +2,94 @@ package gapi
 
 import (
 	"context"
-	"strconv"
-	"time"
 
 	"github.com/RobinHood3082/simplebank/internal/pb"
 	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
 	"github.com/RobinHood3082/simplebank/util"
-	"github.com/RobinHood3082/simplebank/worker"
-	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// AddAccountBalance deposits money into an account. Deposits over the approval threshold are held
+// until a banker other than the one making them approves them.
 func (server *Server) AddAccountBalance(ctx context.Context, req *pb.AddAccountBalanceRequest) (*pb.AddAccountBalanceResponse, error) {
-	authPayload, err := server.authorizeUser(
-		ctx,
-		[]string{util.BankerRole, util.DepositorRole},
-	)
-
+	authPayload, err := server.authorizeUser(ctx)
 	if err != nil {
 		return nil, unauthenticatedError(err)
 	}
 
-	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	amount, violations := validateAddAccountBalanceRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var account persistence.Account
+	if util.RoleHasPermission(authPayload.Role, util.PermissionAccountDepositAny) {
+		account, err = server.getAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber())
+	} else {
+		account, err = server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountCanTransferRole)
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "account not found")
+		return nil, err
+	}
+
+	if amount.Currency != account.Currency {
+		return nil, status.Errorf(codes.InvalidArgument, "account currency mismatch: expected %s, got %s", account.Currency, amount.Currency)
 	}
 
-	if authPayload.Role != util.BankerRole && authPayload.Username != account.Owner {
-		return nil, status.Errorf(codes.PermissionDenied, "cannot deposit to another user's account")
+	if _, err := account.BalanceMoney().Add(amount); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot add balance: %s", err)
 	}
 
-	if req.GetAmount() <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "amount must be positive")
+	held, err := server.exceedsApprovalThreshold(server.config.ApprovalDepositThreshold, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if held {
+		request, err := server.requestApproval(ctx, util.ApprovalAddAccountBalance, persistence.AccountDeposit{
+			AccountID: account.ID,
+			Amount:    amount,
+		}, authPayload.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.AddAccountBalanceResponse{
+			Account:         convertAccount(account),
+			ApprovalRequest: convertApprovalRequest(request),
+		}, nil
 	}
 
 	res, err := server.store.AddAccountBalance(ctx, persistence.AddAccountBalanceParams{
-		ID:     req.GetAccountId(),
-		Amount: req.GetAmount(),
+		ID:     account.ID,
+		Amount: amount.Amount,
 	})
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to add account balance")
 	}
 
-	accountIDStr := strconv.FormatInt(account.ID, 10)
-	if len(accountIDStr) > 4 {
-		accountIDStr = accountIDStr[len(accountIDStr)-4:]
-	}
+	server.notifyBalanceAdded(ctx, res, amount)
 
-	updatedAccount, _ := server.store.GetAccount(ctx, req.GetAccountId())
+	return &pb.AddAccountBalanceResponse{
+		Account: convertAccount(res),
+	}, nil
+}
 
-	taskPayload := worker.PayloadSendBalanceAddedEmail{
-		Username:     updatedAccount.Owner,
-		AccountID:    "xxxx" + accountIDStr,
-		AddedBalance: req.GetAmount(),
-		Currency:     updatedAccount.Currency,
-		NewBalance:   updatedAccount.Balance,
+func validateAddAccountBalanceRequest(req *pb.AddAccountBalanceRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
 	}
 
-	opts := []asynq.Option{
-		asynq.MaxRetry(10),
-		asynq.ProcessIn(10 * time.Second),
-		asynq.Queue(worker.QueueCritical),
+	amount, err := parseMoney(req.GetAmount())
+	if err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	} else if err := validator.ValidateAmount(amount); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
 	}
 
-	_ = server.taskDistributor.DistributeTask(ctx, worker.TaskSendBalanceAddedEmail, taskPayload, opts...)
-
-	return &pb.AddAccountBalanceResponse{
-		Account: convertAccount(res),
-	}, nil
+	return amount, violations
 }
@@ -0,0 +1,94 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApproveApprovalRequest executes an operation held for approval. The user who requested it cannot approve it,
+// and role changes can only be approved by users who could have requested them.
+func (server *Server) ApproveApprovalRequest(ctx context.Context, req *pb.ApproveApprovalRequestRequest) (*pb.ApproveApprovalRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateApproveApprovalRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	err = server.authorizeApprovalReviewer(ctx, req.GetId(), authPayload.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	txResult, err := server.store.ApproveApprovalRequestTx(ctx, persistence.ApproveApprovalRequestTxParams{
+		RequestID:  req.GetId(),
+		ReviewedBy: authPayload.Username,
+		ReviewNote: req.GetNote(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "approval request not found")
+		}
+		if errors.Is(err, persistence.ErrSelfApproval) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s", err)
+		}
+		if errors.Is(err, persistence.ErrApprovalRequestNotPending) ||
+			errors.Is(err, persistence.ErrApprovalRequestExpired) ||
+			errors.Is(err, persistence.ErrInvalidDisputeTransition) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, server.internalError("failed to approve approval request", err)
+	}
+
+	rsp := &pb.ApproveApprovalRequestResponse{
+		Request: convertApprovalRequest(txResult.Request),
+	}
+
+	switch txResult.Request.Operation {
+	case util.ApprovalAddAccountBalance:
+		var deposit persistence.AccountDeposit
+		_ = json.Unmarshal(txResult.Request.Payload, &deposit)
+
+		server.notifyBalanceAdded(ctx, txResult.Account, deposit.Amount)
+		rsp.Account = convertAccount(txResult.Account)
+	case util.ApprovalUpdateDisputeStatus:
+		_ = server.distributeDisputeEmail(ctx, txResult.DisputeEvent)
+
+		accountNumber, err := server.getAccountNumber(ctx, txResult.Dispute.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		rsp.Dispute = convertDispute(txResult.Dispute, accountNumber)
+	case util.ApprovalUpdateUserRole:
+		rsp.User = convertUser(txResult.User)
+	case util.ApprovalUpdateAccountLimits:
+		rsp.AccountLimits = convertAccountLimits(txResult.Account, txResult.AccountLimit)
+	}
+
+	return rsp, nil
+}
+
+func validateApproveApprovalRequestRequest(req *pb.ApproveApprovalRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateApprovalRequestId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if err := validator.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
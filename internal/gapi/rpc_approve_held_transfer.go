@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApproveHeldTransfer makes a transfer that was blocked by the risk checks
+func (server *Server) ApproveHeldTransfer(ctx context.Context, req *pb.ApproveHeldTransferRequest) (*pb.ApproveHeldTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateApproveHeldTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	txResult, err := server.store.ApproveHeldTransferTx(ctx, persistence.ApproveHeldTransferTxParams{
+		AssessmentID:     req.GetId(),
+		ReviewedBy:       authPayload.Username,
+		BeneficiaryLimit: server.beneficiaryLimit(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "transfer risk assessment not found")
+		}
+		if errors.Is(err, persistence.ErrTransferSelfReview) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s", err)
+		}
+		if errors.Is(err, persistence.ErrTransferNotHeld) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, persistence.ErrInsufficientFunds) || errors.Is(err, persistence.ErrBeneficiaryLimitExceeded) {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot approve held transfer: %s", err)
+		}
+		return nil, server.internalError("failed to approve held transfer", err)
+	}
+
+	transfer := txResult.Transfer.Transfer
+	data := webhook.TransferData{
+		ID:                transfer.ID,
+		FromAccountNumber: txResult.Transfer.FromAccount.AccountNumber,
+		ToAccountNumber:   txResult.Transfer.ToAccount.AccountNumber,
+		Amount:            util.Money{Amount: transfer.Amount, Currency: txResult.Assessment.Currency},
+		Reference:         transfer.Reference,
+		CreatedAt:         transfer.CreatedAt.Time,
+	}
+
+	_ = server.taskDistributor.DistributeWebhookEvent(ctx, webhook.EventTransferCreated, data, transfer.FromAccountID, transfer.ToAccountID)
+
+	return &pb.ApproveHeldTransferResponse{
+		Assessment: convertTransferRiskAssessment(txResult.Assessment, txResult.Transfer.FromAccount.AccountNumber, txResult.Transfer.ToAccount.AccountNumber),
+		Transfer:   convertTransfer(transfer, txResult.Transfer.FromAccount, txResult.Transfer.ToAccount),
+	}, nil
+}
+
+func validateApproveHeldTransferRequest(req *pb.ApproveHeldTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateTransferRiskAssessmentId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
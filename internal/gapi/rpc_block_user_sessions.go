@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockUserSessions blocks every session of a user, so none of their refresh tokens
+// can renew access tokens anymore
+func (server *Server) BlockUserSessions(ctx context.Context, req *pb.BlockUserSessionsRequest) (*pb.BlockUserSessionsResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateBlockUserSessionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, req.GetUsername())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+
+	blocked, err := server.store.BlockUserSessions(ctx, user.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to block sessions")
+	}
+
+	return &pb.BlockUserSessionsResponse{
+		BlockedSessions: blocked,
+	}, nil
+}
+
+func validateBlockUserSessionsRequest(req *pb.BlockUserSessionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfirmPaymentBatch confirms a pending batch and queues the execution of its valid payments.
+// The user must still be allowed to transfer from every account the valid payments are made from.
+func (server *Server) ConfirmPaymentBatch(ctx context.Context, req *pb.ConfirmPaymentBatchRequest) (*pb.ConfirmPaymentBatchResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmPaymentBatchRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	paymentBatch, err := server.authorizePaymentBatchOwner(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := server.store.ListPaymentBatchItems(ctx, paymentBatch.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payment batch items")
+	}
+
+	valid := 0
+	authorized := make(map[int64]bool)
+	for _, item := range items {
+		if item.Status != util.PaymentBatchItemValid {
+			continue
+		}
+		valid++
+
+		fromAccountID := item.FromAccountID.Int64
+		if authorized[fromAccountID] {
+			continue
+		}
+
+		_, err = server.authorizeAccountMember(ctx, fromAccountID, authPayload.Username, util.AccountCanTransferRole)
+		if err != nil {
+			return nil, err
+		}
+		authorized[fromAccountID] = true
+	}
+
+	if valid == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment batch has no valid payments")
+	}
+
+	arg := persistence.ConfirmPaymentBatchTxParams{
+		PaymentBatchID: paymentBatch.ID,
+		AfterConfirm: func(paymentBatch persistence.PaymentBatch) error {
+			taskPayload := &worker.PayloadExecutePaymentBatch{
+				PaymentBatchID: paymentBatch.ID,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.Queue(worker.QueueCritical),
+				asynq.TaskID(fmt.Sprintf("payment_batch:%d", paymentBatch.ID)),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskExecutePaymentBatch, taskPayload, opts...)
+		},
+	}
+
+	txResult, err := server.store.ConfirmPaymentBatchTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, persistence.ErrPaymentBatchNotPending) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, server.internalError("failed to confirm payment batch", err)
+	}
+
+	return &pb.ConfirmPaymentBatchResponse{
+		PaymentBatch: convertPaymentBatch(txResult.PaymentBatch),
+	}, nil
+}
+
+func validateConfirmPaymentBatchRequest(req *pb.ConfirmPaymentBatchRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentBatchId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
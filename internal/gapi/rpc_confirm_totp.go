@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfirmTOTP enables two-factor authentication with a code from the authenticator app,
+// and returns the recovery codes of the user
+func (server *Server) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	txResult, err := server.store.ConfirmTotpEnrollmentTx(ctx, persistence.ConfirmTotpEnrollmentTxParams{
+		Username: authPayload.Username,
+		Code:     req.GetCode(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, status.Errorf(codes.FailedPrecondition, "TOTP enrollment has not been started")
+		case errors.Is(err, persistence.ErrTotpAlreadyEnabled):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		case errors.Is(err, persistence.ErrInvalidMfaCode):
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to confirm TOTP enrollment")
+	}
+
+	return &pb.ConfirmTOTPResponse{
+		RecoveryCodes: txResult.RecoveryCodes,
+	}, nil
+}
+
+func validateConfirmTOTPRequest(req *pb.ConfirmTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateTotpCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
@@ -2,7 +2,7 @@ package gapi
 
 import (
 	"context"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/hibiken/asynq"
@@ -14,6 +14,7 @@ import (
 	persistence "github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/pkg/validator"
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
 	"github.com/RobinHood3082/simplebank/worker"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -21,11 +22,7 @@ import (
 )
 
 func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
-	authPayload, err := server.authorizeUser(
-		ctx,
-		[]string{util.BankerRole, util.DepositorRole},
-	)
-
+	authPayload, err := server.authorizeUser(ctx)
 	if err != nil {
 		return nil, unauthenticatedError(err)
 	}
@@ -35,7 +32,7 @@ func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 		return nil, invalidArgumentError(violations)
 	}
 
-	if authPayload.Role != util.BankerRole && authPayload.Username != req.GetUsername() {
+	if !util.RoleHasPermission(authPayload.Role, util.PermissionAccountCreateAny) && authPayload.Username != req.GetUsername() {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot create account for another user")
 	}
 
@@ -45,17 +42,12 @@ func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 			Balance:  0,
 			Currency: req.GetCurrency(),
 		},
+		BankCode: server.config.BankCode,
 		AfterCreate: func(account persistence.Account) error {
-			accountIDStr := strconv.FormatInt(account.ID, 10)
-			if len(accountIDStr) > 4 {
-				accountIDStr = accountIDStr[len(accountIDStr)-4:]
-			}
-
 			taskPayload := worker.PayloadSendAccountCreatedEmail{
 				Username:  account.Owner,
-				Balance:   account.Balance,
-				Currency:  account.Currency,
-				AccountID: "xxxx" + accountIDStr,
+				Balance:   account.BalanceMoney(),
+				AccountID: util.MaskAccountNumber(account.AccountNumber),
 			}
 
 			opts := []asynq.Option{
@@ -64,7 +56,19 @@ func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 				asynq.Queue(worker.QueueCritical),
 			}
 
-			return server.taskDistributor.DistributeTask(ctx, worker.TaskSendAccountCreatedEmail, taskPayload, opts...)
+			err := server.taskDistributor.DistributeTask(ctx, worker.TaskSendAccountCreatedEmail, taskPayload, opts...)
+			if err != nil {
+				return err
+			}
+
+			data := webhook.AccountData{
+				AccountNumber: account.AccountNumber,
+				Owner:         account.Owner,
+				Balance:       account.BalanceMoney(),
+				CreatedAt:     account.CreatedAt.Time,
+			}
+
+			return server.taskDistributor.DistributeWebhookEvent(ctx, webhook.EventAccountCreated, data, account.ID)
 		},
 	}
 
@@ -74,13 +78,17 @@ func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 			return nil, status.Errorf(codes.NotFound, "user does not exist")
 		}
 
+		if errors.Is(err, persistence.ErrCurrencyDisabled) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
 			case pgerrcode.UniqueViolation, pgerrcode.ForeignKeyViolation:
 				return nil, status.Errorf(codes.AlreadyExists, "account creation forbidden")
 			}
 		}
-		return nil, status.Errorf(codes.Internal, "failed to create account: %s", err)
+		return nil, server.internalError("failed to create account", err)
 	}
 
 	rsp := &pb.CreateAccountResponse{
@@ -91,7 +99,7 @@ func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 }
 
 func validateCreateAccountRequest(req *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+	if err := validator.ValidateAccountCurrency(req.GetCurrency()); err != nil {
 		violations = append(violations, fieldViolation("currency", err))
 	}
 
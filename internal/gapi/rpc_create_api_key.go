@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"slices"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateApiKey creates an API key acting as the user within its scopes. The key is only returned here,
+// only its hash is stored.
+func (server *Server) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateApiKeyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	key, prefix, err := util.GenerateAPIKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate API key")
+	}
+
+	scopes := slices.Clone(req.GetScopes())
+	slices.Sort(scopes)
+
+	allowedIps := req.GetAllowedIps()
+	if allowedIps == nil {
+		allowedIps = []string{}
+	}
+
+	apiKey, err := server.store.CreateApiKey(ctx, persistence.CreateApiKeyParams{
+		Owner:      authPayload.Username,
+		Name:       req.GetName(),
+		Prefix:     prefix,
+		SecretHash: util.HashToken(key),
+		Scopes:     slices.Compact(scopes),
+		AllowedIps: allowedIps,
+	})
+	if err != nil {
+		return nil, server.internalError("failed to create API key", err)
+	}
+
+	return &pb.CreateApiKeyResponse{
+		ApiKey: convertApiKey(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func validateCreateApiKeyRequest(req *pb.CreateApiKeyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateApiKeyName(req.GetName()); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if err := validator.ValidateApiKeyScopes(req.GetScopes()); err != nil {
+		violations = append(violations, fieldViolation("scopes", err))
+	}
+
+	if err := validator.ValidateIPAllowlist(req.GetAllowedIps()); err != nil {
+		violations = append(violations, fieldViolation("allowed_ips", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,104 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateBeneficiary(ctx context.Context, req *pb.CreateBeneficiaryRequest) (*pb.CreateBeneficiaryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateBeneficiaryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Currency != req.GetCurrency() {
+		return nil, status.Errorf(codes.InvalidArgument, "account currency mismatch: expected %s, got %s", account.Currency, req.GetCurrency())
+	}
+
+	limit, err := util.MoneyFromUnits(account.Currency, server.config.NewBeneficiaryTransferLimit, 0)
+	if err != nil {
+		return nil, server.internalError("invalid new beneficiary transfer limit", err)
+	}
+
+	arg := persistence.CreateBeneficiaryTxParams{
+		CreateBeneficiaryParams: persistence.CreateBeneficiaryParams{
+			Owner:     authPayload.Username,
+			Nickname:  req.GetNickname(),
+			AccountID: account.ID,
+			Currency:  account.Currency,
+		},
+		AfterCreate: func(beneficiary persistence.Beneficiary) error {
+			taskPayload := &worker.PayloadSendBeneficiaryAddedEmail{
+				Username:         beneficiary.Owner,
+				Nickname:         beneficiary.Nickname,
+				AccountID:        util.MaskAccountNumber(account.AccountNumber),
+				CoolingOffEndsAt: beneficiary.CoolingOffEndsAt(server.config.BeneficiaryCoolingOffPeriod),
+				CoolingOffLimit:  limit,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskSendBeneficiaryAddedEmail, taskPayload, opts...)
+		},
+	}
+
+	txResult, err := server.store.CreateBeneficiaryTx(ctx, arg)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return nil, status.Errorf(codes.AlreadyExists, "beneficiary with this nickname or account already exists")
+			case pgerrcode.ForeignKeyViolation:
+				return nil, status.Errorf(codes.NotFound, "user does not exist")
+			}
+		}
+		return nil, server.internalError("failed to create beneficiary", err)
+	}
+
+	return &pb.CreateBeneficiaryResponse{
+		Beneficiary: convertBeneficiary(txResult.Beneficiary, account.AccountNumber, server.config.BeneficiaryCoolingOffPeriod),
+	}, nil
+}
+
+func validateCreateBeneficiaryRequest(req *pb.CreateBeneficiaryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
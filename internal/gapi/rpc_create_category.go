@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateCategoryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	category, err := server.store.CreateCategory(ctx, persistence.CreateCategoryParams{
+		Owner: authPayload.Username,
+		Name:  req.GetName(),
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "category %q already exists", req.GetName())
+		}
+		return nil, server.internalError("failed to create category", err)
+	}
+
+	return &pb.CreateCategoryResponse{
+		Category: convertCategory(category),
+	}, nil
+}
+
+func validateCreateCategoryRequest(req *pb.CreateCategoryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateCategoryName(req.GetName()); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// CreateCategoryRule adds a rule that categorizes transfers by counterparty, by a keyword in their reference, or both.
+// Rules are applied by the spending summary job, to past transfers as well as new ones,
+// and never replace a category the user chose.
+func (server *Server) CreateCategoryRule(ctx context.Context, req *pb.CreateCategoryRuleRequest) (*pb.CreateCategoryRuleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateCategoryRuleRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	category, err := server.authorizeCategoryOwner(ctx, req.GetCategoryId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := persistence.CreateCategoryRuleParams{
+		Owner:      authPayload.Username,
+		CategoryID: category.ID,
+	}
+
+	var counterpartyAccountNumber string
+	if req.GetCounterpartyAccountId() != 0 || req.GetCounterpartyAccountNumber() != "" {
+		counterparty, err := server.getAccountRef(ctx, req.GetCounterpartyAccountId(), req.GetCounterpartyAccountNumber())
+		if err != nil {
+			return nil, err
+		}
+		arg.CounterpartyAccountID = pgtype.Int8{Int64: counterparty.ID, Valid: true}
+		counterpartyAccountNumber = counterparty.AccountNumber
+	}
+
+	if keyword := strings.TrimSpace(req.GetKeyword()); keyword != "" {
+		arg.Keyword = pgtype.Text{String: keyword, Valid: true}
+	}
+
+	rule, err := server.store.CreateCategoryRule(ctx, arg)
+	if err != nil {
+		return nil, server.internalError("failed to create category rule", err)
+	}
+
+	_ = server.taskDistributor.DistributeSpendingSummaryRefresh(ctx)
+
+	return &pb.CreateCategoryRuleResponse{
+		Rule: convertCategoryRule(rule, counterpartyAccountNumber),
+	}, nil
+}
+
+func validateCreateCategoryRuleRequest(req *pb.CreateCategoryRuleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateCategoryId(req.GetCategoryId()); err != nil {
+		violations = append(violations, fieldViolation("category_id", err))
+	}
+
+	hasCounterparty := req.GetCounterpartyAccountId() != 0 || req.GetCounterpartyAccountNumber() != ""
+	if hasCounterparty {
+		if violation := validateAccountRef("counterparty_account", req.GetCounterpartyAccountId(), req.GetCounterpartyAccountNumber()); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+
+	if req.GetKeyword() != "" {
+		if err := validator.ValidateCategoryKeyword(req.GetKeyword()); err != nil {
+			violations = append(violations, fieldViolation("keyword", err))
+		}
+	} else if !hasCounterparty {
+		violations = append(violations, fieldViolation("keyword", fmt.Errorf("is required when no counterparty is given")))
+	}
+
+	return violations
+}
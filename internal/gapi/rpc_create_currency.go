@@ -0,0 +1,65 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateCurrency adds a currency to the catalogue. Its exponent cannot be changed later
+// because account balances are stored in minor units.
+func (server *Server) CreateCurrency(ctx context.Context, req *pb.CreateCurrencyRequest) (*pb.CreateCurrencyResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateCurrencyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	currency, err := server.store.CreateCurrency(ctx, persistence.CreateCurrencyParams{
+		Code:     req.GetCode(),
+		Name:     req.GetName(),
+		Exponent: req.GetExponent(),
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "currency %s already exists", req.GetCode())
+		}
+		return nil, server.internalError("failed to create currency", err)
+	}
+
+	util.PutCurrency(currency.Catalogue())
+
+	rsp := &pb.CreateCurrencyResponse{
+		Currency: convertCurrency(currency),
+	}
+
+	return rsp, nil
+}
+
+func validateCreateCurrencyRequest(req *pb.CreateCurrencyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateCurrencyCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	if err := validator.ValidateCurrencyName(req.GetName()); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if err := validator.ValidateCurrencyExponent(req.GetExponent()); err != nil {
+		violations = append(violations, fieldViolation("exponent", err))
+	}
+
+	return violations
+}
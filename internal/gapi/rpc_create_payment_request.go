@@ -0,0 +1,117 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreatePaymentRequest(ctx context.Context, req *pb.CreatePaymentRequestRequest) (*pb.CreatePaymentRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	amount, violations := validateCreatePaymentRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if req.GetPayer() == authPayload.Username {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot request money from yourself")
+	}
+
+	account, err := server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountCanTransferRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Currency != account.Currency {
+		return nil, status.Errorf(codes.InvalidArgument, "account currency mismatch: expected %s, got %s", account.Currency, amount.Currency)
+	}
+
+	arg := persistence.CreatePaymentRequestTxParams{
+		CreatePaymentRequestParams: persistence.CreatePaymentRequestParams{
+			Requester: authPayload.Username,
+			Payer:     req.GetPayer(),
+			AccountID: account.ID,
+			Amount:    amount.Amount,
+			Currency:  amount.Currency,
+			Note:      req.GetNote(),
+		},
+		AfterCreate: func(paymentRequest persistence.PaymentRequest) error {
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			err := server.taskDistributor.DistributeTask(ctx, worker.TaskSendPaymentRequestEmail, &worker.PayloadSendPaymentRequestEmail{
+				PaymentRequestID: paymentRequest.ID,
+			}, opts...)
+			if err != nil {
+				return err
+			}
+
+			opts = []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessAt(paymentRequest.ExpiresAt.Time),
+				asynq.Queue(worker.QueueDefault),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskExpirePaymentRequest, &worker.PayloadExpirePaymentRequest{
+				PaymentRequestID: paymentRequest.ID,
+			}, opts...)
+		},
+	}
+
+	txResult, err := server.store.CreatePaymentRequestTx(ctx, arg)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.ForeignKeyViolation:
+				return nil, status.Errorf(codes.NotFound, "payer does not exist")
+			}
+		}
+		return nil, server.internalError("failed to create payment request", err)
+	}
+
+	return &pb.CreatePaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(txResult.PaymentRequest),
+	}, nil
+}
+
+func validateCreatePaymentRequestRequest(req *pb.CreatePaymentRequestRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUsername(req.GetPayer()); err != nil {
+		violations = append(violations, fieldViolation("payer", err))
+	}
+
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	amount, err := parseMoney(req.GetAmount())
+	if err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	} else if err := validator.ValidateAmount(amount); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := validator.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return amount, violations
+}
@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreatePocket(ctx context.Context, req *pb.CreatePocketRequest) (*pb.CreatePocketResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreatePocketRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountCanTransferRole)
+	if err != nil {
+		return nil, err
+	}
+
+	pocket, err := server.store.CreatePocket(ctx, persistence.CreatePocketParams{
+		AccountID: account.ID,
+		Name:      req.GetName(),
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return nil, status.Errorf(codes.AlreadyExists, "pocket %q already exists", req.GetName())
+			}
+		}
+		return nil, server.internalError("failed to create pocket", err)
+	}
+
+	return &pb.CreatePocketResponse{
+		Pocket: convertPocket(pocket, account),
+	}, nil
+}
+
+func validateCreatePocketRequest(req *pb.CreatePocketRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if err := validator.ValidatePocketName(req.GetName()); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	return violations
+}
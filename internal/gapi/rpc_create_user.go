@@ -11,7 +11,6 @@ import (
 	"github.com/RobinHood3082/simplebank/internal/pb"
 	persistence "github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/pkg/validator"
-	"github.com/RobinHood3082/simplebank/util"
 	"github.com/RobinHood3082/simplebank/worker"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -24,7 +23,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
-	hashedPassword, err := util.HashPassword(req.GetPassword())
+	hashedPassword, err := server.passwordHasher.HashPassword(req.GetPassword())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to hash password")
 	}
@@ -59,7 +58,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 				return nil, status.Errorf(codes.AlreadyExists, "username already exists")
 			}
 		}
-		return nil, status.Errorf(codes.Internal, "failed to create user: %s", err)
+		return nil, server.internalError("failed to create user", err)
 	}
 
 	rsp := &pb.CreateUserResponse{
@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.CreateWebhookResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateWebhookRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate webhook secret")
+	}
+
+	hook, err := server.store.CreateWebhook(ctx, persistence.CreateWebhookParams{
+		Owner:      authPayload.Username,
+		Url:        req.GetUrl(),
+		Secret:     secret,
+		EventTypes: req.GetEventTypes(),
+	})
+	if err != nil {
+		return nil, server.internalError("failed to create webhook", err)
+	}
+
+	return &pb.CreateWebhookResponse{
+		Webhook: convertWebhook(hook),
+		Secret:  hook.Secret,
+	}, nil
+}
+
+func validateCreateWebhookRequest(req *pb.CreateWebhookRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateWebhookURL(req.GetUrl()); err != nil {
+		violations = append(violations, fieldViolation("url", err))
+	}
+
+	if err := validator.ValidateWebhookEventTypes(req.GetEventTypes()); err != nil {
+		violations = append(violations, fieldViolation("event_types", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeclinePaymentRequest(ctx context.Context, req *pb.DeclinePaymentRequestRequest) (*pb.DeclinePaymentRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeclinePaymentRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	paymentRequest, err := server.authorizePaymentRequestPayer(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := persistence.DeclinePaymentRequestTxParams{
+		PaymentRequestID: paymentRequest.ID,
+		AfterDecline: func(paymentRequest persistence.PaymentRequest) error {
+			taskPayload := &worker.PayloadSendPaymentRequestEmail{
+				PaymentRequestID: paymentRequest.ID,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskSendPaymentRequestEmail, taskPayload, opts...)
+		},
+	}
+
+	txResult, err := server.store.DeclinePaymentRequestTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, persistence.ErrPaymentRequestNotPending) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, server.internalError("failed to decline payment request", err)
+	}
+
+	return &pb.DeclinePaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(txResult.PaymentRequest),
+	}, nil
+}
+
+func validateDeclinePaymentRequestRequest(req *pb.DeclinePaymentRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentRequestId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteBeneficiary(ctx context.Context, req *pb.DeleteBeneficiaryRequest) (*pb.DeleteBeneficiaryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeleteBeneficiaryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	deleted, err := server.store.DeleteBeneficiary(ctx, persistence.DeleteBeneficiaryParams{
+		ID:    req.GetId(),
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		return nil, server.internalError("failed to delete beneficiary", err)
+	}
+
+	if deleted == 0 {
+		return nil, status.Errorf(codes.NotFound, "beneficiary not found")
+	}
+
+	return &pb.DeleteBeneficiaryResponse{}, nil
+}
+
+func validateDeleteBeneficiaryRequest(req *pb.DeleteBeneficiaryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateBeneficiaryId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
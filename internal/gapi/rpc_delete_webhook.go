@@ -0,0 +1,47 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteWebhook stops all future deliveries to the webhook. Its delivery log is kept.
+func (server *Server) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeleteWebhookRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	deleted, err := server.store.DeactivateWebhook(ctx, persistence.DeactivateWebhookParams{
+		ID:    req.GetId(),
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		return nil, server.internalError("failed to delete webhook", err)
+	}
+
+	if deleted == 0 {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
+
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+func validateDeleteWebhookRequest(req *pb.DeleteWebhookRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateWebhookId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
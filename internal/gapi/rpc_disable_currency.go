@@ -0,0 +1,21 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+)
+
+// DisableCurrency stops new accounts from being opened in a currency. Accounts already held in it keep working.
+func (server *Server) DisableCurrency(ctx context.Context, req *pb.DisableCurrencyRequest) (*pb.DisableCurrencyResponse, error) {
+	currency, err := server.setCurrencyEnabled(ctx, req.GetCode(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.DisableCurrencyResponse{
+		Currency: convertCurrency(currency),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DisableTOTP turns off two-factor authentication, which only roles with util.PermissionMfaOptional are allowed to do
+func (server *Server) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if !util.RoleHasPermission(authPayload.Role, util.PermissionMfaOptional) {
+		return nil, status.Errorf(codes.PermissionDenied, "role %s must use two-factor authentication", authPayload.Role)
+	}
+
+	violations := validateDisableTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	err = server.store.DisableTotpTx(ctx, persistence.DisableTotpTxParams{
+		Username: authPayload.Username,
+		Code:     req.GetCode(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, persistence.ErrTotpNotEnabled):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		case errors.Is(err, persistence.ErrInvalidMfaCode):
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to disable TOTP")
+	}
+
+	return &pb.DisableTOTPResponse{}, nil
+}
+
+func validateDisableTOTPRequest(req *pb.DisableTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateMfaCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
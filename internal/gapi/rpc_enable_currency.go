@@ -0,0 +1,21 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+)
+
+// EnableCurrency allows new accounts in a previously disabled currency
+func (server *Server) EnableCurrency(ctx context.Context, req *pb.EnableCurrencyRequest) (*pb.EnableCurrencyResponse, error) {
+	currency, err := server.setCurrencyEnabled(ctx, req.GetCode(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.EnableCurrencyResponse{
+		Currency: convertCurrency(currency),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,25 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+)
+
+// EnrollTOTP starts enabling two-factor authentication with a new secret. Starting again replaces
+// the secret of an enrollment that was not confirmed.
+func (server *Server) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	enrollment, err := server.enrollTotp(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Enrollment: server.convertTotpEnrollment(enrollment),
+	}, nil
+}
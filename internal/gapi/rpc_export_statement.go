@@ -0,0 +1,183 @@
+package gapi
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/export"
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// statementPageSize is how many entries are read from the database at a time
+	statementPageSize = 500
+	// statementChunkSize is the most data sent in a single message of the stream
+	statementChunkSize = 32 * 1024
+)
+
+var statementFormats = map[pb.StatementFormat]export.Format{
+	pb.StatementFormat_STATEMENT_FORMAT_CSV:     export.FormatCSV,
+	pb.StatementFormat_STATEMENT_FORMAT_OFX:     export.FormatOFX,
+	pb.StatementFormat_STATEMENT_FORMAT_CAMT053: export.FormatCamt053,
+}
+
+// ExportStatement streams the entries of an account over a period as a file. Entries are read a page at a time
+// and the file is sent in chunks as it is written, so long histories are never held in memory.
+// Once the first chunk has been sent, a failure can only be reported by ending the stream with an error.
+func (server *Server) ExportStatement(req *pb.ExportStatementRequest, stream grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	ctx := stream.Context()
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return unauthenticatedError(err)
+	}
+
+	format, from, to, violations := validateExportStatementRequest(req, time.Now())
+	if violations != nil {
+		return invalidArgumentError(violations)
+	}
+
+	var account persistence.Account
+	if util.RoleHasPermission(authPayload.Role, util.PermissionAccountReadAny) {
+		account, err = server.getAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber())
+	} else {
+		account, err = server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountViewOnlyRole)
+	}
+	if err != nil {
+		return err
+	}
+
+	fromTime := pgtype.Timestamptz{Time: from, Valid: true}
+	toTime := pgtype.Timestamptz{Time: to, Valid: true}
+
+	balances, err := server.store.GetStatementBalances(ctx, persistence.GetStatementBalancesParams{
+		FromTime:  fromTime,
+		ToTime:    toTime,
+		AccountID: account.ID,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get statement balances")
+	}
+
+	statement := export.Statement{
+		ID:             fmt.Sprintf("%s-%s-%s", account.AccountNumber, from.UTC().Format("20060102"), to.UTC().Format("20060102")),
+		AccountNumber:  account.AccountNumber,
+		Currency:       account.Currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: util.Money{Amount: balances.OpeningBalance, Currency: account.Currency},
+		ClosingBalance: util.Money{Amount: balances.ClosingBalance, Currency: account.Currency},
+		CreatedAt:      time.Now(),
+	}
+
+	filename := fmt.Sprintf("statement-%s.%s", statement.ID, format.Extension())
+	err = stream.SetHeader(metadata.Pairs(contentDispositionHeader, fmt.Sprintf("attachment; filename=%q", filename)))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to set headers")
+	}
+
+	chunks := bufio.NewWriterSize(&httpBodyWriter{stream: stream, contentType: format.ContentType()}, statementChunkSize)
+
+	writer, err := export.NewWriter(format, chunks, statement)
+	if err != nil {
+		return server.internalError("failed to write statement", err)
+	}
+
+	var afterID int64
+	for {
+		entries, err := server.store.ListStatementEntries(ctx, persistence.ListStatementEntriesParams{
+			AccountID: account.ID,
+			FromTime:  fromTime,
+			ToTime:    toTime,
+			AfterID:   afterID,
+			Limit:     statementPageSize,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list statement entries")
+		}
+
+		for _, entry := range entries {
+			err = writer.WriteEntry(export.Entry{
+				ID:                        entry.ID,
+				Amount:                    util.Money{Amount: entry.Amount, Currency: account.Currency},
+				BookedAt:                  entry.CreatedAt.Time,
+				TransferID:                entry.TransferID.Int64,
+				CounterpartyAccountNumber: entry.CounterpartyAccountNumber,
+				Reference:                 entry.Reference,
+			})
+			if err != nil {
+				return status.Errorf(codes.Unavailable, "failed to send statement: %s", err)
+			}
+			afterID = entry.ID
+		}
+
+		if len(entries) < statementPageSize {
+			break
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return status.Errorf(codes.Unavailable, "failed to send statement: %s", err)
+	}
+	if err := chunks.Flush(); err != nil {
+		return status.Errorf(codes.Unavailable, "failed to send statement: %s", err)
+	}
+
+	return nil
+}
+
+// validateExportStatementRequest returns the export format and the statement period, which ends now at the latest
+func validateExportStatementRequest(req *pb.ExportStatementRequest, now time.Time) (format export.Format, from, to time.Time, violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	format, ok := statementFormats[req.GetFormat()]
+	if !ok {
+		violations = append(violations, fieldViolation("format", fmt.Errorf("must be one of CSV, OFX or CAMT053")))
+	}
+
+	to = now
+	if req.GetCreatedBefore() != nil && req.GetCreatedBefore().AsTime().Before(now) {
+		to = req.GetCreatedBefore().AsTime()
+	}
+
+	if req.GetCreatedAfter() == nil {
+		violations = append(violations, fieldViolation("created_after", fmt.Errorf("is required")))
+	} else {
+		from = req.GetCreatedAfter().AsTime()
+		if !from.Before(to) {
+			violations = append(violations, fieldViolation("created_after", fmt.Errorf("must be before created_before and in the past")))
+		}
+	}
+
+	return format, from, to, violations
+}
+
+// httpBodyWriter sends everything written to it as HttpBody messages on the stream
+type httpBodyWriter struct {
+	stream      grpc.ServerStreamingServer[httpbody.HttpBody]
+	contentType string
+}
+
+func (w *httpBodyWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&httpbody.HttpBody{
+		ContentType: w.contentType,
+		// the stream may hold on to the message after Send returns, while the caller reuses p
+		Data: append([]byte(nil), p...),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
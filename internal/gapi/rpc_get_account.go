@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var account persistence.Account
+	if util.RoleHasPermission(authPayload.Role, util.PermissionAccountReadAny) {
+		account, err = server.getAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber())
+	} else {
+		account, err = server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountViewOnlyRole)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pockets, err := server.store.ListPockets(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list pockets")
+	}
+
+	available, err := account.AvailableBalance(pockets)
+	if err != nil {
+		return nil, server.internalError("failed to compute available balance", err)
+	}
+
+	rsp := &pb.GetAccountResponse{
+		Account:          convertAccount(account),
+		TotalBalance:     convertMoney(account.BalanceMoney()),
+		AvailableBalance: convertMoney(available),
+	}
+	for _, pocket := range pockets {
+		rsp.Pockets = append(rsp.Pockets, convertPocket(pocket, account))
+	}
+
+	return rsp, nil
+}
+
+func validateGetAccountRequest(req *pb.GetAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
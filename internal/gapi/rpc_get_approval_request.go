@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetApprovalRequest returns a held operation with who requested it and who reviewed it
+func (server *Server) GetApprovalRequest(ctx context.Context, req *pb.GetApprovalRequestRequest) (*pb.GetApprovalRequestResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetApprovalRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	request, err := server.store.GetApprovalRequest(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "approval request not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get approval request")
+	}
+
+	return &pb.GetApprovalRequestResponse{
+		Request: convertApprovalRequest(request),
+	}, nil
+}
+
+func validateGetApprovalRequestRequest(req *pb.GetApprovalRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateApprovalRequestId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetDispute returns a dispute with its attachments and history to the customer who opened it or a banker
+func (server *Server) GetDispute(ctx context.Context, req *pb.GetDisputeRequest) (*pb.GetDisputeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetDisputeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	dispute, err := server.authorizeDisputeViewer(ctx, req.GetId(), authPayload.Username, authPayload.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := server.store.ListDisputeAttachments(ctx, dispute.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list dispute attachments")
+	}
+
+	events, err := server.store.ListDisputeEvents(ctx, dispute.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list dispute events")
+	}
+
+	accountNumber, err := server.getAccountNumber(ctx, dispute.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.GetDisputeResponse{
+		Dispute: convertDispute(dispute, accountNumber),
+	}
+	for _, attachment := range attachments {
+		rsp.Attachments = append(rsp.Attachments, convertDisputeAttachment(attachment))
+	}
+	for _, event := range events {
+		rsp.Events = append(rsp.Events, convertDisputeEvent(event))
+	}
+
+	return rsp, nil
+}
+
+func validateGetDisputeRequest(req *pb.GetDisputeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateDisputeId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetDisputeAttachment returns the content of a file attached to a dispute the user can see
+func (server *Server) GetDisputeAttachment(ctx context.Context, req *pb.GetDisputeAttachmentRequest) (*httpbody.HttpBody, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetDisputeAttachmentRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	dispute, err := server.authorizeDisputeViewer(ctx, req.GetDisputeId(), authPayload.Username, authPayload.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := server.store.GetDisputeAttachment(ctx, req.GetId())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "attachment not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get attachment")
+	}
+
+	if attachment.DisputeID != dispute.ID {
+		return nil, status.Errorf(codes.NotFound, "attachment not found")
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: attachment.ContentType,
+		Data:        attachment.Content,
+	}, nil
+}
+
+func validateGetDisputeAttachmentRequest(req *pb.GetDisputeAttachmentRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateDisputeId(req.GetDisputeId()); err != nil {
+		violations = append(violations, fieldViolation("dispute_id", err))
+	}
+
+	if err := validator.ValidateDisputeId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
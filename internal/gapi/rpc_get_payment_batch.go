@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetPaymentBatch(ctx context.Context, req *pb.GetPaymentBatchRequest) (*pb.GetPaymentBatchResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetPaymentBatchRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	paymentBatch, err := server.authorizePaymentBatchOwner(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := server.store.ListPaymentBatchItems(ctx, paymentBatch.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payment batch items")
+	}
+
+	rsp := &pb.GetPaymentBatchResponse{
+		PaymentBatch: convertPaymentBatch(paymentBatch),
+	}
+
+	for _, item := range items {
+		rsp.Items = append(rsp.Items, convertPaymentBatchItem(item))
+	}
+
+	return rsp, nil
+}
+
+func validateGetPaymentBatchRequest(req *pb.GetPaymentBatchRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentBatchId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
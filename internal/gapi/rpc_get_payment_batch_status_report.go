@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pain"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// paymentBatchItemStatuses maps the status of a batch item to its pain.002 transaction status
+var paymentBatchItemStatuses = map[string]string{
+	util.PaymentBatchItemValid:    pain.StatusPending,
+	util.PaymentBatchItemInvalid:  pain.StatusRejected,
+	util.PaymentBatchItemExecuted: pain.StatusSettled,
+	util.PaymentBatchItemFailed:   pain.StatusRejected,
+}
+
+// GetPaymentBatchStatusReport returns a pain.002 report on the payments of a batch, for import into the ERP
+// that created the pain.001 file. Payments that have not been executed yet are reported as pending.
+func (server *Server) GetPaymentBatchStatusReport(ctx context.Context, req *pb.GetPaymentBatchStatusReportRequest) (*httpbody.HttpBody, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetPaymentBatchStatusReportRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	paymentBatch, err := server.authorizePaymentBatchOwner(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := server.store.ListPaymentBatchItems(ctx, paymentBatch.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payment batch items")
+	}
+
+	now := time.Now()
+	report := pain.StatusReport{
+		MessageID:           fmt.Sprintf("STS-%d-%d", paymentBatch.ID, now.Unix()),
+		CreatedAt:           now,
+		OriginalMessageID:   paymentBatch.MessageID,
+		OriginalMessageName: paymentBatch.MessageName,
+	}
+
+	for _, item := range items {
+		report.Transactions = append(report.Transactions, pain.TransactionStatus{
+			OriginalPaymentInformationID: item.PaymentInformationID,
+			OriginalInstructionID:        item.InstructionID,
+			OriginalEndToEndID:           item.EndToEndID,
+			Status:                       paymentBatchItemStatuses[item.Status],
+			ReasonCode:                   item.ReasonCode,
+			Reason:                       item.Reason,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := pain.WriteStatusReport(&buf, report); err != nil {
+		return nil, server.internalError("failed to write status report", err)
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: "application/xml",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func validateGetPaymentBatchStatusReportRequest(req *pb.GetPaymentBatchStatusReportRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentBatchId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,179 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultSpendingAnalyticsMonths = 12
+	maxSpendingAnalyticsMonths     = 36
+)
+
+// GetSpendingAnalytics reports the inflow and outflow of an account, or of all the user's accounts,
+// per category and per month. It reads the spending summaries, so transfers made or categorized
+// since the last refresh are not counted yet. Amounts in different currencies are never added up.
+func (server *Server) GetSpendingAnalytics(ctx context.Context, req *pb.GetSpendingAnalyticsRequest) (*pb.GetSpendingAnalyticsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	fromMonth, toMonth, violations := validateGetSpendingAnalyticsRequest(req, time.Now())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var accountIDs []int64
+	if req.GetAccountId() != 0 || req.GetAccountNumber() != "" {
+		account, err := server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountViewOnlyRole)
+		if err != nil {
+			return nil, err
+		}
+		accountIDs = []int64{account.ID}
+	} else {
+		accounts, err := server.store.ListAllMemberAccounts(ctx, authPayload.Username)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list accounts")
+		}
+		for _, account := range accounts {
+			accountIDs = append(accountIDs, account.ID)
+		}
+	}
+
+	summaries, err := server.store.ListSpendingSummaries(ctx, persistence.ListSpendingSummariesParams{
+		Username:   authPayload.Username,
+		AccountIds: accountIDs,
+		FromMonth:  pgtype.Date{Time: fromMonth, Valid: true},
+		ToMonth:    pgtype.Date{Time: toMonth.AddDate(0, 1, 0), Valid: true},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list spending summaries")
+	}
+
+	return summarizeSpending(summaries), nil
+}
+
+// validateGetSpendingAnalyticsRequest returns the first days of the first and last months to analyse
+func validateGetSpendingAnalyticsRequest(req *pb.GetSpendingAnalyticsRequest, now time.Time) (fromMonth, toMonth time.Time, violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetAccountId() != 0 || req.GetAccountNumber() != "" {
+		if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+
+	toMonth = startOfMonth(now)
+	if req.GetToMonth() != nil {
+		toMonth = startOfMonth(req.GetToMonth().AsTime())
+	}
+
+	fromMonth = toMonth.AddDate(0, 1-defaultSpendingAnalyticsMonths, 0)
+	if req.GetFromMonth() != nil {
+		fromMonth = startOfMonth(req.GetFromMonth().AsTime())
+	}
+
+	switch {
+	case toMonth.Before(fromMonth):
+		violations = append(violations, fieldViolation("to_month", fmt.Errorf("must not be before from_month")))
+	case !fromMonth.AddDate(0, maxSpendingAnalyticsMonths, 0).After(toMonth):
+		violations = append(violations, fieldViolation("from_month", fmt.Errorf("must be within %d months of to_month", maxSpendingAnalyticsMonths)))
+	}
+
+	return fromMonth, toMonth, violations
+}
+
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// summarizeSpending turns the per month and category summaries into the response,
+// adding them up per category and per month within each currency
+func summarizeSpending(summaries []persistence.ListSpendingSummariesRow) *pb.GetSpendingAnalyticsResponse {
+	type categoryKey struct {
+		id       int64
+		currency string
+	}
+	type monthKey struct {
+		month    time.Time
+		currency string
+	}
+
+	categoryNames := map[int64]string{}
+	categoryTotals := map[categoryKey]*[2]int64{}
+	monthTotals := map[monthKey]*[2]int64{}
+	var categoryKeys []categoryKey
+	var monthKeys []monthKey
+
+	rsp := &pb.GetSpendingAnalyticsResponse{}
+	for _, summary := range summaries {
+		month := summary.Month.Time
+		rsp.Totals = append(rsp.Totals, newSpendingTotal(month, summary.CategoryID, summary.CategoryName, summary.Currency, summary.Inflow, summary.Outflow))
+
+		categoryNames[summary.CategoryID] = summary.CategoryName
+
+		ck := categoryKey{id: summary.CategoryID, currency: summary.Currency}
+		if categoryTotals[ck] == nil {
+			categoryTotals[ck] = &[2]int64{}
+			categoryKeys = append(categoryKeys, ck)
+		}
+		categoryTotals[ck][0] += summary.Inflow
+		categoryTotals[ck][1] += summary.Outflow
+
+		mk := monthKey{month: month, currency: summary.Currency}
+		if monthTotals[mk] == nil {
+			monthTotals[mk] = &[2]int64{}
+			monthKeys = append(monthKeys, mk)
+		}
+		monthTotals[mk][0] += summary.Inflow
+		monthTotals[mk][1] += summary.Outflow
+	}
+
+	sort.Slice(categoryKeys, func(i, j int) bool {
+		if categoryKeys[i].id != categoryKeys[j].id {
+			return categoryKeys[i].id < categoryKeys[j].id
+		}
+		return categoryKeys[i].currency < categoryKeys[j].currency
+	})
+	for _, key := range categoryKeys {
+		total := categoryTotals[key]
+		rsp.CategoryTotals = append(rsp.CategoryTotals, newSpendingTotal(time.Time{}, key.id, categoryNames[key.id], key.currency, total[0], total[1]))
+	}
+
+	sort.Slice(monthKeys, func(i, j int) bool {
+		if !monthKeys[i].month.Equal(monthKeys[j].month) {
+			return monthKeys[i].month.Before(monthKeys[j].month)
+		}
+		return monthKeys[i].currency < monthKeys[j].currency
+	})
+	for _, key := range monthKeys {
+		total := monthTotals[key]
+		rsp.MonthTotals = append(rsp.MonthTotals, newSpendingTotal(key.month, 0, "", key.currency, total[0], total[1]))
+	}
+
+	return rsp
+}
+
+func newSpendingTotal(month time.Time, categoryID int64, categoryName string, currency string, inflow int64, outflow int64) *pb.SpendingTotal {
+	total := &pb.SpendingTotal{
+		CategoryId:   categoryID,
+		CategoryName: categoryName,
+		Inflow:       convertMoney(util.Money{Amount: inflow, Currency: currency}),
+		Outflow:      convertMoney(util.Money{Amount: outflow, Currency: currency}),
+	}
+	if !month.IsZero() {
+		total.Month = timestamppb.New(month)
+	}
+	return total
+}
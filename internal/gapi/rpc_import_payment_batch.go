@@ -0,0 +1,269 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pain"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPaymentBatchItems is the most credit transfers a single pain.001 file may hold
+const maxPaymentBatchItems = 1000
+
+// ImportPaymentBatch parses a pain.001 file and stores it as a pending batch. Every credit transfer is checked
+// and stored, the invalid ones with the reason they cannot be executed, so the response is a preview of the batch.
+func (server *Server) ImportPaymentBatch(ctx context.Context, req *pb.ImportPaymentBatchRequest) (*pb.ImportPaymentBatchResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateImportPaymentBatchRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	initiation, err := pain.Parse(bytes.NewReader(req.GetDocument()))
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("document", err)})
+	}
+
+	if len(initiation.Transfers) > maxPaymentBatchItems {
+		err = fmt.Errorf("must contain at most %d credit transfers", maxPaymentBatchItems)
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("document", err)})
+	}
+
+	checker := server.newPaymentBatchChecker(authPayload.Username)
+
+	arg := persistence.ImportPaymentBatchTxParams{
+		CreatePaymentBatchParams: persistence.CreatePaymentBatchParams{
+			Owner:       authPayload.Username,
+			MessageID:   initiation.MessageID,
+			MessageName: initiation.MessageName,
+		},
+	}
+
+	for _, transfer := range initiation.Transfers {
+		item, err := checker.check(ctx, transfer)
+		if err != nil {
+			return nil, err
+		}
+
+		arg.Items = append(arg.Items, item)
+	}
+
+	txResult, err := server.store.ImportPaymentBatchTx(ctx, arg)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "a file with message ID %s has already been imported", initiation.MessageID)
+		}
+		return nil, server.internalError("failed to import payment batch", err)
+	}
+
+	rsp := &pb.ImportPaymentBatchResponse{
+		PaymentBatch: convertPaymentBatch(txResult.PaymentBatch),
+	}
+
+	for _, item := range txResult.Items {
+		rsp.Items = append(rsp.Items, convertPaymentBatchItem(item))
+	}
+
+	return rsp, nil
+}
+
+func validateImportPaymentBatchRequest(req *pb.ImportPaymentBatchRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentBatchDocument(req.GetDocument()); err != nil {
+		violations = append(violations, fieldViolation("document", err))
+	}
+
+	return violations
+}
+
+type accountLookup struct {
+	account persistence.Account
+	err     error
+}
+
+// paymentBatchChecker checks the credit transfers of a file in order. Each account is looked up once per file,
+// and the valid transfers from an account draw down its available balance, so together they never exceed it.
+type paymentBatchChecker struct {
+	server      *Server
+	username    string
+	accounts    map[string]accountLookup
+	debtors     map[int64]error
+	available   map[int64]int64
+	endToEndIDs map[string]bool
+}
+
+func (server *Server) newPaymentBatchChecker(username string) *paymentBatchChecker {
+	return &paymentBatchChecker{
+		server:      server,
+		username:    username,
+		accounts:    make(map[string]accountLookup),
+		debtors:     make(map[int64]error),
+		available:   make(map[int64]int64),
+		endToEndIDs: make(map[string]bool),
+	}
+}
+
+// check turns a credit transfer into a batch item, which is invalid with an ISO 20022 reason code when it cannot be
+// executed. An error is only returned when the check itself fails.
+func (checker *paymentBatchChecker) check(ctx context.Context, transfer pain.CreditTransfer) (persistence.CreatePaymentBatchItemParams, error) {
+	item := persistence.CreatePaymentBatchItemParams{
+		PaymentInformationID: transfer.PaymentInformationID,
+		InstructionID:        transfer.InstructionID,
+		EndToEndID:           transfer.EndToEndID,
+		FromAccountNumber:    transfer.DebtorAccount,
+		ToAccountNumber:      transfer.CreditorAccount,
+		Currency:             transfer.Currency,
+		Reference:            transfer.RemittanceInformation,
+		Status:               util.PaymentBatchItemValid,
+	}
+
+	reasonCode, reason, err := checker.validate(ctx, transfer, &item)
+	if err != nil {
+		return item, err
+	}
+
+	if reasonCode != "" {
+		item.Status = util.PaymentBatchItemInvalid
+		item.ReasonCode = reasonCode
+		item.Reason = reason
+	}
+
+	return item, nil
+}
+
+// validate fills in the accounts and amount of the item and returns why it cannot be executed, if it cannot
+func (checker *paymentBatchChecker) validate(ctx context.Context, transfer pain.CreditTransfer, item *persistence.CreatePaymentBatchItemParams) (reasonCode, reason string, err error) {
+	if transfer.HasEndToEndID() {
+		if checker.endToEndIDs[transfer.EndToEndID] {
+			return pain.ReasonDuplication, "end to end ID is used by an earlier payment in the file", nil
+		}
+		checker.endToEndIDs[transfer.EndToEndID] = true
+	}
+
+	amount, err := util.ParseMoney(transfer.Amount, transfer.Currency)
+	if err != nil {
+		if errors.Is(err, util.ErrUnsupportedCurrency) {
+			return pain.ReasonNotAllowedCurrency, err.Error(), nil
+		}
+		return pain.ReasonInvalidAmount, err.Error(), nil
+	}
+	if err := validator.ValidateAmount(amount); err != nil {
+		return pain.ReasonInvalidAmount, fmt.Sprintf("amount %s", err), nil
+	}
+	item.Amount = amount.Amount
+	item.Currency = amount.Currency
+
+	if err := validator.ValidateTransferReference(transfer.RemittanceInformation); err != nil {
+		return pain.ReasonNarrative, fmt.Sprintf("remittance information %s", err), nil
+	}
+
+	if err := validator.ValidateAccountNumber(transfer.DebtorAccount); err != nil {
+		return pain.ReasonIncorrectAccountNumber, fmt.Sprintf("debtor account: %s", err), nil
+	}
+
+	fromAccount, err := checker.getDebtorAccount(ctx, transfer.DebtorAccount)
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return pain.ReasonTransactionForbidden, fmt.Sprintf("debtor account: %s", status.Convert(err).Message()), nil
+		}
+		return "", "", err
+	}
+	item.FromAccountID = pgtype.Int8{Int64: fromAccount.ID, Valid: true}
+
+	if err := validator.ValidateAccountNumber(transfer.CreditorAccount); err != nil {
+		return pain.ReasonIncorrectAccountNumber, fmt.Sprintf("creditor account: %s", err), nil
+	}
+
+	toAccount, err := checker.getAccount(ctx, transfer.CreditorAccount)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return pain.ReasonIncorrectAccountNumber, "creditor account not found", nil
+		}
+		return "", "", err
+	}
+	item.ToAccountID = pgtype.Int8{Int64: toAccount.ID, Valid: true}
+
+	if fromAccount.ID == toAccount.ID {
+		return pain.ReasonNarrative, "debtor and creditor accounts are the same", nil
+	}
+
+	for _, account := range []persistence.Account{fromAccount, toAccount} {
+		if account.Currency != amount.Currency {
+			return pain.ReasonNotAllowedCurrency, fmt.Sprintf("account currency mismatch: expected %s, got %s", account.Currency, amount.Currency), nil
+		}
+	}
+
+	available, err := checker.getAvailableBalance(ctx, fromAccount)
+	if err != nil {
+		return "", "", err
+	}
+
+	if available < amount.Amount {
+		return pain.ReasonInsufficientFunds, "insufficient funds, including the earlier payments in the file", nil
+	}
+	checker.available[fromAccount.ID] = available - amount.Amount
+
+	return "", "", nil
+}
+
+func (checker *paymentBatchChecker) getAccount(ctx context.Context, number string) (persistence.Account, error) {
+	number = util.NormalizeAccountNumber(number)
+
+	lookup, ok := checker.accounts[number]
+	if !ok {
+		lookup.account, lookup.err = checker.server.getAccountRef(ctx, 0, number)
+		checker.accounts[number] = lookup
+	}
+
+	return lookup.account, lookup.err
+}
+
+// getDebtorAccount looks up an account the user must be allowed to transfer from. Unknown accounts are
+// reported like other users' accounts, so account numbers cannot be probed.
+func (checker *paymentBatchChecker) getDebtorAccount(ctx context.Context, number string) (persistence.Account, error) {
+	account, err := checker.getAccount(ctx, number)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return account, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+		}
+		return account, err
+	}
+
+	err, ok := checker.debtors[account.ID]
+	if !ok {
+		_, err = checker.server.authorizeAccountMember(ctx, account.ID, checker.username, util.AccountCanTransferRole)
+		checker.debtors[account.ID] = err
+	}
+
+	return account, err
+}
+
+// getAvailableBalance returns what is left of the account's available balance after the earlier valid transfers
+func (checker *paymentBatchChecker) getAvailableBalance(ctx context.Context, account persistence.Account) (int64, error) {
+	available, ok := checker.available[account.ID]
+	if ok {
+		return available, nil
+	}
+
+	pocketed, err := checker.server.store.GetPocketedBalance(ctx, account.ID)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get pocketed balance")
+	}
+
+	return account.Balance - pocketed, nil
+}
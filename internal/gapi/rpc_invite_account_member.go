@@ -0,0 +1,105 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) InviteAccountMember(ctx context.Context, req *pb.InviteAccountMemberRequest) (*pb.InviteAccountMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateInviteAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountOwnerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.store.GetAccountMember(ctx, persistence.GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+	})
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "user is already a member of the account")
+	}
+	if err != pgx.ErrNoRows {
+		return nil, status.Errorf(codes.Internal, "failed to get account member")
+	}
+
+	arg := persistence.CreateAccountInvitationTxParams{
+		CreateAccountInvitationParams: persistence.CreateAccountInvitationParams{
+			AccountID: account.ID,
+			Username:  req.GetUsername(),
+			Role:      req.GetRole(),
+			InvitedBy: authPayload.Username,
+		},
+		AfterCreate: func(invitation persistence.AccountInvitation) error {
+			taskPayload := &worker.PayloadSendAccountInvitationEmail{
+				InvitationID: invitation.ID,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskSendAccountInvitationEmail, taskPayload, opts...)
+		},
+	}
+
+	txResult, err := server.store.CreateAccountInvitationTx(ctx, arg)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.ForeignKeyViolation:
+				return nil, status.Errorf(codes.NotFound, "user does not exist")
+			}
+		}
+		return nil, server.internalError("failed to invite account member", err)
+	}
+
+	rsp := &pb.InviteAccountMemberResponse{
+		InvitationId: txResult.Invitation.ID,
+		ExpiresAt:    timestamppb.New(txResult.Invitation.ExpiresAt.Time),
+	}
+
+	return rsp, nil
+}
+
+func validateInviteAccountMemberRequest(req *pb.InviteAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if err := validator.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := validator.ValidateAccountRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,54 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccountMembers(ctx context.Context, req *pb.ListAccountMembersRequest) (*pb.ListAccountMembersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountMembersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var account persistence.Account
+	if util.RoleHasPermission(authPayload.Role, util.PermissionAccountReadAny) {
+		account, err = server.getAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber())
+	} else {
+		account, err = server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountViewOnlyRole)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account members")
+	}
+
+	rsp := &pb.ListAccountMembersResponse{}
+	for _, member := range members {
+		rsp.Members = append(rsp.Members, convertAccountMember(member, account))
+	}
+
+	return rsp, nil
+}
+
+func validateListAccountMembersRequest(req *pb.ListAccountMembersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ListApiKeysResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	apiKeys, err := server.store.ListApiKeys(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list API keys")
+	}
+
+	rsp := &pb.ListApiKeysResponse{}
+	for _, apiKey := range apiKeys {
+		rsp.ApiKeys = append(rsp.ApiKeys, convertApiKey(apiKey))
+	}
+
+	return rsp, nil
+}
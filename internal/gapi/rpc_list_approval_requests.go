@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListApprovalRequests lists held operations oldest first, so listing the pending ones
+// gives bankers their approval queue in order
+func (server *Server) ListApprovalRequests(ctx context.Context, req *pb.ListApprovalRequestsRequest) (*pb.ListApprovalRequestsResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListApprovalRequestsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	requests, err := server.store.ListApprovalRequests(ctx, persistence.ListApprovalRequestsParams{
+		Status: pgtype.Text{String: req.GetStatus(), Valid: req.GetStatus() != ""},
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list approval requests")
+	}
+
+	rsp := &pb.ListApprovalRequestsResponse{}
+	for _, request := range requests {
+		rsp.Requests = append(rsp.Requests, convertApprovalRequest(request))
+	}
+
+	return rsp, nil
+}
+
+func validateListApprovalRequestsRequest(req *pb.ListApprovalRequestsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetStatus() != "" {
+		if err := validator.ValidateApprovalStatus(req.GetStatus()); err != nil {
+			violations = append(violations, fieldViolation("status", err))
+		}
+	}
+
+	if err := validator.ValidatePageId(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListBeneficiaries(ctx context.Context, req *pb.ListBeneficiariesRequest) (*pb.ListBeneficiariesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListBeneficiariesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	beneficiaries, err := server.store.ListBeneficiaries(ctx, persistence.ListBeneficiariesParams{
+		Owner:  authPayload.Username,
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list beneficiaries")
+	}
+
+	rsp := &pb.ListBeneficiariesResponse{}
+	for _, beneficiary := range beneficiaries {
+		rsp.Beneficiaries = append(rsp.Beneficiaries, convertBeneficiary(beneficiary.Beneficiary, beneficiary.AccountNumber, server.config.BeneficiaryCoolingOffPeriod))
+	}
+
+	return rsp, nil
+}
+
+func validateListBeneficiariesRequest(req *pb.ListBeneficiariesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePageId(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	categories, err := server.store.ListCategories(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list categories")
+	}
+
+	rsp := &pb.ListCategoriesResponse{}
+	for _, category := range categories {
+		rsp.Categories = append(rsp.Categories, convertCategory(category))
+	}
+
+	return rsp, nil
+}
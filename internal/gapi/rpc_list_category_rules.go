@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCategoryRules(ctx context.Context, req *pb.ListCategoryRulesRequest) (*pb.ListCategoryRulesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	rules, err := server.store.ListCategoryRules(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list category rules")
+	}
+
+	rsp := &pb.ListCategoryRulesResponse{}
+	for _, rule := range rules {
+		rsp.Rules = append(rsp.Rules, convertCategoryRule(rule.CategoryRule, rule.CounterpartyAccountNumber.String))
+	}
+
+	return rsp, nil
+}
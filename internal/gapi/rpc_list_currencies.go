@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCurrencies(ctx context.Context, req *pb.ListCurrenciesRequest) (*pb.ListCurrenciesResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	currencies, err := server.store.ListCurrencies(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list currencies")
+	}
+
+	rsp := &pb.ListCurrenciesResponse{}
+	for _, currency := range currencies {
+		rsp.Currencies = append(rsp.Currencies, convertCurrency(currency))
+	}
+
+	return rsp, nil
+}
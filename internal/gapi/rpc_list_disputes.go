@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListDisputes lists disputes oldest first, optionally in one status. Bankers and auditors see every dispute,
+// customers only see the ones they opened.
+func (server *Server) ListDisputes(ctx context.Context, req *pb.ListDisputesRequest) (*pb.ListDisputesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListDisputesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := persistence.ListDisputesParams{
+		Status: pgtype.Text{String: req.GetStatus(), Valid: req.GetStatus() != ""},
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	}
+
+	if !util.RoleHasPermission(authPayload.Role, util.PermissionDisputeReadAny) {
+		arg.OpenedBy = pgtype.Text{String: authPayload.Username, Valid: true}
+	}
+
+	disputes, err := server.store.ListDisputes(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list disputes")
+	}
+
+	rsp := &pb.ListDisputesResponse{}
+	for _, dispute := range disputes {
+		rsp.Disputes = append(rsp.Disputes, convertDispute(dispute.Dispute, dispute.AccountNumber))
+	}
+
+	return rsp, nil
+}
+
+func validateListDisputesRequest(req *pb.ListDisputesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetStatus() != "" {
+		if err := validator.ValidateDisputeStatus(req.GetStatus()); err != nil {
+			violations = append(violations, fieldViolation("status", err))
+		}
+	}
+
+	if err := validator.ValidatePageId(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
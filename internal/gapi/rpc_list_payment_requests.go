@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListPaymentRequests(ctx context.Context, req *pb.ListPaymentRequestsRequest) (*pb.ListPaymentRequestsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListPaymentRequestsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	limit := req.GetPageSize()
+	offset := (req.GetPageId() - 1) * req.GetPageSize()
+
+	var paymentRequests []persistence.PaymentRequest
+	if req.GetDirection() == pb.PaymentRequestDirection_PAYMENT_REQUEST_DIRECTION_INCOMING {
+		paymentRequests, err = server.store.ListIncomingPaymentRequests(ctx, persistence.ListIncomingPaymentRequestsParams{
+			Payer:  authPayload.Username,
+			Limit:  limit,
+			Offset: offset,
+		})
+	} else {
+		paymentRequests, err = server.store.ListOutgoingPaymentRequests(ctx, persistence.ListOutgoingPaymentRequestsParams{
+			Requester: authPayload.Username,
+			Limit:     limit,
+			Offset:    offset,
+		})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payment requests")
+	}
+
+	rsp := &pb.ListPaymentRequestsResponse{}
+	for _, paymentRequest := range paymentRequests {
+		rsp.PaymentRequests = append(rsp.PaymentRequests, convertPaymentRequest(paymentRequest))
+	}
+
+	return rsp, nil
+}
+
+func validateListPaymentRequestsRequest(req *pb.ListPaymentRequestsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetDirection() == pb.PaymentRequestDirection_PAYMENT_REQUEST_DIRECTION_UNSPECIFIED {
+		violations = append(violations, fieldViolation("direction", fmt.Errorf("must be specified")))
+	}
+
+	if err := validator.ValidatePageId(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,40 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListRoles lists the roles stored in the database with their permissions, which may not have been
+// picked up by every instance yet
+func (server *Server) ListRoles(ctx context.Context, req *pb.ListRolesRequest) (*pb.ListRolesResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	roles, err := server.store.ListRoles(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list roles")
+	}
+
+	rolePermissions, err := server.store.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list role permissions")
+	}
+
+	permissions := map[string][]string{}
+	for _, rolePermission := range rolePermissions {
+		permissions[rolePermission.Role] = append(permissions[rolePermission.Role], rolePermission.Permission)
+	}
+
+	rsp := &pb.ListRolesResponse{}
+	for _, role := range roles {
+		rsp.Roles = append(rsp.Roles, convertRole(role, permissions[role.Name]))
+	}
+
+	return rsp, nil
+}
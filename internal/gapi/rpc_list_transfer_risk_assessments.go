@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTransferRiskAssessments lists the risk assessments with the given status, oldest first,
+// so listing the held transfers gives bankers their review queue in order
+func (server *Server) ListTransferRiskAssessments(ctx context.Context, req *pb.ListTransferRiskAssessmentsRequest) (*pb.ListTransferRiskAssessmentsResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListTransferRiskAssessmentsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	assessments, err := server.store.ListTransferRiskAssessments(ctx, persistence.ListTransferRiskAssessmentsParams{
+		Status: req.GetStatus(),
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer risk assessments")
+	}
+
+	rsp := &pb.ListTransferRiskAssessmentsResponse{}
+	for _, assessment := range assessments {
+		rsp.Assessments = append(rsp.Assessments, convertTransferRiskAssessment(assessment.TransferRiskAssessment, assessment.FromAccountNumber, assessment.ToAccountNumber))
+	}
+
+	return rsp, nil
+}
+
+func validateListTransferRiskAssessmentsRequest(req *pb.ListTransferRiskAssessmentsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateTransferRiskStatus(req.GetStatus()); err != nil {
+		violations = append(violations, fieldViolation("status", err))
+	}
+
+	if err := validator.ValidatePageId(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	webhooks, err := server.store.ListWebhooks(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhooks")
+	}
+
+	rsp := &pb.ListWebhooksResponse{}
+	for _, webhook := range webhooks {
+		rsp.Webhooks = append(rsp.Webhooks, convertWebhook(webhook))
+	}
+
+	return rsp, nil
+}
@@ -2,76 +2,68 @@ package gapi
 
 import (
 	"context"
+	"errors"
 
 	"github.com/RobinHood3082/simplebank/internal/pb"
-	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/pkg/validator"
-	"github.com/RobinHood3082/simplebank/util"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// LoginUser checks the password of a user and starts a session, unless two-factor authentication
+// is required, in which case VerifyMFA starts it. Failed logins are throttled per username and client
+// address, and lock the username after too many.
 func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
 	violations := validateLoginUserRequest(req)
 	if violations != nil {
 		return nil, invalidArgumentError(violations)
 	}
 
+	clientIP := server.extractMetadata(ctx).ClientIp
+	if err := server.checkLoginAttempt(ctx, req.GetUsername(), clientIP); err != nil {
+		return nil, err
+	}
+
 	user, err := server.store.GetUser(ctx, req.GetUsername())
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			_ = server.passwordHasher.CheckPassword(req.GetPassword(), server.dummyPasswordHash)
+			return nil, server.recordFailedLogin(ctx, req.GetUsername(), clientIP, false)
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get user")
 	}
 
-	if err := util.CheckPassword(req.GetPassword(), user.HashedPassword); err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid password")
+	if err := server.passwordHasher.CheckPassword(req.GetPassword(), user.HashedPassword); err != nil {
+		return nil, server.recordFailedLogin(ctx, user.Username, clientIP, true)
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		user.Username,
-		user.Role,
-		server.config.AccessTokenDuration,
-	)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create access token")
+	server.rehashPassword(ctx, user, req.GetPassword())
+
+	// failed logins are only forgotten once the login is complete, VerifyMFA does it for logins with a second step
+	rsp, err := server.mfaChallenge(ctx, user)
+	if err != nil || rsp != nil {
+		return rsp, err
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
-		user.Username,
-		user.Role,
-		server.config.RefreshTokenDuration,
-	)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create refresh token")
+	if _, err := server.store.DeleteLoginThrottle(ctx, user.Username); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reset failed logins")
 	}
 
-	mtdt := server.extractMetadata(ctx)
-	session, err := server.store.CreateSession(ctx, persistence.CreateSessionParams{
-		ID:           pgtype.UUID{Bytes: refreshPayload.ID, Valid: true},
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    mtdt.UserAgent,
-		ClientIp:     mtdt.ClientIp,
-		IsBlocked:    false,
-		ExpiresAt:    pgtype.Timestamptz{Time: refreshPayload.ExpiredAt, Valid: true},
-	})
+	login, err := server.startSession(ctx, user)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create session")
+		return nil, err
 	}
 
-	rsp := &pb.LoginUserResponse{
-		SessionId:             uuid.UUID(session.ID.Bytes).String(),
-		AccessToken:           accessToken,
-		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
-		RefreshToken:          refreshToken,
-		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiredAt),
+	rsp = &pb.LoginUserResponse{
+		SessionId:             uuid.UUID(login.session.ID.Bytes).String(),
+		AccessToken:           login.accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(login.accessPayload.ExpiredAt),
+		RefreshToken:          login.refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(login.refreshPayload.ExpiredAt),
 		User:                  convertUser(user),
 	}
 
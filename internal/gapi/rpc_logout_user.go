@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LogoutUser blocks the session of a refresh token. It needs no access token,
+// so a client whose access token has expired can still logout.
+func (server *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest) (*pb.LogoutUserResponse, error) {
+	violations := validateLogoutUserRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token")
+	}
+
+	refreshToken, err := server.store.GetRefreshToken(ctx, pgtype.UUID{Bytes: refreshPayload.ID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get refresh token")
+	}
+
+	if !util.CheckToken(req.GetRefreshToken(), refreshToken.TokenHash) {
+		return nil, status.Errorf(codes.Unauthenticated, "mismatched refresh token")
+	}
+
+	session, err := server.store.GetSession(ctx, refreshToken.SessionID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get session")
+	}
+
+	if session.Username != refreshPayload.Username {
+		return nil, status.Errorf(codes.Unauthenticated, "mismatched refresh token")
+	}
+
+	_, err = server.store.BlockSession(ctx, persistence.BlockSessionParams{
+		ID:       session.ID,
+		Username: session.Username,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to logout user")
+	}
+
+	return &pb.LogoutUserResponse{}, nil
+}
+
+func validateLogoutUserRequest(req *pb.LogoutUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateRefreshToken(req.GetRefreshToken()); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+
+	return violations
+}
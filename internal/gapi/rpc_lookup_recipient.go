@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) LookupRecipient(ctx context.Context, req *pb.LookupRecipientRequest) (*pb.LookupRecipientResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateLookupRecipientRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	recipient, err := server.store.ResolveRecipient(ctx, req.GetRecipient(), req.GetCurrency())
+	if err != nil {
+		if errors.Is(err, persistence.ErrRecipientNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to look up recipient")
+	}
+
+	return &pb.LookupRecipientResponse{
+		DisplayName: util.MaskName(recipient.User.FullName),
+		Currency:    recipient.Account.Currency,
+	}, nil
+}
+
+func validateLookupRecipientRequest(req *pb.LookupRecipientRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateRecipient(req.GetRecipient()); err != nil {
+		violations = append(violations, fieldViolation("recipient", err))
+	}
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
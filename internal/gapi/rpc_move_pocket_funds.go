@@ -0,0 +1,97 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) MovePocketFunds(ctx context.Context, req *pb.MovePocketFundsRequest) (*pb.MovePocketFundsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	amount, violations := validateMovePocketFundsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pocket, err := server.store.GetPocket(ctx, req.GetPocketId())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "pocket not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get pocket")
+	}
+
+	_, err = server.authorizeAccountMember(ctx, pocket.AccountID, authPayload.Username, util.AccountCanTransferRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetDirection() == pb.PocketMoveDirection_POCKET_MOVE_DIRECTION_TO_ACCOUNT {
+		amount, err = amount.Neg()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid amount: %s", err)
+		}
+	}
+
+	result, err := server.store.MovePocketFundsTx(ctx, persistence.MovePocketFundsTxParams{
+		PocketID: pocket.ID,
+		Amount:   amount,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrCurrencyMismatch) {
+			return nil, status.Errorf(codes.InvalidArgument, "pocket currency mismatch: %s", err)
+		}
+		if errors.Is(err, persistence.ErrInsufficientFunds) {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot move funds: %s", err)
+		}
+		return nil, server.internalError("failed to move pocket funds", err)
+	}
+
+	pockets, err := server.store.ListPockets(ctx, result.Account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list pockets")
+	}
+
+	available, err := result.Account.AvailableBalance(pockets)
+	if err != nil {
+		return nil, server.internalError("failed to compute available balance", err)
+	}
+
+	return &pb.MovePocketFundsResponse{
+		Pocket:           convertPocket(result.Pocket, result.Account),
+		Account:          convertAccount(result.Account),
+		AvailableBalance: convertMoney(available),
+	}, nil
+}
+
+func validateMovePocketFundsRequest(req *pb.MovePocketFundsRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePocketId(req.GetPocketId()); err != nil {
+		violations = append(violations, fieldViolation("pocket_id", err))
+	}
+
+	amount, err := parseMoney(req.GetAmount())
+	if err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	} else if err := validator.ValidateAmount(amount); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if req.GetDirection() == pb.PocketMoveDirection_POCKET_MOVE_DIRECTION_UNSPECIFIED {
+		violations = append(violations, fieldViolation("direction", fmt.Errorf("must be specified")))
+	}
+
+	return amount, violations
+}
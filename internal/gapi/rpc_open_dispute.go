@@ -0,0 +1,120 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OpenDispute opens a dispute on a transfer sent from an account the user owns.
+// A transfer can only be disputed once.
+func (server *Server) OpenDispute(ctx context.Context, req *pb.OpenDisputeRequest) (*pb.OpenDisputeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateOpenDisputeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	transfer, err := server.authorizeTransferMember(ctx, req.GetTransferId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.authorizeAccountMember(ctx, transfer.FromAccountID, authPayload.Username, util.AccountOwnerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.store.GetAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account")
+	}
+
+	arg := persistence.OpenDisputeTxParams{
+		CreateDisputeParams: persistence.CreateDisputeParams{
+			TransferID: transfer.ID,
+			AccountID:  account.ID,
+			OpenedBy:   authPayload.Username,
+			Reason:     req.GetReason(),
+			Amount:     transfer.Amount,
+			Currency:   account.Currency,
+		},
+		AfterOpen: func(dispute persistence.Dispute, event persistence.DisputeEvent) error {
+			return server.distributeDisputeEmail(ctx, event)
+		},
+	}
+
+	for _, attachment := range req.GetAttachments() {
+		arg.Attachments = append(arg.Attachments, persistence.CreateDisputeAttachmentParams{
+			FileName:    attachment.GetFileName(),
+			ContentType: attachment.GetContentType(),
+			Content:     attachment.GetContent(),
+		})
+	}
+
+	txResult, err := server.store.OpenDisputeTx(ctx, arg)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "transfer has already been disputed")
+		}
+		return nil, server.internalError("failed to open dispute", err)
+	}
+
+	rsp := &pb.OpenDisputeResponse{
+		Dispute: convertDispute(txResult.Dispute, account.AccountNumber),
+	}
+	for _, attachment := range txResult.Attachments {
+		rsp.Attachments = append(rsp.Attachments, convertDisputeAttachment(persistence.ListDisputeAttachmentsRow{
+			ID:          attachment.ID,
+			DisputeID:   attachment.DisputeID,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Size:        int64(len(attachment.Content)),
+			CreatedAt:   attachment.CreatedAt,
+		}))
+	}
+
+	return rsp, nil
+}
+
+func validateOpenDisputeRequest(req *pb.OpenDisputeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateTransferId(req.GetTransferId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+
+	if err := validator.ValidateDisputeReason(req.GetReason()); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	if err := validator.ValidateDisputeAttachmentCount(len(req.GetAttachments())); err != nil {
+		violations = append(violations, fieldViolation("attachments", err))
+	}
+
+	for _, attachment := range req.GetAttachments() {
+		if err := validator.ValidateDisputeAttachmentName(attachment.GetFileName()); err != nil {
+			violations = append(violations, fieldViolation("attachments.file_name", err))
+		}
+
+		if err := validator.ValidateDisputeAttachmentContentType(attachment.GetContentType()); err != nil {
+			violations = append(violations, fieldViolation("attachments.content_type", err))
+		}
+
+		if err := validator.ValidateDisputeAttachmentContent(attachment.GetContent()); err != nil {
+			violations = append(violations, fieldViolation("attachments.content", err))
+		}
+	}
+
+	return violations
+}
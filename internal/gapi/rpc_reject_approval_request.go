@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RejectApprovalRequest closes an operation held for approval without executing it.
+// Bankers can also withdraw the operations they requested this way.
+func (server *Server) RejectApprovalRequest(ctx context.Context, req *pb.RejectApprovalRequestRequest) (*pb.RejectApprovalRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRejectApprovalRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.store.GetApprovalRequest(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "approval request not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get approval request")
+	}
+
+	request, err := server.store.ReviewApprovalRequest(ctx, persistence.ReviewApprovalRequestParams{
+		ID:         req.GetId(),
+		Status:     util.ApprovalRejected,
+		ReviewedBy: pgtype.Text{String: authPayload.Username, Valid: true},
+		ReviewNote: req.GetNote(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", persistence.ErrApprovalRequestNotPending)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reject approval request")
+	}
+
+	return &pb.RejectApprovalRequestResponse{
+		Request: convertApprovalRequest(request),
+	}, nil
+}
+
+func validateRejectApprovalRequestRequest(req *pb.RejectApprovalRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateApprovalRequestId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if err := validator.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RejectHeldTransfer closes a transfer that was blocked by the risk checks without making it
+func (server *Server) RejectHeldTransfer(ctx context.Context, req *pb.RejectHeldTransferRequest) (*pb.RejectHeldTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRejectHeldTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.store.GetTransferRiskAssessment(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "transfer risk assessment not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transfer risk assessment")
+	}
+
+	assessment, err := server.store.ReviewTransferRiskAssessment(ctx, persistence.ReviewTransferRiskAssessmentParams{
+		ID:         req.GetId(),
+		Status:     util.TransferRiskRejected,
+		ReviewedBy: pgtype.Text{String: authPayload.Username, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", persistence.ErrTransferNotHeld)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reject held transfer")
+	}
+
+	fromAccountNumber, err := server.getAccountNumber(ctx, assessment.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	toAccountNumber, err := server.getAccountNumber(ctx, assessment.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RejectHeldTransferResponse{
+		Assessment: convertTransferRiskAssessment(assessment, fromAccountNumber, toAccountNumber),
+	}, nil
+}
+
+func validateRejectHeldTransferRequest(req *pb.RejectHeldTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateTransferRiskAssessmentId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RemoveAccountMember(ctx context.Context, req *pb.RemoveAccountMemberRequest) (*pb.RemoveAccountMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRemoveAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// members can always leave an account, only owners can remove others
+	requiredRole := util.AccountOwnerRole
+	if authPayload.Username == req.GetUsername() {
+		requiredRole = util.AccountViewOnlyRole
+	}
+
+	account, err := server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, requiredRole)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := persistence.RemoveAccountMemberTxParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+		AfterRemove: func(member persistence.AccountMember) error {
+			taskPayload := &worker.PayloadSendAccountMemberRemovedEmail{
+				Username:  member.Username,
+				AccountID: util.MaskAccountNumber(account.AccountNumber),
+				RemovedBy: authPayload.Username,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueDefault),
+			}
+
+			return server.taskDistributor.DistributeTask(ctx, worker.TaskSendAccountMemberRemovedEmail, taskPayload, opts...)
+		},
+	}
+
+	txResult, err := server.store.RemoveAccountMemberTx(ctx, arg)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account member not found")
+		}
+
+		if errors.Is(err, persistence.ErrLastAccountOwner) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, server.internalError("failed to remove account member", err)
+	}
+
+	rsp := &pb.RemoveAccountMemberResponse{
+		Member: convertAccountMember(txResult.Member, account),
+	}
+
+	return rsp, nil
+}
+
+func validateRemoveAccountMemberRequest(req *pb.RemoveAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if err := validator.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
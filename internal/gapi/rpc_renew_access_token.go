@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RenewAccessToken rotates the refresh token: the presented one can no longer be used and a new one is
+// returned with the access token. A refresh token used twice has leaked, so its session is revoked.
+// Both tokens carry the current role of the user, so role changes apply from the next renewal.
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRenewAccessTokenRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token")
+	}
+
+	var refreshToken string
+	var newRefreshPayload *token.Payload
+	txResult, err := server.store.RotateRefreshTokenTx(ctx, persistence.RotateRefreshTokenTxParams{
+		ID:           pgtype.UUID{Bytes: refreshPayload.ID, Valid: true},
+		RefreshToken: req.GetRefreshToken(),
+		Username:     refreshPayload.Username,
+		IssueRefreshToken: func(session persistence.Session, user persistence.User) (persistence.IssuedRefreshToken, error) {
+			var err error
+			refreshToken, newRefreshPayload, err = server.tokenMaker.CreateToken(
+				user.Username,
+				user.Role,
+				time.Until(session.ExpiresAt.Time),
+			)
+			if err != nil {
+				return persistence.IssuedRefreshToken{}, err
+			}
+
+			return persistence.IssuedRefreshToken{
+				ID:        pgtype.UUID{Bytes: newRefreshPayload.ID, Valid: true},
+				Token:     refreshToken,
+				ExpiresAt: newRefreshPayload.ExpiredAt,
+			}, nil
+		},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		case errors.Is(err, persistence.ErrRefreshTokenReused),
+			errors.Is(err, persistence.ErrRefreshTokenMismatch),
+			errors.Is(err, persistence.ErrSessionBlocked),
+			errors.Is(err, persistence.ErrSessionExpired):
+			return nil, status.Errorf(codes.Unauthenticated, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to renew refresh token")
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		txResult.User.Username,
+		txResult.User.Role,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token")
+	}
+
+	return &pb.RenewAccessTokenResponse{
+		SessionId:             uuid.UUID(txResult.Session.ID.Bytes).String(),
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(newRefreshPayload.ExpiredAt),
+	}, nil
+}
+
+func validateRenewAccessTokenRequest(req *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateRefreshToken(req.GetRefreshToken()); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReplayWebhookDelivery sends the payload of a past delivery again as a new delivery,
+// so the original attempt stays in the log. The event keeps its ID, letting receivers deduplicate it.
+func (server *Server) ReplayWebhookDelivery(ctx context.Context, req *pb.ReplayWebhookDeliveryRequest) (*pb.ReplayWebhookDeliveryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateReplayWebhookDeliveryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	delivery, err := server.store.GetWebhookDelivery(ctx, req.GetDeliveryId())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get webhook delivery")
+	}
+
+	_, err = server.authorizeWebhookOwner(ctx, delivery.WebhookID, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	replay, err := server.store.CreateWebhookDelivery(ctx, persistence.CreateWebhookDeliveryParams{
+		WebhookID: delivery.WebhookID,
+		EventID:   delivery.EventID,
+		EventType: delivery.EventType,
+		Payload:   delivery.Payload,
+		ReplayOf:  pgtype.Int8{Int64: delivery.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, server.internalError("failed to create webhook delivery", err)
+	}
+
+	err = server.taskDistributor.DistributeWebhookDelivery(ctx, replay.ID)
+	if err != nil {
+		return nil, server.internalError("failed to queue webhook delivery", err)
+	}
+
+	return &pb.ReplayWebhookDeliveryResponse{
+		Delivery: convertWebhookDelivery(replay),
+	}, nil
+}
+
+func validateReplayWebhookDeliveryRequest(req *pb.ReplayWebhookDeliveryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateWebhookDeliveryId(req.GetDeliveryId()); err != nil {
+		violations = append(violations, fieldViolation("delivery_id", err))
+	}
+
+	return violations
+}
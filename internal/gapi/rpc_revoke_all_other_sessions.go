@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeAllOtherSessions blocks every session of the user except the one it is using,
+// which has to be active itself
+func (server *Server) RevokeAllOtherSessions(ctx context.Context, req *pb.RevokeAllOtherSessionsRequest) (*pb.RevokeAllOtherSessionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeAllOtherSessionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	session, err := server.authorizeSessionOwner(ctx, req.GetSessionId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.IsBlocked {
+		return nil, status.Errorf(codes.FailedPrecondition, "session is blocked")
+	}
+
+	revoked, err := server.store.BlockOtherSessions(ctx, persistence.BlockOtherSessionsParams{
+		Username: session.Username,
+		ID:       session.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions")
+	}
+
+	return &pb.RevokeAllOtherSessionsResponse{
+		RevokedSessions: revoked,
+	}, nil
+}
+
+func validateRevokeAllOtherSessionsRequest(req *pb.RevokeAllOtherSessionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateSessionId(req.GetSessionId()); err != nil {
+		violations = append(violations, fieldViolation("session_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeApiKey revokes an API key of the user, or of any user for admins. The key is rejected from then on.
+func (server *Server) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyRequest) (*pb.RevokeApiKeyResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeApiKeyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	apiKey, err := server.authorizeApiKeyRevoker(ctx, req.GetId(), authPayload.Username, authPayload.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err = server.store.RevokeApiKey(ctx, apiKey.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "API key is already revoked")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke API key")
+	}
+
+	return &pb.RevokeApiKeyResponse{
+		ApiKey: convertApiKey(apiKey),
+	}, nil
+}
+
+func validateRevokeApiKeyRequest(req *pb.RevokeApiKeyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateApiKeyId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
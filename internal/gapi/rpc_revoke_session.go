@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSession blocks a session of the user, its refresh token can no longer renew access tokens
+func (server *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeSessionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	session, err := server.authorizeSessionOwner(ctx, req.GetSessionId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err = server.store.BlockSession(ctx, persistence.BlockSessionParams{
+		ID:       session.ID,
+		Username: session.Username,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke session")
+	}
+
+	return &pb.RevokeSessionResponse{
+		Session: convertSession(session),
+	}, nil
+}
+
+func validateRevokeSessionRequest(req *pb.RevokeSessionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateSessionId(req.GetSessionId()); err != nil {
+		violations = append(violations, fieldViolation("session_id", err))
+	}
+
+	return violations
+}
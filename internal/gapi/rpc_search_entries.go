@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SearchEntries(ctx context.Context, req *pb.SearchEntriesRequest) (*pb.SearchEntriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	filters, violations := validateSearchRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	accountIDs, err := server.searchAccountIDs(ctx, authPayload, req, filters.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := server.store.SearchEntries(ctx, persistence.SearchEntriesParams{
+		AccountIds:    accountIDs,
+		Direction:     filters.direction,
+		CreatedAfter:  filters.createdAfter,
+		CreatedBefore: filters.createdBefore,
+		MinAmount:     filters.minAmount,
+		MaxAmount:     filters.maxAmount,
+		Sort:          filters.sort,
+		Limit:         req.GetPageSize(),
+		Offset:        (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search entries")
+	}
+
+	rsp := &pb.SearchEntriesResponse{}
+	for _, entry := range entries {
+		rsp.Entries = append(rsp.Entries, convertSearchEntry(entry))
+	}
+
+	return rsp, nil
+}
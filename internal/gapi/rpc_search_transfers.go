@@ -0,0 +1,87 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SearchTransfers(ctx context.Context, req *pb.SearchTransfersRequest) (*pb.SearchTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	filters, violations := validateSearchTransfersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	accountIDs, err := server.searchAccountIDs(ctx, authPayload, req, filters.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := persistence.SearchTransfersParams{
+		Direction:     filters.direction,
+		AccountIds:    accountIDs,
+		CreatedAfter:  filters.createdAfter,
+		CreatedBefore: filters.createdBefore,
+		MinAmount:     filters.minAmount,
+		MaxAmount:     filters.maxAmount,
+		Sort:          filters.sort,
+		Limit:         req.GetPageSize(),
+		Offset:        (req.GetPageId() - 1) * req.GetPageSize(),
+	}
+
+	if req.GetCounterpartyAccountId() != 0 || req.GetCounterpartyAccountNumber() != "" {
+		counterparty, err := server.getAccountRef(ctx, req.GetCounterpartyAccountId(), req.GetCounterpartyAccountNumber())
+		if err != nil {
+			// an unknown counterparty has no transfers; reporting it would let account numbers be probed
+			if status.Code(err) == codes.NotFound {
+				return &pb.SearchTransfersResponse{}, nil
+			}
+			return nil, err
+		}
+
+		arg.CounterpartyAccountID = pgtype.Int8{Int64: counterparty.ID, Valid: true}
+	}
+
+	if req.GetReference() != "" {
+		arg.Reference = pgtype.Text{String: persistence.ContainsPattern(req.GetReference()), Valid: true}
+	}
+
+	transfers, err := server.store.SearchTransfers(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search transfers")
+	}
+
+	rsp := &pb.SearchTransfersResponse{}
+	for _, transfer := range transfers {
+		rsp.Transfers = append(rsp.Transfers, convertSearchTransfer(transfer))
+	}
+
+	return rsp, nil
+}
+
+func validateSearchTransfersRequest(req *pb.SearchTransfersRequest) (searchFilters, []*errdetails.BadRequest_FieldViolation) {
+	filters, violations := validateSearchRequest(req)
+
+	if req.GetCounterpartyAccountId() != 0 || req.GetCounterpartyAccountNumber() != "" {
+		if violation := validateAccountRef("counterparty_account", req.GetCounterpartyAccountId(), req.GetCounterpartyAccountNumber()); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+
+	if err := validator.ValidateTransferReference(req.GetReference()); err != nil {
+		violations = append(violations, fieldViolation("reference", err))
+	}
+
+	return filters, violations
+}
@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// SetTransferCategory lets a member of either account of a transfer put it in one of their categories.
+// Each member categorizes a transfer independently, and their choice is never overridden by a rule.
+func (server *Server) SetTransferCategory(ctx context.Context, req *pb.SetTransferCategoryRequest) (*pb.SetTransferCategoryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSetTransferCategoryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	transfer, err := server.authorizeTransferMember(ctx, req.GetTransferId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := server.authorizeCategoryOwner(ctx, req.GetCategoryId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.store.SetTransferCategory(ctx, persistence.SetTransferCategoryParams{
+		TransferID: transfer.ID,
+		Username:   authPayload.Username,
+		CategoryID: category.ID,
+	})
+	if err != nil {
+		return nil, server.internalError("failed to set transfer category", err)
+	}
+
+	_ = server.taskDistributor.DistributeSpendingSummaryRefresh(ctx)
+
+	return &pb.SetTransferCategoryResponse{}, nil
+}
+
+func validateSetTransferCategoryRequest(req *pb.SetTransferCategoryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateTransferId(req.GetTransferId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+
+	if err := validator.ValidateCategoryId(req.GetCategoryId()); err != nil {
+		violations = append(violations, fieldViolation("category_id", err))
+	}
+
+	return violations
+}
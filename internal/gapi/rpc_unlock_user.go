@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnlockUser lifts the lockout of a user and forgets their failed logins, only bankers can use it
+func (server *Server) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUnlockUserRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, req.GetUsername())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+
+	throttle, err := server.store.GetLoginThrottle(ctx, user.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &pb.UnlockUserResponse{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get failed logins")
+	}
+
+	if _, err := server.store.DeleteLoginThrottle(ctx, user.Username); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unlock user")
+	}
+
+	return &pb.UnlockUserResponse{
+		WasLocked: throttle.LockedUntil.Valid && time.Now().Before(throttle.LockedUntil.Time),
+	}, nil
+}
+
+func validateUnlockUserRequest(req *pb.UnlockUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
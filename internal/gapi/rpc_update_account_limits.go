@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateAccountLimits requests limits for an account in place of the configured ones. Raising a limit
+// weakens the checks on the account, so the change is always held until another banker approves it.
+func (server *Server) UpdateAccountLimits(ctx context.Context, req *pb.UpdateAccountLimitsRequest) (*pb.UpdateAccountLimitsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	change, violations := validateUpdateAccountLimitsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber())
+	if err != nil {
+		return nil, err
+	}
+	change.AccountID = account.ID
+
+	for _, money := range []*pb.Money{req.GetNewCounterpartyThreshold(), req.GetNewBeneficiaryTransferLimit()} {
+		if money != nil && money.GetCurrencyCode() != account.Currency {
+			return nil, status.Errorf(codes.InvalidArgument, "account currency mismatch: expected %s, got %s", account.Currency, money.GetCurrencyCode())
+		}
+	}
+
+	request, err := server.requestApproval(ctx, util.ApprovalUpdateAccountLimits, change, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateAccountLimitsResponse{
+		Request: convertApprovalRequest(request),
+	}, nil
+}
+
+func validateUpdateAccountLimitsRequest(req *pb.UpdateAccountLimitsRequest) (change persistence.AccountLimitsChange, violations []*errdetails.BadRequest_FieldViolation) {
+	if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if req.MaxTransfersPerHour != nil {
+		if err := validator.ValidateMaxTransfersPerHour(req.GetMaxTransfersPerHour()); err != nil {
+			violations = append(violations, fieldViolation("max_transfers_per_hour", err))
+		}
+		change.MaxTransfersPerHour = pgtype.Int8{Int64: req.GetMaxTransfersPerHour(), Valid: true}
+	}
+
+	if req.NewCounterpartyThreshold != nil {
+		threshold, err := parseMoney(req.GetNewCounterpartyThreshold())
+		if err == nil {
+			err = validator.ValidateAmount(threshold)
+		}
+		if err != nil {
+			violations = append(violations, fieldViolation("new_counterparty_threshold", err))
+		}
+		change.NewCounterpartyThreshold = pgtype.Int8{Int64: threshold.Amount, Valid: true}
+	}
+
+	if req.NewBeneficiaryTransferLimit != nil {
+		limit, err := parseMoney(req.GetNewBeneficiaryTransferLimit())
+		if err == nil {
+			err = validator.ValidateAmount(limit)
+		}
+		if err != nil {
+			violations = append(violations, fieldViolation("new_beneficiary_transfer_limit", err))
+		}
+		change.NewBeneficiaryTransferLimit = pgtype.Int8{Int64: limit.Amount, Valid: true}
+	}
+
+	return change, violations
+}
@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateBeneficiary(ctx context.Context, req *pb.UpdateBeneficiaryRequest) (*pb.UpdateBeneficiaryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateBeneficiaryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	beneficiary, err := server.store.UpdateBeneficiary(ctx, persistence.UpdateBeneficiaryParams{
+		ID:       req.GetId(),
+		Owner:    authPayload.Username,
+		Nickname: req.GetNickname(),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "beneficiary not found")
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return nil, status.Errorf(codes.AlreadyExists, "beneficiary with this nickname already exists")
+			}
+		}
+		return nil, server.internalError("failed to update beneficiary", err)
+	}
+
+	accountNumber, err := server.getAccountNumber(ctx, beneficiary.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateBeneficiaryResponse{
+		Beneficiary: convertBeneficiary(beneficiary, accountNumber, server.config.BeneficiaryCoolingOffPeriod),
+	}, nil
+}
+
+func validateUpdateBeneficiaryRequest(req *pb.UpdateBeneficiaryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateBeneficiaryId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if err := validator.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	return violations
+}
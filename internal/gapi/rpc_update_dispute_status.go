@@ -0,0 +1,110 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateDisputeStatus moves a dispute to its next status on behalf of a banker, booking the provisional credit,
+// chargeback or reversal that goes with it. Bookings over the approval threshold are held until another banker
+// approves them.
+func (server *Server) UpdateDisputeStatus(ctx context.Context, req *pb.UpdateDisputeStatusRequest) (*pb.UpdateDisputeStatusResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateDisputeStatusRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	dispute, err := server.store.GetDispute(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "dispute not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get dispute")
+	}
+
+	accountNumber, err := server.getAccountNumber(ctx, dispute.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.CanMoveDispute(dispute.Status, req.GetStatus()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "dispute cannot move to %s", req.GetStatus())
+	}
+
+	if disputeMoveBooksEntries(dispute, req.GetStatus()) {
+		held, err := server.exceedsApprovalThreshold(server.config.ApprovalDisputeThreshold, util.Money{Amount: dispute.Amount, Currency: dispute.Currency})
+		if err != nil {
+			return nil, err
+		}
+
+		if held {
+			request, err := server.requestApproval(ctx, util.ApprovalUpdateDisputeStatus, persistence.DisputeStatusChange{
+				DisputeID: dispute.ID,
+				Status:    req.GetStatus(),
+				Note:      req.GetNote(),
+			}, authPayload.Username)
+			if err != nil {
+				return nil, err
+			}
+
+			return &pb.UpdateDisputeStatusResponse{
+				Dispute:         convertDispute(dispute, accountNumber),
+				ApprovalRequest: convertApprovalRequest(request),
+			}, nil
+		}
+	}
+
+	txResult, err := server.store.UpdateDisputeStatusTx(ctx, persistence.UpdateDisputeStatusTxParams{
+		DisputeID: req.GetId(),
+		Status:    req.GetStatus(),
+		Actor:     authPayload.Username,
+		Note:      req.GetNote(),
+		AfterUpdate: func(dispute persistence.Dispute, event persistence.DisputeEvent) error {
+			return server.distributeDisputeEmail(ctx, event)
+		},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "dispute not found")
+		}
+		if errors.Is(err, persistence.ErrInvalidDisputeTransition) {
+			return nil, status.Errorf(codes.FailedPrecondition, "dispute cannot move to %s", req.GetStatus())
+		}
+		return nil, server.internalError("failed to update dispute status", err)
+	}
+
+	return &pb.UpdateDisputeStatusResponse{
+		Dispute: convertDispute(txResult.Dispute, accountNumber),
+		Event:   convertDisputeEvent(txResult.Event),
+	}, nil
+}
+
+func validateUpdateDisputeStatusRequest(req *pb.UpdateDisputeStatusRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateDisputeId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if err := validator.ValidateDisputeStatus(req.GetStatus()); err != nil {
+		violations = append(violations, fieldViolation("status", err))
+	}
+
+	if err := validator.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
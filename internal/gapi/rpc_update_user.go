@@ -8,7 +8,9 @@ import (
 	persistence "github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/pkg/validator"
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -16,11 +18,7 @@ import (
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	authPayload, err := server.authorizeUser(
-		ctx,
-		[]string{util.BankerRole, util.DepositorRole},
-	)
-
+	authPayload, err := server.authorizeUser(ctx)
 	if err != nil {
 		return nil, unauthenticatedError(err)
 	}
@@ -30,14 +28,14 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
-	if authPayload.Role != util.BankerRole && authPayload.Username != req.GetUsername() {
+	if !util.RoleHasPermission(authPayload.Role, util.PermissionUserUpdateAny) && authPayload.Username != req.GetUsername() {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
 	}
 
 	hashedPassword := ""
 	if req.Password != nil {
 		var err error
-		hashedPassword, err = util.HashPassword(req.GetPassword())
+		hashedPassword, err = server.passwordHasher.HashPassword(req.GetPassword())
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to hash password")
 		}
@@ -57,6 +55,19 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 			String: req.GetEmail(),
 			Valid:  req.Email != nil,
 		},
+		EmailChangedAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: req.Email != nil,
+		},
+		// a new email address can receive money only once it has been verified again
+		IsEmailVerified: pgtype.Bool{
+			Bool:  false,
+			Valid: req.Email != nil,
+		},
+		Handle: pgtype.Text{
+			String: util.NormalizeHandle(req.GetHandle()),
+			Valid:  req.Handle != nil,
+		},
 		PasswordChangedAt: pgtype.Timestamptz{
 			Time:  time.Now(),
 			Valid: req.Password != nil,
@@ -68,6 +79,12 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		if err == pgx.ErrNoRows {
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return nil, status.Errorf(codes.AlreadyExists, "email or handle is already taken")
+			}
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update user")
 	}
 
@@ -101,5 +118,11 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		}
 	}
 
+	if req.Handle != nil {
+		if err := validator.ValidateHandle(req.GetHandle()); err != nil {
+			violations = append(violations, fieldViolation("handle", err))
+		}
+	}
+
 	return violations
 }
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateUserRole requests a change of the role of a user. Role changes are privileged,
+// so they are always held until another user allowed to review approvals approves them.
+// Users can only change roles that grant nothing they are not granted themselves, both the user's
+// current role and the new one.
+func (server *Server) UpdateUserRole(ctx context.Context, req *pb.UpdateUserRoleRequest) (*pb.UpdateUserRoleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateUserRoleRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, req.GetUsername())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+
+	if !util.RoleCanChangeRole(authPayload.Role, user.Role, req.GetRole()) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot change role %s to %s, role %s does not grant every permission of both", user.Role, req.GetRole(), authPayload.Role)
+	}
+
+	if user.Role == req.GetRole() {
+		return nil, status.Errorf(codes.FailedPrecondition, "user already has role %s", req.GetRole())
+	}
+
+	request, err := server.requestApproval(ctx, util.ApprovalUpdateUserRole, persistence.UserRoleChange{
+		Username: user.Username,
+		Role:     req.GetRole(),
+	}, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateUserRoleResponse{
+		Request: convertApprovalRequest(request),
+	}, nil
+}
+
+func validateUpdateUserRoleRequest(req *pb.UpdateUserRoleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := validator.ValidateUserRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	return violations
+}
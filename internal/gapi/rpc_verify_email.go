@@ -7,8 +7,6 @@ import (
 	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/pkg/validator"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
@@ -25,7 +23,7 @@ func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReques
 		},
 	)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to verify email: %s", err)
+		return nil, server.internalError("failed to verify email", err)
 	}
 
 	rsp := &pb.VerifyEmailResponse{
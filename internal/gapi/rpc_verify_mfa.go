@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// VerifyMFA completes a login that requires two-factor authentication and starts its session.
+// Wrong codes count as failed logins of the user.
+func (server *Server) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error) {
+	violations := validateVerifyMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	txResult, err := server.store.VerifyMfaTx(ctx, persistence.VerifyMfaTxParams{
+		Token: req.GetMfaToken(),
+		Code:  req.GetCode(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, status.Errorf(codes.Unauthenticated, "invalid MFA token")
+		case errors.Is(err, persistence.ErrInvalidMfaCode):
+			clientIP := server.extractMetadata(ctx).ClientIp
+			if err := server.countFailedLogin(ctx, txResult.Challenge.Username, clientIP, true); err != nil {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Unauthenticated, "%s", persistence.ErrInvalidMfaCode)
+		case errors.Is(err, persistence.ErrLoginLocked):
+			return nil, status.Errorf(codes.ResourceExhausted, "%s", err)
+		case errors.Is(err, persistence.ErrMfaChallengeCompleted),
+			errors.Is(err, persistence.ErrMfaChallengeExpired),
+			errors.Is(err, persistence.ErrTooManyMfaAttempts),
+			errors.Is(err, persistence.ErrTotpNotEnabled):
+			return nil, status.Errorf(codes.Unauthenticated, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to verify MFA code")
+	}
+
+	user, err := server.store.GetUser(ctx, txResult.Challenge.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+
+	if _, err := server.store.DeleteLoginThrottle(ctx, user.Username); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reset failed logins")
+	}
+
+	login, err := server.startSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.VerifyMFAResponse{
+		SessionId:             uuid.UUID(login.session.ID.Bytes).String(),
+		AccessToken:           login.accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(login.accessPayload.ExpiredAt),
+		RefreshToken:          login.refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(login.refreshPayload.ExpiredAt),
+		User:                  convertUser(user),
+		RecoveryCodes:         txResult.RecoveryCodes,
+	}, nil
+}
+
+func validateVerifyMFARequest(req *pb.VerifyMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateMfaToken(req.GetMfaToken()); err != nil {
+		violations = append(violations, fieldViolation("mfa_token", err))
+	}
+
+	if err := validator.ValidateMfaCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
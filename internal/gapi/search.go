@@ -0,0 +1,156 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/internal/pb"
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/pkg/validator"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var searchDirections = map[pb.SearchDirection]string{
+	pb.SearchDirection_SEARCH_DIRECTION_UNSPECIFIED: "",
+	pb.SearchDirection_SEARCH_DIRECTION_INCOMING:    util.SearchDirectionIncoming,
+	pb.SearchDirection_SEARCH_DIRECTION_OUTGOING:    util.SearchDirectionOutgoing,
+}
+
+var searchSortOrders = map[pb.SearchSortOrder]string{
+	pb.SearchSortOrder_SEARCH_SORT_ORDER_UNSPECIFIED:  util.SearchSortNewestFirst,
+	pb.SearchSortOrder_SEARCH_SORT_ORDER_NEWEST_FIRST: util.SearchSortNewestFirst,
+	pb.SearchSortOrder_SEARCH_SORT_ORDER_OLDEST_FIRST: util.SearchSortOldestFirst,
+	pb.SearchSortOrder_SEARCH_SORT_ORDER_AMOUNT_DESC:  util.SearchSortAmountDesc,
+	pb.SearchSortOrder_SEARCH_SORT_ORDER_AMOUNT_ASC:   util.SearchSortAmountAsc,
+}
+
+// searchFilters holds the validated filters shared by transfer and entry searches
+type searchFilters struct {
+	direction     string
+	sort          string
+	createdAfter  pgtype.Timestamptz
+	createdBefore pgtype.Timestamptz
+	// currency is set when an amount bound is given, since amounts are only comparable within a currency
+	currency  string
+	minAmount pgtype.Int8
+	maxAmount pgtype.Int8
+}
+
+type searchRequest interface {
+	GetAccountId() int64
+	GetAccountNumber() string
+	GetDirection() pb.SearchDirection
+	GetCreatedAfter() *timestamppb.Timestamp
+	GetCreatedBefore() *timestamppb.Timestamp
+	GetMinAmount() *pb.Money
+	GetMaxAmount() *pb.Money
+	GetSort() pb.SearchSortOrder
+	GetPageId() int32
+	GetPageSize() int32
+}
+
+func validateSearchRequest(req searchRequest) (filters searchFilters, violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetAccountId() != 0 || req.GetAccountNumber() != "" {
+		if violation := validateAccountRef("account", req.GetAccountId(), req.GetAccountNumber()); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+
+	var ok bool
+	if filters.direction, ok = searchDirections[req.GetDirection()]; !ok {
+		violations = append(violations, fieldViolation("direction", fmt.Errorf("is not supported")))
+	}
+
+	if filters.sort, ok = searchSortOrders[req.GetSort()]; !ok {
+		violations = append(violations, fieldViolation("sort", fmt.Errorf("is not supported")))
+	}
+
+	if req.GetCreatedAfter() != nil {
+		filters.createdAfter = pgtype.Timestamptz{Time: req.GetCreatedAfter().AsTime(), Valid: true}
+	}
+
+	if req.GetCreatedBefore() != nil {
+		filters.createdBefore = pgtype.Timestamptz{Time: req.GetCreatedBefore().AsTime(), Valid: true}
+		if filters.createdAfter.Valid && !filters.createdAfter.Time.Before(filters.createdBefore.Time) {
+			violations = append(violations, fieldViolation("created_before", fmt.Errorf("must be after created_after")))
+		}
+	}
+
+	if req.GetMinAmount() != nil {
+		amount, err := parseMoney(req.GetMinAmount())
+		if err != nil {
+			violations = append(violations, fieldViolation("min_amount", err))
+		} else {
+			filters.currency = amount.Currency
+			filters.minAmount = pgtype.Int8{Int64: amount.Amount, Valid: true}
+		}
+	}
+
+	if req.GetMaxAmount() != nil {
+		amount, err := parseMoney(req.GetMaxAmount())
+		switch {
+		case err != nil:
+			violations = append(violations, fieldViolation("max_amount", err))
+		case filters.currency != "" && amount.Currency != filters.currency:
+			violations = append(violations, fieldViolation("max_amount", fmt.Errorf("must be in the currency of min_amount")))
+		case filters.minAmount.Valid && amount.Amount < filters.minAmount.Int64:
+			violations = append(violations, fieldViolation("max_amount", fmt.Errorf("must not be less than min_amount")))
+		default:
+			filters.currency = amount.Currency
+			filters.maxAmount = pgtype.Int8{Int64: amount.Amount, Valid: true}
+		}
+	}
+
+	if err := validator.ValidatePageId(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return filters, violations
+}
+
+// searchAccountIDs returns the accounts a search runs over: the referenced account, or every account the user
+// is a member of when none is referenced. Bankers can search any account. When currency is set, only accounts
+// held in that currency are searched.
+func (server *Server) searchAccountIDs(ctx context.Context, authPayload *token.Payload, req searchRequest, currency string) ([]int64, error) {
+	if req.GetAccountId() == 0 && req.GetAccountNumber() == "" {
+		accounts, err := server.store.ListAllMemberAccounts(ctx, authPayload.Username)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list accounts")
+		}
+
+		accountIDs := []int64{}
+		for _, account := range accounts {
+			if currency == "" || account.Currency == currency {
+				accountIDs = append(accountIDs, account.ID)
+			}
+		}
+		return accountIDs, nil
+	}
+
+	var account persistence.Account
+	var err error
+	if util.RoleHasPermission(authPayload.Role, util.PermissionAccountReadAny) {
+		account, err = server.getAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber())
+	} else {
+		account, err = server.authorizeAccountRef(ctx, req.GetAccountId(), req.GetAccountNumber(), authPayload.Username, util.AccountViewOnlyRole)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if currency != "" && account.Currency != currency {
+		return nil, status.Errorf(codes.InvalidArgument, "account currency mismatch: expected %s, got %s", account.Currency, currency)
+	}
+
+	return []int64{account.ID}, nil
+}
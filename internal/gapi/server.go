@@ -6,6 +6,7 @@ import (
 	"github.com/RobinHood3082/simplebank/internal/pb"
 	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/risk"
 	"github.com/RobinHood3082/simplebank/util"
 	"github.com/RobinHood3082/simplebank/worker"
 )
@@ -16,18 +17,31 @@ type Server struct {
 	store           persistence.Store
 	logger          *slog.Logger
 	tokenMaker      token.Maker
+	passwordHasher  util.PasswordHasher
 	config          util.Config
 	taskDistributor worker.TaskDistributor
+	riskEngine      *risk.Engine
+	// dummyPasswordHash is checked for unknown usernames, so they take as long to reject as wrong passwords
+	dummyPasswordHash string
 }
 
 // NewServer creates a new gRPC server
-func NewServer(store persistence.Store, logger *slog.Logger, tokenMaker token.Maker, config util.Config, taskDistributor worker.TaskDistributor) *Server {
+func NewServer(store persistence.Store, logger *slog.Logger, tokenMaker token.Maker, passwordHasher util.PasswordHasher, config util.Config, taskDistributor worker.TaskDistributor, riskEngine *risk.Engine) *Server {
 	server := &Server{
 		store:           store,
 		logger:          logger,
 		tokenMaker:      tokenMaker,
+		passwordHasher:  passwordHasher,
 		config:          config,
 		taskDistributor: taskDistributor,
+		riskEngine:      riskEngine,
 	}
+
+	var err error
+	server.dummyPasswordHash, err = passwordHasher.HashPassword(util.RandomString(32))
+	if err != nil {
+		logger.Error("Cannot hash the dummy password, unknown usernames are rejected faster than wrong passwords", "Error", err)
+	}
+
 	return server
 }
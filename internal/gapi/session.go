@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizeSessionOwner checks that the session belongs to the user,
+// other users' sessions are reported as not found
+func (server *Server) authorizeSessionOwner(ctx context.Context, sessionID string, username string) (persistence.Session, error) {
+	session, err := server.store.GetSession(ctx, pgtype.UUID{Bytes: uuid.MustParse(sessionID), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return session, status.Errorf(codes.NotFound, "session not found")
+		}
+		return session, status.Errorf(codes.Internal, "failed to get session")
+	}
+
+	if session.Username != username {
+		return session, status.Errorf(codes.NotFound, "session not found")
+	}
+
+	return session, nil
+}
+
+// loginSession holds the tokens of a session started by a login
+type loginSession struct {
+	session        persistence.Session
+	accessToken    string
+	accessPayload  *token.Payload
+	refreshToken   string
+	refreshPayload *token.Payload
+}
+
+// startSession creates the access and refresh tokens of a user who has logged in, and their session
+func (server *Server) startSession(ctx context.Context, user persistence.User) (loginSession, error) {
+	var login loginSession
+	var err error
+
+	login.accessToken, login.accessPayload, err = server.tokenMaker.CreateToken(
+		user.Username,
+		user.Role,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		return login, status.Errorf(codes.Internal, "failed to create access token")
+	}
+
+	login.refreshToken, login.refreshPayload, err = server.tokenMaker.CreateToken(
+		user.Username,
+		user.Role,
+		server.config.RefreshTokenDuration,
+	)
+	if err != nil {
+		return login, status.Errorf(codes.Internal, "failed to create refresh token")
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	txResult, err := server.store.CreateSessionTx(ctx, persistence.CreateSessionTxParams{
+		CreateSessionParams: persistence.CreateSessionParams{
+			ID:        pgtype.UUID{Bytes: login.refreshPayload.ID, Valid: true},
+			Username:  user.Username,
+			UserAgent: mtdt.UserAgent,
+			ClientIp:  mtdt.ClientIp,
+			IsBlocked: false,
+			ExpiresAt: pgtype.Timestamptz{Time: login.refreshPayload.ExpiredAt, Valid: true},
+		},
+		RefreshToken: login.refreshToken,
+	})
+	if err != nil {
+		return login, status.Errorf(codes.Internal, "failed to create session")
+	}
+
+	login.session = txResult.Session
+	return login, nil
+}
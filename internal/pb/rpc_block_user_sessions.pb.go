@@ -0,0 +1,210 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_block_user_sessions.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BlockUserSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *BlockUserSessionsRequest) Reset() {
+	*x = BlockUserSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_block_user_sessions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockUserSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserSessionsRequest) ProtoMessage() {}
+
+func (x *BlockUserSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_block_user_sessions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserSessionsRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_block_user_sessions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlockUserSessionsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type BlockUserSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockedSessions int64 `protobuf:"varint,1,opt,name=blocked_sessions,json=blockedSessions,proto3" json:"blocked_sessions,omitempty"`
+}
+
+func (x *BlockUserSessionsResponse) Reset() {
+	*x = BlockUserSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_block_user_sessions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockUserSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserSessionsResponse) ProtoMessage() {}
+
+func (x *BlockUserSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_block_user_sessions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserSessionsResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_block_user_sessions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockUserSessionsResponse) GetBlockedSessions() int64 {
+	if x != nil {
+		return x.BlockedSessions
+	}
+	return 0
+}
+
+var File_rpc_block_user_sessions_proto protoreflect.FileDescriptor
+
+var file_rpc_block_user_sessions_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x72, 0x70, 0x63, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x02, 0x70, 0x62, 0x22, 0x36, 0x0a, 0x18, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x46, 0x0a, 0x19, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x48, 0x6f, 0x6f, 0x64, 0x33, 0x30, 0x38, 0x32, 0x2f,
+	0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_block_user_sessions_proto_rawDescOnce sync.Once
+	file_rpc_block_user_sessions_proto_rawDescData = file_rpc_block_user_sessions_proto_rawDesc
+)
+
+func file_rpc_block_user_sessions_proto_rawDescGZIP() []byte {
+	file_rpc_block_user_sessions_proto_rawDescOnce.Do(func() {
+		file_rpc_block_user_sessions_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_block_user_sessions_proto_rawDescData)
+	})
+	return file_rpc_block_user_sessions_proto_rawDescData
+}
+
+var file_rpc_block_user_sessions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_block_user_sessions_proto_goTypes = []any{
+	(*BlockUserSessionsRequest)(nil),  // 0: pb.BlockUserSessionsRequest
+	(*BlockUserSessionsResponse)(nil), // 1: pb.BlockUserSessionsResponse
+}
+var file_rpc_block_user_sessions_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_block_user_sessions_proto_init() }
+func file_rpc_block_user_sessions_proto_init() {
+	if File_rpc_block_user_sessions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_block_user_sessions_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockUserSessionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_block_user_sessions_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockUserSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_block_user_sessions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_block_user_sessions_proto_goTypes,
+		DependencyIndexes: file_rpc_block_user_sessions_proto_depIdxs,
+		MessageInfos:      file_rpc_block_user_sessions_proto_msgTypes,
+	}.Build()
+	File_rpc_block_user_sessions_proto = out.File
+	file_rpc_block_user_sessions_proto_rawDesc = nil
+	file_rpc_block_user_sessions_proto_goTypes = nil
+	file_rpc_block_user_sessions_proto_depIdxs = nil
+}
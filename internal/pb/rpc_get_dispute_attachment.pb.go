@@ -0,0 +1,155 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_get_dispute_attachment.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetDisputeAttachmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DisputeId int64 `protobuf:"varint,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Id        int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetDisputeAttachmentRequest) Reset() {
+	*x = GetDisputeAttachmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_dispute_attachment_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDisputeAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDisputeAttachmentRequest) ProtoMessage() {}
+
+func (x *GetDisputeAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_dispute_attachment_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDisputeAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*GetDisputeAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_dispute_attachment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetDisputeAttachmentRequest) GetDisputeId() int64 {
+	if x != nil {
+		return x.DisputeId
+	}
+	return 0
+}
+
+func (x *GetDisputeAttachmentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_rpc_get_dispute_attachment_proto protoreflect.FileDescriptor
+
+var file_rpc_get_dispute_attachment_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x75, 0x74,
+	0x65, 0x5f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x22, 0x4c, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73,
+	0x70, 0x75, 0x74, 0x65, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x69, 0x73, 0x70, 0x75,
+	0x74, 0x65, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x48, 0x6f, 0x6f, 0x64, 0x33, 0x30, 0x38, 0x32,
+	0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_dispute_attachment_proto_rawDescOnce sync.Once
+	file_rpc_get_dispute_attachment_proto_rawDescData = file_rpc_get_dispute_attachment_proto_rawDesc
+)
+
+func file_rpc_get_dispute_attachment_proto_rawDescGZIP() []byte {
+	file_rpc_get_dispute_attachment_proto_rawDescOnce.Do(func() {
+		file_rpc_get_dispute_attachment_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_dispute_attachment_proto_rawDescData)
+	})
+	return file_rpc_get_dispute_attachment_proto_rawDescData
+}
+
+var file_rpc_get_dispute_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rpc_get_dispute_attachment_proto_goTypes = []any{
+	(*GetDisputeAttachmentRequest)(nil), // 0: pb.GetDisputeAttachmentRequest
+}
+var file_rpc_get_dispute_attachment_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_dispute_attachment_proto_init() }
+func file_rpc_get_dispute_attachment_proto_init() {
+	if File_rpc_get_dispute_attachment_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_get_dispute_attachment_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetDisputeAttachmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_dispute_attachment_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_dispute_attachment_proto_goTypes,
+		DependencyIndexes: file_rpc_get_dispute_attachment_proto_depIdxs,
+		MessageInfos:      file_rpc_get_dispute_attachment_proto_msgTypes,
+	}.Build()
+	File_rpc_get_dispute_attachment_proto = out.File
+	file_rpc_get_dispute_attachment_proto_rawDesc = nil
+	file_rpc_get_dispute_attachment_proto_goTypes = nil
+	file_rpc_get_dispute_attachment_proto_depIdxs = nil
+}
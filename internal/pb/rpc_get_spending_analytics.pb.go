@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_get_spending_analytics.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetSpendingAnalyticsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the account to analyse; all accounts of the logged in user are analysed if neither is set
+	AccountId     int64  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountNumber string `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	// the first and last month to analyse, given by any time within them. Defaults to the last 12 months.
+	FromMonth *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from_month,json=fromMonth,proto3" json:"from_month,omitempty"`
+	ToMonth   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to_month,json=toMonth,proto3" json:"to_month,omitempty"`
+}
+
+func (x *GetSpendingAnalyticsRequest) Reset() {
+	*x = GetSpendingAnalyticsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_spending_analytics_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSpendingAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSpendingAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetSpendingAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_spending_analytics_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpendingAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetSpendingAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_spending_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetSpendingAnalyticsRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *GetSpendingAnalyticsRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *GetSpendingAnalyticsRequest) GetFromMonth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromMonth
+	}
+	return nil
+}
+
+func (x *GetSpendingAnalyticsRequest) GetToMonth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToMonth
+	}
+	return nil
+}
+
+type GetSpendingAnalyticsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// totals per category within each month
+	Totals []*SpendingTotal `protobuf:"bytes,1,rep,name=totals,proto3" json:"totals,omitempty"`
+	// totals per category over the whole period
+	CategoryTotals []*SpendingTotal `protobuf:"bytes,2,rep,name=category_totals,json=categoryTotals,proto3" json:"category_totals,omitempty"`
+	// totals per month over every category
+	MonthTotals []*SpendingTotal `protobuf:"bytes,3,rep,name=month_totals,json=monthTotals,proto3" json:"month_totals,omitempty"`
+}
+
+func (x *GetSpendingAnalyticsResponse) Reset() {
+	*x = GetSpendingAnalyticsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_spending_analytics_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSpendingAnalyticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSpendingAnalyticsResponse) ProtoMessage() {}
+
+func (x *GetSpendingAnalyticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_spending_analytics_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpendingAnalyticsResponse.ProtoReflect.Descriptor instead.
+func (*GetSpendingAnalyticsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_spending_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSpendingAnalyticsResponse) GetTotals() []*SpendingTotal {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *GetSpendingAnalyticsResponse) GetCategoryTotals() []*SpendingTotal {
+	if x != nil {
+		return x.CategoryTotals
+	}
+	return nil
+}
+
+func (x *GetSpendingAnalyticsResponse) GetMonthTotals() []*SpendingTotal {
+	if x != nil {
+		return x.MonthTotals
+	}
+	return nil
+}
+
+var File_rpc_get_spending_analytics_proto protoreflect.FileDescriptor
+
+var file_rpc_get_spending_analytics_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x5f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0e, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd5, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x53,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x39, 0x0a,
+	0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66,
+	0x72, 0x6f, 0x6d, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x12, 0x35, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x6d,
+	0x6f, 0x6e, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x6f, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x22,
+	0xbb, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41,
+	0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x29, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x3a, 0x0a, 0x0f, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x0e, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x34, 0x0a, 0x0c, 0x6d, 0x6f, 0x6e, 0x74, 0x68,
+	0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x70, 0x62, 0x2e, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x52, 0x0b, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x42, 0x31, 0x5a,
+	0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69,
+	0x6e, 0x48, 0x6f, 0x6f, 0x64, 0x33, 0x30, 0x38, 0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_spending_analytics_proto_rawDescOnce sync.Once
+	file_rpc_get_spending_analytics_proto_rawDescData = file_rpc_get_spending_analytics_proto_rawDesc
+)
+
+func file_rpc_get_spending_analytics_proto_rawDescGZIP() []byte {
+	file_rpc_get_spending_analytics_proto_rawDescOnce.Do(func() {
+		file_rpc_get_spending_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_spending_analytics_proto_rawDescData)
+	})
+	return file_rpc_get_spending_analytics_proto_rawDescData
+}
+
+var file_rpc_get_spending_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_spending_analytics_proto_goTypes = []any{
+	(*GetSpendingAnalyticsRequest)(nil),  // 0: pb.GetSpendingAnalyticsRequest
+	(*GetSpendingAnalyticsResponse)(nil), // 1: pb.GetSpendingAnalyticsResponse
+	(*timestamppb.Timestamp)(nil),        // 2: google.protobuf.Timestamp
+	(*SpendingTotal)(nil),                // 3: pb.SpendingTotal
+}
+var file_rpc_get_spending_analytics_proto_depIdxs = []int32{
+	2, // 0: pb.GetSpendingAnalyticsRequest.from_month:type_name -> google.protobuf.Timestamp
+	2, // 1: pb.GetSpendingAnalyticsRequest.to_month:type_name -> google.protobuf.Timestamp
+	3, // 2: pb.GetSpendingAnalyticsResponse.totals:type_name -> pb.SpendingTotal
+	3, // 3: pb.GetSpendingAnalyticsResponse.category_totals:type_name -> pb.SpendingTotal
+	3, // 4: pb.GetSpendingAnalyticsResponse.month_totals:type_name -> pb.SpendingTotal
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_spending_analytics_proto_init() }
+func file_rpc_get_spending_analytics_proto_init() {
+	if File_rpc_get_spending_analytics_proto != nil {
+		return
+	}
+	file_category_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_get_spending_analytics_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSpendingAnalyticsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_get_spending_analytics_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSpendingAnalyticsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_spending_analytics_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_spending_analytics_proto_goTypes,
+		DependencyIndexes: file_rpc_get_spending_analytics_proto_depIdxs,
+		MessageInfos:      file_rpc_get_spending_analytics_proto_msgTypes,
+	}.Build()
+	File_rpc_get_spending_analytics_proto = out.File
+	file_rpc_get_spending_analytics_proto_rawDesc = nil
+	file_rpc_get_spending_analytics_proto_goTypes = nil
+	file_rpc_get_spending_analytics_proto_depIdxs = nil
+}
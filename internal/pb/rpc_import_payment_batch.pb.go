@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_import_payment_batch.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ImportPaymentBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the pain.001 XML file, base64 encoded in JSON
+	Document []byte `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+}
+
+func (x *ImportPaymentBatchRequest) Reset() {
+	*x = ImportPaymentBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_import_payment_batch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportPaymentBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPaymentBatchRequest) ProtoMessage() {}
+
+func (x *ImportPaymentBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_import_payment_batch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPaymentBatchRequest.ProtoReflect.Descriptor instead.
+func (*ImportPaymentBatchRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_import_payment_batch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ImportPaymentBatchRequest) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+type ImportPaymentBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaymentBatch *PaymentBatch `protobuf:"bytes,1,opt,name=payment_batch,json=paymentBatch,proto3" json:"payment_batch,omitempty"`
+	// every credit transfer of the file in order, with the reason it cannot be executed if it is invalid
+	Items []*PaymentBatchItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *ImportPaymentBatchResponse) Reset() {
+	*x = ImportPaymentBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_import_payment_batch_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportPaymentBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPaymentBatchResponse) ProtoMessage() {}
+
+func (x *ImportPaymentBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_import_payment_batch_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPaymentBatchResponse.ProtoReflect.Descriptor instead.
+func (*ImportPaymentBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_import_payment_batch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImportPaymentBatchResponse) GetPaymentBatch() *PaymentBatch {
+	if x != nil {
+		return x.PaymentBatch
+	}
+	return nil
+}
+
+func (x *ImportPaymentBatchResponse) GetItems() []*PaymentBatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_rpc_import_payment_batch_proto protoreflect.FileDescriptor
+
+var file_rpc_import_payment_batch_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x1a, 0x13, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x37, 0x0a, 0x19, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0x7f, 0x0a, 0x1a, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x35, 0x0a, 0x0d, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2a, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x48, 0x6f, 0x6f, 0x64, 0x33, 0x30, 0x38, 0x32, 0x2f,
+	0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_import_payment_batch_proto_rawDescOnce sync.Once
+	file_rpc_import_payment_batch_proto_rawDescData = file_rpc_import_payment_batch_proto_rawDesc
+)
+
+func file_rpc_import_payment_batch_proto_rawDescGZIP() []byte {
+	file_rpc_import_payment_batch_proto_rawDescOnce.Do(func() {
+		file_rpc_import_payment_batch_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_import_payment_batch_proto_rawDescData)
+	})
+	return file_rpc_import_payment_batch_proto_rawDescData
+}
+
+var file_rpc_import_payment_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_import_payment_batch_proto_goTypes = []any{
+	(*ImportPaymentBatchRequest)(nil),  // 0: pb.ImportPaymentBatchRequest
+	(*ImportPaymentBatchResponse)(nil), // 1: pb.ImportPaymentBatchResponse
+	(*PaymentBatch)(nil),               // 2: pb.PaymentBatch
+	(*PaymentBatchItem)(nil),           // 3: pb.PaymentBatchItem
+}
+var file_rpc_import_payment_batch_proto_depIdxs = []int32{
+	2, // 0: pb.ImportPaymentBatchResponse.payment_batch:type_name -> pb.PaymentBatch
+	3, // 1: pb.ImportPaymentBatchResponse.items:type_name -> pb.PaymentBatchItem
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_import_payment_batch_proto_init() }
+func file_rpc_import_payment_batch_proto_init() {
+	if File_rpc_import_payment_batch_proto != nil {
+		return
+	}
+	file_payment_batch_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_import_payment_batch_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ImportPaymentBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_import_payment_batch_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ImportPaymentBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_import_payment_batch_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_import_payment_batch_proto_goTypes,
+		DependencyIndexes: file_rpc_import_payment_batch_proto_depIdxs,
+		MessageInfos:      file_rpc_import_payment_batch_proto_msgTypes,
+	}.Build()
+	File_rpc_import_payment_batch_proto = out.File
+	file_rpc_import_payment_batch_proto_rawDesc = nil
+	file_rpc_import_payment_batch_proto_goTypes = nil
+	file_rpc_import_payment_batch_proto_depIdxs = nil
+}
@@ -0,0 +1,231 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_lookup_recipient.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LookupRecipientRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// a username, a verified email address or an @handle
+	Recipient string `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Currency  string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *LookupRecipientRequest) Reset() {
+	*x = LookupRecipientRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_lookup_recipient_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupRecipientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRecipientRequest) ProtoMessage() {}
+
+func (x *LookupRecipientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_lookup_recipient_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRecipientRequest.ProtoReflect.Descriptor instead.
+func (*LookupRecipientRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_lookup_recipient_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LookupRecipientRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *LookupRecipientRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type LookupRecipientResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the recipient's full name with everything but the first name reduced to initials
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Currency    string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *LookupRecipientResponse) Reset() {
+	*x = LookupRecipientResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_lookup_recipient_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupRecipientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRecipientResponse) ProtoMessage() {}
+
+func (x *LookupRecipientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_lookup_recipient_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRecipientResponse.ProtoReflect.Descriptor instead.
+func (*LookupRecipientResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_lookup_recipient_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LookupRecipientResponse) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *LookupRecipientResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+var File_rpc_lookup_recipient_proto protoreflect.FileDescriptor
+
+var file_rpc_lookup_recipient_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x5f, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62,
+	0x22, 0x52, 0x0a, 0x16, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x22, 0x58, 0x0a, 0x17, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x31,
+	0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x6f, 0x62,
+	0x69, 0x6e, 0x48, 0x6f, 0x6f, 0x64, 0x33, 0x30, 0x38, 0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_lookup_recipient_proto_rawDescOnce sync.Once
+	file_rpc_lookup_recipient_proto_rawDescData = file_rpc_lookup_recipient_proto_rawDesc
+)
+
+func file_rpc_lookup_recipient_proto_rawDescGZIP() []byte {
+	file_rpc_lookup_recipient_proto_rawDescOnce.Do(func() {
+		file_rpc_lookup_recipient_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_lookup_recipient_proto_rawDescData)
+	})
+	return file_rpc_lookup_recipient_proto_rawDescData
+}
+
+var file_rpc_lookup_recipient_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_lookup_recipient_proto_goTypes = []any{
+	(*LookupRecipientRequest)(nil),  // 0: pb.LookupRecipientRequest
+	(*LookupRecipientResponse)(nil), // 1: pb.LookupRecipientResponse
+}
+var file_rpc_lookup_recipient_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_lookup_recipient_proto_init() }
+func file_rpc_lookup_recipient_proto_init() {
+	if File_rpc_lookup_recipient_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_lookup_recipient_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*LookupRecipientRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_lookup_recipient_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*LookupRecipientResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_lookup_recipient_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_lookup_recipient_proto_goTypes,
+		DependencyIndexes: file_rpc_lookup_recipient_proto_depIdxs,
+		MessageInfos:      file_rpc_lookup_recipient_proto_msgTypes,
+	}.Build()
+	File_rpc_lookup_recipient_proto = out.File
+	file_rpc_lookup_recipient_proto_rawDesc = nil
+	file_rpc_lookup_recipient_proto_goTypes = nil
+	file_rpc_lookup_recipient_proto_depIdxs = nil
+}
@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_move_pocket_funds.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PocketMoveDirection int32
+
+const (
+	PocketMoveDirection_POCKET_MOVE_DIRECTION_UNSPECIFIED PocketMoveDirection = 0
+	// move money from the parent account into the pocket
+	PocketMoveDirection_POCKET_MOVE_DIRECTION_TO_POCKET PocketMoveDirection = 1
+	// move money from the pocket back to the parent account
+	PocketMoveDirection_POCKET_MOVE_DIRECTION_TO_ACCOUNT PocketMoveDirection = 2
+)
+
+// Enum value maps for PocketMoveDirection.
+var (
+	PocketMoveDirection_name = map[int32]string{
+		0: "POCKET_MOVE_DIRECTION_UNSPECIFIED",
+		1: "POCKET_MOVE_DIRECTION_TO_POCKET",
+		2: "POCKET_MOVE_DIRECTION_TO_ACCOUNT",
+	}
+	PocketMoveDirection_value = map[string]int32{
+		"POCKET_MOVE_DIRECTION_UNSPECIFIED": 0,
+		"POCKET_MOVE_DIRECTION_TO_POCKET":   1,
+		"POCKET_MOVE_DIRECTION_TO_ACCOUNT":  2,
+	}
+)
+
+func (x PocketMoveDirection) Enum() *PocketMoveDirection {
+	p := new(PocketMoveDirection)
+	*p = x
+	return p
+}
+
+func (x PocketMoveDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PocketMoveDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_move_pocket_funds_proto_enumTypes[0].Descriptor()
+}
+
+func (PocketMoveDirection) Type() protoreflect.EnumType {
+	return &file_rpc_move_pocket_funds_proto_enumTypes[0]
+}
+
+func (x PocketMoveDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PocketMoveDirection.Descriptor instead.
+func (PocketMoveDirection) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_move_pocket_funds_proto_rawDescGZIP(), []int{0}
+}
+
+type MovePocketFundsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PocketId  int64               `protobuf:"varint,1,opt,name=pocket_id,json=pocketId,proto3" json:"pocket_id,omitempty"`
+	Amount    *Money              `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Direction PocketMoveDirection `protobuf:"varint,3,opt,name=direction,proto3,enum=pb.PocketMoveDirection" json:"direction,omitempty"`
+}
+
+func (x *MovePocketFundsRequest) Reset() {
+	*x = MovePocketFundsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_move_pocket_funds_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovePocketFundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePocketFundsRequest) ProtoMessage() {}
+
+func (x *MovePocketFundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_move_pocket_funds_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePocketFundsRequest.ProtoReflect.Descriptor instead.
+func (*MovePocketFundsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_move_pocket_funds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MovePocketFundsRequest) GetPocketId() int64 {
+	if x != nil {
+		return x.PocketId
+	}
+	return 0
+}
+
+func (x *MovePocketFundsRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *MovePocketFundsRequest) GetDirection() PocketMoveDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return PocketMoveDirection_POCKET_MOVE_DIRECTION_UNSPECIFIED
+}
+
+type MovePocketFundsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pocket           *Pocket  `protobuf:"bytes,1,opt,name=pocket,proto3" json:"pocket,omitempty"`
+	Account          *Account `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	AvailableBalance *Money   `protobuf:"bytes,3,opt,name=available_balance,json=availableBalance,proto3" json:"available_balance,omitempty"`
+}
+
+func (x *MovePocketFundsResponse) Reset() {
+	*x = MovePocketFundsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_move_pocket_funds_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovePocketFundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePocketFundsResponse) ProtoMessage() {}
+
+func (x *MovePocketFundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_move_pocket_funds_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePocketFundsResponse.ProtoReflect.Descriptor instead.
+func (*MovePocketFundsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_move_pocket_funds_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MovePocketFundsResponse) GetPocket() *Pocket {
+	if x != nil {
+		return x.Pocket
+	}
+	return nil
+}
+
+func (x *MovePocketFundsResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *MovePocketFundsResponse) GetAvailableBalance() *Money {
+	if x != nil {
+		return x.AvailableBalance
+	}
+	return nil
+}
+
+var File_rpc_move_pocket_funds_proto protoreflect.FileDescriptor
+
+var file_rpc_move_pocket_funds_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x72, 0x70, 0x63, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x70, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70,
+	0x62, 0x1a, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x0b, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x70,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x01, 0x0a, 0x16,
+	0x4d, 0x6f, 0x76, 0x65, 0x50, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x75, 0x6e, 0x64, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x50,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9c, 0x01,
+	0x0a, 0x17, 0x4d, 0x6f, 0x76, 0x65, 0x50, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x75, 0x6e, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x06, 0x70, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x50,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x25, 0x0a,
+	0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x10, 0x61, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x2a, 0x87, 0x01, 0x0a,
+	0x13, 0x50, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x21, 0x50, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4d,
+	0x4f, 0x56, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x50,
+	0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x5f, 0x50, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x10, 0x01,
+	0x12, 0x24, 0x0a, 0x20, 0x50, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x5f,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x5f, 0x41, 0x43, 0x43,
+	0x4f, 0x55, 0x4e, 0x54, 0x10, 0x02, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x48, 0x6f, 0x6f, 0x64, 0x33, 0x30,
+	0x38, 0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_rpc_move_pocket_funds_proto_rawDescOnce sync.Once
+	file_rpc_move_pocket_funds_proto_rawDescData = file_rpc_move_pocket_funds_proto_rawDesc
+)
+
+func file_rpc_move_pocket_funds_proto_rawDescGZIP() []byte {
+	file_rpc_move_pocket_funds_proto_rawDescOnce.Do(func() {
+		file_rpc_move_pocket_funds_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_move_pocket_funds_proto_rawDescData)
+	})
+	return file_rpc_move_pocket_funds_proto_rawDescData
+}
+
+var file_rpc_move_pocket_funds_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rpc_move_pocket_funds_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_move_pocket_funds_proto_goTypes = []any{
+	(PocketMoveDirection)(0),        // 0: pb.PocketMoveDirection
+	(*MovePocketFundsRequest)(nil),  // 1: pb.MovePocketFundsRequest
+	(*MovePocketFundsResponse)(nil), // 2: pb.MovePocketFundsResponse
+	(*Money)(nil),                   // 3: pb.Money
+	(*Pocket)(nil),                  // 4: pb.Pocket
+	(*Account)(nil),                 // 5: pb.Account
+}
+var file_rpc_move_pocket_funds_proto_depIdxs = []int32{
+	3, // 0: pb.MovePocketFundsRequest.amount:type_name -> pb.Money
+	0, // 1: pb.MovePocketFundsRequest.direction:type_name -> pb.PocketMoveDirection
+	4, // 2: pb.MovePocketFundsResponse.pocket:type_name -> pb.Pocket
+	5, // 3: pb.MovePocketFundsResponse.account:type_name -> pb.Account
+	3, // 4: pb.MovePocketFundsResponse.available_balance:type_name -> pb.Money
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_rpc_move_pocket_funds_proto_init() }
+func file_rpc_move_pocket_funds_proto_init() {
+	if File_rpc_move_pocket_funds_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_money_proto_init()
+	file_pocket_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_move_pocket_funds_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*MovePocketFundsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_move_pocket_funds_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*MovePocketFundsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_move_pocket_funds_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_move_pocket_funds_proto_goTypes,
+		DependencyIndexes: file_rpc_move_pocket_funds_proto_depIdxs,
+		EnumInfos:         file_rpc_move_pocket_funds_proto_enumTypes,
+		MessageInfos:      file_rpc_move_pocket_funds_proto_msgTypes,
+	}.Build()
+	File_rpc_move_pocket_funds_proto = out.File
+	file_rpc_move_pocket_funds_proto_rawDesc = nil
+	file_rpc_move_pocket_funds_proto_goTypes = nil
+	file_rpc_move_pocket_funds_proto_depIdxs = nil
+}
@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_reject_held_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RejectHeldTransferRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the risk assessment of the held transfer
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RejectHeldTransferRequest) Reset() {
+	*x = RejectHeldTransferRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_reject_held_transfer_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectHeldTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectHeldTransferRequest) ProtoMessage() {}
+
+func (x *RejectHeldTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reject_held_transfer_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectHeldTransferRequest.ProtoReflect.Descriptor instead.
+func (*RejectHeldTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_reject_held_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RejectHeldTransferRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RejectHeldTransferResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Assessment *TransferRiskAssessment `protobuf:"bytes,1,opt,name=assessment,proto3" json:"assessment,omitempty"`
+}
+
+func (x *RejectHeldTransferResponse) Reset() {
+	*x = RejectHeldTransferResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_reject_held_transfer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectHeldTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectHeldTransferResponse) ProtoMessage() {}
+
+func (x *RejectHeldTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reject_held_transfer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectHeldTransferResponse.ProtoReflect.Descriptor instead.
+func (*RejectHeldTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_reject_held_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RejectHeldTransferResponse) GetAssessment() *TransferRiskAssessment {
+	if x != nil {
+		return x.Assessment
+	}
+	return nil
+}
+
+var File_rpc_reject_held_transfer_proto protoreflect.FileDescriptor
+
+var file_rpc_reject_held_transfer_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x68, 0x65, 0x6c,
+	0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x1a, 0x1e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x72,
+	0x69, 0x73, 0x6b, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x73, 0x73, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2b, 0x0a, 0x19, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x48, 0x65,
+	0x6c, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x58, 0x0a, 0x1a, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x48, 0x65, 0x6c, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3a, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x73, 0x73, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x69, 0x73, 0x6b, 0x41, 0x73, 0x73, 0x65, 0x73, 0x73, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x0a, 0x61, 0x73, 0x73, 0x65, 0x73, 0x73, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x31, 0x5a, 0x2f, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x48,
+	0x6f, 0x6f, 0x64, 0x33, 0x30, 0x38, 0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61,
+	0x6e, 0x6b, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_reject_held_transfer_proto_rawDescOnce sync.Once
+	file_rpc_reject_held_transfer_proto_rawDescData = file_rpc_reject_held_transfer_proto_rawDesc
+)
+
+func file_rpc_reject_held_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_reject_held_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_reject_held_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_reject_held_transfer_proto_rawDescData)
+	})
+	return file_rpc_reject_held_transfer_proto_rawDescData
+}
+
+var file_rpc_reject_held_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_reject_held_transfer_proto_goTypes = []any{
+	(*RejectHeldTransferRequest)(nil),  // 0: pb.RejectHeldTransferRequest
+	(*RejectHeldTransferResponse)(nil), // 1: pb.RejectHeldTransferResponse
+	(*TransferRiskAssessment)(nil),     // 2: pb.TransferRiskAssessment
+}
+var file_rpc_reject_held_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.RejectHeldTransferResponse.assessment:type_name -> pb.TransferRiskAssessment
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_reject_held_transfer_proto_init() }
+func file_rpc_reject_held_transfer_proto_init() {
+	if File_rpc_reject_held_transfer_proto != nil {
+		return
+	}
+	file_transfer_risk_assessment_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_reject_held_transfer_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*RejectHeldTransferRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_reject_held_transfer_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*RejectHeldTransferResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_reject_held_transfer_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_reject_held_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_reject_held_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_reject_held_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_reject_held_transfer_proto = out.File
+	file_rpc_reject_held_transfer_proto_rawDesc = nil
+	file_rpc_reject_held_transfer_proto_goTypes = nil
+	file_rpc_reject_held_transfer_proto_depIdxs = nil
+}
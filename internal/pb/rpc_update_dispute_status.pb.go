@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: rpc_update_dispute_status.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpdateDisputeStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// investigating, provisional_credit, resolved_for_customer or resolved_against_customer
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// sent to both parties of the transfer
+	Note string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *UpdateDisputeStatusRequest) Reset() {
+	*x = UpdateDisputeStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_update_dispute_status_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDisputeStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDisputeStatusRequest) ProtoMessage() {}
+
+func (x *UpdateDisputeStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_dispute_status_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDisputeStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDisputeStatusRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_update_dispute_status_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateDisputeStatusRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateDisputeStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateDisputeStatusRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type UpdateDisputeStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dispute *Dispute      `protobuf:"bytes,1,opt,name=dispute,proto3" json:"dispute,omitempty"`
+	Event   *DisputeEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	// set instead of moving the dispute when the move has to be approved by another banker
+	ApprovalRequest *ApprovalRequest `protobuf:"bytes,3,opt,name=approval_request,json=approvalRequest,proto3" json:"approval_request,omitempty"`
+}
+
+func (x *UpdateDisputeStatusResponse) Reset() {
+	*x = UpdateDisputeStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_update_dispute_status_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDisputeStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDisputeStatusResponse) ProtoMessage() {}
+
+func (x *UpdateDisputeStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_dispute_status_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDisputeStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDisputeStatusResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_update_dispute_status_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateDisputeStatusResponse) GetDispute() *Dispute {
+	if x != nil {
+		return x.Dispute
+	}
+	return nil
+}
+
+func (x *UpdateDisputeStatusResponse) GetEvent() *DisputeEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *UpdateDisputeStatusResponse) GetApprovalRequest() *ApprovalRequest {
+	if x != nil {
+		return x.ApprovalRequest
+	}
+	return nil
+}
+
+var File_rpc_update_dispute_status_proto protoreflect.FileDescriptor
+
+var file_rpc_update_dispute_status_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x72, 0x70, 0x63, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x69, 0x73,
+	0x70, 0x75, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x16, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0d, 0x64,
+	0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x58, 0x0a, 0x1a,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x22, 0xac, 0x01, 0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x70, 0x75, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x73,
+	0x70, 0x75, 0x74, 0x65, 0x52, 0x07, 0x64, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x12, 0x26, 0x0a,
+	0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x62, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x10, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61,
+	0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x0f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x48, 0x6f, 0x6f, 0x64, 0x33, 0x30, 0x38,
+	0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_update_dispute_status_proto_rawDescOnce sync.Once
+	file_rpc_update_dispute_status_proto_rawDescData = file_rpc_update_dispute_status_proto_rawDesc
+)
+
+func file_rpc_update_dispute_status_proto_rawDescGZIP() []byte {
+	file_rpc_update_dispute_status_proto_rawDescOnce.Do(func() {
+		file_rpc_update_dispute_status_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_update_dispute_status_proto_rawDescData)
+	})
+	return file_rpc_update_dispute_status_proto_rawDescData
+}
+
+var file_rpc_update_dispute_status_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_update_dispute_status_proto_goTypes = []any{
+	(*UpdateDisputeStatusRequest)(nil),  // 0: pb.UpdateDisputeStatusRequest
+	(*UpdateDisputeStatusResponse)(nil), // 1: pb.UpdateDisputeStatusResponse
+	(*Dispute)(nil),                     // 2: pb.Dispute
+	(*DisputeEvent)(nil),                // 3: pb.DisputeEvent
+	(*ApprovalRequest)(nil),             // 4: pb.ApprovalRequest
+}
+var file_rpc_update_dispute_status_proto_depIdxs = []int32{
+	2, // 0: pb.UpdateDisputeStatusResponse.dispute:type_name -> pb.Dispute
+	3, // 1: pb.UpdateDisputeStatusResponse.event:type_name -> pb.DisputeEvent
+	4, // 2: pb.UpdateDisputeStatusResponse.approval_request:type_name -> pb.ApprovalRequest
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rpc_update_dispute_status_proto_init() }
+func file_rpc_update_dispute_status_proto_init() {
+	if File_rpc_update_dispute_status_proto != nil {
+		return
+	}
+	file_approval_request_proto_init()
+	file_dispute_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_update_dispute_status_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateDisputeStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_update_dispute_status_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateDisputeStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_update_dispute_status_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_update_dispute_status_proto_goTypes,
+		DependencyIndexes: file_rpc_update_dispute_status_proto_depIdxs,
+		MessageInfos:      file_rpc_update_dispute_status_proto_msgTypes,
+	}.Build()
+	File_rpc_update_dispute_status_proto = out.File
+	file_rpc_update_dispute_status_proto_rawDesc = nil
+	file_rpc_update_dispute_status_proto_goTypes = nil
+	file_rpc_update_dispute_status_proto_depIdxs = nil
+}
@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: search.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchDirection int32
+
+const (
+	// money moving in either direction
+	SearchDirection_SEARCH_DIRECTION_UNSPECIFIED SearchDirection = 0
+	// money received by the searched accounts
+	SearchDirection_SEARCH_DIRECTION_INCOMING SearchDirection = 1
+	// money sent from the searched accounts
+	SearchDirection_SEARCH_DIRECTION_OUTGOING SearchDirection = 2
+)
+
+// Enum value maps for SearchDirection.
+var (
+	SearchDirection_name = map[int32]string{
+		0: "SEARCH_DIRECTION_UNSPECIFIED",
+		1: "SEARCH_DIRECTION_INCOMING",
+		2: "SEARCH_DIRECTION_OUTGOING",
+	}
+	SearchDirection_value = map[string]int32{
+		"SEARCH_DIRECTION_UNSPECIFIED": 0,
+		"SEARCH_DIRECTION_INCOMING":    1,
+		"SEARCH_DIRECTION_OUTGOING":    2,
+	}
+)
+
+func (x SearchDirection) Enum() *SearchDirection {
+	p := new(SearchDirection)
+	*p = x
+	return p
+}
+
+func (x SearchDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_search_proto_enumTypes[0].Descriptor()
+}
+
+func (SearchDirection) Type() protoreflect.EnumType {
+	return &file_search_proto_enumTypes[0]
+}
+
+func (x SearchDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchDirection.Descriptor instead.
+func (SearchDirection) EnumDescriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{0}
+}
+
+type SearchSortOrder int32
+
+const (
+	// same as SEARCH_SORT_ORDER_NEWEST_FIRST
+	SearchSortOrder_SEARCH_SORT_ORDER_UNSPECIFIED  SearchSortOrder = 0
+	SearchSortOrder_SEARCH_SORT_ORDER_NEWEST_FIRST SearchSortOrder = 1
+	SearchSortOrder_SEARCH_SORT_ORDER_OLDEST_FIRST SearchSortOrder = 2
+	SearchSortOrder_SEARCH_SORT_ORDER_AMOUNT_DESC  SearchSortOrder = 3
+	SearchSortOrder_SEARCH_SORT_ORDER_AMOUNT_ASC   SearchSortOrder = 4
+)
+
+// Enum value maps for SearchSortOrder.
+var (
+	SearchSortOrder_name = map[int32]string{
+		0: "SEARCH_SORT_ORDER_UNSPECIFIED",
+		1: "SEARCH_SORT_ORDER_NEWEST_FIRST",
+		2: "SEARCH_SORT_ORDER_OLDEST_FIRST",
+		3: "SEARCH_SORT_ORDER_AMOUNT_DESC",
+		4: "SEARCH_SORT_ORDER_AMOUNT_ASC",
+	}
+	SearchSortOrder_value = map[string]int32{
+		"SEARCH_SORT_ORDER_UNSPECIFIED":  0,
+		"SEARCH_SORT_ORDER_NEWEST_FIRST": 1,
+		"SEARCH_SORT_ORDER_OLDEST_FIRST": 2,
+		"SEARCH_SORT_ORDER_AMOUNT_DESC":  3,
+		"SEARCH_SORT_ORDER_AMOUNT_ASC":   4,
+	}
+)
+
+func (x SearchSortOrder) Enum() *SearchSortOrder {
+	p := new(SearchSortOrder)
+	*p = x
+	return p
+}
+
+func (x SearchSortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchSortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_search_proto_enumTypes[1].Descriptor()
+}
+
+func (SearchSortOrder) Type() protoreflect.EnumType {
+	return &file_search_proto_enumTypes[1]
+}
+
+func (x SearchSortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchSortOrder.Descriptor instead.
+func (SearchSortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{1}
+}
+
+var File_search_proto protoreflect.FileDescriptor
+
+var file_search_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x70, 0x62, 0x2a, 0x71, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x45, 0x41, 0x52, 0x43,
+	0x48, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x43, 0x4f,
+	0x4d, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48,
+	0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x55, 0x54, 0x47, 0x4f,
+	0x49, 0x4e, 0x47, 0x10, 0x02, 0x2a, 0xc1, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x41,
+	0x52, 0x43, 0x48, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e,
+	0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x5f, 0x4e, 0x45, 0x57, 0x45, 0x53, 0x54, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x01,
+	0x12, 0x22, 0x0a, 0x1e, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f,
+	0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x4f, 0x4c, 0x44, 0x45, 0x53, 0x54, 0x5f, 0x46, 0x49, 0x52,
+	0x53, 0x54, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x53,
+	0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54,
+	0x5f, 0x44, 0x45, 0x53, 0x43, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x45, 0x41, 0x52, 0x43,
+	0x48, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x41, 0x4d, 0x4f,
+	0x55, 0x4e, 0x54, 0x5f, 0x41, 0x53, 0x43, 0x10, 0x04, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x48, 0x6f, 0x6f,
+	0x64, 0x33, 0x30, 0x38, 0x32, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_search_proto_rawDescOnce sync.Once
+	file_search_proto_rawDescData = file_search_proto_rawDesc
+)
+
+func file_search_proto_rawDescGZIP() []byte {
+	file_search_proto_rawDescOnce.Do(func() {
+		file_search_proto_rawDescData = protoimpl.X.CompressGZIP(file_search_proto_rawDescData)
+	})
+	return file_search_proto_rawDescData
+}
+
+var file_search_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_search_proto_goTypes = []any{
+	(SearchDirection)(0), // 0: pb.SearchDirection
+	(SearchSortOrder)(0), // 1: pb.SearchSortOrder
+}
+var file_search_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_search_proto_init() }
+func file_search_proto_init() {
+	if File_search_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_search_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_search_proto_goTypes,
+		DependencyIndexes: file_search_proto_depIdxs,
+		EnumInfos:         file_search_proto_enumTypes,
+	}.Build()
+	File_search_proto = out.File
+	file_search_proto_rawDesc = nil
+	file_search_proto_goTypes = nil
+	file_search_proto_depIdxs = nil
+}
@@ -0,0 +1,141 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAccountMember(t *testing.T, account Account, role string) AccountMember {
+	user := createRandomUser(t)
+	arg := CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+		Role:      role,
+	}
+
+	member, err := testQueries.CreateAccountMember(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, member)
+
+	require.Equal(t, arg.AccountID, member.AccountID)
+	require.Equal(t, arg.Username, member.Username)
+	require.Equal(t, arg.Role, member.Role)
+	require.NotZero(t, member.CreatedAt)
+
+	return member
+}
+
+func TestCreateAccountMember(t *testing.T) {
+	account := createRandomAccount(t)
+	createRandomAccountMember(t, account, util.AccountCanTransferRole)
+}
+
+func TestGetAccountMember(t *testing.T) {
+	account := createRandomAccount(t)
+	member1 := createRandomAccountMember(t, account, util.AccountViewOnlyRole)
+
+	member2, err := testQueries.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member1.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, member1, member2)
+}
+
+func TestDeleteAccountMember(t *testing.T) {
+	account := createRandomAccount(t)
+	member := createRandomAccountMember(t, account, util.AccountViewOnlyRole)
+
+	err := testQueries.DeleteAccountMember(context.Background(), DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.EqualError(t, err, pgx.ErrNoRows.Error())
+}
+
+func TestListMemberAccounts(t *testing.T) {
+	account := createRandomAccount(t)
+	member := createRandomAccountMember(t, account, util.AccountOwnerRole)
+
+	accounts, err := testQueries.ListMemberAccounts(context.Background(), ListMemberAccountsParams{
+		Username: member.Username,
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	members, err := testQueries.ListAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+
+	owners, err := testQueries.CountAccountOwners(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), owners)
+}
+
+func TestRemoveLastAccountOwnerTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	owner := createRandomAccountMember(t, account, util.AccountOwnerRole)
+
+	_, err := store.RemoveAccountMemberTx(context.Background(), RemoveAccountMemberTxParams{
+		AccountID:   account.ID,
+		Username:    owner.Username,
+		AfterRemove: func(member AccountMember) error { return nil },
+	})
+	require.ErrorIs(t, err, ErrLastAccountOwner)
+}
+
+func TestAcceptAccountInvitationTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	invitee := createRandomUser(t)
+
+	invitation, err := testQueries.CreateAccountInvitation(context.Background(), CreateAccountInvitationParams{
+		AccountID: account.ID,
+		Username:  invitee.Username,
+		Role:      util.AccountViewOnlyRole,
+		InvitedBy: account.Owner,
+	})
+	require.NoError(t, err)
+	require.Empty(t, invitation.SecretCodeHash)
+
+	secretCode, err := util.GenerateToken()
+	require.NoError(t, err)
+
+	invitation, err = testQueries.SetAccountInvitationSecret(context.Background(), SetAccountInvitationSecretParams{
+		ID:             invitation.ID,
+		SecretCodeHash: util.HashToken(secretCode),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, secretCode, invitation.SecretCodeHash)
+
+	_, err = store.AcceptAccountInvitationTx(context.Background(), AcceptAccountInvitationTxParams{
+		InvitationID: invitation.ID,
+		SecretCode:   invitation.SecretCodeHash,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	result, err := store.AcceptAccountInvitationTx(context.Background(), AcceptAccountInvitationTxParams{
+		InvitationID: invitation.ID,
+		SecretCode:   secretCode,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Invitation.IsAccepted)
+	require.Equal(t, invitee.Username, result.Member.Username)
+	require.Equal(t, util.AccountViewOnlyRole, result.Member.Role)
+}
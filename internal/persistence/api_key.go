@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidApiKey       = errors.New("invalid API key")
+	ErrApiKeyAddressDenied = errors.New("API key cannot be used from this address")
+)
+
+type AuthenticateApiKeyParams struct {
+	Key      string
+	ClientIp string
+}
+
+type AuthenticateApiKeyResult struct {
+	ApiKey ApiKey
+	// Owner is read on every use, so the key acts with the current role of its owner
+	Owner User
+}
+
+// AuthenticateApiKey finds the key, checks that it is used from an allowed address and records its use.
+// Malformed, unknown and revoked keys are all reported as ErrInvalidApiKey.
+func (store *PgStore) AuthenticateApiKey(ctx context.Context, arg AuthenticateApiKeyParams) (AuthenticateApiKeyResult, error) {
+	var result AuthenticateApiKeyResult
+
+	prefix, ok := util.ParseAPIKeyPrefix(arg.Key)
+	if !ok {
+		return result, ErrInvalidApiKey
+	}
+
+	apiKey, err := store.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, ErrInvalidApiKey
+		}
+		return result, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if apiKey.RevokedAt.Valid || !util.CheckToken(arg.Key, apiKey.SecretHash) {
+		return result, ErrInvalidApiKey
+	}
+
+	if !util.IPAllowed(apiKey.AllowedIps, arg.ClientIp) {
+		return result, ErrApiKeyAddressDenied
+	}
+
+	result.Owner, err = store.GetUser(ctx, apiKey.Owner)
+	if err != nil {
+		return result, fmt.Errorf("failed to get API key owner: %w", err)
+	}
+
+	err = store.TouchApiKey(ctx, TouchApiKeyParams{
+		ID:         apiKey.ID,
+		LastUsedIp: pgtype.Text{String: clientHost(arg.ClientIp), Valid: true},
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to record API key use: %w", err)
+	}
+
+	result.ApiKey = apiKey
+	return result, nil
+}
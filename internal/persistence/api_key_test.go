@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomApiKey(t *testing.T, user User, allowedIps []string) (ApiKey, string) {
+	key, prefix, err := util.GenerateAPIKey()
+	require.NoError(t, err)
+
+	apiKey, err := testQueries.CreateApiKey(context.Background(), CreateApiKeyParams{
+		Owner:      user.Username,
+		Name:       util.RandomString(8),
+		Prefix:     prefix,
+		SecretHash: util.HashToken(key),
+		Scopes:     []string{util.ScopeAccountsRead},
+		AllowedIps: allowedIps,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, apiKey.Owner)
+	require.Equal(t, prefix, apiKey.Prefix)
+	require.Equal(t, []string{util.ScopeAccountsRead}, apiKey.Scopes)
+	require.False(t, apiKey.LastUsedAt.Valid)
+	require.False(t, apiKey.RevokedAt.Valid)
+
+	return apiKey, key
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	apiKey, key := createRandomApiKey(t, user, []string{})
+
+	result, err := store.AuthenticateApiKey(context.Background(), AuthenticateApiKeyParams{
+		Key:      key,
+		ClientIp: "192.0.2.10:4321",
+	})
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, result.ApiKey.ID)
+	require.Equal(t, user.Username, result.Owner.Username)
+	require.Equal(t, user.Role, result.Owner.Role)
+
+	apiKey, err = testQueries.GetApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.True(t, apiKey.LastUsedAt.Valid)
+	require.Equal(t, "192.0.2.10", apiKey.LastUsedIp.String)
+
+	apiKeys, err := testQueries.ListApiKeys(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 1)
+	require.Equal(t, apiKey.ID, apiKeys[0].ID)
+}
+
+func TestAuthenticateApiKeyInvalid(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	apiKey, key := createRandomApiKey(t, user, []string{})
+
+	// the right prefix with another secret
+	otherKey, otherPrefix, err := util.GenerateAPIKey()
+	require.NoError(t, err)
+	secret := otherKey[len(otherPrefix)+1:]
+
+	for _, invalidKey := range []string{"", "sbk_", apiKey.Prefix, apiKey.Prefix + "_" + secret, otherKey} {
+		_, err = store.AuthenticateApiKey(context.Background(), AuthenticateApiKeyParams{
+			Key:      invalidKey,
+			ClientIp: "192.0.2.10",
+		})
+		require.ErrorIs(t, err, ErrInvalidApiKey)
+	}
+
+	revoked, err := testQueries.RevokeApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	_, err = testQueries.RevokeApiKey(context.Background(), apiKey.ID)
+	require.Error(t, err)
+
+	_, err = store.AuthenticateApiKey(context.Background(), AuthenticateApiKeyParams{
+		Key:      key,
+		ClientIp: "192.0.2.10",
+	})
+	require.ErrorIs(t, err, ErrInvalidApiKey)
+}
+
+func TestAuthenticateApiKeyAllowlist(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	apiKey, key := createRandomApiKey(t, user, []string{"198.51.100.0/24", "2001:db8::1"})
+
+	for _, clientIp := range []string{"198.51.100.7:443", "[2001:db8::1]:443"} {
+		_, err := store.AuthenticateApiKey(context.Background(), AuthenticateApiKeyParams{
+			Key:      key,
+			ClientIp: clientIp,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := store.AuthenticateApiKey(context.Background(), AuthenticateApiKeyParams{
+		Key:      key,
+		ClientIp: "203.0.113.1:443",
+	})
+	require.ErrorIs(t, err, ErrApiKeyAddressDenied)
+
+	// uses from denied addresses are not recorded
+	apiKey, err = testQueries.GetApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.NotEqual(t, "203.0.113.1", apiKey.LastUsedIp.String)
+}
@@ -0,0 +1,214 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomApprovalRequest(t *testing.T, operation string, payload any, requester User, expiresAt time.Time) ApprovalRequest {
+	var scheduled []ApprovalRequest
+	arg := CreateApprovalRequestTxParams{
+		Operation:   operation,
+		Payload:     payload,
+		RequestedBy: requester.Username,
+		ExpiresAt:   expiresAt,
+		AfterCreate: func(request ApprovalRequest) error {
+			scheduled = append(scheduled, request)
+			return nil
+		},
+	}
+
+	result, err := NewStore(testDB).CreateApprovalRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	request := result.Request
+	require.NotZero(t, request.ID)
+	require.Equal(t, operation, request.Operation)
+	require.Equal(t, requester.Username, request.RequestedBy)
+	require.Equal(t, util.ApprovalPending, request.Status)
+	require.False(t, request.ReviewedBy.Valid)
+	require.WithinDuration(t, expiresAt, request.ExpiresAt.Time, time.Second)
+	require.Equal(t, []ApprovalRequest{request}, scheduled)
+
+	want, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.JSONEq(t, string(want), string(request.Payload))
+
+	return request
+}
+
+func TestApproveApprovalRequestTxAddAccountBalance(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+	maker := createRandomUser(t)
+	checker := createRandomUser(t)
+
+	deposit := AccountDeposit{
+		AccountID: account.ID,
+		Amount:    util.Money{Amount: 100, Currency: account.Currency},
+	}
+	request := createRandomApprovalRequest(t, util.ApprovalAddAccountBalance, deposit, maker, time.Now().Add(time.Hour))
+
+	// the banker who made the request cannot approve it
+	_, err := store.ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: maker.Username,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: checker.Username,
+		ReviewNote: "checked the deposit slip",
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.ApprovalApproved, result.Request.Status)
+	require.Equal(t, checker.Username, result.Request.ReviewedBy.String)
+	require.Equal(t, "checked the deposit slip", result.Request.ReviewNote)
+	require.WithinDuration(t, time.Now(), result.Request.ReviewedAt.Time, time.Second)
+	require.Equal(t, account.Balance+100, result.Account.Balance)
+
+	// the deposit is made exactly once
+	_, err = store.ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: checker.Username,
+	})
+	require.ErrorIs(t, err, ErrApprovalRequestNotPending)
+
+	account, err = testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Account.Balance, account.Balance)
+}
+
+func TestApproveApprovalRequestTxUpdateUserRole(t *testing.T) {
+	user := createRandomUser(t)
+	maker := createRandomUser(t)
+	checker := createRandomUser(t)
+
+	change := UserRoleChange{Username: user.Username, Role: util.BankerRole}
+	request := createRandomApprovalRequest(t, util.ApprovalUpdateUserRole, change, maker, time.Now().Add(time.Hour))
+
+	result, err := NewStore(testDB).ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: checker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.BankerRole, result.User.Role)
+}
+
+func TestApproveApprovalRequestTxUpdateAccountLimits(t *testing.T) {
+	account := createRandomAccount(t)
+	maker := createRandomUser(t)
+	checker := createRandomUser(t)
+
+	_, err := testQueries.GetAccountLimit(context.Background(), account.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	change := AccountLimitsChange{
+		AccountID:           account.ID,
+		MaxTransfersPerHour: pgtype.Int8{Int64: 50, Valid: true},
+	}
+	request := createRandomApprovalRequest(t, util.ApprovalUpdateAccountLimits, change, maker, time.Now().Add(time.Hour))
+
+	result, err := NewStore(testDB).ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: checker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, result.AccountLimit.AccountID)
+	require.Equal(t, account.AccountNumber, result.Account.AccountNumber)
+	require.Equal(t, change.MaxTransfersPerHour, result.AccountLimit.MaxTransfersPerHour)
+	require.False(t, result.AccountLimit.NewCounterpartyThreshold.Valid)
+	require.Equal(t, checker.Username, result.AccountLimit.UpdatedBy)
+
+	// a second change replaces the overrides
+	change.MaxTransfersPerHour = pgtype.Int8{}
+	change.NewBeneficiaryTransferLimit = pgtype.Int8{Int64: 100000, Valid: true}
+	request = createRandomApprovalRequest(t, util.ApprovalUpdateAccountLimits, change, maker, time.Now().Add(time.Hour))
+
+	_, err = NewStore(testDB).ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: checker.Username,
+	})
+	require.NoError(t, err)
+
+	limit, err := testQueries.GetAccountLimit(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.False(t, limit.MaxTransfersPerHour.Valid)
+	require.Equal(t, change.NewBeneficiaryTransferLimit, limit.NewBeneficiaryTransferLimit)
+}
+
+func TestApproveApprovalRequestTxUpdateDisputeStatus(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	dispute := openRandomDispute(t, fromAccount, toAccount).Dispute
+	maker := createRandomUser(t)
+	checker := createRandomUser(t)
+
+	_, err := moveDispute(t, dispute, util.DisputeInvestigating)
+	require.NoError(t, err)
+
+	change := DisputeStatusChange{DisputeID: dispute.ID, Status: util.DisputeProvisionalCredit, Note: "card skimmed"}
+	request := createRandomApprovalRequest(t, util.ApprovalUpdateDisputeStatus, change, maker, time.Now().Add(time.Hour))
+
+	result, err := NewStore(testDB).ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: checker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.DisputeProvisionalCredit, result.Dispute.Status)
+	require.True(t, result.Dispute.CreditEntryID.Valid)
+	require.Equal(t, maker.Username, result.DisputeEvent.Actor)
+	require.Equal(t, change.Note, result.DisputeEvent.Note)
+}
+
+func TestExpireApprovalRequest(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	maker := createRandomUser(t)
+	checker := createRandomUser(t)
+	change := UserRoleChange{Username: user.Username, Role: util.BankerRole}
+
+	// requests are not expired early
+	request := createRandomApprovalRequest(t, util.ApprovalUpdateUserRole, change, maker, time.Now().Add(time.Hour))
+	_, err := testQueries.ExpireApprovalRequest(context.Background(), request.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	request = createRandomApprovalRequest(t, util.ApprovalUpdateUserRole, change, maker, time.Now().Add(-time.Second))
+
+	_, err = store.ApproveApprovalRequestTx(context.Background(), ApproveApprovalRequestTxParams{
+		RequestID:  request.ID,
+		ReviewedBy: checker.Username,
+	})
+	require.ErrorIs(t, err, ErrApprovalRequestExpired)
+
+	expired, err := testQueries.ExpireApprovalRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.ApprovalExpired, expired.Status)
+
+	// an expired request cannot be rejected either
+	_, err = testQueries.ReviewApprovalRequest(context.Background(), ReviewApprovalRequestParams{
+		ID:         request.ID,
+		Status:     util.ApprovalRejected,
+		ReviewedBy: pgtype.Text{String: checker.Username, Valid: true},
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	user, err = testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, util.DepositorRole, user.Role)
+
+	pending, err := testQueries.ListApprovalRequests(context.Background(), ListApprovalRequestsParams{
+		Status: pgtype.Text{String: util.ApprovalExpired, Valid: true},
+		Limit:  1000,
+	})
+	require.NoError(t, err)
+	require.Contains(t, pending, expired)
+}
@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomBeneficiary(t *testing.T, owner User, account Account) Beneficiary {
+	arg := CreateBeneficiaryParams{
+		Owner:     owner.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: account.ID,
+		Currency:  account.Currency,
+	}
+
+	beneficiary, err := testQueries.CreateBeneficiary(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, beneficiary)
+
+	require.NotZero(t, beneficiary.ID)
+	require.Equal(t, arg.Owner, beneficiary.Owner)
+	require.Equal(t, arg.Nickname, beneficiary.Nickname)
+	require.Equal(t, arg.AccountID, beneficiary.AccountID)
+	require.Equal(t, arg.Currency, beneficiary.Currency)
+	require.NotZero(t, beneficiary.CreatedAt)
+
+	return beneficiary
+}
+
+func TestCreateBeneficiary(t *testing.T) {
+	createRandomBeneficiary(t, createRandomUser(t), createRandomAccount(t))
+}
+
+func TestGetBeneficiary(t *testing.T) {
+	beneficiary1 := createRandomBeneficiary(t, createRandomUser(t), createRandomAccount(t))
+
+	beneficiary2, err := testQueries.GetBeneficiary(context.Background(), beneficiary1.ID)
+	require.NoError(t, err)
+	require.Equal(t, beneficiary1.ID, beneficiary2.ID)
+	require.Equal(t, beneficiary1.Nickname, beneficiary2.Nickname)
+	require.WithinDuration(t, beneficiary1.CreatedAt.Time, beneficiary2.CreatedAt.Time, time.Second)
+}
+
+func TestGetBeneficiaryByAccount(t *testing.T) {
+	owner := createRandomUser(t)
+	account := createRandomAccount(t)
+	beneficiary1 := createRandomBeneficiary(t, owner, account)
+
+	beneficiary2, err := testQueries.GetBeneficiaryByAccount(context.Background(), GetBeneficiaryByAccountParams{
+		Owner:     owner.Username,
+		AccountID: account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, beneficiary1.ID, beneficiary2.ID)
+
+	// the account is not a beneficiary of another user
+	_, err = testQueries.GetBeneficiaryByAccount(context.Background(), GetBeneficiaryByAccountParams{
+		Owner:     createRandomUser(t).Username,
+		AccountID: account.ID,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestUpdateBeneficiary(t *testing.T) {
+	owner := createRandomUser(t)
+	beneficiary1 := createRandomBeneficiary(t, owner, createRandomAccount(t))
+
+	arg := UpdateBeneficiaryParams{
+		ID:       beneficiary1.ID,
+		Owner:    owner.Username,
+		Nickname: util.RandomOwner(),
+	}
+
+	beneficiary2, err := testQueries.UpdateBeneficiary(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Nickname, beneficiary2.Nickname)
+	require.Equal(t, beneficiary1.AccountID, beneficiary2.AccountID)
+
+	// another user cannot rename it
+	arg.Owner = createRandomUser(t).Username
+	_, err = testQueries.UpdateBeneficiary(context.Background(), arg)
+	require.EqualError(t, err, pgx.ErrNoRows.Error())
+}
+
+func TestDeleteBeneficiary(t *testing.T) {
+	owner := createRandomUser(t)
+	beneficiary := createRandomBeneficiary(t, owner, createRandomAccount(t))
+
+	deleted, err := testQueries.DeleteBeneficiary(context.Background(), DeleteBeneficiaryParams{
+		ID:    beneficiary.ID,
+		Owner: createRandomUser(t).Username,
+	})
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = testQueries.DeleteBeneficiary(context.Background(), DeleteBeneficiaryParams{
+		ID:    beneficiary.ID,
+		Owner: owner.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = testQueries.GetBeneficiary(context.Background(), beneficiary.ID)
+	require.EqualError(t, err, pgx.ErrNoRows.Error())
+}
+
+func TestListBeneficiaries(t *testing.T) {
+	owner := createRandomUser(t)
+	for range 3 {
+		createRandomBeneficiary(t, owner, createRandomAccount(t))
+	}
+
+	beneficiaries, err := testQueries.ListBeneficiaries(context.Background(), ListBeneficiariesParams{
+		Owner:  owner.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, beneficiaries, 3)
+
+	for _, beneficiary := range beneficiaries {
+		require.Equal(t, owner.Username, beneficiary.Beneficiary.Owner)
+		require.NotEmpty(t, beneficiary.AccountNumber)
+	}
+}
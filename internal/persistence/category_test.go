@@ -0,0 +1,199 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCategory(t *testing.T, owner string) Category {
+	arg := CreateCategoryParams{
+		Owner: owner,
+		Name:  util.RandomString(8),
+	}
+
+	category, err := testQueries.CreateCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Owner, category.Owner)
+	require.Equal(t, arg.Name, category.Name)
+	require.NotZero(t, category.ID)
+	require.NotZero(t, category.CreatedAt)
+
+	return category
+}
+
+func TestListCategories(t *testing.T) {
+	user := createRandomUser(t)
+	category1 := createRandomCategory(t, user.Username)
+	category2 := createRandomCategory(t, user.Username)
+	createRandomCategory(t, createRandomUser(t).Username)
+
+	categories, err := testQueries.ListCategories(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, categories, 2)
+	require.ElementsMatch(t, []int64{category1.ID, category2.ID}, []int64{categories[0].ID, categories[1].ID})
+
+	_, err = testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		Owner: user.Username,
+		Name:  category1.Name,
+	})
+	require.Error(t, err)
+}
+
+func TestApplyCategoryRules(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	member := createRandomAccountMember(t, account1, util.AccountViewOnlyRole)
+
+	rent := createRandomCategory(t, member.Username)
+	landlord := createRandomCategory(t, member.Username)
+
+	keywordRule, err := testQueries.CreateCategoryRule(context.Background(), CreateCategoryRuleParams{
+		Owner:      member.Username,
+		CategoryID: rent.ID,
+		Keyword:    pgtype.Text{String: "rent", Valid: true},
+	})
+	require.NoError(t, err)
+
+	counterpartyRule, err := testQueries.CreateCategoryRule(context.Background(), CreateCategoryRuleParams{
+		Owner:                 member.Username,
+		CategoryID:            landlord.ID,
+		CounterpartyAccountID: pgtype.Int8{Int64: account2.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	transfer := func(reference string) Transfer {
+		result, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        util.Money{Amount: 100, Currency: account1.Currency},
+			Reference:     reference,
+		})
+		require.NoError(t, err)
+		require.Equal(t, result.Transfer.ID, result.FromEntry.TransferID.Int64)
+		require.Equal(t, result.Transfer.ID, result.ToEntry.TransferID.Int64)
+		return result.Transfer
+	}
+
+	// both rules match, the oldest one wins
+	rentTransfer := transfer("March RENT")
+	// only the counterparty rule matches
+	otherTransfer := transfer(util.RandomString(12))
+
+	categorized, err := testQueries.ApplyCategoryRules(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, categorized, int64(2))
+
+	transferCategory, err := testQueries.GetTransferCategory(context.Background(), GetTransferCategoryParams{
+		TransferID: rentTransfer.ID,
+		Username:   member.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, rent.ID, transferCategory.CategoryID)
+	require.Equal(t, keywordRule.ID, transferCategory.RuleID.Int64)
+
+	transferCategory, err = testQueries.GetTransferCategory(context.Background(), GetTransferCategoryParams{
+		TransferID: otherTransfer.ID,
+		Username:   member.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, landlord.ID, transferCategory.CategoryID)
+	require.Equal(t, counterpartyRule.ID, transferCategory.RuleID.Int64)
+
+	// a category chosen by the user is kept when the rules run again
+	transferCategory, err = testQueries.SetTransferCategory(context.Background(), SetTransferCategoryParams{
+		TransferID: rentTransfer.ID,
+		Username:   member.Username,
+		CategoryID: landlord.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, transferCategory.RuleID.Valid)
+
+	_, err = testQueries.ApplyCategoryRules(context.Background())
+	require.NoError(t, err)
+
+	transferCategory, err = testQueries.GetTransferCategory(context.Background(), GetTransferCategoryParams{
+		TransferID: rentTransfer.ID,
+		Username:   member.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, landlord.ID, transferCategory.CategoryID)
+	require.False(t, transferCategory.RuleID.Valid)
+
+	rules, err := testQueries.ListCategoryRules(context.Background(), member.Username)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, keywordRule.ID, rules[0].CategoryRule.ID)
+	require.False(t, rules[0].CounterpartyAccountNumber.Valid)
+	require.Equal(t, account2.AccountNumber, rules[1].CounterpartyAccountNumber.String)
+}
+
+func TestListSpendingSummaries(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	sender := createRandomAccountMember(t, account1, util.AccountOwnerRole)
+	receiver := createRandomAccountMember(t, account2, util.AccountOwnerRole)
+	category := createRandomCategory(t, sender.Username)
+
+	var transferIDs []int64
+	for _, amount := range []int64{100, 250} {
+		result, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        util.Money{Amount: amount, Currency: account1.Currency},
+		})
+		require.NoError(t, err)
+		transferIDs = append(transferIDs, result.Transfer.ID)
+	}
+
+	_, err := testQueries.SetTransferCategory(context.Background(), SetTransferCategoryParams{
+		TransferID: transferIDs[0],
+		Username:   sender.Username,
+		CategoryID: category.ID,
+	})
+	require.NoError(t, err)
+
+	err = testQueries.RefreshSpendingSummaries(context.Background())
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	list := func(username string, accountID int64) []ListSpendingSummariesRow {
+		summaries, err := testQueries.ListSpendingSummaries(context.Background(), ListSpendingSummariesParams{
+			Username:   username,
+			AccountIds: []int64{accountID},
+			FromMonth:  pgtype.Date{Time: thisMonth.AddDate(0, -1, 0), Valid: true},
+			ToMonth:    pgtype.Date{Time: thisMonth.AddDate(0, 1, 0), Valid: true},
+		})
+		require.NoError(t, err)
+		return summaries
+	}
+
+	summaries := list(sender.Username, account1.ID)
+	require.Len(t, summaries, 2)
+	require.Zero(t, summaries[0].CategoryID)
+	require.Equal(t, int64(250), summaries[0].Outflow)
+	require.Equal(t, category.ID, summaries[1].CategoryID)
+	require.Equal(t, category.Name, summaries[1].CategoryName)
+	require.Equal(t, int64(100), summaries[1].Outflow)
+	require.Zero(t, summaries[1].Inflow)
+	require.Equal(t, account1.Currency, summaries[1].Currency)
+
+	// the receiver has not categorized anything
+	summaries = list(receiver.Username, account2.ID)
+	require.Len(t, summaries, 1)
+	require.Zero(t, summaries[0].CategoryID)
+	require.Equal(t, int64(350), summaries[0].Inflow)
+	require.Zero(t, summaries[0].Outflow)
+
+	// only members see the summaries of an account
+	require.Empty(t, list(sender.Username, account2.ID))
+}
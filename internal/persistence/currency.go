@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+// Catalogue returns the currency in the form cached by the util package
+func (currency Currency) Catalogue() util.Currency {
+	return util.Currency{
+		Code:     currency.Code,
+		Name:     currency.Name,
+		Exponent: int(currency.Exponent),
+		Enabled:  currency.IsEnabled,
+	}
+}
+
+// RefreshCurrencies reloads the currency catalogue cached by the util package from the database
+func (store *PgStore) RefreshCurrencies(ctx context.Context) error {
+	currencies, err := store.ListCurrencies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list currencies: %w", err)
+	}
+
+	catalogue := make([]util.Currency, len(currencies))
+	for i, currency := range currencies {
+		catalogue[i] = currency.Catalogue()
+	}
+
+	util.SetCurrencies(catalogue)
+	return nil
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCurrency(t *testing.T) Currency {
+	arg := CreateCurrencyParams{
+		Code:     strings.ToUpper(util.RandomString(3)),
+		Name:     util.RandomString(8),
+		Exponent: int32(util.RandomInt(0, 3)),
+	}
+
+	currency, err := testQueries.CreateCurrency(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Code, currency.Code)
+	require.Equal(t, arg.Name, currency.Name)
+	require.Equal(t, arg.Exponent, currency.Exponent)
+	require.True(t, currency.IsEnabled)
+	require.NotZero(t, currency.CreatedAt)
+
+	return currency
+}
+
+func TestRefreshCurrencies(t *testing.T) {
+	store := NewStore(testDB)
+	currency := createRandomCurrency(t)
+
+	err := store.RefreshCurrencies(context.Background())
+	require.NoError(t, err)
+	require.True(t, util.IsCurrencyEnabled(currency.Code))
+	require.True(t, util.IsCurrencyEnabled(util.USD))
+
+	disabled, err := testQueries.UpdateCurrencyEnabled(context.Background(), UpdateCurrencyEnabledParams{
+		Code:      currency.Code,
+		IsEnabled: false,
+	})
+	require.NoError(t, err)
+	require.False(t, disabled.IsEnabled)
+
+	err = store.RefreshCurrencies(context.Background())
+	require.NoError(t, err)
+	require.True(t, util.IsCurrencySupported(currency.Code))
+	require.False(t, util.IsCurrencyEnabled(currency.Code))
+
+	cached, ok := util.LookupCurrency(currency.Code)
+	require.True(t, ok)
+	require.Equal(t, disabled.Catalogue(), cached)
+}
@@ -0,0 +1,178 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func openRandomDispute(t *testing.T, fromAccount, toAccount Account) OpenDisputeTxResult {
+	transfer := createRandomTransfer(t, fromAccount, toAccount)
+
+	var notified []DisputeEvent
+	arg := OpenDisputeTxParams{
+		CreateDisputeParams: CreateDisputeParams{
+			TransferID: transfer.ID,
+			AccountID:  fromAccount.ID,
+			OpenedBy:   fromAccount.Owner,
+			Reason:     util.RandomString(20),
+			Amount:     transfer.Amount,
+			Currency:   fromAccount.Currency,
+		},
+		Attachments: []CreateDisputeAttachmentParams{
+			{
+				FileName:    "receipt.pdf",
+				ContentType: "application/pdf",
+				Content:     []byte("%PDF-1.4"),
+			},
+		},
+		AfterOpen: func(dispute Dispute, event DisputeEvent) error {
+			notified = append(notified, event)
+			return nil
+		},
+	}
+
+	result, err := NewStore(testDB).OpenDisputeTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	dispute := result.Dispute
+	require.NotZero(t, dispute.ID)
+	require.Equal(t, transfer.ID, dispute.TransferID)
+	require.Equal(t, fromAccount.ID, dispute.AccountID)
+	require.Equal(t, arg.OpenedBy, dispute.OpenedBy)
+	require.Equal(t, arg.Reason, dispute.Reason)
+	require.Equal(t, util.DisputeOpen, dispute.Status)
+	require.False(t, dispute.CreditEntryID.Valid)
+	require.False(t, dispute.ResolvedAt.Valid)
+
+	require.Len(t, result.Attachments, 1)
+	require.Equal(t, dispute.ID, result.Attachments[0].DisputeID)
+	require.Equal(t, arg.Attachments[0].Content, result.Attachments[0].Content)
+
+	require.Equal(t, util.DisputeOpen, result.Event.Status)
+	require.Equal(t, arg.OpenedBy, result.Event.Actor)
+	require.Equal(t, []DisputeEvent{result.Event}, notified)
+
+	return result
+}
+
+func moveDispute(t *testing.T, dispute Dispute, status string) (UpdateDisputeStatusTxResult, error) {
+	return NewStore(testDB).UpdateDisputeStatusTx(context.Background(), UpdateDisputeStatusTxParams{
+		DisputeID: dispute.ID,
+		Status:    status,
+		Actor:     "banker",
+		Note:      util.RandomString(10),
+		AfterUpdate: func(dispute Dispute, event DisputeEvent) error {
+			return nil
+		},
+	})
+}
+
+func TestOpenDisputeTx(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	opened := openRandomDispute(t, fromAccount, toAccount)
+
+	attachments, err := testQueries.ListDisputeAttachments(context.Background(), opened.Dispute.ID)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "receipt.pdf", attachments[0].FileName)
+	require.Equal(t, int64(len("%PDF-1.4")), attachments[0].Size)
+
+	// a transfer can only be disputed once
+	_, err = NewStore(testDB).OpenDisputeTx(context.Background(), OpenDisputeTxParams{
+		CreateDisputeParams: CreateDisputeParams{
+			TransferID: opened.Dispute.TransferID,
+			AccountID:  fromAccount.ID,
+			OpenedBy:   fromAccount.Owner,
+			Reason:     util.RandomString(20),
+			Amount:     opened.Dispute.Amount,
+			Currency:   fromAccount.Currency,
+		},
+		AfterOpen: func(dispute Dispute, event DisputeEvent) error {
+			return nil
+		},
+	})
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, pgerrcode.UniqueViolation, pgErr.Code)
+}
+
+func TestUpdateDisputeStatusTxProvisionalCredit(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	dispute := openRandomDispute(t, fromAccount, toAccount).Dispute
+
+	// a dispute has to be investigated before it is credited
+	_, err := moveDispute(t, dispute, util.DisputeProvisionalCredit)
+	require.ErrorIs(t, err, ErrInvalidDisputeTransition)
+
+	_, err = moveDispute(t, dispute, util.DisputeInvestigating)
+	require.NoError(t, err)
+
+	result, err := moveDispute(t, dispute, util.DisputeProvisionalCredit)
+	require.NoError(t, err)
+	require.True(t, result.Dispute.CreditEntryID.Valid)
+
+	credit, err := testQueries.GetEntry(context.Background(), result.Dispute.CreditEntryID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.ID, credit.AccountID)
+	require.Equal(t, dispute.Amount, credit.Amount)
+
+	// resolving against the customer takes the provisional credit back
+	result, err = moveDispute(t, dispute, util.DisputeResolvedAgainstCustomer)
+	require.NoError(t, err)
+	require.True(t, result.Dispute.ReversalEntryID.Valid)
+	require.False(t, result.Dispute.ChargebackEntryID.Valid)
+	require.WithinDuration(t, time.Now(), result.Dispute.ResolvedAt.Time, time.Second)
+
+	reversal, err := testQueries.GetEntry(context.Background(), result.Dispute.ReversalEntryID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, -dispute.Amount, reversal.Amount)
+
+	account, err := testQueries.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance, account.Balance)
+
+	events, err := testQueries.ListDisputeEvents(context.Background(), dispute.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.Equal(t, util.DisputeResolvedAgainstCustomer, events[3].Status)
+
+	// a resolved dispute cannot move anymore
+	_, err = moveDispute(t, dispute, util.DisputeInvestigating)
+	require.ErrorIs(t, err, ErrInvalidDisputeTransition)
+}
+
+func TestUpdateDisputeStatusTxResolvedForCustomer(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	dispute := openRandomDispute(t, fromAccount, toAccount).Dispute
+
+	_, err := moveDispute(t, dispute, util.DisputeInvestigating)
+	require.NoError(t, err)
+
+	result, err := moveDispute(t, dispute, util.DisputeResolvedForCustomer)
+	require.NoError(t, err)
+	require.True(t, result.Dispute.CreditEntryID.Valid)
+	require.True(t, result.Dispute.ChargebackEntryID.Valid)
+	require.False(t, result.Dispute.ReversalEntryID.Valid)
+
+	chargeback, err := testQueries.GetEntry(context.Background(), result.Dispute.ChargebackEntryID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, toAccount.ID, chargeback.AccountID)
+	require.Equal(t, -dispute.Amount, chargeback.Amount)
+
+	account, err := testQueries.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance+dispute.Amount, account.Balance)
+
+	account, err = testQueries.GetAccount(context.Background(), toAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, toAccount.Balance-dispute.Amount, account.Balance)
+}
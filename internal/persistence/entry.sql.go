@@ -7,36 +7,72 @@ package persistence
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3
+) RETURNING id, account_id, amount, created_at, pocket_id, transfer_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID  int64       `json:"account_id"`
+	Amount     int64       `json:"amount"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount, arg.TransferID)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.PocketID,
+		&i.TransferID,
+	)
+	return i, err
+}
+
+const createPocketEntry = `-- name: CreatePocketEntry :one
+INSERT INTO entries (
+  account_id,
+  pocket_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) RETURNING id, account_id, amount, created_at, pocket_id, transfer_id
+`
+
+type CreatePocketEntryParams struct {
+	AccountID int64       `json:"account_id"`
+	PocketID  pgtype.Int8 `json:"pocket_id"`
+	Amount    int64       `json:"amount"`
+}
+
+func (q *Queries) CreatePocketEntry(ctx context.Context, arg CreatePocketEntryParams) (Entry, error) {
+	row := q.db.QueryRow(ctx, createPocketEntry, arg.AccountID, arg.PocketID, arg.Amount)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.PocketID,
+		&i.TransferID,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, pocket_id, transfer_id FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -48,12 +84,44 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.PocketID,
+		&i.TransferID,
 	)
 	return i, err
 }
 
+const getStatementBalances = `-- name: GetStatementBalances :one
+SELECT
+    (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= $1), 0))::bigint AS opening_balance,
+    (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= $2), 0))::bigint AS closing_balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= $1
+WHERE a.id = $3
+GROUP BY a.id
+`
+
+type GetStatementBalancesParams struct {
+	FromTime  pgtype.Timestamptz `json:"from_time"`
+	ToTime    pgtype.Timestamptz `json:"to_time"`
+	AccountID int64              `json:"account_id"`
+}
+
+type GetStatementBalancesRow struct {
+	OpeningBalance int64 `json:"opening_balance"`
+	ClosingBalance int64 `json:"closing_balance"`
+}
+
+// GetStatementBalances works the balances of an account at from_time and to_time back from its current balance
+// through the entries booked since. Both are read in one statement so they agree with each other.
+func (q *Queries) GetStatementBalances(ctx context.Context, arg GetStatementBalancesParams) (GetStatementBalancesRow, error) {
+	row := q.db.QueryRow(ctx, getStatementBalances, arg.FromTime, arg.ToTime, arg.AccountID)
+	var i GetStatementBalancesRow
+	err := row.Scan(&i.OpeningBalance, &i.ClosingBalance)
+	return i, err
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, pocket_id, transfer_id FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
@@ -80,6 +148,172 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.PocketID,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT
+    e.id,
+    e.amount,
+    e.created_at,
+    e.transfer_id,
+    COALESCE(t.reference, '')::varchar AS reference,
+    COALESCE(c.account_number, '')::varchar AS counterparty_account_number
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts c ON c.id = CASE WHEN e.amount < 0 THEN t.to_account_id ELSE t.from_account_id END
+WHERE
+    e.account_id = $1
+    AND e.created_at >= $2
+    AND e.created_at < $3
+    AND e.id > $4
+ORDER BY e.id
+LIMIT $5
+`
+
+type ListStatementEntriesParams struct {
+	AccountID int64              `json:"account_id"`
+	FromTime  pgtype.Timestamptz `json:"from_time"`
+	ToTime    pgtype.Timestamptz `json:"to_time"`
+	AfterID   int64              `json:"after_id"`
+	Limit     int32              `json:"limit"`
+}
+
+type ListStatementEntriesRow struct {
+	ID                        int64              `json:"id"`
+	Amount                    int64              `json:"amount"`
+	CreatedAt                 pgtype.Timestamptz `json:"created_at"`
+	TransferID                pgtype.Int8        `json:"transfer_id"`
+	Reference                 string             `json:"reference"`
+	CounterpartyAccountNumber string             `json:"counterparty_account_number"`
+}
+
+// ListStatementEntries pages through the entries of an account booked in [from_time, to_time), in booking order,
+// together with the transfer each one is part of. Pass the last entry ID seen as after_id to get the next page.
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listStatementEntries,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.Reference,
+			&i.CounterpartyAccountNumber,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchEntries = `-- name: SearchEntries :many
+SELECT
+    e.id, e.account_id, e.amount, e.created_at, e.pocket_id, e.transfer_id,
+    a.account_number,
+    a.currency
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+WHERE
+    e.account_id = ANY($1::bigint[])
+    AND (
+        $2::varchar = ''
+        OR ($2::varchar = 'incoming' AND e.amount > 0)
+        OR ($2::varchar = 'outgoing' AND e.amount < 0)
+    )
+    AND ($3::timestamptz IS NULL OR e.created_at >= $3)
+    AND ($4::timestamptz IS NULL OR e.created_at < $4)
+    AND ($5::bigint IS NULL OR abs(e.amount) >= $5)
+    AND ($6::bigint IS NULL OR abs(e.amount) <= $6)
+ORDER BY
+    CASE WHEN $7::varchar = 'amount_asc' THEN abs(e.amount) END ASC,
+    CASE WHEN $7::varchar = 'amount_desc' THEN abs(e.amount) END DESC,
+    CASE WHEN $7::varchar = 'oldest' THEN e.created_at END ASC,
+    e.created_at DESC,
+    e.id DESC
+LIMIT $8
+OFFSET $9
+`
+
+type SearchEntriesParams struct {
+	AccountIds    []int64            `json:"account_ids"`
+	Direction     string             `json:"direction"`
+	CreatedAfter  pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `json:"created_before"`
+	MinAmount     pgtype.Int8        `json:"min_amount"`
+	MaxAmount     pgtype.Int8        `json:"max_amount"`
+	Sort          string             `json:"sort"`
+	Limit         int32              `json:"limit"`
+	Offset        int32              `json:"offset"`
+}
+
+type SearchEntriesRow struct {
+	ID            int64              `json:"id"`
+	AccountID     int64              `json:"account_id"`
+	Amount        int64              `json:"amount"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	PocketID      pgtype.Int8        `json:"pocket_id"`
+	TransferID    pgtype.Int8        `json:"transfer_id"`
+	AccountNumber string             `json:"account_number"`
+	Currency      string             `json:"currency"`
+}
+
+// SearchEntries finds entries of the given accounts. Filters left NULL match every entry.
+// Amount filters and sorting use the absolute amount, so they work the same for both directions.
+func (q *Queries) SearchEntries(ctx context.Context, arg SearchEntriesParams) ([]SearchEntriesRow, error) {
+	rows, err := q.db.Query(ctx, searchEntries,
+		arg.AccountIds,
+		arg.Direction,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchEntriesRow{}
+	for rows.Next() {
+		var i SearchEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.PocketID,
+			&i.TransferID,
+			&i.AccountNumber,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
@@ -68,3 +69,111 @@ func TestListEntries(t *testing.T) {
 		require.Equal(t, account.ID, entry.AccountID)
 	}
 }
+
+func TestSearchEntries(t *testing.T) {
+	account := createRandomAccount(t)
+
+	deposit, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{AccountID: account.ID, Amount: 500})
+	require.NoError(t, err)
+	withdrawal, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{AccountID: account.ID, Amount: -200})
+	require.NoError(t, err)
+
+	search := func(arg SearchEntriesParams) []SearchEntriesRow {
+		arg.AccountIds = []int64{account.ID}
+		arg.Limit = 10
+		entries, err := testQueries.SearchEntries(context.Background(), arg)
+		require.NoError(t, err)
+		return entries
+	}
+
+	entries := search(SearchEntriesParams{})
+	require.Len(t, entries, 2)
+	require.Equal(t, withdrawal.ID, entries[0].ID)
+	require.Equal(t, account.AccountNumber, entries[0].AccountNumber)
+	require.Equal(t, account.Currency, entries[0].Currency)
+
+	entries = search(SearchEntriesParams{Direction: util.SearchDirectionIncoming})
+	require.Len(t, entries, 1)
+	require.Equal(t, deposit.ID, entries[0].ID)
+
+	entries = search(SearchEntriesParams{Direction: util.SearchDirectionOutgoing})
+	require.Len(t, entries, 1)
+	require.Equal(t, withdrawal.ID, entries[0].ID)
+
+	entries = search(SearchEntriesParams{MinAmount: pgtype.Int8{Int64: 300, Valid: true}})
+	require.Len(t, entries, 1)
+	require.Equal(t, deposit.ID, entries[0].ID)
+
+	entries = search(SearchEntriesParams{Sort: util.SearchSortAmountAsc})
+	require.Len(t, entries, 2)
+	require.Equal(t, withdrawal.ID, entries[0].ID)
+}
+
+func TestListStatementEntries(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	from := time.Now().Add(-time.Minute)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        util.Money{Amount: 10, Currency: account1.Currency},
+		Reference:     "rent",
+	})
+	require.NoError(t, err)
+
+	deposit, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{AccountID: account1.ID, Amount: 500})
+	require.NoError(t, err)
+
+	arg := ListStatementEntriesParams{
+		AccountID: account1.ID,
+		FromTime:  pgtype.Timestamptz{Time: from, Valid: true},
+		ToTime:    pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+		Limit:     1,
+	}
+
+	entries, err := testQueries.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, result.FromEntry.ID, entries[0].ID)
+	require.Equal(t, int64(-10), entries[0].Amount)
+	require.Equal(t, result.Transfer.ID, entries[0].TransferID.Int64)
+	require.Equal(t, "rent", entries[0].Reference)
+	require.Equal(t, account2.AccountNumber, entries[0].CounterpartyAccountNumber)
+
+	arg.AfterID = entries[0].ID
+	entries, err = testQueries.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, deposit.ID, entries[0].ID)
+	require.False(t, entries[0].TransferID.Valid)
+	require.Empty(t, entries[0].Reference)
+	require.Empty(t, entries[0].CounterpartyAccountNumber)
+
+	arg.AfterID = entries[0].ID
+	entries, err = testQueries.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestGetStatementBalances(t *testing.T) {
+	account := createRandomAccount(t)
+
+	from := time.Now().Add(-time.Minute)
+	_, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{AccountID: account.ID, Amount: 500})
+	require.NoError(t, err)
+	to := time.Now()
+	_, err = testQueries.CreateEntry(context.Background(), CreateEntryParams{AccountID: account.ID, Amount: -200})
+	require.NoError(t, err)
+
+	balances, err := testQueries.GetStatementBalances(context.Background(), GetStatementBalancesParams{
+		FromTime:  pgtype.Timestamptz{Time: from, Valid: true},
+		ToTime:    pgtype.Timestamptz{Time: to, Valid: true},
+		AccountID: account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-300, balances.OpeningBalance)
+	require.Equal(t, account.Balance+200, balances.ClosingBalance)
+}
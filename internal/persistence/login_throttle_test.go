@@ -0,0 +1,197 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+var testLoginPolicy = LoginPolicy{
+	MaxFailedAttempts:      3,
+	MaxFailedAttemptsPerIP: 100,
+	FailureWindow:          15 * time.Minute,
+	BaseDelay:              time.Minute,
+	LockoutDuration:        30 * time.Minute,
+}
+
+func randomClientIP() string {
+	return fmt.Sprintf("10.%d.%d.%d", util.RandomInt(0, 255), util.RandomInt(0, 255), util.RandomInt(0, 255))
+}
+
+func recordFailedLogin(t *testing.T, username string, clientIP string, policy LoginPolicy) RecordFailedLoginTxResult {
+	result, err := NewStore(testDB).RecordFailedLoginTx(context.Background(), RecordFailedLoginTxParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   policy,
+	})
+	require.NoError(t, err)
+	require.Equal(t, username, result.Throttle.Username)
+
+	return result
+}
+
+func TestRecordFailedLoginTx(t *testing.T) {
+	store := NewStore(testDB)
+	// unknown usernames are throttled like existing ones
+	username := util.RandomOwner()
+	clientIP := randomClientIP()
+
+	retryAfter, err := store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   testLoginPolicy,
+	})
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	result := recordFailedLogin(t, username, clientIP+":50312", testLoginPolicy)
+	require.Equal(t, int32(1), result.Throttle.FailedAttempts)
+	require.False(t, result.Locked)
+
+	// every failed login doubles the wait before the next attempt
+	retryAfter, err = store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   testLoginPolicy,
+	})
+	require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+	require.InDelta(t, time.Minute, retryAfter, float64(time.Second))
+
+	result = recordFailedLogin(t, username, clientIP, testLoginPolicy)
+	require.Equal(t, int32(2), result.Throttle.FailedAttempts)
+
+	retryAfter, err = store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   testLoginPolicy,
+	})
+	require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+	require.InDelta(t, 2*time.Minute, retryAfter, float64(time.Second))
+
+	result = recordFailedLogin(t, username, clientIP, testLoginPolicy)
+	require.True(t, result.Locked)
+	require.Zero(t, result.Throttle.FailedAttempts)
+	require.WithinDuration(t, time.Now().Add(testLoginPolicy.LockoutDuration), result.Throttle.LockedUntil.Time, time.Second)
+
+	retryAfter, err = store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   testLoginPolicy,
+	})
+	require.ErrorIs(t, err, ErrLoginLocked)
+	require.InDelta(t, testLoginPolicy.LockoutDuration, retryAfter, float64(time.Second))
+
+	// unlocking forgets the failed logins
+	deleted, err := testQueries.DeleteLoginThrottle(context.Background(), username)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+		Username: username,
+		ClientIp: clientIP,
+		Policy:   testLoginPolicy,
+	})
+	require.NoError(t, err)
+}
+
+func TestCheckLoginAttemptPerIP(t *testing.T) {
+	store := NewStore(testDB)
+	clientIP := randomClientIP()
+	policy := testLoginPolicy
+	policy.MaxFailedAttemptsPerIP = 3
+
+	// the port changes with every connection but the failed logins are counted together
+	for i := 0; i < 3; i++ {
+		recordFailedLogin(t, util.RandomOwner(), fmt.Sprintf("%s:%d", clientIP, 50000+i), policy)
+	}
+
+	retryAfter, err := store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+		Username: util.RandomOwner(),
+		ClientIp: clientIP + ":60000",
+		Policy:   policy,
+	})
+	require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+	require.Equal(t, policy.FailureWindow, retryAfter)
+
+	_, err = store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+		Username: util.RandomOwner(),
+		ClientIp: randomClientIP(),
+		Policy:   policy,
+	})
+	require.NoError(t, err)
+}
+
+func TestCheckLoginAttemptConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	username := util.RandomOwner()
+
+	// concurrent logins of a username are let through one at a time, before any of them has failed
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.CheckLoginAttempt(context.Background(), CheckLoginAttemptParams{
+				Username: username,
+				ClientIp: randomClientIP(),
+				Policy:   testLoginPolicy,
+			})
+			errs <- err
+		}()
+	}
+
+	allowed := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			allowed++
+			continue
+		}
+		require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+	}
+	require.Equal(t, 1, allowed)
+}
+
+func TestPruneFailedLogins(t *testing.T) {
+	store := NewStore(testDB)
+	username := util.RandomOwner()
+	clientIP := randomClientIP()
+	recordFailedLogin(t, username, clientIP, testLoginPolicy)
+
+	// failed logins within the window are kept
+	err := store.PruneFailedLogins(context.Background(), testLoginPolicy)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetLoginThrottle(context.Background(), username)
+	require.NoError(t, err)
+
+	policy := testLoginPolicy
+	policy.FailureWindow = -time.Second
+	err = store.PruneFailedLogins(context.Background(), policy)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetLoginThrottle(context.Background(), username)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	failures, err := testQueries.CountFailedLoginsFromIP(context.Background(), CountFailedLoginsFromIPParams{
+		ClientIp: clientIP,
+		Since:    pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Zero(t, failures)
+}
+
+func TestClientHost(t *testing.T) {
+	require.Equal(t, "10.0.0.1", clientHost("10.0.0.1:50312"))
+	require.Equal(t, "10.0.0.1", clientHost("10.0.0.1"))
+	require.Equal(t, "10.0.0.1", clientHost("[::ffff:10.0.0.1]:443"))
+	require.Equal(t, "2001:db8:1:2::/64", clientHost("[2001:db8:1:2:3:4:5:6]:443"))
+	// addresses that cannot be parsed are counted together rather than one by one
+	require.Equal(t, unknownClientHost, clientHost("1.2.3.4, 5.6.7.8"))
+	require.Equal(t, unknownClientHost, clientHost(""))
+}
@@ -8,10 +8,12 @@ import (
 
 	"github.com/RobinHood3082/simplebank/util"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var testQueries *Queries
 var testDB *pgxpool.Pool
+var testPasswordHasher util.PasswordHasher
 
 func TestMain(m *testing.M) {
 	ctx := context.Background()
@@ -22,6 +24,11 @@ func TestMain(m *testing.M) {
 		log.Fatal("cannot load config:", err)
 	}
 
+	testPasswordHasher, err = util.NewBcryptHasher(bcrypt.MinCost)
+	if err != nil {
+		log.Fatal("cannot create password hasher:", err)
+	}
+
 	testDB, err = pgxpool.New(ctx, config.DBSource)
 	if err != nil {
 		log.Fatal("cannot connect to db:", err)
@@ -0,0 +1,235 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTotpEnrollment(t *testing.T, user User) TotpEnrollment {
+	secret, err := util.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	enrollment, err := testQueries.CreateTotpEnrollment(context.Background(), CreateTotpEnrollmentParams{
+		Username: user.Username,
+		Secret:   secret,
+	})
+	require.NoError(t, err)
+	require.Equal(t, secret, enrollment.Secret)
+	require.False(t, enrollment.ConfirmedAt.Valid)
+
+	return enrollment
+}
+
+func currentTotpCode(t *testing.T, enrollment TotpEnrollment) string {
+	code, err := util.TOTPCode(enrollment.Secret, util.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func createRandomMfaChallenge(t *testing.T, user User, expiresAt time.Time) string {
+	token, err := util.GenerateToken()
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateMfaChallenge(context.Background(), CreateMfaChallengeParams{
+		Username:  user.Username,
+		TokenHash: util.HashToken(token),
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	require.NoError(t, err)
+
+	return token
+}
+
+func enableRandomTotp(t *testing.T, user User) (TotpEnrollment, []string) {
+	enrollment := createRandomTotpEnrollment(t, user)
+
+	result, err := NewStore(testDB).ConfirmTotpEnrollmentTx(context.Background(), ConfirmTotpEnrollmentTxParams{
+		Username: user.Username,
+		Code:     currentTotpCode(t, enrollment),
+	})
+	require.NoError(t, err)
+	require.True(t, result.Enrollment.ConfirmedAt.Valid)
+	require.Len(t, result.RecoveryCodes, util.RecoveryCodeCount)
+
+	return result.Enrollment, result.RecoveryCodes
+}
+
+func TestConfirmTotpEnrollmentTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	enrollment := createRandomTotpEnrollment(t, user)
+
+	_, err := store.ConfirmTotpEnrollmentTx(context.Background(), ConfirmTotpEnrollmentTxParams{
+		Username: user.Username,
+		Code:     "000000",
+	})
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+
+	code := currentTotpCode(t, enrollment)
+	result, err := store.ConfirmTotpEnrollmentTx(context.Background(), ConfirmTotpEnrollmentTxParams{
+		Username: user.Username,
+		Code:     code,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Enrollment.ConfirmedAt.Valid)
+
+	count, err := testQueries.CountUnusedRecoveryCodes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(util.RecoveryCodeCount), count)
+
+	_, err = store.ConfirmTotpEnrollmentTx(context.Background(), ConfirmTotpEnrollmentTxParams{
+		Username: user.Username,
+		Code:     code,
+	})
+	require.ErrorIs(t, err, ErrTotpAlreadyEnabled)
+
+	// a confirmed enrollment cannot be restarted with another secret
+	_, err = testQueries.CreateTotpEnrollment(context.Background(), CreateTotpEnrollmentParams{
+		Username: user.Username,
+		Secret:   enrollment.Secret + "A",
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestVerifyMfaTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	enrollment, recoveryCodes := enableRandomTotp(t, user)
+
+	// the code that confirmed the enrollment cannot be used again
+	token := createRandomMfaChallenge(t, user, time.Now().Add(time.Minute))
+	_, err := store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  currentTotpCode(t, enrollment),
+	})
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+
+	result, err := store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  recoveryCodes[0],
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, result.Challenge.Username)
+	require.Equal(t, int32(1), result.Challenge.Attempts)
+	require.True(t, result.Challenge.CompletedAt.Valid)
+	require.Empty(t, result.RecoveryCodes)
+
+	_, err = store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  recoveryCodes[1],
+	})
+	require.ErrorIs(t, err, ErrMfaChallengeCompleted)
+
+	// recovery codes can only be used once
+	token = createRandomMfaChallenge(t, user, time.Now().Add(time.Minute))
+	_, err = store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  recoveryCodes[0],
+	})
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+
+	token = createRandomMfaChallenge(t, user, time.Now().Add(-time.Second))
+	_, err = store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  recoveryCodes[1],
+	})
+	require.ErrorIs(t, err, ErrMfaChallengeExpired)
+
+	_, err = store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: util.RandomString(43),
+		Code:  recoveryCodes[1],
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestVerifyMfaTxTooManyAttempts(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	_, recoveryCodes := enableRandomTotp(t, user)
+	token := createRandomMfaChallenge(t, user, time.Now().Add(time.Minute))
+
+	for i := 0; i < MaxMfaChallengeAttempts; i++ {
+		_, err := store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+			Token: token,
+			Code:  "000000",
+		})
+		require.ErrorIs(t, err, ErrInvalidMfaCode)
+	}
+
+	_, err := store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  recoveryCodes[0],
+	})
+	require.ErrorIs(t, err, ErrTooManyMfaAttempts)
+}
+
+func TestVerifyMfaTxLoginLocked(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	enrollment, _ := enableRandomTotp(t, user)
+	token := createRandomMfaChallenge(t, user, time.Now().Add(time.Minute))
+
+	// wrong codes counted as failed logins lock the username while the challenge is open
+	for i := int32(0); i < testLoginPolicy.MaxFailedAttempts; i++ {
+		recordFailedLogin(t, user.Username, randomClientIP(), testLoginPolicy)
+	}
+
+	_, err := store.VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  currentTotpCode(t, enrollment),
+	})
+	require.ErrorIs(t, err, ErrLoginLocked)
+}
+
+func TestVerifyMfaTxForcedEnrollment(t *testing.T) {
+	user := createRandomUser(t)
+	enrollment := createRandomTotpEnrollment(t, user)
+	token := createRandomMfaChallenge(t, user, time.Now().Add(time.Minute))
+
+	result, err := NewStore(testDB).VerifyMfaTx(context.Background(), VerifyMfaTxParams{
+		Token: token,
+		Code:  currentTotpCode(t, enrollment),
+	})
+	require.NoError(t, err)
+	require.True(t, result.Enrollment.ConfirmedAt.Valid)
+	require.Len(t, result.RecoveryCodes, util.RecoveryCodeCount)
+}
+
+func TestDisableTotpTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	_, recoveryCodes := enableRandomTotp(t, user)
+
+	err := store.DisableTotpTx(context.Background(), DisableTotpTxParams{
+		Username: user.Username,
+		Code:     "000000",
+	})
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+
+	// recovery codes are accepted in any case and without their dashes
+	err = store.DisableTotpTx(context.Background(), DisableTotpTxParams{
+		Username: user.Username,
+		Code:     strings.ToUpper(strings.ReplaceAll(recoveryCodes[2], "-", "")),
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetTotpEnrollment(context.Background(), user.Username)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	count, err := testQueries.CountUnusedRecoveryCodes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	err = store.DisableTotpTx(context.Background(), DisableTotpTxParams{
+		Username: user.Username,
+		Code:     recoveryCodes[3],
+	})
+	require.ErrorIs(t, err, ErrTotpNotEnabled)
+}
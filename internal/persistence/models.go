@@ -14,6 +14,158 @@ type Account struct {
 	Balance   int64              `json:"balance"`
 	Currency  string             `json:"currency"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// customer-facing identifier: bank code, mod-97 check digits and a random 10 digit body
+	AccountNumber string `json:"account_number"`
+}
+
+type AccountInvitation struct {
+	ID        int64  `json:"id"`
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	InvitedBy string `json:"invited_by"`
+	// hex encoded SHA-256 of the emailed code, empty until the invitation email is sent
+	SecretCodeHash string             `json:"secret_code_hash"`
+	IsAccepted     bool               `json:"is_accepted"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
+type AccountLimit struct {
+	AccountID int64 `json:"account_id"`
+	// overrides RISK_MAX_TRANSFERS_PER_HOUR, the default applies when null
+	MaxTransfersPerHour pgtype.Int8 `json:"max_transfers_per_hour"`
+	// overrides RISK_NEW_COUNTERPARTY_THRESHOLD, in minor units of the account currency
+	NewCounterpartyThreshold pgtype.Int8 `json:"new_counterparty_threshold"`
+	// overrides NEW_BENEFICIARY_TRANSFER_LIMIT, in minor units of the account currency
+	NewBeneficiaryTransferLimit pgtype.Int8 `json:"new_beneficiary_transfer_limit"`
+	// the banker who approved the override
+	UpdatedBy string             `json:"updated_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type AccountMember struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	// owner, can-transfer or view-only
+	Role      string             `json:"role"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ApiKey struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+	// the start of the key, shown to tell keys apart
+	Prefix string `json:"prefix"`
+	// hex encoded SHA-256 of the whole key
+	SecretHash string   `json:"secret_hash"`
+	Scopes     []string `json:"scopes"`
+	// addresses and CIDR ranges the key can be used from, any address when empty
+	AllowedIps []string `json:"allowed_ips"`
+	// updated at most once a minute
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp pgtype.Text        `json:"last_used_ip"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type ApprovalRequest struct {
+	ID int64 `json:"id"`
+	// add_account_balance, update_dispute_status or update_user_role
+	Operation string `json:"operation"`
+	// the arguments of the operation
+	Payload     []byte `json:"payload"`
+	RequestedBy string `json:"requested_by"`
+	// pending, approved, rejected or expired. Approved operations have been executed.
+	Status string `json:"status"`
+	// a banker other than the one who requested the operation
+	ReviewedBy pgtype.Text        `json:"reviewed_by"`
+	ReviewNote string             `json:"review_note"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Beneficiary struct {
+	ID        int64              `json:"id"`
+	Owner     string             `json:"owner"`
+	Nickname  string             `json:"nickname"`
+	AccountID int64              `json:"account_id"`
+	Currency  string             `json:"currency"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Category struct {
+	ID        int64              `json:"id"`
+	Owner     string             `json:"owner"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type CategoryRule struct {
+	ID         int64  `json:"id"`
+	Owner      string `json:"owner"`
+	CategoryID int64  `json:"category_id"`
+	// matches transfers to or from this account
+	CounterpartyAccountID pgtype.Int8 `json:"counterparty_account_id"`
+	// matches transfers whose reference contains it, ignoring case
+	Keyword   pgtype.Text        `json:"keyword"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Currency struct {
+	// ISO 4217 alphabetic code
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// number of decimal places of the minor unit, fixed once accounts hold amounts in it
+	Exponent int32 `json:"exponent"`
+	// disabled currencies cannot be used to open new accounts
+	IsEnabled bool               `json:"is_enabled"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Dispute struct {
+	ID         int64 `json:"id"`
+	TransferID int64 `json:"transfer_id"`
+	// the account the disputed transfer was sent from
+	AccountID int64  `json:"account_id"`
+	OpenedBy  string `json:"opened_by"`
+	Reason    string `json:"reason"`
+	// open, investigating, provisional_credit, resolved_for_customer or resolved_against_customer
+	Status string `json:"status"`
+	// in minor units, the amount of the disputed transfer
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// the entry crediting the customer, provisionally or once resolved in their favour
+	CreditEntryID pgtype.Int8 `json:"credit_entry_id"`
+	// the entry taking the money back from the recipient once resolved for the customer
+	ChargebackEntryID pgtype.Int8 `json:"chargeback_entry_id"`
+	// the entry taking back a provisional credit once resolved against the customer
+	ReversalEntryID pgtype.Int8        `json:"reversal_entry_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	ResolvedAt      pgtype.Timestamptz `json:"resolved_at"`
+}
+
+type DisputeAttachment struct {
+	ID          int64              `json:"id"`
+	DisputeID   int64              `json:"dispute_id"`
+	FileName    string             `json:"file_name"`
+	ContentType string             `json:"content_type"`
+	Content     []byte             `json:"content"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type DisputeEvent struct {
+	ID        int64 `json:"id"`
+	DisputeID int64 `json:"dispute_id"`
+	// the status the dispute moved to
+	Status string `json:"status"`
+	// the user who opened the dispute or the banker who moved it
+	Actor     string             `json:"actor"`
+	Note      string             `json:"note"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type Entry struct {
@@ -22,17 +174,193 @@ type Entry struct {
 	// can be negative or zero
 	Amount    int64              `json:"amount"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// set when the entry moves money into or out of a pocket
+	PocketID pgtype.Int8 `json:"pocket_id"`
+	// set when the entry is one side of a transfer
+	TransferID pgtype.Int8 `json:"transfer_id"`
+}
+
+type FailedLogin struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// the client address without its port, or the /64 network of an IPv6 client
+	ClientIp  string             `json:"client_ip"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginThrottle struct {
+	// not a reference to users, unknown usernames are throttled the same way
+	Username string `json:"username"`
+	// consecutive failed logins, reset by a successful login, a lockout or an unlock
+	FailedAttempts int32              `json:"failed_attempts"`
+	LastFailedAt   pgtype.Timestamptz `json:"last_failed_at"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+	// when a login of the username was last let through, so concurrent logins cannot skip the wait
+	LastAttemptAt pgtype.Timestamptz `json:"last_attempt_at"`
+}
+
+type MfaChallenge struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// hex encoded SHA-256 of the challenge token returned by login
+	TokenHash string `json:"token_hash"`
+	// wrong codes entered, the challenge fails after too many
+	Attempts    int32              `json:"attempts"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type PasswordReset struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// the address the code was sent to
+	Email string `json:"email"`
+	// hex encoded SHA-256 of the emailed code
+	SecretCodeHash string             `json:"secret_code_hash"`
+	IsUsed         bool               `json:"is_used"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
+type PaymentBatch struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	// GrpHdr/MsgId of the pain.001 file, a file is only accepted once
+	MessageID string `json:"message_id"`
+	// the pain.001 version of the file, e.g. pain.001.001.09
+	MessageName string `json:"message_name"`
+	// pending until confirmed, then confirmed until every valid item has been executed, then completed
+	Status      string             `json:"status"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ConfirmedAt pgtype.Timestamptz `json:"confirmed_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+}
+
+type PaymentBatchItem struct {
+	ID                   int64  `json:"id"`
+	BatchID              int64  `json:"batch_id"`
+	PaymentInformationID string `json:"payment_information_id"`
+	InstructionID        string `json:"instruction_id"`
+	EndToEndID           string `json:"end_to_end_id"`
+	// as given in the file, from_account_id is set when it was found
+	FromAccountNumber string      `json:"from_account_number"`
+	FromAccountID     pgtype.Int8 `json:"from_account_id"`
+	// as given in the file, to_account_id is set when it was found
+	ToAccountNumber string      `json:"to_account_number"`
+	ToAccountID     pgtype.Int8 `json:"to_account_id"`
+	// in minor units, 0 when the amount in the file could not be read
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Reference string `json:"reference"`
+	// valid or invalid when imported, valid items become executed or failed
+	Status string `json:"status"`
+	// ISO 20022 status reason code of an invalid or failed item
+	ReasonCode string      `json:"reason_code"`
+	Reason     string      `json:"reason"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+}
+
+type PaymentRequest struct {
+	ID        int64  `json:"id"`
+	Requester string `json:"requester"`
+	Payer     string `json:"payer"`
+	// the requester's account that receives the money
+	AccountID int64 `json:"account_id"`
+	// must be positive
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Note     string `json:"note"`
+	// pending, accepted, declined or expired
+	Status     string             `json:"status"`
+	TransferID pgtype.Int8        `json:"transfer_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+}
+
+type Pocket struct {
+	ID        int64  `json:"id"`
+	AccountID int64  `json:"account_id"`
+	Name      string `json:"name"`
+	// ring-fenced part of the parent account balance
+	Balance   int64              `json:"balance"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type RecoveryCode struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// hex encoded SHA-256 of the recovery code
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type RefreshToken struct {
+	// the ID of the refresh token payload
+	ID        pgtype.UUID `json:"id"`
+	SessionID pgtype.UUID `json:"session_id"`
+	// hex encoded SHA-256 of the refresh token
+	TokenHash string `json:"token_hash"`
+	// set when the token is renewed, using it again revokes the session
+	RotatedAt pgtype.Timestamptz `json:"rotated_at"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Role struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type RolePermission struct {
+	Role string `json:"role"`
+	// permissions ending in .any apply to the resources of every user
+	Permission string             `json:"permission"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
 type Session struct {
-	ID           pgtype.UUID        `json:"id"`
-	Username     string             `json:"username"`
-	RefreshToken string             `json:"refresh_token"`
-	UserAgent    string             `json:"user_agent"`
-	ClientIp     string             `json:"client_ip"`
-	IsBlocked    bool               `json:"is_blocked"`
-	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ID        pgtype.UUID        `json:"id"`
+	Username  string             `json:"username"`
+	UserAgent string             `json:"user_agent"`
+	ClientIp  string             `json:"client_ip"`
+	IsBlocked bool               `json:"is_blocked"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type SpendingSummary struct {
+	Username   string      `json:"username"`
+	AccountID  int64       `json:"account_id"`
+	Month      pgtype.Date `json:"month"`
+	CategoryID int64       `json:"category_id"`
+	Inflow     int64       `json:"inflow"`
+	Outflow    int64       `json:"outflow"`
+}
+
+type TokenSigningKey struct {
+	Kid string `json:"kid"`
+	// EdDSA or ES256
+	Algorithm string `json:"algorithm"`
+	// PKCS #8 private key encrypted with the token symmetric key
+	SealedPrivateKey []byte             `json:"sealed_private_key"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	// when the last token the key signed has expired
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type TotpEnrollment struct {
+	Username string `json:"username"`
+	// base32 encoded TOTP secret
+	Secret string `json:"secret"`
+	// the time step of the last accepted code, codes cannot be used twice
+	LastUsedStep int64 `json:"last_used_step"`
+	// set once a code has been verified, TOTP is only enabled from then on
+	ConfirmedAt pgtype.Timestamptz `json:"confirmed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type Transfer struct {
@@ -42,6 +370,39 @@ type Transfer struct {
 	// must be positive
 	Amount    int64              `json:"amount"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// free text set by the sender, e.g. an invoice number
+	Reference string `json:"reference"`
+}
+
+type TransferCategory struct {
+	TransferID int64  `json:"transfer_id"`
+	Username   string `json:"username"`
+	CategoryID int64  `json:"category_id"`
+	// the rule that categorized the transfer, NULL when the user chose the category
+	RuleID    pgtype.Int8        `json:"rule_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type TransferRiskAssessment struct {
+	ID            int64 `json:"id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	// in minor units
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+	Reference   string `json:"reference"`
+	RequestedBy string `json:"requested_by"`
+	ClientIp    string `json:"client_ip"`
+	// allow, review or block
+	Decision string `json:"decision"`
+	// the rules that did not allow the transfer and why
+	Findings []byte `json:"findings"`
+	// executed when the transfer was made right away, blocked transfers are held until approved or rejected by a banker
+	Status     string             `json:"status"`
+	TransferID pgtype.Int8        `json:"transfer_id"`
+	ReviewedBy pgtype.Text        `json:"reviewed_by"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
 type User struct {
@@ -53,6 +414,9 @@ type User struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	IsEmailVerified   bool               `json:"is_email_verified"`
 	Role              string             `json:"role"`
+	// user-chosen payment handle, stored lowercase without the leading @
+	Handle         pgtype.Text        `json:"handle"`
+	EmailChangedAt pgtype.Timestamptz `json:"email_changed_at"`
 }
 
 type VerifyEmail struct {
@@ -64,3 +428,34 @@ type VerifyEmail struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
 }
+
+type Webhook struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	Url   string `json:"url"`
+	// HMAC-SHA256 signing key, shown to the owner once
+	Secret string `json:"secret"`
+	// event types the webhook is subscribed to
+	EventTypes []string           `json:"event_types"`
+	IsActive   bool               `json:"is_active"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID        int64  `json:"id"`
+	WebhookID int64  `json:"webhook_id"`
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+	// the exact event body that is signed and sent
+	Payload []byte `json:"payload"`
+	// pending, succeeded or failed
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	// HTTP status of the last attempt, null if no response was received
+	ResponseCode  pgtype.Int4        `json:"response_code"`
+	LastError     string             `json:"last_error"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	LastAttemptAt pgtype.Timestamptz `json:"last_attempt_at"`
+	// the delivery this one replays, null for the original delivery of an event
+	ReplayOf pgtype.Int8 `json:"replay_of"`
+}
@@ -0,0 +1,22 @@
+package persistence
+
+import "github.com/RobinHood3082/simplebank/util"
+
+// BalanceMoney returns the account balance in the account currency
+func (account Account) BalanceMoney() util.Money {
+	return util.Money{Amount: account.Balance, Currency: account.Currency}
+}
+
+// AvailableBalance returns the part of the account balance that is not ring-fenced in the given pockets
+func (account Account) AvailableBalance(pockets []Pocket) (util.Money, error) {
+	available := account.BalanceMoney()
+	for _, pocket := range pockets {
+		var err error
+		available, err = available.Sub(util.Money{Amount: pocket.Balance, Currency: account.Currency})
+		if err != nil {
+			return util.Money{}, err
+		}
+	}
+
+	return available, nil
+}
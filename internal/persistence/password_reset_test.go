@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPasswordReset(t *testing.T, user User) (PasswordReset, string) {
+	secretCode, err := util.GenerateToken()
+	require.NoError(t, err)
+
+	reset, err := testQueries.CreatePasswordReset(context.Background(), CreatePasswordResetParams{
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: util.HashToken(secretCode),
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, reset.Username)
+	require.Equal(t, user.Email, reset.Email)
+	require.False(t, reset.IsUsed)
+	require.True(t, reset.ExpiresAt.Time.After(time.Now()))
+
+	return reset, secretCode
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	for i := 0; i < 2; i++ {
+		createRandomSession(t, user, time.Now().Add(time.Hour))
+	}
+
+	reset, secretCode := createRandomPasswordReset(t, user)
+	older, olderSecretCode := createRandomPasswordReset(t, user)
+
+	hashedPassword, err := testPasswordHasher.HashPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	_, err = store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		ResetID:        reset.ID,
+		SecretCode:     olderSecretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+
+	result, err := store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		ResetID:        reset.ID,
+		SecretCode:     secretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, result.User.HashedPassword)
+	require.WithinDuration(t, time.Now(), result.User.PasswordChangedAt.Time, time.Second)
+	require.Equal(t, int64(2), result.RevokedSessions)
+
+	sessions, err := testQueries.ListSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	for _, session := range sessions {
+		require.True(t, session.IsBlocked)
+	}
+
+	// the code can only be used once
+	_, err = store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		ResetID:        reset.ID,
+		SecretCode:     secretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+
+	// and the other pending resets stop working
+	_, err = store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		ResetID:        older.ID,
+		SecretCode:     olderSecretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+}
@@ -0,0 +1,166 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func importRandomPaymentBatch(t *testing.T, fromAccount, toAccount Account, amounts ...int64) ImportPaymentBatchTxResult {
+	store := NewStore(testDB)
+
+	arg := ImportPaymentBatchTxParams{
+		CreatePaymentBatchParams: CreatePaymentBatchParams{
+			Owner:       fromAccount.Owner,
+			MessageID:   util.RandomString(12),
+			MessageName: "pain.001.001.09",
+		},
+	}
+
+	for _, amount := range amounts {
+		arg.Items = append(arg.Items, CreatePaymentBatchItemParams{
+			PaymentInformationID: "PMT-1",
+			EndToEndID:           util.RandomString(8),
+			FromAccountNumber:    fromAccount.AccountNumber,
+			FromAccountID:        pgtype.Int8{Int64: fromAccount.ID, Valid: true},
+			ToAccountNumber:      toAccount.AccountNumber,
+			ToAccountID:          pgtype.Int8{Int64: toAccount.ID, Valid: true},
+			Amount:               amount,
+			Currency:             fromAccount.Currency,
+			Reference:            util.RandomString(12),
+			Status:               util.PaymentBatchItemValid,
+		})
+	}
+
+	result, err := store.ImportPaymentBatchTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.NotZero(t, result.PaymentBatch.ID)
+	require.Equal(t, arg.Owner, result.PaymentBatch.Owner)
+	require.Equal(t, arg.MessageID, result.PaymentBatch.MessageID)
+	require.Equal(t, util.PaymentBatchPending, result.PaymentBatch.Status)
+	require.False(t, result.PaymentBatch.ConfirmedAt.Valid)
+
+	require.Len(t, result.Items, len(amounts))
+	for i, item := range result.Items {
+		require.Equal(t, result.PaymentBatch.ID, item.BatchID)
+		require.Equal(t, amounts[i], item.Amount)
+		require.Equal(t, util.PaymentBatchItemValid, item.Status)
+	}
+
+	return result
+}
+
+func TestImportPaymentBatchTx(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	batch := importRandomPaymentBatch(t, fromAccount, toAccount, 10, 20)
+
+	items, err := testQueries.ListPaymentBatchItems(context.Background(), batch.PaymentBatch.ID)
+	require.NoError(t, err)
+	require.Equal(t, batch.Items, items)
+
+	// a file is only accepted once
+	_, err = NewStore(testDB).ImportPaymentBatchTx(context.Background(), ImportPaymentBatchTxParams{
+		CreatePaymentBatchParams: CreatePaymentBatchParams{
+			Owner:       batch.PaymentBatch.Owner,
+			MessageID:   batch.PaymentBatch.MessageID,
+			MessageName: batch.PaymentBatch.MessageName,
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestConfirmPaymentBatchTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	batch := importRandomPaymentBatch(t, fromAccount, toAccount, 10)
+
+	confirmed := 0
+	arg := ConfirmPaymentBatchTxParams{
+		PaymentBatchID: batch.PaymentBatch.ID,
+		AfterConfirm: func(paymentBatch PaymentBatch) error {
+			confirmed++
+			return nil
+		},
+	}
+
+	result, err := store.ConfirmPaymentBatchTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentBatchConfirmed, result.PaymentBatch.Status)
+	require.True(t, result.PaymentBatch.ConfirmedAt.Valid)
+	require.Equal(t, 1, confirmed)
+
+	_, err = store.ConfirmPaymentBatchTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrPaymentBatchNotPending)
+	require.Equal(t, 1, confirmed)
+}
+
+func TestExecutePaymentBatchItemTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount, err := testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     createRandomAccount(t).ID,
+		Amount: 100,
+	})
+	require.NoError(t, err)
+	toAccount := createRandomAccount(t)
+	batch := importRandomPaymentBatch(t, fromAccount, toAccount, 10, fromAccount.Balance)
+
+	_, err = store.ConfirmPaymentBatchTx(context.Background(), ConfirmPaymentBatchTxParams{
+		PaymentBatchID: batch.PaymentBatch.ID,
+		AfterConfirm:   func(paymentBatch PaymentBatch) error { return nil },
+	})
+	require.NoError(t, err)
+
+	assessment := TransferAssessment{
+		RequestedBy: batch.PaymentBatch.Owner,
+		Decision:    "allow",
+		Findings:    []byte(`[]`),
+	}
+
+	// the batch cannot be completed while items are left to execute
+	_, err = testQueries.CompletePaymentBatch(context.Background(), batch.PaymentBatch.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	result, err := store.ExecutePaymentBatchItemTx(context.Background(), ExecutePaymentBatchItemTxParams{ItemID: batch.Items[0].ID, Assessment: assessment})
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentBatchItemExecuted, result.Item.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Item.TransferID.Int64)
+	require.Equal(t, batch.Items[0].Reference, result.Transfer.Transfer.Reference)
+	require.Equal(t, fromAccount.Balance-10, result.Transfer.FromAccount.Balance)
+	require.Equal(t, toAccount.Balance+10, result.Transfer.ToAccount.Balance)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Assessment.TransferID.Int64)
+
+	_, err = store.ExecutePaymentBatchItemTx(context.Background(), ExecutePaymentBatchItemTxParams{ItemID: batch.Items[0].ID, Assessment: assessment})
+	require.ErrorIs(t, err, ErrPaymentBatchItemNotExecutable)
+
+	// the first item has spent part of the balance the second one needs
+	_, err = store.ExecutePaymentBatchItemTx(context.Background(), ExecutePaymentBatchItemTxParams{ItemID: batch.Items[1].ID, Assessment: assessment})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	account, err := testQueries.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance-10, account.Balance)
+
+	failed, err := testQueries.ResolvePaymentBatchItem(context.Background(), ResolvePaymentBatchItemParams{
+		ID:         batch.Items[1].ID,
+		Status:     util.PaymentBatchItemFailed,
+		ReasonCode: "AM04",
+		Reason:     "insufficient funds",
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentBatchItemFailed, failed.Status)
+	require.False(t, failed.TransferID.Valid)
+
+	paymentBatch, err := testQueries.CompletePaymentBatch(context.Background(), batch.PaymentBatch.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentBatchCompleted, paymentBatch.Status)
+	require.True(t, paymentBatch.CompletedAt.Valid)
+}
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPaymentRequest(t *testing.T, requesterAccount Account, payer User) PaymentRequest {
+	arg := CreatePaymentRequestParams{
+		Requester: requesterAccount.Owner,
+		Payer:     payer.Username,
+		AccountID: requesterAccount.ID,
+		Amount:    util.RandomInt(1, 100),
+		Currency:  requesterAccount.Currency,
+		Note:      util.RandomString(12),
+	}
+
+	paymentRequest, err := testQueries.CreatePaymentRequest(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, paymentRequest)
+
+	require.NotZero(t, paymentRequest.ID)
+	require.Equal(t, arg.Requester, paymentRequest.Requester)
+	require.Equal(t, arg.Payer, paymentRequest.Payer)
+	require.Equal(t, arg.AccountID, paymentRequest.AccountID)
+	require.Equal(t, arg.Amount, paymentRequest.Amount)
+	require.Equal(t, arg.Currency, paymentRequest.Currency)
+	require.Equal(t, arg.Note, paymentRequest.Note)
+	require.Equal(t, util.PaymentRequestPending, paymentRequest.Status)
+	require.False(t, paymentRequest.TransferID.Valid)
+	require.True(t, paymentRequest.ExpiresAt.Time.After(paymentRequest.CreatedAt.Time))
+
+	return paymentRequest
+}
+
+func TestListPaymentRequests(t *testing.T) {
+	requesterAccount := createRandomAccount(t)
+	payer := createRandomUser(t)
+	paymentRequest := createRandomPaymentRequest(t, requesterAccount, payer)
+
+	incoming, err := testQueries.ListIncomingPaymentRequests(context.Background(), ListIncomingPaymentRequestsParams{
+		Payer:  payer.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, incoming, 1)
+	require.Equal(t, paymentRequest.ID, incoming[0].ID)
+
+	outgoing, err := testQueries.ListOutgoingPaymentRequests(context.Background(), ListOutgoingPaymentRequestsParams{
+		Requester: requesterAccount.Owner,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, outgoing, 1)
+	require.Equal(t, paymentRequest.ID, outgoing[0].ID)
+}
+
+func TestAcceptPaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	requesterAccount := createRandomAccount(t)
+	payerAccount := createRandomAccount(t)
+	payer, err := testQueries.GetUser(context.Background(), payerAccount.Owner)
+	require.NoError(t, err)
+
+	paymentRequest := createRandomPaymentRequest(t, requesterAccount, payer)
+
+	arg := AcceptPaymentRequestTxParams{
+		PaymentRequestID: paymentRequest.ID,
+		FromAccountID:    payerAccount.ID,
+		Assessment: TransferAssessment{
+			RequestedBy: payer.Username,
+			ClientIp:    "10.0.0.1:50312",
+			Decision:    "allow",
+			Findings:    []byte(`[]`),
+		},
+		AfterAccept: func(paymentRequest PaymentRequest) error { return nil },
+	}
+
+	result, err := store.AcceptPaymentRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentRequestAccepted, result.PaymentRequest.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.PaymentRequest.TransferID.Int64)
+	require.Equal(t, util.TransferRiskExecuted, result.Assessment.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Assessment.TransferID.Int64)
+	require.Equal(t, payerAccount.Balance-paymentRequest.Amount, result.Transfer.FromAccount.Balance)
+	require.Equal(t, requesterAccount.Balance+paymentRequest.Amount, result.Transfer.ToAccount.Balance)
+
+	// a request can only be paid once
+	_, err = store.AcceptPaymentRequestTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+
+	_, err = store.DeclinePaymentRequestTx(context.Background(), DeclinePaymentRequestTxParams{
+		PaymentRequestID: paymentRequest.ID,
+		AfterDecline:     func(paymentRequest PaymentRequest) error { return nil },
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+}
+
+func TestDeclinePaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	paymentRequest := createRandomPaymentRequest(t, createRandomAccount(t), createRandomUser(t))
+
+	result, err := store.DeclinePaymentRequestTx(context.Background(), DeclinePaymentRequestTxParams{
+		PaymentRequestID: paymentRequest.ID,
+		AfterDecline:     func(paymentRequest PaymentRequest) error { return nil },
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentRequestDeclined, result.PaymentRequest.Status)
+	require.False(t, result.PaymentRequest.TransferID.Valid)
+}
+
+func TestExpirePaymentRequest(t *testing.T) {
+	paymentRequest := createRandomPaymentRequest(t, createRandomAccount(t), createRandomUser(t))
+
+	// not due yet
+	_, err := testQueries.ExpirePaymentRequest(context.Background(), paymentRequest.ID)
+	require.EqualError(t, err, pgx.ErrNoRows.Error())
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPocket(t *testing.T, account Account) Pocket {
+	arg := CreatePocketParams{
+		AccountID: account.ID,
+		Name:      util.RandomString(8),
+	}
+
+	pocket, err := testQueries.CreatePocket(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, pocket)
+
+	require.Equal(t, arg.AccountID, pocket.AccountID)
+	require.Equal(t, arg.Name, pocket.Name)
+	require.Zero(t, pocket.Balance)
+	require.NotZero(t, pocket.ID)
+	require.NotZero(t, pocket.CreatedAt)
+
+	return pocket
+}
+
+func TestCreatePocket(t *testing.T) {
+	account := createRandomAccount(t)
+	createRandomPocket(t, account)
+}
+
+func TestListPockets(t *testing.T) {
+	account := createRandomAccount(t)
+	for range 3 {
+		createRandomPocket(t, account)
+	}
+
+	pockets, err := testQueries.ListPockets(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, pockets, 3)
+
+	for _, pocket := range pockets {
+		require.Equal(t, account.ID, pocket.AccountID)
+	}
+}
+
+func TestMovePocketFundsTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	pocket := createRandomPocket(t, account)
+	amount := util.Money{Amount: account.Balance, Currency: account.Currency}
+
+	// move the whole balance into the pocket
+	result, err := store.MovePocketFundsTx(context.Background(), MovePocketFundsTxParams{
+		PocketID: pocket.ID,
+		Amount:   amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, amount.Amount, result.Pocket.Balance)
+	require.Equal(t, -amount.Amount, result.AccountEntry.Amount)
+	require.False(t, result.AccountEntry.PocketID.Valid)
+	require.Equal(t, amount.Amount, result.PocketEntry.Amount)
+	require.Equal(t, pocket.ID, result.PocketEntry.PocketID.Int64)
+
+	// the total balance is unchanged, only the available balance goes down
+	updatedAccount, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, updatedAccount.Balance)
+
+	pocketed, err := store.GetPocketedBalance(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, amount.Amount, pocketed)
+
+	// nothing is left to move
+	_, err = store.MovePocketFundsTx(context.Background(), MovePocketFundsTxParams{
+		PocketID: pocket.ID,
+		Amount:   util.Money{Amount: 1, Currency: account.Currency},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// move it back to the parent account
+	negated, err := amount.Neg()
+	require.NoError(t, err)
+
+	result, err = store.MovePocketFundsTx(context.Background(), MovePocketFundsTxParams{
+		PocketID: pocket.ID,
+		Amount:   negated,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Pocket.Balance)
+
+	_, err = store.MovePocketFundsTx(context.Background(), MovePocketFundsTxParams{
+		PocketID: pocket.ID,
+		Amount:   util.Money{Amount: -1, Currency: account.Currency},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -11,26 +11,171 @@ import (
 )
 
 type Querier interface {
+	AcceptAccountInvitation(ctx context.Context, arg AcceptAccountInvitationParams) (AccountInvitation, error)
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddFailedLoginAttempt(ctx context.Context, arg AddFailedLoginAttemptParams) (LoginThrottle, error)
+	AddMfaChallengeAttempt(ctx context.Context, id int64) (MfaChallenge, error)
+	AddPocketBalance(ctx context.Context, arg AddPocketBalanceParams) (Pocket, error)
+	ApplyCategoryRules(ctx context.Context) (int64, error)
+	BlockOtherSessions(ctx context.Context, arg BlockOtherSessionsParams) (int64, error)
+	BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	ClaimLoginThrottle(ctx context.Context, username string) (LoginThrottle, error)
+	CompleteMfaChallenge(ctx context.Context, id int64) (MfaChallenge, error)
+	CompletePaymentBatch(ctx context.Context, id int64) (PaymentBatch, error)
+	ConfirmPaymentBatch(ctx context.Context, id int64) (PaymentBatch, error)
+	CountAccountOwners(ctx context.Context, accountID int64) (int64, error)
+	CountFailedLoginsFromIP(ctx context.Context, arg CountFailedLoginsFromIPParams) (int64, error)
+	CountTransfersSince(ctx context.Context, arg CountTransfersSinceParams) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, username string) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountInvitation(ctx context.Context, arg CreateAccountInvitationParams) (AccountInvitation, error)
+	CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateApprovalRequest(ctx context.Context, arg CreateApprovalRequestParams) (ApprovalRequest, error)
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateCategoryRule(ctx context.Context, arg CreateCategoryRuleParams) (CategoryRule, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
+	CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, error)
+	CreateDisputeAttachment(ctx context.Context, arg CreateDisputeAttachmentParams) (DisputeAttachment, error)
+	CreateDisputeEvent(ctx context.Context, arg CreateDisputeEventParams) (DisputeEvent, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFailedLogin(ctx context.Context, arg CreateFailedLoginParams) (FailedLogin, error)
+	CreateMfaChallenge(ctx context.Context, arg CreateMfaChallengeParams) (MfaChallenge, error)
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreatePaymentBatch(ctx context.Context, arg CreatePaymentBatchParams) (PaymentBatch, error)
+	CreatePaymentBatchItem(ctx context.Context, arg CreatePaymentBatchItemParams) (PaymentBatchItem, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreatePocket(ctx context.Context, arg CreatePocketParams) (Pocket, error)
+	CreatePocketEntry(ctx context.Context, arg CreatePocketEntryParams) (Entry, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTokenSigningKey(ctx context.Context, arg CreateTokenSigningKeyParams) (TokenSigningKey, error)
+	CreateTotpEnrollment(ctx context.Context, arg CreateTotpEnrollmentParams) (TotpEnrollment, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferRiskAssessment(ctx context.Context, arg CreateTransferRiskAssessmentParams) (TransferRiskAssessment, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	DeactivateWebhook(ctx context.Context, arg DeactivateWebhookParams) (int64, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) error
+	DeleteBeneficiary(ctx context.Context, arg DeleteBeneficiaryParams) (int64, error)
+	DeleteExpiredTokenSigningKeys(ctx context.Context) (int64, error)
+	DeleteFailedLoginsBefore(ctx context.Context, before pgtype.Timestamptz) (int64, error)
+	DeleteLoginThrottle(ctx context.Context, username string) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, username string) error
+	DeleteStaleLoginThrottles(ctx context.Context, windowStart pgtype.Timestamptz) (int64, error)
+	DeleteTotpEnrollment(ctx context.Context, username string) error
+	ExpireApprovalRequest(ctx context.Context, id int64) (ApprovalRequest, error)
+	ExpirePaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error)
+	GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountInvitation(ctx context.Context, id int64) (AccountInvitation, error)
+	GetAccountLimit(ctx context.Context, accountID int64) (AccountLimit, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetApiKey(ctx context.Context, id int64) (ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
+	GetApprovalRequest(ctx context.Context, id int64) (ApprovalRequest, error)
+	GetApprovalRequestForUpdate(ctx context.Context, id int64) (ApprovalRequest, error)
+	GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error)
+	GetBeneficiaryByAccount(ctx context.Context, arg GetBeneficiaryByAccountParams) (Beneficiary, error)
+	GetCategory(ctx context.Context, id int64) (Category, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	GetDispute(ctx context.Context, id int64) (Dispute, error)
+	GetDisputeAttachment(ctx context.Context, id int64) (DisputeAttachment, error)
+	GetDisputeEvent(ctx context.Context, id int64) (DisputeEvent, error)
+	GetDisputeForUpdate(ctx context.Context, id int64) (Dispute, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetLoginThrottle(ctx context.Context, username string) (LoginThrottle, error)
+	GetMfaChallengeForUpdate(ctx context.Context, tokenHash string) (MfaChallenge, error)
+	GetPaymentBatch(ctx context.Context, id int64) (PaymentBatch, error)
+	GetPaymentBatchItemForUpdate(ctx context.Context, id int64) (PaymentBatchItem, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPocket(ctx context.Context, id int64) (Pocket, error)
+	GetPocketForUpdate(ctx context.Context, id int64) (Pocket, error)
+	GetPocketedBalance(ctx context.Context, accountID int64) (int64, error)
+	GetRefreshToken(ctx context.Context, id pgtype.UUID) (RefreshToken, error)
+	GetRefreshTokenForUpdate(ctx context.Context, id pgtype.UUID) (RefreshToken, error)
 	GetSession(ctx context.Context, id pgtype.UUID) (Session, error)
+	GetStatementBalances(ctx context.Context, arg GetStatementBalancesParams) (GetStatementBalancesRow, error)
+	GetTotpEnrollment(ctx context.Context, username string) (TotpEnrollment, error)
+	GetTotpEnrollmentForUpdate(ctx context.Context, username string) (TotpEnrollment, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferCategory(ctx context.Context, arg GetTransferCategoryParams) (TransferCategory, error)
+	GetTransferRiskAssessment(ctx context.Context, id int64) (TransferRiskAssessment, error)
+	GetTransferRiskAssessmentForUpdate(ctx context.Context, id int64) (TransferRiskAssessment, error)
+	GetTransferredAmountSince(ctx context.Context, arg GetTransferredAmountSinceParams) (int64, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserByHandle(ctx context.Context, handle pgtype.Text) (User, error)
+	GetWebhook(ctx context.Context, id int64) (Webhook, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error)
+	InvalidatePasswordResets(ctx context.Context, username string) error
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAllMemberAccounts(ctx context.Context, username string) ([]Account, error)
+	ListApiKeys(ctx context.Context, owner string) ([]ApiKey, error)
+	ListApprovalRequests(ctx context.Context, arg ListApprovalRequestsParams) ([]ApprovalRequest, error)
+	ListBeneficiaries(ctx context.Context, arg ListBeneficiariesParams) ([]ListBeneficiariesRow, error)
+	ListCategories(ctx context.Context, owner string) ([]Category, error)
+	ListCategoryRules(ctx context.Context, owner string) ([]ListCategoryRulesRow, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListDisputeAttachments(ctx context.Context, disputeID int64) ([]ListDisputeAttachmentsRow, error)
+	ListDisputeEvents(ctx context.Context, disputeID int64) ([]DisputeEvent, error)
+	ListDisputes(ctx context.Context, arg ListDisputesParams) ([]ListDisputesRow, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListIncomingPaymentRequests(ctx context.Context, arg ListIncomingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListMemberAccounts(ctx context.Context, arg ListMemberAccountsParams) ([]Account, error)
+	ListOutgoingPaymentRequests(ctx context.Context, arg ListOutgoingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListPaymentBatchItems(ctx context.Context, batchID int64) ([]PaymentBatchItem, error)
+	ListPockets(ctx context.Context, accountID int64) ([]Pocket, error)
+	ListRecentLogins(ctx context.Context, arg ListRecentLoginsParams) ([]ListRecentLoginsRow, error)
+	ListRolePermissions(ctx context.Context) ([]RolePermission, error)
+	ListRoles(ctx context.Context) ([]Role, error)
+	ListSessions(ctx context.Context, username string) ([]Session, error)
+	ListSpendingSummaries(ctx context.Context, arg ListSpendingSummariesParams) ([]ListSpendingSummariesRow, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListTokenSigningKeys(ctx context.Context) ([]TokenSigningKey, error)
+	ListTransferRiskAssessments(ctx context.Context, arg ListTransferRiskAssessmentsParams) ([]ListTransferRiskAssessmentsRow, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context, owner string) ([]Webhook, error)
+	ListWebhooksForEvent(ctx context.Context, arg ListWebhooksForEventParams) ([]Webhook, error)
+	LockLogin(ctx context.Context, arg LockLoginParams) (LoginThrottle, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	RefreshSpendingSummaries(ctx context.Context) error
+	RehashPassword(ctx context.Context, arg RehashPasswordParams) (int64, error)
+	ResolvePaymentBatchItem(ctx context.Context, arg ResolvePaymentBatchItemParams) (PaymentBatchItem, error)
+	ResolvePaymentRequest(ctx context.Context, arg ResolvePaymentRequestParams) (PaymentRequest, error)
+	ReviewApprovalRequest(ctx context.Context, arg ReviewApprovalRequestParams) (ApprovalRequest, error)
+	ReviewTransferRiskAssessment(ctx context.Context, arg ReviewTransferRiskAssessmentParams) (TransferRiskAssessment, error)
+	RevokeApiKey(ctx context.Context, id int64) (ApiKey, error)
+	RotateRefreshToken(ctx context.Context, id pgtype.UUID) (RefreshToken, error)
+	SearchEntries(ctx context.Context, arg SearchEntriesParams) ([]SearchEntriesRow, error)
+	SearchTransfers(ctx context.Context, arg SearchTransfersParams) ([]SearchTransfersRow, error)
+	SetAccountInvitationSecret(ctx context.Context, arg SetAccountInvitationSecretParams) (AccountInvitation, error)
+	SetTransferCategory(ctx context.Context, arg SetTransferCategoryParams) (TransferCategory, error)
+	StartLoginAttempt(ctx context.Context, username string) error
+	TouchApiKey(ctx context.Context, arg TouchApiKeyParams) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateBeneficiary(ctx context.Context, arg UpdateBeneficiaryParams) (Beneficiary, error)
+	UpdateCurrencyEnabled(ctx context.Context, arg UpdateCurrencyEnabledParams) (Currency, error)
+	UpdateDisputeStatus(ctx context.Context, arg UpdateDisputeStatusParams) (Dispute, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertAccountLimit(ctx context.Context, arg UpsertAccountLimitParams) (AccountLimit, error)
+	UsePasswordReset(ctx context.Context, arg UsePasswordResetParams) (PasswordReset, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (RecoveryCode, error)
+	UseTotpStep(ctx context.Context, arg UseTotpStepParams) (TotpEnrollment, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrRecipientNotFound = errors.New("recipient not found")
+
+type Recipient struct {
+	User    User
+	Account Account
+}
+
+// ResolveRecipient finds the account that receives money sent to a username, a verified email address
+// or an @handle. Since a user holds at most one account per currency, the currency picks the account.
+func (store *PgStore) ResolveRecipient(ctx context.Context, recipient string, currency string) (Recipient, error) {
+	var result Recipient
+	var err error
+
+	switch {
+	case strings.HasPrefix(recipient, "@"):
+		result.User, err = store.GetUserByHandle(ctx, pgtype.Text{String: util.NormalizeHandle(recipient), Valid: true})
+	case strings.Contains(recipient, "@"):
+		result.User, err = store.GetUserByEmail(ctx, recipient)
+		// an unverified email may belong to someone else, so it never receives money
+		if err == nil && !result.User.IsEmailVerified {
+			err = pgx.ErrNoRows
+		}
+	default:
+		result.User, err = store.GetUser(ctx, recipient)
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, ErrRecipientNotFound
+		}
+		return result, err
+	}
+
+	result.Account, err = store.GetAccountByOwnerAndCurrency(ctx, GetAccountByOwnerAndCurrencyParams{
+		Owner:    result.User.Username,
+		Currency: currency,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, fmt.Errorf("%w: recipient has no %s account", ErrRecipientNotFound, currency)
+		}
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRecipient(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	handle := util.RandomOwner()
+
+	user, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username: account.Owner,
+		Handle:   pgtype.Text{String: handle, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// by username and by handle, in any case
+	for _, recipient := range []string{user.Username, "@" + handle, "@" + strings.ToUpper(handle)} {
+		result, err := store.ResolveRecipient(context.Background(), recipient, account.Currency)
+		require.NoError(t, err)
+		require.Equal(t, account.ID, result.Account.ID)
+		require.Equal(t, user.Username, result.User.Username)
+	}
+
+	// by email only once it is verified
+	_, err = store.ResolveRecipient(context.Background(), user.Email, account.Currency)
+	require.ErrorIs(t, err, ErrRecipientNotFound)
+
+	_, err = testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username:        user.Username,
+		IsEmailVerified: pgtype.Bool{Bool: true, Valid: true},
+	})
+	require.NoError(t, err)
+
+	result, err := store.ResolveRecipient(context.Background(), user.Email, account.Currency)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, result.Account.ID)
+
+	// no account in another currency
+	otherCurrency := util.USD
+	if account.Currency == util.USD {
+		otherCurrency = util.EUR
+	}
+	_, err = store.ResolveRecipient(context.Background(), user.Username, otherCurrency)
+	require.ErrorIs(t, err, ErrRecipientNotFound)
+
+	_, err = store.ResolveRecipient(context.Background(), "@"+util.RandomOwner(), account.Currency)
+	require.ErrorIs(t, err, ErrRecipientNotFound)
+}
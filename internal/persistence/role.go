@@ -0,0 +1,32 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+// RefreshRoles reloads the role permissions cached by the util package from the database
+func (store *PgStore) RefreshRoles(ctx context.Context) error {
+	roles, err := store.ListRoles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	rolePermissions, err := store.ListRolePermissions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	permissions := make(map[string][]string, len(roles))
+	for _, role := range roles {
+		permissions[role.Name] = []string{}
+	}
+	for _, rolePermission := range rolePermissions {
+		permissions[rolePermission.Role] = append(permissions[rolePermission.Role], rolePermission.Permission)
+	}
+
+	util.SetRolePermissions(permissions)
+	return nil
+}
@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshRoles(t *testing.T) {
+	store := NewStore(testDB)
+
+	// the built-in permissions are what the database is seeded with
+	builtIn := map[string][]string{}
+	for _, role := range util.Roles() {
+		builtIn[role] = util.RolePermissions(role)
+	}
+
+	err := store.RefreshRoles(context.Background())
+	require.NoError(t, err)
+
+	roles, err := store.ListRoles(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, roles)
+
+	for _, role := range []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole} {
+		require.True(t, util.IsRoleSupported(role))
+		require.Equal(t, builtIn[role], util.RolePermissions(role))
+	}
+
+	require.True(t, util.RoleHasPermission(util.BankerRole, util.PermissionAccountDepositAny))
+	require.False(t, util.RoleHasPermission(util.AuditorRole, util.PermissionAccountDeposit))
+}
@@ -0,0 +1,11 @@
+package persistence
+
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern builds an ILIKE pattern that matches text anywhere in a column.
+// LIKE wildcards in text are escaped so they match literally.
+func ContainsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
@@ -11,35 +11,93 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const blockOtherSessions = `-- name: BlockOtherSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND id <> $2 AND is_blocked = false
+`
+
+type BlockOtherSessionsParams struct {
+	Username string      `json:"username"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+// BlockOtherSessions blocks every session of a user except the one given
+func (q *Queries) BlockOtherSessions(ctx context.Context, arg BlockOtherSessionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, blockOtherSessions, arg.Username, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const blockSession = `-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND username = $2
+RETURNING id, username, user_agent, client_ip, is_blocked, expires_at, created_at
+`
+
+type BlockSessionParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Username string      `json:"username"`
+}
+
+func (q *Queries) BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error) {
+	row := q.db.QueryRow(ctx, blockSession, arg.ID, arg.Username)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const blockUserSessions = `-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND is_blocked = false
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, username string) (int64, error) {
+	result, err := q.db.Exec(ctx, blockUserSessions, username)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
   id,
   username,
-  refresh_token,
   user_agent,
   client_ip,
   is_blocked,
   expires_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, username, user_agent, client_ip, is_blocked, expires_at, created_at
 `
 
 type CreateSessionParams struct {
-	ID           pgtype.UUID        `json:"id"`
-	Username     string             `json:"username"`
-	RefreshToken string             `json:"refresh_token"`
-	UserAgent    string             `json:"user_agent"`
-	ClientIp     string             `json:"client_ip"`
-	IsBlocked    bool               `json:"is_blocked"`
-	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	ID        pgtype.UUID        `json:"id"`
+	Username  string             `json:"username"`
+	UserAgent string             `json:"user_agent"`
+	ClientIp  string             `json:"client_ip"`
+	IsBlocked bool               `json:"is_blocked"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
 	row := q.db.QueryRow(ctx, createSession,
 		arg.ID,
 		arg.Username,
-		arg.RefreshToken,
 		arg.UserAgent,
 		arg.ClientIp,
 		arg.IsBlocked,
@@ -49,7 +107,6 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 	err := row.Scan(
 		&i.ID,
 		&i.Username,
-		&i.RefreshToken,
 		&i.UserAgent,
 		&i.ClientIp,
 		&i.IsBlocked,
@@ -60,7 +117,7 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at 
+SELECT id, username, user_agent, client_ip, is_blocked, expires_at, created_at 
 FROM sessions 
 WHERE id = $1 
 LIMIT 1
@@ -72,7 +129,6 @@ func (q *Queries) GetSession(ctx context.Context, id pgtype.UUID) (Session, erro
 	err := row.Scan(
 		&i.ID,
 		&i.Username,
-		&i.RefreshToken,
 		&i.UserAgent,
 		&i.ClientIp,
 		&i.IsBlocked,
@@ -81,3 +137,77 @@ func (q *Queries) GetSession(ctx context.Context, id pgtype.UUID) (Session, erro
 	)
 	return i, err
 }
+
+const listRecentLogins = `-- name: ListRecentLogins :many
+SELECT client_ip, created_at
+FROM sessions
+WHERE username = $1
+ORDER BY created_at DESC
+LIMIT $2
+`
+
+type ListRecentLoginsParams struct {
+	Username string `json:"username"`
+	Limit    int32  `json:"limit"`
+}
+
+type ListRecentLoginsRow struct {
+	ClientIp  string             `json:"client_ip"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListRecentLogins(ctx context.Context, arg ListRecentLoginsParams) ([]ListRecentLoginsRow, error) {
+	rows, err := q.db.Query(ctx, listRecentLogins, arg.Username, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRecentLoginsRow{}
+	for rows.Next() {
+		var i ListRecentLoginsRow
+		if err := rows.Scan(&i.ClientIp, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessions = `-- name: ListSessions :many
+SELECT id, username, user_agent, client_ip, is_blocked, expires_at, created_at
+FROM sessions
+WHERE username = $1 AND expires_at > now()
+ORDER BY created_at DESC
+`
+
+// ListSessions lists the sessions of a user that have not expired yet, newest first
+func (q *Queries) ListSessions(ctx context.Context, username string) ([]Session, error) {
+	rows, err := q.db.Query(ctx, listSessions, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
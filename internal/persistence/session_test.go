@@ -0,0 +1,217 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, user User, expiresAt time.Time) Session {
+	return createRandomSessionTx(t, user, util.RandomString(32), expiresAt).Session
+}
+
+func createRandomSessionTx(t *testing.T, user User, refreshToken string, expiresAt time.Time) CreateSessionTxResult {
+	arg := CreateSessionTxParams{
+		CreateSessionParams: CreateSessionParams{
+			ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Username:  user.Username,
+			UserAgent: util.RandomString(10),
+			ClientIp:  "127.0.0.1",
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		},
+		RefreshToken: refreshToken,
+	}
+
+	result, err := NewStore(testDB).CreateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	session := result.Session
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.Username, session.Username)
+	require.False(t, session.IsBlocked)
+
+	// the first refresh token shares the ID of the session and only its hash is stored
+	require.Equal(t, session.ID, result.RefreshToken.ID)
+	require.Equal(t, session.ID, result.RefreshToken.SessionID)
+	require.Equal(t, util.HashToken(refreshToken), result.RefreshToken.TokenHash)
+	require.False(t, result.RefreshToken.RotatedAt.Valid)
+
+	return result
+}
+
+func renewRefreshToken(t *testing.T, user User, id pgtype.UUID, refreshToken string) (string, RotateRefreshTokenTxResult, error) {
+	newRefreshToken := util.RandomString(32)
+	result, err := NewStore(testDB).RotateRefreshTokenTx(context.Background(), RotateRefreshTokenTxParams{
+		ID:           id,
+		RefreshToken: refreshToken,
+		Username:     user.Username,
+		IssueRefreshToken: func(session Session, user User) (IssuedRefreshToken, error) {
+			return IssuedRefreshToken{
+				ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				Token:     newRefreshToken,
+				ExpiresAt: session.ExpiresAt.Time,
+			}, nil
+		},
+	})
+
+	return newRefreshToken, result, err
+}
+
+func TestListSessions(t *testing.T) {
+	user := createRandomUser(t)
+	expired := createRandomSession(t, user, time.Now().Add(-time.Minute))
+	first := createRandomSession(t, user, time.Now().Add(time.Hour))
+	second := createRandomSession(t, user, time.Now().Add(time.Hour))
+
+	sessions, err := testQueries.ListSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.NotContains(t, sessions, expired)
+	require.ElementsMatch(t, []Session{first, second}, sessions)
+}
+
+func TestBlockSession(t *testing.T) {
+	user := createRandomUser(t)
+	other := createRandomUser(t)
+	session := createRandomSession(t, user, time.Now().Add(time.Hour))
+
+	// a session can only be blocked by its owner
+	_, err := testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:       session.ID,
+		Username: other.Username,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	blocked, err := testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:       session.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	session, err = testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+}
+
+func TestBlockOtherSessions(t *testing.T) {
+	user := createRandomUser(t)
+	current := createRandomSession(t, user, time.Now().Add(time.Hour))
+	for i := 0; i < 2; i++ {
+		createRandomSession(t, user, time.Now().Add(time.Hour))
+	}
+
+	revoked, err := testQueries.BlockOtherSessions(context.Background(), BlockOtherSessionsParams{
+		Username: user.Username,
+		ID:       current.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), revoked)
+
+	sessions, err := testQueries.ListSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	for _, session := range sessions {
+		require.Equal(t, session.ID != current.ID, session.IsBlocked)
+	}
+}
+
+func TestBlockUserSessions(t *testing.T) {
+	user := createRandomUser(t)
+	other := createRandomSession(t, createRandomUser(t), time.Now().Add(time.Hour))
+	for i := 0; i < 3; i++ {
+		createRandomSession(t, user, time.Now().Add(time.Hour))
+	}
+
+	blocked, err := testQueries.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), blocked)
+
+	// blocked sessions are not counted again
+	blocked, err = testQueries.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Zero(t, blocked)
+
+	other, err = testQueries.GetSession(context.Background(), other.ID)
+	require.NoError(t, err)
+	require.False(t, other.IsBlocked)
+}
+
+func TestRotateRefreshTokenTx(t *testing.T) {
+	user := createRandomUser(t)
+	refreshToken := util.RandomString(32)
+	created := createRandomSessionTx(t, user, refreshToken, time.Now().Add(time.Hour))
+
+	// a token that does not match the stored hash is not renewed
+	_, _, err := renewRefreshToken(t, user, created.RefreshToken.ID, util.RandomString(32))
+	require.ErrorIs(t, err, ErrRefreshTokenMismatch)
+
+	_, _, err = renewRefreshToken(t, createRandomUser(t), created.RefreshToken.ID, refreshToken)
+	require.ErrorIs(t, err, ErrRefreshTokenMismatch)
+
+	newRefreshToken, result, err := renewRefreshToken(t, user, created.RefreshToken.ID, refreshToken)
+	require.NoError(t, err)
+	require.Equal(t, created.Session.ID, result.Session.ID)
+	require.Equal(t, created.Session.ID, result.RefreshToken.SessionID)
+	require.NotEqual(t, created.RefreshToken.ID, result.RefreshToken.ID)
+	require.Equal(t, util.HashToken(newRefreshToken), result.RefreshToken.TokenHash)
+	require.WithinDuration(t, created.Session.ExpiresAt.Time, result.RefreshToken.ExpiresAt.Time, time.Second)
+
+	rotated, err := testQueries.GetRefreshToken(context.Background(), created.RefreshToken.ID)
+	require.NoError(t, err)
+	require.True(t, rotated.RotatedAt.Valid)
+
+	require.Equal(t, user.Role, result.User.Role)
+
+	// the new token can be renewed in turn, and carries the role the user has by then
+	updated, err := testQueries.UpdateUserRole(context.Background(), UpdateUserRoleParams{
+		Username: user.Username,
+		Role:     util.BankerRole,
+	})
+	require.NoError(t, err)
+
+	_, result, err = renewRefreshToken(t, user, result.RefreshToken.ID, newRefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, updated.Role, result.User.Role)
+}
+
+func TestRotateRefreshTokenTxReuse(t *testing.T) {
+	user := createRandomUser(t)
+	refreshToken := util.RandomString(32)
+	created := createRandomSessionTx(t, user, refreshToken, time.Now().Add(time.Hour))
+
+	newRefreshToken, result, err := renewRefreshToken(t, user, created.RefreshToken.ID, refreshToken)
+	require.NoError(t, err)
+
+	// using the renewed token again revokes the whole session
+	_, reused, err := renewRefreshToken(t, user, created.RefreshToken.ID, refreshToken)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+	require.True(t, reused.Session.IsBlocked)
+
+	session, err := testQueries.GetSession(context.Background(), created.Session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+
+	// including the token it was renewed with
+	_, _, err = renewRefreshToken(t, user, result.RefreshToken.ID, newRefreshToken)
+	require.ErrorIs(t, err, ErrSessionBlocked)
+}
+
+func TestRotateRefreshTokenTxExpired(t *testing.T) {
+	user := createRandomUser(t)
+	refreshToken := util.RandomString(32)
+	created := createRandomSessionTx(t, user, refreshToken, time.Now().Add(-time.Second))
+
+	_, _, err := renewRefreshToken(t, user, created.RefreshToken.ID, refreshToken)
+	require.ErrorIs(t, err, ErrSessionExpired)
+
+	// the token was not renewed
+	stored, err := testQueries.GetRefreshToken(context.Background(), created.RefreshToken.ID)
+	require.NoError(t, err)
+	require.False(t, stored.RotatedAt.Valid)
+}
@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -13,6 +14,37 @@ type Store interface {
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
 	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error)
+	CreateAccountInvitationTx(ctx context.Context, arg CreateAccountInvitationTxParams) (CreateAccountInvitationTxResult, error)
+	AcceptAccountInvitationTx(ctx context.Context, arg AcceptAccountInvitationTxParams) (AcceptAccountInvitationTxResult, error)
+	RemoveAccountMemberTx(ctx context.Context, arg RemoveAccountMemberTxParams) (RemoveAccountMemberTxResult, error)
+	MovePocketFundsTx(ctx context.Context, arg MovePocketFundsTxParams) (MovePocketFundsTxResult, error)
+	CreateBeneficiaryTx(ctx context.Context, arg CreateBeneficiaryTxParams) (CreateBeneficiaryTxResult, error)
+	ResolveRecipient(ctx context.Context, recipient string, currency string) (Recipient, error)
+	CreatePaymentRequestTx(ctx context.Context, arg CreatePaymentRequestTxParams) (CreatePaymentRequestTxResult, error)
+	AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (AcceptPaymentRequestTxResult, error)
+	DeclinePaymentRequestTx(ctx context.Context, arg DeclinePaymentRequestTxParams) (DeclinePaymentRequestTxResult, error)
+	ImportPaymentBatchTx(ctx context.Context, arg ImportPaymentBatchTxParams) (ImportPaymentBatchTxResult, error)
+	ConfirmPaymentBatchTx(ctx context.Context, arg ConfirmPaymentBatchTxParams) (ConfirmPaymentBatchTxResult, error)
+	ExecutePaymentBatchItemTx(ctx context.Context, arg ExecutePaymentBatchItemTxParams) (ExecutePaymentBatchItemTxResult, error)
+	AssessedTransferTx(ctx context.Context, arg AssessedTransferTxParams) (AssessedTransferTxResult, error)
+	ApproveHeldTransferTx(ctx context.Context, arg ApproveHeldTransferTxParams) (ApproveHeldTransferTxResult, error)
+	OpenDisputeTx(ctx context.Context, arg OpenDisputeTxParams) (OpenDisputeTxResult, error)
+	UpdateDisputeStatusTx(ctx context.Context, arg UpdateDisputeStatusTxParams) (UpdateDisputeStatusTxResult, error)
+	CreateApprovalRequestTx(ctx context.Context, arg CreateApprovalRequestTxParams) (CreateApprovalRequestTxResult, error)
+	ApproveApprovalRequestTx(ctx context.Context, arg ApproveApprovalRequestTxParams) (ApproveApprovalRequestTxResult, error)
+	CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error)
+	RotateRefreshTokenTx(ctx context.Context, arg RotateRefreshTokenTxParams) (RotateRefreshTokenTxResult, error)
+	ConfirmTotpEnrollmentTx(ctx context.Context, arg ConfirmTotpEnrollmentTxParams) (ConfirmTotpEnrollmentTxResult, error)
+	VerifyMfaTx(ctx context.Context, arg VerifyMfaTxParams) (VerifyMfaTxResult, error)
+	DisableTotpTx(ctx context.Context, arg DisableTotpTxParams) error
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error)
+	CheckLoginAttempt(ctx context.Context, arg CheckLoginAttemptParams) (time.Duration, error)
+	RecordFailedLoginTx(ctx context.Context, arg RecordFailedLoginTxParams) (RecordFailedLoginTxResult, error)
+	PruneFailedLogins(ctx context.Context, policy LoginPolicy) error
+	AuthenticateApiKey(ctx context.Context, arg AuthenticateApiKeyParams) (AuthenticateApiKeyResult, error)
+	RefreshCurrencies(ctx context.Context) error
+	RefreshRoles(ctx context.Context) error
+	RotateTokenSigningKeys(ctx context.Context, arg RotateTokenSigningKeysParams) error
 }
 
 // PgStore provides all functions to execute db queries and transactions
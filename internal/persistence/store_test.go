@@ -3,7 +3,9 @@ package persistence
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/RobinHood3082/simplebank/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,7 +27,7 @@ func TestTransferTx(t *testing.T) {
 			result, err := store.TransferTx(context.Background(), TransferTxParams{
 				FromAccountID: account1.ID,
 				ToAccountID:   account2.ID,
-				Amount:        amount,
+				Amount:        util.Money{Amount: amount, Currency: account1.Currency},
 			})
 
 			errors <- err
@@ -132,7 +134,7 @@ func TestTransferTxDeadlock(t *testing.T) {
 			_, err := store.TransferTx(context.Background(), TransferTxParams{
 				FromAccountID: fromAccountID,
 				ToAccountID:   toAccountID,
-				Amount:        amount,
+				Amount:        util.Money{Amount: amount, Currency: account1.Currency},
 			})
 
 			errors <- err
@@ -155,3 +157,83 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxPocketedFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	pocket := createRandomPocket(t, account1)
+
+	// set aside all but 10 of the balance
+	_, err := store.MovePocketFundsTx(context.Background(), MovePocketFundsTxParams{
+		PocketID: pocket.ID,
+		Amount:   util.Money{Amount: account1.Balance - 10, Currency: account1.Currency},
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        util.Money{Amount: 11, Currency: account1.Currency},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        util.Money{Amount: 10, Currency: account1.Currency},
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+10, result.ToAccount.Balance)
+}
+
+func TestTransferTxBeneficiaryLimit(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	beneficiaryAccount := createRandomAccount(t)
+
+	sender, err := testQueries.GetUser(context.Background(), account1.Owner)
+	require.NoError(t, err)
+
+	// the sender can transfer from both accounts, the limit counts what was sent from either
+	for _, account := range []Account{account1, account2} {
+		_, err = testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+			AccountID: account.ID,
+			Username:  sender.Username,
+			Role:      util.AccountCanTransferRole,
+		})
+		require.NoError(t, err)
+	}
+	createRandomBeneficiary(t, sender, beneficiaryAccount)
+
+	beneficiaryLimit := BeneficiaryLimit{CoolingOffPeriod: time.Hour, Units: 5}
+	limit, err := util.MoneyFromUnits(beneficiaryAccount.Currency, beneficiaryLimit.Units, 0)
+	require.NoError(t, err)
+
+	send := func(from Account, amount int64, limit BeneficiaryLimit) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID:    from.ID,
+			ToAccountID:      beneficiaryAccount.ID,
+			Amount:           util.Money{Amount: amount, Currency: beneficiaryAccount.Currency},
+			SentBy:           sender.Username,
+			BeneficiaryLimit: limit,
+		})
+		return err
+	}
+
+	require.NoError(t, send(account1, limit.Amount-1, beneficiaryLimit))
+	require.ErrorIs(t, send(account2, 2, beneficiaryLimit), ErrBeneficiaryLimitExceeded)
+	require.NoError(t, send(account2, 1, beneficiaryLimit))
+	require.ErrorIs(t, send(account1, 1, beneficiaryLimit), ErrBeneficiaryLimitExceeded)
+
+	// the limit no longer applies once the cooling-off period is over
+	require.NoError(t, send(account1, 1, BeneficiaryLimit{Units: beneficiaryLimit.Units}))
+}
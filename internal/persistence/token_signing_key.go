@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type RotateTokenSigningKeysParams struct {
+	Keys      *token.KeySet
+	Algorithm string
+	// EncryptionKey seals the private keys stored in the database
+	EncryptionKey []byte
+	// RotationInterval is how old the newest key of Algorithm gets before a new key is made
+	RotationInterval time.Duration
+	// KeyLifetime is how long a new key is kept, long enough for the tokens it signs until its successor takes over
+	KeyLifetime time.Duration
+}
+
+// RotateTokenSigningKeys makes a new signing key once the newest key of the algorithm is older than
+// RotationInterval, deletes expired keys and loads the remaining ones into Keys. Every instance calls it,
+// instances rotating at the same time each add a key, which is harmless since only the newest one signs.
+func (store *PgStore) RotateTokenSigningKeys(ctx context.Context, arg RotateTokenSigningKeysParams) error {
+	_, err := store.DeleteExpiredTokenSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired token signing keys: %w", err)
+	}
+
+	rows, err := store.ListTokenSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list token signing keys: %w", err)
+	}
+
+	rotate := true
+	for _, row := range rows {
+		if row.Algorithm == arg.Algorithm && time.Since(row.CreatedAt.Time) < arg.RotationInterval {
+			rotate = false
+		}
+	}
+
+	if rotate {
+		key, err := token.GenerateSigningKey(arg.Algorithm)
+		if err != nil {
+			return err
+		}
+
+		sealedPrivateKey, err := token.SealPrivateKey(arg.EncryptionKey, key)
+		if err != nil {
+			return err
+		}
+
+		row, err := store.CreateTokenSigningKey(ctx, CreateTokenSigningKeyParams{
+			Kid:              key.ID,
+			Algorithm:        key.Algorithm,
+			SealedPrivateKey: sealedPrivateKey,
+			ExpiresAt:        pgtype.Timestamptz{Time: time.Now().Add(arg.KeyLifetime), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create token signing key: %w", err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	keys := make([]token.SigningKey, len(rows))
+	for i, row := range rows {
+		privateKey, err := token.OpenPrivateKey(arg.EncryptionKey, row.Kid, row.Algorithm, row.SealedPrivateKey)
+		if err != nil {
+			return err
+		}
+
+		keys[i] = token.SigningKey{
+			ID:         row.Kid,
+			Algorithm:  row.Algorithm,
+			PrivateKey: privateKey,
+			CreatedAt:  row.CreatedAt.Time,
+			ExpiresAt:  row.ExpiresAt.Time,
+		}
+	}
+
+	arg.Keys.Set(keys)
+	return nil
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/token"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+// testTokenEncryptionKey is fixed, keys left behind by earlier runs are loaded as well
+var testTokenEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func keyIDs(keys []token.SigningKey) []string {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+	return ids
+}
+
+func TestRotateTokenSigningKeys(t *testing.T) {
+	store := NewStore(testDB)
+	keys := token.NewKeySet(time.Minute)
+
+	arg := RotateTokenSigningKeysParams{
+		Keys:             keys,
+		Algorithm:        token.EdDSAAlgorithm,
+		EncryptionKey:    testTokenEncryptionKey,
+		RotationInterval: time.Hour,
+		KeyLifetime:      time.Minute,
+	}
+
+	err := store.RotateTokenSigningKeys(context.Background(), arg)
+	require.NoError(t, err)
+
+	loadedKeys := keys.Keys()
+	require.NotEmpty(t, loadedKeys)
+
+	// the newest key is younger than the rotation interval, so no key is added
+	err = store.RotateTokenSigningKeys(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, keyIDs(loadedKeys), keyIDs(keys.Keys()))
+
+	arg.RotationInterval = 0
+	err = store.RotateTokenSigningKeys(context.Background(), arg)
+	require.NoError(t, err)
+
+	rotatedKeys := keys.Keys()
+	require.Len(t, rotatedKeys, len(loadedKeys)+1)
+	require.Subset(t, keyIDs(rotatedKeys), keyIDs(loadedKeys))
+
+	newKey := rotatedKeys[len(rotatedKeys)-1]
+	require.Equal(t, token.EdDSAAlgorithm, newKey.Algorithm)
+	require.WithinDuration(t, time.Now(), newKey.CreatedAt, time.Second)
+	require.WithinDuration(t, time.Now().Add(time.Minute), newKey.ExpiresAt, time.Second)
+
+	// the loaded keys sign and verify tokens
+	maker, err := token.NewAsymmetricPasetoMaker(keys, "simplebank", "simplebank")
+	require.NoError(t, err)
+
+	accessToken, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(accessToken)
+	require.NoError(t, err)
+	require.Len(t, maker.PublicKeys().Keys, len(rotatedKeys))
+}
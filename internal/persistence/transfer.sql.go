@@ -7,26 +7,54 @@ package persistence
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTransfersSince = `-- name: CountTransfersSince :one
+SELECT COUNT(*) FROM transfers
+WHERE
+    from_account_id = $1
+    AND created_at >= $2
+`
+
+type CountTransfersSinceParams struct {
+	FromAccountID int64              `json:"from_account_id"`
+	Since         pgtype.Timestamptz `json:"since"`
+}
+
+func (q *Queries) CountTransfersSince(ctx context.Context, arg CountTransfersSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTransfersSince, arg.FromAccountID, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  reference
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+  $1, $2, $3, $4
+) RETURNING id, from_account_id, to_account_id, amount, created_at, reference
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Reference     string `json:"reference"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRow(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Reference,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +62,13 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Reference,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, reference FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -52,12 +81,59 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Reference,
 	)
 	return i, err
 }
 
+const getTransferredAmountSince = `-- name: GetTransferredAmountSince :one
+SELECT COALESCE(SUM(t.amount), 0)::bigint AS transferred_amount
+FROM transfers t
+JOIN account_members m ON m.account_id = t.from_account_id
+WHERE
+    m.username = $1
+    AND m.role IN ('owner', 'can-transfer')
+    AND t.to_account_id = $2
+    AND t.created_at >= $3
+`
+
+type GetTransferredAmountSinceParams struct {
+	Username    string             `json:"username"`
+	ToAccountID int64              `json:"to_account_id"`
+	Since       pgtype.Timestamptz `json:"since"`
+}
+
+// GetTransferredAmountSince sums what was sent to an account from every account the user can transfer from
+func (q *Queries) GetTransferredAmountSince(ctx context.Context, arg GetTransferredAmountSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getTransferredAmountSince, arg.Username, arg.ToAccountID, arg.Since)
+	var transferred_amount int64
+	err := row.Scan(&transferred_amount)
+	return transferred_amount, err
+}
+
+const hasTransferredTo = `-- name: HasTransferredTo :one
+SELECT EXISTS (
+    SELECT 1 FROM transfers
+    WHERE
+        from_account_id = $1
+        AND to_account_id = $2
+)
+`
+
+type HasTransferredToParams struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+}
+
+func (q *Queries) HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasTransferredTo, arg.FromAccountID, arg.ToAccountID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, reference FROM transfers
 WHERE 
     from_account_id = $1 OR
     to_account_id = $2
@@ -93,6 +169,111 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Reference,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTransfers = `-- name: SearchTransfers :many
+SELECT
+    t.id, t.from_account_id, t.to_account_id, t.amount, t.created_at, t.reference,
+    f.account_number AS from_account_number,
+    r.account_number AS to_account_number,
+    f.currency
+FROM transfers t
+JOIN accounts f ON f.id = t.from_account_id
+JOIN accounts r ON r.id = t.to_account_id
+WHERE
+    (
+        ($1::varchar IN ('', 'outgoing') AND t.from_account_id = ANY($2::bigint[]))
+        OR ($1::varchar IN ('', 'incoming') AND t.to_account_id = ANY($2::bigint[]))
+    )
+    AND (
+        $3::bigint IS NULL
+        OR (t.from_account_id = ANY($2::bigint[]) AND t.to_account_id = $3)
+        OR (t.to_account_id = ANY($2::bigint[]) AND t.from_account_id = $3)
+    )
+    AND ($4::timestamptz IS NULL OR t.created_at >= $4)
+    AND ($5::timestamptz IS NULL OR t.created_at < $5)
+    AND ($6::bigint IS NULL OR t.amount >= $6)
+    AND ($7::bigint IS NULL OR t.amount <= $7)
+    AND ($8::varchar IS NULL OR t.reference ILIKE $8)
+ORDER BY
+    CASE WHEN $9::varchar = 'amount_asc' THEN t.amount END ASC,
+    CASE WHEN $9::varchar = 'amount_desc' THEN t.amount END DESC,
+    CASE WHEN $9::varchar = 'oldest' THEN t.created_at END ASC,
+    t.created_at DESC,
+    t.id DESC
+LIMIT $10
+OFFSET $11
+`
+
+type SearchTransfersParams struct {
+	Direction             string             `json:"direction"`
+	AccountIds            []int64            `json:"account_ids"`
+	CounterpartyAccountID pgtype.Int8        `json:"counterparty_account_id"`
+	CreatedAfter          pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore         pgtype.Timestamptz `json:"created_before"`
+	MinAmount             pgtype.Int8        `json:"min_amount"`
+	MaxAmount             pgtype.Int8        `json:"max_amount"`
+	Reference             pgtype.Text        `json:"reference"`
+	Sort                  string             `json:"sort"`
+	Limit                 int32              `json:"limit"`
+	Offset                int32              `json:"offset"`
+}
+
+type SearchTransfersRow struct {
+	ID                int64              `json:"id"`
+	FromAccountID     int64              `json:"from_account_id"`
+	ToAccountID       int64              `json:"to_account_id"`
+	Amount            int64              `json:"amount"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	Reference         string             `json:"reference"`
+	FromAccountNumber string             `json:"from_account_number"`
+	ToAccountNumber   string             `json:"to_account_number"`
+	Currency          string             `json:"currency"`
+}
+
+// SearchTransfers finds transfers into or out of the given accounts. Filters left NULL match every transfer.
+// reference is an ILIKE pattern.
+func (q *Queries) SearchTransfers(ctx context.Context, arg SearchTransfersParams) ([]SearchTransfersRow, error) {
+	rows, err := q.db.Query(ctx, searchTransfers,
+		arg.Direction,
+		arg.AccountIds,
+		arg.CounterpartyAccountID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Reference,
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchTransfersRow{}
+	for rows.Next() {
+		var i SearchTransfersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Reference,
+			&i.FromAccountNumber,
+			&i.ToAccountNumber,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,187 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func assessRandomTransfer(t *testing.T, fromAccount, toAccount Account, hold bool) AssessedTransferTxResult {
+	arg := AssessedTransferTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        util.Money{Amount: 10, Currency: fromAccount.Currency},
+			Reference:     util.RandomString(12),
+		},
+		TransferAssessment: TransferAssessment{
+			RequestedBy: fromAccount.Owner,
+			ClientIp:    "10.0.0.1:50312",
+			Decision:    "block",
+			Findings:    []byte(`[{"rule":"velocity","decision":"block","reason":"too many transfers"}]`),
+		},
+		Hold: hold,
+	}
+
+	result, err := NewStore(testDB).AssessedTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	assessment := result.Assessment
+	require.NotZero(t, assessment.ID)
+	require.Equal(t, fromAccount.ID, assessment.FromAccountID)
+	require.Equal(t, toAccount.ID, assessment.ToAccountID)
+	require.Equal(t, int64(10), assessment.Amount)
+	require.Equal(t, fromAccount.Currency, assessment.Currency)
+	require.Equal(t, arg.Reference, assessment.Reference)
+	require.Equal(t, arg.RequestedBy, assessment.RequestedBy)
+	require.JSONEq(t, string(arg.Findings), string(assessment.Findings))
+	require.False(t, assessment.ReviewedBy.Valid)
+
+	return result
+}
+
+func TestAssessedTransferTx(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+
+	result := assessRandomTransfer(t, fromAccount, toAccount, false)
+	require.Equal(t, util.TransferRiskExecuted, result.Assessment.Status)
+	require.NotZero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Assessment.TransferID.Int64)
+	require.Equal(t, fromAccount.Balance-10, result.Transfer.FromAccount.Balance)
+
+	// a held transfer does not move any money
+	result = assessRandomTransfer(t, fromAccount, toAccount, true)
+	require.Equal(t, util.TransferRiskHeld, result.Assessment.Status)
+	require.False(t, result.Assessment.TransferID.Valid)
+	require.Zero(t, result.Transfer.Transfer.ID)
+
+	account, err := testQueries.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance-10, account.Balance)
+
+	held, err := testQueries.ListTransferRiskAssessments(context.Background(), ListTransferRiskAssessmentsParams{
+		Status: util.TransferRiskHeld,
+		Limit:  1000,
+	})
+	require.NoError(t, err)
+	require.Contains(t, held, ListTransferRiskAssessmentsRow{
+		TransferRiskAssessment: result.Assessment,
+		FromAccountNumber:      fromAccount.AccountNumber,
+		ToAccountNumber:        toAccount.AccountNumber,
+	})
+}
+
+func TestApproveHeldTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	banker := createRandomUser(t)
+
+	held := assessRandomTransfer(t, fromAccount, toAccount, true)
+
+	// the sender cannot approve their own transfer
+	_, err := store.ApproveHeldTransferTx(context.Background(), ApproveHeldTransferTxParams{
+		AssessmentID: held.Assessment.ID,
+		ReviewedBy:   held.Assessment.RequestedBy,
+	})
+	require.ErrorIs(t, err, ErrTransferSelfReview)
+
+	result, err := store.ApproveHeldTransferTx(context.Background(), ApproveHeldTransferTxParams{
+		AssessmentID: held.Assessment.ID,
+		ReviewedBy:   banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.TransferRiskApproved, result.Assessment.Status)
+	require.Equal(t, banker.Username, result.Assessment.ReviewedBy.String)
+	require.WithinDuration(t, time.Now(), result.Assessment.ReviewedAt.Time, time.Second)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Assessment.TransferID.Int64)
+	require.Equal(t, held.Assessment.Reference, result.Transfer.Transfer.Reference)
+	require.Equal(t, fromAccount.Balance-10, result.Transfer.FromAccount.Balance)
+	require.Equal(t, toAccount.Balance+10, result.Transfer.ToAccount.Balance)
+
+	// a held transfer is made at most once
+	_, err = store.ApproveHeldTransferTx(context.Background(), ApproveHeldTransferTxParams{
+		AssessmentID: held.Assessment.ID,
+		ReviewedBy:   banker.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferNotHeld)
+}
+
+func TestRejectHeldTransfer(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	banker := createRandomUser(t)
+
+	held := assessRandomTransfer(t, fromAccount, toAccount, true)
+
+	arg := ReviewTransferRiskAssessmentParams{
+		ID:         held.Assessment.ID,
+		Status:     util.TransferRiskRejected,
+		ReviewedBy: pgtype.Text{String: banker.Username, Valid: true},
+	}
+
+	assessment, err := testQueries.ReviewTransferRiskAssessment(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, util.TransferRiskRejected, assessment.Status)
+	require.False(t, assessment.TransferID.Valid)
+
+	_, err = testQueries.ReviewTransferRiskAssessment(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// executed transfers cannot be reviewed
+	executed := assessRandomTransfer(t, fromAccount, toAccount, false)
+	arg.ID = executed.Assessment.ID
+	_, err = testQueries.ReviewTransferRiskAssessment(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestTransferRiskSignals(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	since := pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	count, err := testQueries.CountTransfersSince(context.Background(), CountTransfersSinceParams{
+		FromAccountID: fromAccount.ID,
+		Since:         since,
+	})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	transferred, err := testQueries.HasTransferredTo(context.Background(), HasTransferredToParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, transferred)
+
+	assessRandomTransfer(t, fromAccount, toAccount, false)
+	assessRandomTransfer(t, fromAccount, toAccount, false)
+
+	count, err = testQueries.CountTransfersSince(context.Background(), CountTransfersSinceParams{
+		FromAccountID: fromAccount.ID,
+		Since:         since,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	transferred, err = testQueries.HasTransferredTo(context.Background(), HasTransferredToParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, transferred)
+
+	// the other way round is a new counterparty
+	transferred, err = testQueries.HasTransferredTo(context.Background(), HasTransferredToParams{
+		FromAccountID: toAccount.ID,
+		ToAccountID:   fromAccount.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, transferred)
+}
@@ -2,10 +2,12 @@ package persistence
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,6 +16,7 @@ func createRandomTransfer(t *testing.T, account1, account2 Account) Transfer {
 		FromAccountID: account1.ID,
 		ToAccountID:   account2.ID,
 		Amount:        util.RandomMoney(),
+		Reference:     util.RandomString(12),
 	}
 
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
@@ -23,6 +26,7 @@ func createRandomTransfer(t *testing.T, account1, account2 Account) Transfer {
 	require.Equal(t, account1.ID, transfer.FromAccountID)
 	require.Equal(t, account2.ID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.Reference, transfer.Reference)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -77,3 +81,59 @@ func TestListTransfers(t *testing.T) {
 		require.Equal(t, account2.ID, transfer.ToAccountID)
 	}
 }
+
+func TestSearchTransfers(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	sent := createRandomTransfer(t, account1, account2)
+	received := createRandomTransfer(t, account3, account1)
+	createRandomTransfer(t, account2, account3)
+
+	search := func(arg SearchTransfersParams) []SearchTransfersRow {
+		arg.AccountIds = []int64{account1.ID}
+		arg.Limit = 10
+		transfers, err := testQueries.SearchTransfers(context.Background(), arg)
+		require.NoError(t, err)
+		return transfers
+	}
+
+	transfers := search(SearchTransfersParams{})
+	require.Len(t, transfers, 2)
+	require.Equal(t, received.ID, transfers[0].ID)
+	require.Equal(t, sent.ID, transfers[1].ID)
+	require.Equal(t, account1.AccountNumber, transfers[1].FromAccountNumber)
+	require.Equal(t, account2.AccountNumber, transfers[1].ToAccountNumber)
+	require.Equal(t, account1.Currency, transfers[1].Currency)
+
+	transfers = search(SearchTransfersParams{Direction: util.SearchDirectionOutgoing})
+	require.Len(t, transfers, 1)
+	require.Equal(t, sent.ID, transfers[0].ID)
+
+	transfers = search(SearchTransfersParams{Direction: util.SearchDirectionIncoming})
+	require.Len(t, transfers, 1)
+	require.Equal(t, received.ID, transfers[0].ID)
+
+	transfers = search(SearchTransfersParams{CounterpartyAccountID: pgtype.Int8{Int64: account3.ID, Valid: true}})
+	require.Len(t, transfers, 1)
+	require.Equal(t, received.ID, transfers[0].ID)
+
+	transfers = search(SearchTransfersParams{Reference: pgtype.Text{String: ContainsPattern(strings.ToUpper(sent.Reference[2:8])), Valid: true}})
+	require.Len(t, transfers, 1)
+	require.Equal(t, sent.ID, transfers[0].ID)
+
+	transfers = search(SearchTransfersParams{
+		MinAmount: pgtype.Int8{Int64: sent.Amount, Valid: true},
+		MaxAmount: pgtype.Int8{Int64: sent.Amount, Valid: true},
+		Direction: util.SearchDirectionOutgoing,
+	})
+	require.Len(t, transfers, 1)
+
+	transfers = search(SearchTransfersParams{CreatedAfter: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}})
+	require.Empty(t, transfers)
+
+	transfers = search(SearchTransfersParams{Sort: util.SearchSortOldestFirst})
+	require.Len(t, transfers, 2)
+	require.Equal(t, sent.ID, transfers[0].ID)
+}
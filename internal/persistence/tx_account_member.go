@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+var ErrLastAccountOwner = errors.New("cannot remove the last owner of an account")
+
+type CreateAccountInvitationTxParams struct {
+	CreateAccountInvitationParams
+	AfterCreate func(invitation AccountInvitation) error
+}
+
+type CreateAccountInvitationTxResult struct {
+	Invitation AccountInvitation
+}
+
+// CreateAccountInvitationTx stores an invitation to join an account, AfterCreate is used to notify the invitee
+func (store *PgStore) CreateAccountInvitationTx(ctx context.Context, arg CreateAccountInvitationTxParams) (CreateAccountInvitationTxResult, error) {
+	var result CreateAccountInvitationTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.Invitation, err = q.CreateAccountInvitation(ctx, arg.CreateAccountInvitationParams)
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterCreate(result.Invitation)
+		},
+	)
+
+	return result, err
+}
+
+type AcceptAccountInvitationTxParams struct {
+	InvitationID int64
+	SecretCode   string
+}
+
+type AcceptAccountInvitationTxResult struct {
+	Invitation AccountInvitation
+	Member     AccountMember
+}
+
+// AcceptAccountInvitationTx marks the invitation as accepted and adds the invitee to the account
+func (store *PgStore) AcceptAccountInvitationTx(ctx context.Context, arg AcceptAccountInvitationTxParams) (AcceptAccountInvitationTxResult, error) {
+	var result AcceptAccountInvitationTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.Invitation, err = q.AcceptAccountInvitation(ctx, AcceptAccountInvitationParams{
+				ID:             arg.InvitationID,
+				SecretCodeHash: util.HashToken(arg.SecretCode),
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Member, err = q.CreateAccountMember(ctx, CreateAccountMemberParams{
+				AccountID: result.Invitation.AccountID,
+				Username:  result.Invitation.Username,
+				Role:      result.Invitation.Role,
+			})
+
+			return err
+		},
+	)
+
+	return result, err
+}
+
+type RemoveAccountMemberTxParams struct {
+	AccountID   int64
+	Username    string
+	AfterRemove func(member AccountMember) error
+}
+
+type RemoveAccountMemberTxResult struct {
+	Member AccountMember
+}
+
+// RemoveAccountMemberTx removes a member from an account, refusing to leave the account without an owner
+func (store *PgStore) RemoveAccountMemberTx(ctx context.Context, arg RemoveAccountMemberTxParams) (RemoveAccountMemberTxResult, error) {
+	var result RemoveAccountMemberTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			// lock the account so concurrent removals cannot both pass the owner check
+			_, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			result.Member, err = q.GetAccountMember(ctx, GetAccountMemberParams{
+				AccountID: arg.AccountID,
+				Username:  arg.Username,
+			})
+			if err != nil {
+				return err
+			}
+
+			if result.Member.Role == util.AccountOwnerRole {
+				owners, err := q.CountAccountOwners(ctx, arg.AccountID)
+				if err != nil {
+					return err
+				}
+
+				if owners <= 1 {
+					return ErrLastAccountOwner
+				}
+			}
+
+			err = q.DeleteAccountMember(ctx, DeleteAccountMemberParams{
+				AccountID: arg.AccountID,
+				Username:  arg.Username,
+			})
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterRemove(result.Member)
+		},
+	)
+
+	return result, err
+}
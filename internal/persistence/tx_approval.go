@@ -0,0 +1,212 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrApprovalRequestNotPending = errors.New("approval request is no longer pending")
+	ErrApprovalRequestExpired    = errors.New("approval request has expired")
+	ErrSelfApproval              = errors.New("approval request must be approved by another banker")
+)
+
+// AccountDeposit is the payload of a request to add balance to an account
+type AccountDeposit struct {
+	AccountID int64      `json:"account_id"`
+	Amount    util.Money `json:"amount"`
+}
+
+// DisputeStatusChange is the payload of a request to move a dispute
+type DisputeStatusChange struct {
+	DisputeID int64  `json:"dispute_id"`
+	Status    string `json:"status"`
+	Note      string `json:"note"`
+}
+
+// UserRoleChange is the payload of a request to change the role of a user
+type UserRoleChange struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// AccountLimitsChange is the payload of a request to override the limits of an account. Limits that
+// are not set fall back to the configured ones, the amounts are in minor units of the account currency.
+type AccountLimitsChange struct {
+	AccountID                   int64       `json:"account_id"`
+	MaxTransfersPerHour         pgtype.Int8 `json:"max_transfers_per_hour"`
+	NewCounterpartyThreshold    pgtype.Int8 `json:"new_counterparty_threshold"`
+	NewBeneficiaryTransferLimit pgtype.Int8 `json:"new_beneficiary_transfer_limit"`
+}
+
+type CreateApprovalRequestTxParams struct {
+	Operation   string
+	Payload     any
+	RequestedBy string
+	ExpiresAt   time.Time
+	// AfterCreate is used to schedule the expiry of the request
+	AfterCreate func(request ApprovalRequest) error
+}
+
+type CreateApprovalRequestTxResult struct {
+	Request ApprovalRequest
+}
+
+// CreateApprovalRequestTx holds an operation until another banker approves it
+func (store *PgStore) CreateApprovalRequestTx(ctx context.Context, arg CreateApprovalRequestTxParams) (CreateApprovalRequestTxResult, error) {
+	var result CreateApprovalRequestTxResult
+
+	payload, err := json.Marshal(arg.Payload)
+	if err != nil {
+		return result, err
+	}
+
+	err = store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.Request, err = q.CreateApprovalRequest(ctx, CreateApprovalRequestParams{
+				Operation:   arg.Operation,
+				Payload:     payload,
+				RequestedBy: arg.RequestedBy,
+				ExpiresAt:   pgtype.Timestamptz{Time: arg.ExpiresAt, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterCreate(result.Request)
+		},
+	)
+
+	return result, err
+}
+
+type ApproveApprovalRequestTxParams struct {
+	RequestID  int64
+	ReviewedBy string
+	ReviewNote string
+}
+
+// ApproveApprovalRequestTxResult holds the request and what its operation changed,
+// only the fields of the approved operation are set
+type ApproveApprovalRequestTxResult struct {
+	Request ApprovalRequest
+	// Account is set by add_account_balance and update_account_limits
+	Account Account
+	// Dispute and DisputeEvent are set by update_dispute_status
+	Dispute      Dispute
+	DisputeEvent DisputeEvent
+	// User is set by update_user_role
+	User User
+	// AccountLimit is set by update_account_limits
+	AccountLimit AccountLimit
+}
+
+// ApproveApprovalRequestTx executes a held operation on behalf of the banker who approved it, in the same
+// transaction as the approval, so it is executed exactly once. ErrSelfApproval is returned when the banker
+// requested the operation themselves, ErrApprovalRequestNotPending or ErrApprovalRequestExpired when it can
+// no longer be approved.
+func (store *PgStore) ApproveApprovalRequestTx(ctx context.Context, arg ApproveApprovalRequestTxParams) (ApproveApprovalRequestTxResult, error) {
+	var result ApproveApprovalRequestTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			request, err := q.GetApprovalRequestForUpdate(ctx, arg.RequestID)
+			if err != nil {
+				return err
+			}
+
+			if request.Status != util.ApprovalPending {
+				return ErrApprovalRequestNotPending
+			}
+
+			if !request.ExpiresAt.Time.After(time.Now()) {
+				return ErrApprovalRequestExpired
+			}
+
+			if request.RequestedBy == arg.ReviewedBy {
+				return ErrSelfApproval
+			}
+
+			switch request.Operation {
+			case util.ApprovalAddAccountBalance:
+				var deposit AccountDeposit
+				if err := json.Unmarshal(request.Payload, &deposit); err != nil {
+					return err
+				}
+
+				result.Account, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+					ID:     deposit.AccountID,
+					Amount: deposit.Amount.Amount,
+				})
+			case util.ApprovalUpdateDisputeStatus:
+				var change DisputeStatusChange
+				if err := json.Unmarshal(request.Payload, &change); err != nil {
+					return err
+				}
+
+				var moved UpdateDisputeStatusTxResult
+				moved, err = applyDisputeStatus(ctx, q, UpdateDisputeStatusTxParams{
+					DisputeID: change.DisputeID,
+					Status:    change.Status,
+					Actor:     request.RequestedBy,
+					Note:      change.Note,
+				})
+				result.Dispute, result.DisputeEvent = moved.Dispute, moved.Event
+			case util.ApprovalUpdateUserRole:
+				var change UserRoleChange
+				if err := json.Unmarshal(request.Payload, &change); err != nil {
+					return err
+				}
+
+				result.User, err = q.UpdateUserRole(ctx, UpdateUserRoleParams{
+					Username: change.Username,
+					Role:     change.Role,
+				})
+			case util.ApprovalUpdateAccountLimits:
+				var change AccountLimitsChange
+				if err := json.Unmarshal(request.Payload, &change); err != nil {
+					return err
+				}
+
+				result.AccountLimit, err = q.UpsertAccountLimit(ctx, UpsertAccountLimitParams{
+					AccountID:                   change.AccountID,
+					MaxTransfersPerHour:         change.MaxTransfersPerHour,
+					NewCounterpartyThreshold:    change.NewCounterpartyThreshold,
+					NewBeneficiaryTransferLimit: change.NewBeneficiaryTransferLimit,
+					UpdatedBy:                   arg.ReviewedBy,
+				})
+				if err != nil {
+					return err
+				}
+
+				result.Account, err = q.GetAccount(ctx, change.AccountID)
+			default:
+				err = fmt.Errorf("unknown operation %q", request.Operation)
+			}
+			if err != nil {
+				return err
+			}
+
+			result.Request, err = q.ReviewApprovalRequest(ctx, ReviewApprovalRequestParams{
+				ID:         request.ID,
+				Status:     util.ApprovalApproved,
+				ReviewedBy: pgtype.Text{String: arg.ReviewedBy, Valid: true},
+				ReviewNote: arg.ReviewNote,
+			})
+
+			return err
+		},
+	)
+
+	return result, err
+}
@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrBeneficiaryLimitExceeded = errors.New("transfers to a new beneficiary are limited")
+
+// BeneficiaryLimit caps what a user can send to a beneficiary while it is new
+type BeneficiaryLimit struct {
+	// CoolingOffPeriod is how long after being added a beneficiary is subject to the limit
+	CoolingOffPeriod time.Duration
+	// Units is the limit in whole units of the transfer currency,
+	// replaced by the new_beneficiary_transfer_limit of the sending account when it is set
+	Units int64
+}
+
+type CreateBeneficiaryTxParams struct {
+	CreateBeneficiaryParams
+	AfterCreate func(beneficiary Beneficiary) error
+}
+
+type CreateBeneficiaryTxResult struct {
+	Beneficiary Beneficiary
+}
+
+// CreateBeneficiaryTx saves a beneficiary for a user, AfterCreate is used to notify the user
+func (store *PgStore) CreateBeneficiaryTx(ctx context.Context, arg CreateBeneficiaryTxParams) (CreateBeneficiaryTxResult, error) {
+	var result CreateBeneficiaryTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.Beneficiary, err = q.CreateBeneficiary(ctx, arg.CreateBeneficiaryParams)
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterCreate(result.Beneficiary)
+		},
+	)
+
+	return result, err
+}
+
+// CoolingOffEndsAt returns when the beneficiary stops being subject to the new beneficiary transfer limit
+func (beneficiary Beneficiary) CoolingOffEndsAt(period time.Duration) time.Time {
+	return beneficiary.CreatedAt.Time.Add(period)
+}
+
+// checkBeneficiaryLimit returns ErrBeneficiaryLimitExceeded when the receiving account is a beneficiary of the sender
+// in its cooling-off period and the transfer would take what they sent it, from any of their accounts, over the limit.
+// Both accounts must already be locked, so concurrent transfers to the beneficiary are counted one after the other.
+func checkBeneficiaryLimit(ctx context.Context, q *Queries, arg TransferTxParams) error {
+	beneficiary, err := q.GetBeneficiaryByAccount(ctx, GetBeneficiaryByAccountParams{
+		Owner:     arg.SentBy,
+		AccountID: arg.ToAccountID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	coolingOffEndsAt := beneficiary.CoolingOffEndsAt(arg.BeneficiaryLimit.CoolingOffPeriod)
+	if time.Now().After(coolingOffEndsAt) {
+		return nil
+	}
+
+	limit, err := util.MoneyFromUnits(arg.Amount.Currency, arg.BeneficiaryLimit.Units, 0)
+	if err != nil {
+		return err
+	}
+
+	override, err := q.GetAccountLimit(ctx, arg.FromAccountID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if override.NewBeneficiaryTransferLimit.Valid {
+		limit.Amount = override.NewBeneficiaryTransferLimit.Int64
+	}
+
+	transferred, err := q.GetTransferredAmountSince(ctx, GetTransferredAmountSinceParams{
+		Username:    arg.SentBy,
+		ToAccountID: arg.ToAccountID,
+		Since:       beneficiary.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	total, err := arg.Amount.Add(util.Money{Amount: transferred, Currency: arg.Amount.Currency})
+	if err != nil || total.Amount > limit.Amount {
+		return fmt.Errorf("%w to %s until %s", ErrBeneficiaryLimitExceeded, limit, coolingOffEndsAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -2,10 +2,22 @@ package persistence
 
 import (
 	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+var ErrCurrencyDisabled = errors.New("currency is disabled for new accounts")
+
+// maxAccountNumberAttempts bounds how often a colliding random account number is regenerated
+const maxAccountNumberAttempts = 3
+
 type CreateAccountTxParams struct {
 	CreateAccountParams
+	// BankCode prefixes the generated account number; CreateAccountParams.AccountNumber is ignored
+	BankCode    string
 	AfterCreate func(account Account) error
 }
 
@@ -15,6 +27,26 @@ type CreateAccountTxResult struct {
 
 func (store *PgStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error) {
 	var result CreateAccountTxResult
+	var err error
+
+	for range maxAccountNumberAttempts {
+		arg.AccountNumber, err = util.NewAccountNumber(arg.BankCode)
+		if err != nil {
+			return result, err
+		}
+
+		result, err = store.createAccountTx(ctx, arg)
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.ConstraintName != "accounts_account_number_key" {
+			break
+		}
+	}
+
+	return result, err
+}
+
+func (store *PgStore) createAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error) {
+	var result CreateAccountTxResult
 
 	err := store.execTx(
 		ctx,
@@ -26,11 +58,37 @@ func (store *PgStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxPa
 				return err
 			}
 
+			// the cached catalogue may lag behind a banker disabling the currency on another instance
+			currency, err := q.GetCurrency(ctx, arg.CreateAccountParams.Currency)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrCurrencyDisabled
+				}
+				return err
+			}
+
+			if !currency.IsEnabled {
+				return ErrCurrencyDisabled
+			}
+
 			result.Account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
 			if err != nil {
 				return err
 			}
 
+			_, err = q.CreateAccountMember(ctx, CreateAccountMemberParams{
+				AccountID: result.Account.ID,
+				Username:  result.Account.Owner,
+				Role:      util.AccountOwnerRole,
+			})
+			if err != nil {
+				return err
+			}
+
+			if arg.AfterCreate == nil {
+				return nil
+			}
+
 			return arg.AfterCreate(result.Account)
 		},
 	)
@@ -0,0 +1,214 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidDisputeTransition = errors.New("dispute cannot move to that status")
+
+type OpenDisputeTxParams struct {
+	CreateDisputeParams
+	// Attachments are stored in order, their DisputeID is filled in
+	Attachments []CreateDisputeAttachmentParams
+	// AfterOpen is used to notify both parties of the transfer
+	AfterOpen func(dispute Dispute, event DisputeEvent) error
+}
+
+type OpenDisputeTxResult struct {
+	Dispute     Dispute
+	Attachments []DisputeAttachment
+	Event       DisputeEvent
+}
+
+// OpenDisputeTx opens a dispute with its attachments and records who opened it
+func (store *PgStore) OpenDisputeTx(ctx context.Context, arg OpenDisputeTxParams) (OpenDisputeTxResult, error) {
+	var result OpenDisputeTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.Dispute, err = q.CreateDispute(ctx, arg.CreateDisputeParams)
+			if err != nil {
+				return err
+			}
+
+			result.Attachments = make([]DisputeAttachment, 0, len(arg.Attachments))
+			for _, attachmentArg := range arg.Attachments {
+				attachmentArg.DisputeID = result.Dispute.ID
+
+				attachment, err := q.CreateDisputeAttachment(ctx, attachmentArg)
+				if err != nil {
+					return err
+				}
+
+				result.Attachments = append(result.Attachments, attachment)
+			}
+
+			result.Event, err = q.CreateDisputeEvent(ctx, CreateDisputeEventParams{
+				DisputeID: result.Dispute.ID,
+				Status:    result.Dispute.Status,
+				Actor:     arg.OpenedBy,
+				Note:      arg.Reason,
+			})
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterOpen(result.Dispute, result.Event)
+		},
+	)
+
+	return result, err
+}
+
+type UpdateDisputeStatusTxParams struct {
+	DisputeID int64
+	Status    string
+	// Actor is the banker moving the dispute
+	Actor string
+	Note  string
+	// AfterUpdate is used to notify both parties of the transfer
+	AfterUpdate func(dispute Dispute, event DisputeEvent) error
+}
+
+type UpdateDisputeStatusTxResult struct {
+	Dispute Dispute
+	Event   DisputeEvent
+}
+
+// UpdateDisputeStatusTx moves a dispute to its next status and books the ledger entries that go with it:
+//   - a provisional credit credits the customer with the disputed amount
+//   - resolving for the customer takes the money back from the recipient, and credits the customer
+//     unless they were credited provisionally
+//   - resolving against the customer takes back a provisional credit
+//
+// ErrInvalidDisputeTransition is returned when the dispute cannot move to the status.
+func (store *PgStore) UpdateDisputeStatusTx(ctx context.Context, arg UpdateDisputeStatusTxParams) (UpdateDisputeStatusTxResult, error) {
+	var result UpdateDisputeStatusTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result, err = applyDisputeStatus(ctx, q, arg)
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterUpdate(result.Dispute, result.Event)
+		},
+	)
+
+	return result, err
+}
+
+// applyDisputeStatus moves a dispute within a transaction, it is shared with the approval of a held move
+func applyDisputeStatus(ctx context.Context, q *Queries, arg UpdateDisputeStatusTxParams) (UpdateDisputeStatusTxResult, error) {
+	var result UpdateDisputeStatusTxResult
+
+	dispute, err := q.GetDisputeForUpdate(ctx, arg.DisputeID)
+	if err != nil {
+		return result, err
+	}
+
+	if !util.CanMoveDispute(dispute.Status, arg.Status) {
+		return result, ErrInvalidDisputeTransition
+	}
+
+	update := UpdateDisputeStatusParams{
+		ID:     dispute.ID,
+		Status: arg.Status,
+	}
+
+	switch arg.Status {
+	case util.DisputeProvisionalCredit:
+		update.CreditEntryID, err = bookDisputeEntry(ctx, q, dispute.AccountID, dispute.Amount)
+	case util.DisputeResolvedForCustomer:
+		err = chargeBack(ctx, q, dispute, &update)
+	case util.DisputeResolvedAgainstCustomer:
+		if dispute.CreditEntryID.Valid {
+			update.ReversalEntryID, err = bookDisputeEntry(ctx, q, dispute.AccountID, -dispute.Amount)
+		}
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if util.IsDisputeResolved(arg.Status) {
+		update.ResolvedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	}
+
+	result.Dispute, err = q.UpdateDisputeStatus(ctx, update)
+	if err != nil {
+		return result, err
+	}
+
+	result.Event, err = q.CreateDisputeEvent(ctx, CreateDisputeEventParams{
+		DisputeID: dispute.ID,
+		Status:    arg.Status,
+		Actor:     arg.Actor,
+		Note:      arg.Note,
+	})
+
+	return result, err
+}
+
+// chargeBack takes the disputed amount back from the recipient of the transfer and credits the customer,
+// unless they were credited provisionally. Both accounts are booked in ID order, as transfers do,
+// so concurrent bookings cannot deadlock.
+func chargeBack(ctx context.Context, q *Queries, dispute Dispute, update *UpdateDisputeStatusParams) error {
+	transfer, err := q.GetTransfer(ctx, dispute.TransferID)
+	if err != nil {
+		return err
+	}
+
+	credit := !dispute.CreditEntryID.Valid
+	if credit && dispute.AccountID < transfer.ToAccountID {
+		update.CreditEntryID, err = bookDisputeEntry(ctx, q, dispute.AccountID, dispute.Amount)
+		if err != nil {
+			return err
+		}
+		credit = false
+	}
+
+	update.ChargebackEntryID, err = bookDisputeEntry(ctx, q, transfer.ToAccountID, -dispute.Amount)
+	if err != nil {
+		return err
+	}
+
+	if credit {
+		update.CreditEntryID, err = bookDisputeEntry(ctx, q, dispute.AccountID, dispute.Amount)
+	}
+
+	return err
+}
+
+// bookDisputeEntry books an entry that is not part of a transfer, applies it to the account balance
+// and returns the ID of the entry
+func bookDisputeEntry(ctx context.Context, q *Queries, accountID int64, amount int64) (pgtype.Int8, error) {
+	entry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: accountID,
+		Amount:    amount,
+	})
+	if err != nil {
+		return pgtype.Int8{}, err
+	}
+
+	_, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     accountID,
+		Amount: amount,
+	})
+	if err != nil {
+		return pgtype.Int8{}, err
+	}
+
+	return pgtype.Int8{Int64: entry.ID, Valid: true}, nil
+}
@@ -0,0 +1,198 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrLoginLocked          = errors.New("account is temporarily locked after too many failed logins")
+	ErrTooManyLoginAttempts = errors.New("too many failed logins")
+)
+
+// LoginPolicy limits how fast passwords can be guessed
+type LoginPolicy struct {
+	// MaxFailedAttempts is how many consecutive failed logins lock a username
+	MaxFailedAttempts int32
+	// MaxFailedAttemptsPerIP is how many failed logins a client address can make within FailureWindow
+	MaxFailedAttemptsPerIP int64
+	// FailureWindow is how long failed logins are remembered
+	FailureWindow time.Duration
+	// BaseDelay is the wait after the first failed login, it doubles with every further one.
+	// It is also the least time between two logins of a username.
+	BaseDelay time.Duration
+	// LockoutDuration is how long a username stays locked
+	LockoutDuration time.Duration
+}
+
+// delay is how long to wait after the last of failedAttempts consecutive failed logins
+func (policy LoginPolicy) delay(failedAttempts int32) time.Duration {
+	delay := policy.BaseDelay
+	for i := int32(1); i < failedAttempts; i++ {
+		delay *= 2
+		if delay >= policy.LockoutDuration {
+			return policy.LockoutDuration
+		}
+	}
+	return delay
+}
+
+type CheckLoginAttemptParams struct {
+	Username string
+	ClientIp string
+	Policy   LoginPolicy
+}
+
+// CheckLoginAttempt is called before a password is checked. It returns ErrLoginLocked while the username is
+// locked, and ErrTooManyLoginAttempts while the username or the client address has to wait after failed
+// logins, along with how long to wait. Unknown usernames are throttled like existing ones. The throttle of
+// the username is locked while it is checked, and a login it lets through makes the next one wait BaseDelay,
+// so concurrent logins cannot all be let through before the first of them has failed.
+func (store *PgStore) CheckLoginAttempt(ctx context.Context, arg CheckLoginAttemptParams) (time.Duration, error) {
+	var retryAfter time.Duration
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			now := time.Now()
+			windowStart := now.Add(-arg.Policy.FailureWindow)
+
+			failures, err := q.CountFailedLoginsFromIP(ctx, CountFailedLoginsFromIPParams{
+				ClientIp: clientHost(arg.ClientIp),
+				Since:    pgtype.Timestamptz{Time: windowStart, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			if failures >= arg.Policy.MaxFailedAttemptsPerIP {
+				retryAfter = arg.Policy.FailureWindow
+				return ErrTooManyLoginAttempts
+			}
+
+			throttle, err := q.ClaimLoginThrottle(ctx, arg.Username)
+			if err != nil {
+				return err
+			}
+
+			if throttle.LockedUntil.Valid && now.Before(throttle.LockedUntil.Time) {
+				retryAfter = throttle.LockedUntil.Time.Sub(now)
+				return ErrLoginLocked
+			}
+
+			if throttle.FailedAttempts > 0 && !throttle.LastFailedAt.Time.Before(windowStart) {
+				retryAt := throttle.LastFailedAt.Time.Add(arg.Policy.delay(throttle.FailedAttempts))
+				if now.Before(retryAt) {
+					retryAfter = retryAt.Sub(now)
+					return ErrTooManyLoginAttempts
+				}
+			}
+
+			if throttle.LastAttemptAt.Valid {
+				retryAt := throttle.LastAttemptAt.Time.Add(arg.Policy.BaseDelay)
+				if now.Before(retryAt) {
+					retryAfter = retryAt.Sub(now)
+					return ErrTooManyLoginAttempts
+				}
+			}
+
+			return q.StartLoginAttempt(ctx, arg.Username)
+		},
+	)
+
+	return retryAfter, err
+}
+
+type RecordFailedLoginTxParams struct {
+	Username string
+	ClientIp string
+	Policy   LoginPolicy
+}
+
+type RecordFailedLoginTxResult struct {
+	Throttle LoginThrottle
+	// Locked is set when this failed login locked the username
+	Locked bool
+}
+
+// RecordFailedLoginTx counts a failed login for the username and the client address,
+// and locks the username once it reaches MaxFailedAttempts
+func (store *PgStore) RecordFailedLoginTx(ctx context.Context, arg RecordFailedLoginTxParams) (RecordFailedLoginTxResult, error) {
+	var result RecordFailedLoginTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			_, err := q.CreateFailedLogin(ctx, CreateFailedLoginParams{
+				Username: arg.Username,
+				ClientIp: clientHost(arg.ClientIp),
+			})
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			result.Throttle, err = q.AddFailedLoginAttempt(ctx, AddFailedLoginAttemptParams{
+				Username:    arg.Username,
+				WindowStart: pgtype.Timestamptz{Time: now.Add(-arg.Policy.FailureWindow), Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			if result.Throttle.FailedAttempts < arg.Policy.MaxFailedAttempts {
+				return nil
+			}
+
+			result.Locked = true
+			result.Throttle, err = q.LockLogin(ctx, LockLoginParams{
+				Username:    arg.Username,
+				LockedUntil: pgtype.Timestamptz{Time: now.Add(arg.Policy.LockoutDuration), Valid: true},
+			})
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// PruneFailedLogins forgets the failed logins made before the failure window,
+// and the throttles of usernames that are not locked and have not tried to login within it
+func (store *PgStore) PruneFailedLogins(ctx context.Context, policy LoginPolicy) error {
+	windowStart := pgtype.Timestamptz{Time: time.Now().Add(-policy.FailureWindow), Valid: true}
+
+	if _, err := store.DeleteFailedLoginsBefore(ctx, windowStart); err != nil {
+		return err
+	}
+
+	_, err := store.DeleteStaleLoginThrottles(ctx, windowStart)
+	return err
+}
+
+// unknownClientHost counts the failed logins of clients without a valid address together
+const unknownClientHost = "unknown"
+
+// clientHost strips the port from a client address, so every connection of a client is counted together.
+// IPv6 clients are counted by their /64 network, which a single client is usually given whole.
+func clientHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return unknownClientHost
+	}
+
+	ip = ip.Unmap().WithZone("")
+	if ip.Is6() {
+		return netip.PrefixFrom(ip, 64).Masked().String()
+	}
+	return ip.String()
+}
@@ -0,0 +1,253 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxMfaChallengeAttempts is how many wrong codes can be entered for a login before it has to start over
+const MaxMfaChallengeAttempts = 5
+
+var (
+	ErrTotpAlreadyEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTotpNotEnabled        = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMfaCode        = errors.New("invalid two-factor authentication code")
+	ErrMfaChallengeCompleted = errors.New("login has already been completed")
+	ErrMfaChallengeExpired   = errors.New("login has expired")
+	ErrTooManyMfaAttempts    = errors.New("too many wrong codes, login again")
+)
+
+type ConfirmTotpEnrollmentTxParams struct {
+	Username string
+	// Code is a TOTP code computed from the secret of the enrollment
+	Code string
+}
+
+type ConfirmTotpEnrollmentTxResult struct {
+	Enrollment TotpEnrollment
+	// RecoveryCodes are only returned here, only their hashes are stored
+	RecoveryCodes []string
+}
+
+// ConfirmTotpEnrollmentTx enables TOTP once the user proves their authenticator app works, and issues
+// their recovery codes. ErrTotpAlreadyEnabled or ErrInvalidMfaCode are returned when it cannot be confirmed.
+func (store *PgStore) ConfirmTotpEnrollmentTx(ctx context.Context, arg ConfirmTotpEnrollmentTxParams) (ConfirmTotpEnrollmentTxResult, error) {
+	var result ConfirmTotpEnrollmentTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			enrollment, err := q.GetTotpEnrollmentForUpdate(ctx, arg.Username)
+			if err != nil {
+				return err
+			}
+
+			if enrollment.ConfirmedAt.Valid {
+				return ErrTotpAlreadyEnabled
+			}
+
+			var ok bool
+			result.Enrollment, ok, err = checkMfaCode(ctx, q, enrollment, arg.Code)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return ErrInvalidMfaCode
+			}
+
+			result.RecoveryCodes, err = replaceRecoveryCodes(ctx, q, arg.Username)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+type VerifyMfaTxParams struct {
+	// Token is the challenge token returned by login
+	Token string
+	// Code is a TOTP code, or a recovery code once TOTP is enabled
+	Code string
+}
+
+type VerifyMfaTxResult struct {
+	Challenge  MfaChallenge
+	Enrollment TotpEnrollment
+	// RecoveryCodes are set when the code completed a forced enrollment
+	RecoveryCodes []string
+}
+
+// VerifyMfaTx completes the second step of a login. A user who was forced to enroll at login confirms
+// their enrollment with the code and gets their recovery codes. Wrong codes are counted, and after
+// MaxMfaChallengeAttempts the challenge can no longer be completed. Callers also count them as failed
+// logins, so a username locked meanwhile cannot complete its challenge either. ErrInvalidMfaCode,
+// ErrMfaChallengeCompleted, ErrMfaChallengeExpired, ErrTooManyMfaAttempts, ErrLoginLocked or
+// ErrTotpNotEnabled are returned when the login cannot be completed.
+func (store *PgStore) VerifyMfaTx(ctx context.Context, arg VerifyMfaTxParams) (VerifyMfaTxResult, error) {
+	var result VerifyMfaTxResult
+	var wrongCode bool
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			challenge, err := q.GetMfaChallengeForUpdate(ctx, util.HashToken(arg.Token))
+			if err != nil {
+				return err
+			}
+
+			if challenge.CompletedAt.Valid {
+				return ErrMfaChallengeCompleted
+			}
+
+			if time.Now().After(challenge.ExpiresAt.Time) {
+				return ErrMfaChallengeExpired
+			}
+
+			if challenge.Attempts >= MaxMfaChallengeAttempts {
+				return ErrTooManyMfaAttempts
+			}
+
+			throttle, err := q.GetLoginThrottle(ctx, challenge.Username)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+			if throttle.LockedUntil.Valid && time.Now().Before(throttle.LockedUntil.Time) {
+				return ErrLoginLocked
+			}
+
+			enrollment, err := q.GetTotpEnrollmentForUpdate(ctx, challenge.Username)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrTotpNotEnabled
+				}
+				return err
+			}
+
+			var ok bool
+			result.Enrollment, ok, err = checkMfaCode(ctx, q, enrollment, arg.Code)
+			if err != nil {
+				return err
+			}
+
+			// the attempt has to be committed, so the error is only returned after the transaction
+			if !ok {
+				wrongCode = true
+				result.Challenge, err = q.AddMfaChallengeAttempt(ctx, challenge.ID)
+				return err
+			}
+
+			if !enrollment.ConfirmedAt.Valid {
+				result.RecoveryCodes, err = replaceRecoveryCodes(ctx, q, challenge.Username)
+				if err != nil {
+					return err
+				}
+			}
+
+			result.Challenge, err = q.CompleteMfaChallenge(ctx, challenge.ID)
+			return err
+		},
+	)
+	if err == nil && wrongCode {
+		err = ErrInvalidMfaCode
+	}
+
+	return result, err
+}
+
+type DisableTotpTxParams struct {
+	Username string
+	// Code is a TOTP code or a recovery code
+	Code string
+}
+
+// DisableTotpTx turns off TOTP and removes the recovery codes of the user.
+// ErrTotpNotEnabled or ErrInvalidMfaCode are returned when it cannot be disabled.
+func (store *PgStore) DisableTotpTx(ctx context.Context, arg DisableTotpTxParams) error {
+	return store.execTx(
+		ctx,
+		func(q *Queries) error {
+			enrollment, err := q.GetTotpEnrollmentForUpdate(ctx, arg.Username)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrTotpNotEnabled
+				}
+				return err
+			}
+
+			if !enrollment.ConfirmedAt.Valid {
+				return ErrTotpNotEnabled
+			}
+
+			_, ok, err := checkMfaCode(ctx, q, enrollment, arg.Code)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return ErrInvalidMfaCode
+			}
+
+			if err := q.DeleteRecoveryCodes(ctx, arg.Username); err != nil {
+				return err
+			}
+
+			return q.DeleteTotpEnrollment(ctx, arg.Username)
+		},
+	)
+}
+
+// checkMfaCode accepts a TOTP code that has not been used yet, or an unused recovery code once the
+// enrollment is confirmed. An accepted TOTP code confirms the enrollment.
+func checkMfaCode(ctx context.Context, q *Queries, enrollment TotpEnrollment, code string) (TotpEnrollment, bool, error) {
+	if step, ok := util.CheckTOTP(enrollment.Secret, code, time.Now(), enrollment.LastUsedStep); ok {
+		enrollment, err := q.UseTotpStep(ctx, UseTotpStepParams{
+			Username:     enrollment.Username,
+			LastUsedStep: step,
+		})
+		return enrollment, err == nil, err
+	}
+
+	if !enrollment.ConfirmedAt.Valid {
+		return enrollment, false, nil
+	}
+
+	_, err := q.UseRecoveryCode(ctx, UseRecoveryCodeParams{
+		Username: enrollment.Username,
+		CodeHash: util.HashToken(util.NormalizeRecoveryCode(code)),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return enrollment, false, nil
+		}
+		return enrollment, false, err
+	}
+
+	return enrollment, true, nil
+}
+
+// replaceRecoveryCodes issues new recovery codes, the previous ones can no longer be used
+func replaceRecoveryCodes(ctx context.Context, q *Queries, username string) ([]string, error) {
+	codes, err := util.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.DeleteRecoveryCodes(ctx, username); err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		_, err := q.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{
+			Username: username,
+			CodeHash: util.HashToken(code),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidPasswordReset = errors.New("invalid or expired password reset code")
+
+type ResetPasswordTxParams struct {
+	ResetID int64
+	// SecretCode is the code that was emailed, only its hash is stored
+	SecretCode     string
+	HashedPassword string
+}
+
+type ResetPasswordTxResult struct {
+	User User
+	// RevokedSessions is how many sessions were blocked by the reset
+	RevokedSessions int64
+}
+
+// ResetPasswordTx uses a password reset code to set a new password. Every pending reset of the user stops
+// working and all their sessions are revoked. ErrInvalidPasswordReset is returned when the code is wrong,
+// expired or already used.
+func (store *PgStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error) {
+	var result ResetPasswordTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			reset, err := q.UsePasswordReset(ctx, UsePasswordResetParams{
+				ID:             arg.ResetID,
+				SecretCodeHash: util.HashToken(arg.SecretCode),
+			})
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrInvalidPasswordReset
+				}
+				return err
+			}
+
+			result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+				Username: reset.Username,
+				HashedPassword: pgtype.Text{
+					String: arg.HashedPassword,
+					Valid:  true,
+				},
+				PasswordChangedAt: pgtype.Timestamptz{
+					Time:  time.Now(),
+					Valid: true,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := q.InvalidatePasswordResets(ctx, reset.Username); err != nil {
+				return err
+			}
+
+			result.RevokedSessions, err = q.BlockUserSessions(ctx, reset.Username)
+			return err
+		},
+	)
+
+	return result, err
+}
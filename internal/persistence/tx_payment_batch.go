@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrPaymentBatchNotPending        = errors.New("payment batch is no longer pending")
+	ErrPaymentBatchItemNotExecutable = errors.New("payment batch item is not valid or has already been executed")
+)
+
+type ImportPaymentBatchTxParams struct {
+	CreatePaymentBatchParams
+	// Items are stored in order, their BatchID is filled in
+	Items []CreatePaymentBatchItemParams
+}
+
+type ImportPaymentBatchTxResult struct {
+	PaymentBatch PaymentBatch
+	Items        []PaymentBatchItem
+}
+
+// ImportPaymentBatchTx stores a pending batch together with all of its items, valid or not
+func (store *PgStore) ImportPaymentBatchTx(ctx context.Context, arg ImportPaymentBatchTxParams) (ImportPaymentBatchTxResult, error) {
+	var result ImportPaymentBatchTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.PaymentBatch, err = q.CreatePaymentBatch(ctx, arg.CreatePaymentBatchParams)
+			if err != nil {
+				return err
+			}
+
+			result.Items = make([]PaymentBatchItem, 0, len(arg.Items))
+			for _, itemArg := range arg.Items {
+				itemArg.BatchID = result.PaymentBatch.ID
+
+				item, err := q.CreatePaymentBatchItem(ctx, itemArg)
+				if err != nil {
+					return err
+				}
+
+				result.Items = append(result.Items, item)
+			}
+
+			return nil
+		},
+	)
+
+	return result, err
+}
+
+type ConfirmPaymentBatchTxParams struct {
+	PaymentBatchID int64
+	AfterConfirm   func(paymentBatch PaymentBatch) error
+}
+
+type ConfirmPaymentBatchTxResult struct {
+	PaymentBatch PaymentBatch
+}
+
+// ConfirmPaymentBatchTx confirms a pending batch, AfterConfirm is used to queue its execution
+// so a batch is never confirmed without being executed
+func (store *PgStore) ConfirmPaymentBatchTx(ctx context.Context, arg ConfirmPaymentBatchTxParams) (ConfirmPaymentBatchTxResult, error) {
+	var result ConfirmPaymentBatchTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.PaymentBatch, err = q.ConfirmPaymentBatch(ctx, arg.PaymentBatchID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrPaymentBatchNotPending
+				}
+				return err
+			}
+
+			return arg.AfterConfirm(result.PaymentBatch)
+		},
+	)
+
+	return result, err
+}
+
+type ExecutePaymentBatchItemTxParams struct {
+	ItemID int64
+	// Assessment is the risk assessment of the item, recorded with its transfer
+	Assessment TransferAssessment
+	// BeneficiaryLimit applies when the creditor account is a new beneficiary of the batch owner
+	BeneficiaryLimit BeneficiaryLimit
+}
+
+type ExecutePaymentBatchItemTxResult struct {
+	Item       PaymentBatchItem
+	Transfer   TransferTxResult
+	Assessment TransferRiskAssessment
+}
+
+// ExecutePaymentBatchItemTx makes the transfer of a valid item and marks it executed. The funds of the debtor account
+// are checked again, as they may have changed since the batch was imported: ErrInsufficientFunds is returned
+// and nothing is changed when they no longer cover the item.
+func (store *PgStore) ExecutePaymentBatchItemTx(ctx context.Context, arg ExecutePaymentBatchItemTxParams) (ExecutePaymentBatchItemTxResult, error) {
+	var result ExecutePaymentBatchItemTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			item, err := q.GetPaymentBatchItemForUpdate(ctx, arg.ItemID)
+			if err != nil {
+				return err
+			}
+
+			if item.Status != util.PaymentBatchItemValid {
+				return ErrPaymentBatchItemNotExecutable
+			}
+
+			transferArg := TransferTxParams{
+				FromAccountID:    item.FromAccountID.Int64,
+				ToAccountID:      item.ToAccountID.Int64,
+				Amount:           util.Money{Amount: item.Amount, Currency: item.Currency},
+				Reference:        item.Reference,
+				SentBy:           arg.Assessment.RequestedBy,
+				BeneficiaryLimit: arg.BeneficiaryLimit,
+			}
+
+			result.Transfer, err = transfer(ctx, q, transferArg)
+			if err != nil {
+				return err
+			}
+
+			result.Assessment, err = createTransferAssessment(ctx, q, transferArg, arg.Assessment, pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true})
+			if err != nil {
+				return err
+			}
+
+			result.Item, err = q.ResolvePaymentBatchItem(ctx, ResolvePaymentBatchItemParams{
+				ID:         item.ID,
+				Status:     util.PaymentBatchItemExecuted,
+				TransferID: pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true},
+			})
+
+			return err
+		},
+	)
+
+	return result, err
+}
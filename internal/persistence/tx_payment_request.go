@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrPaymentRequestNotPending = errors.New("payment request is no longer pending")
+
+type CreatePaymentRequestTxParams struct {
+	CreatePaymentRequestParams
+	AfterCreate func(paymentRequest PaymentRequest) error
+}
+
+type CreatePaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest
+}
+
+// CreatePaymentRequestTx stores a request for money, AfterCreate is used to notify the payer and schedule its expiry
+func (store *PgStore) CreatePaymentRequestTx(ctx context.Context, arg CreatePaymentRequestTxParams) (CreatePaymentRequestTxResult, error) {
+	var result CreatePaymentRequestTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.PaymentRequest, err = q.CreatePaymentRequest(ctx, arg.CreatePaymentRequestParams)
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterCreate(result.PaymentRequest)
+		},
+	)
+
+	return result, err
+}
+
+type AcceptPaymentRequestTxParams struct {
+	PaymentRequestID int64
+	FromAccountID    int64
+	// Assessment is the risk assessment of the payment, recorded with the transfer
+	Assessment TransferAssessment
+	// BeneficiaryLimit applies when the requester's account is a new beneficiary of the payer
+	BeneficiaryLimit BeneficiaryLimit
+	AfterAccept      func(paymentRequest PaymentRequest) error
+}
+
+type AcceptPaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest
+	Transfer       TransferTxResult
+	Assessment     TransferRiskAssessment
+}
+
+// AcceptPaymentRequestTx pays a pending request from the payer's account and marks it accepted,
+// so a request can never be paid twice
+func (store *PgStore) AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (AcceptPaymentRequestTxResult, error) {
+	var result AcceptPaymentRequestTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			paymentRequest, err := q.GetPaymentRequestForUpdate(ctx, arg.PaymentRequestID)
+			if err != nil {
+				return err
+			}
+
+			if paymentRequest.Status != util.PaymentRequestPending {
+				return ErrPaymentRequestNotPending
+			}
+
+			transferArg := TransferTxParams{
+				FromAccountID:    arg.FromAccountID,
+				ToAccountID:      paymentRequest.AccountID,
+				Amount:           util.Money{Amount: paymentRequest.Amount, Currency: paymentRequest.Currency},
+				Reference:        paymentRequest.Note,
+				SentBy:           arg.Assessment.RequestedBy,
+				BeneficiaryLimit: arg.BeneficiaryLimit,
+			}
+
+			result.Transfer, err = transfer(ctx, q, transferArg)
+			if err != nil {
+				return err
+			}
+
+			result.Assessment, err = createTransferAssessment(ctx, q, transferArg, arg.Assessment, pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true})
+			if err != nil {
+				return err
+			}
+
+			result.PaymentRequest, err = resolvePendingPaymentRequest(ctx, q, ResolvePaymentRequestParams{
+				ID:         paymentRequest.ID,
+				Status:     util.PaymentRequestAccepted,
+				TransferID: pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterAccept(result.PaymentRequest)
+		},
+	)
+
+	return result, err
+}
+
+type DeclinePaymentRequestTxParams struct {
+	PaymentRequestID int64
+	AfterDecline     func(paymentRequest PaymentRequest) error
+}
+
+type DeclinePaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest
+}
+
+// DeclinePaymentRequestTx marks a pending request as declined, AfterDecline is used to notify the requester
+func (store *PgStore) DeclinePaymentRequestTx(ctx context.Context, arg DeclinePaymentRequestTxParams) (DeclinePaymentRequestTxResult, error) {
+	var result DeclinePaymentRequestTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.PaymentRequest, err = resolvePendingPaymentRequest(ctx, q, ResolvePaymentRequestParams{
+				ID:     arg.PaymentRequestID,
+				Status: util.PaymentRequestDeclined,
+			})
+			if err != nil {
+				return err
+			}
+
+			return arg.AfterDecline(result.PaymentRequest)
+		},
+	)
+
+	return result, err
+}
+
+// resolvePendingPaymentRequest moves a pending request to its final state, reporting ErrPaymentRequestNotPending
+// if it was already resolved or has expired
+func resolvePendingPaymentRequest(ctx context.Context, q *Queries, arg ResolvePaymentRequestParams) (PaymentRequest, error) {
+	paymentRequest, err := q.ResolvePaymentRequest(ctx, arg)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return paymentRequest, ErrPaymentRequestNotPending
+	}
+
+	return paymentRequest, err
+}
@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+type MovePocketFundsTxParams struct {
+	PocketID int64
+	// Amount is moved from the parent account into the pocket when positive
+	// and from the pocket back to the parent account when negative
+	Amount util.Money
+}
+
+type MovePocketFundsTxResult struct {
+	Pocket       Pocket
+	Account      Account
+	AccountEntry Entry
+	PocketEntry  Entry
+}
+
+// MovePocketFundsTx moves money between a pocket and its parent account.
+// The money stays in the parent account, so it creates a pair of entries on it
+// that cancel out: one for the parent's available balance and one tagged with the pocket.
+func (store *PgStore) MovePocketFundsTx(ctx context.Context, arg MovePocketFundsTxParams) (MovePocketFundsTxResult, error) {
+	var result MovePocketFundsTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			pocket, err := q.GetPocket(ctx, arg.PocketID)
+			if err != nil {
+				return err
+			}
+
+			// lock the parent account before the pocket, so concurrent moves
+			// into sibling pockets cannot both spend the same available balance
+			result.Account, err = q.GetAccountForUpdate(ctx, pocket.AccountID)
+			if err != nil {
+				return err
+			}
+
+			pocket, err = q.GetPocketForUpdate(ctx, arg.PocketID)
+			if err != nil {
+				return err
+			}
+
+			if arg.Amount.Currency != result.Account.Currency {
+				return fmt.Errorf("%w: %s and %s", util.ErrCurrencyMismatch, result.Account.Currency, arg.Amount.Currency)
+			}
+
+			if arg.Amount.IsPositive() {
+				pocketed, err := q.GetPocketedBalance(ctx, result.Account.ID)
+				if err != nil {
+					return err
+				}
+
+				if result.Account.Balance-pocketed < arg.Amount.Amount {
+					return ErrInsufficientFunds
+				}
+			} else if pocket.Balance < -arg.Amount.Amount {
+				return ErrInsufficientFunds
+			}
+
+			result.AccountEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+				AccountID: result.Account.ID,
+				Amount:    -arg.Amount.Amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.PocketEntry, err = q.CreatePocketEntry(ctx, CreatePocketEntryParams{
+				AccountID: result.Account.ID,
+				PocketID:  pgtype.Int8{Int64: pocket.ID, Valid: true},
+				Amount:    arg.Amount.Amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Pocket, err = q.AddPocketBalance(ctx, AddPocketBalanceParams{
+				ID:     pocket.ID,
+				Amount: arg.Amount.Amount,
+			})
+
+			return err
+		},
+	)
+
+	return result, err
+}
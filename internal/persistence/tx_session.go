@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrRefreshTokenMismatch = errors.New("mismatched refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token has already been used, the session is revoked")
+	ErrSessionBlocked       = errors.New("session is blocked")
+	ErrSessionExpired       = errors.New("session has expired")
+)
+
+type CreateSessionTxParams struct {
+	CreateSessionParams
+	// RefreshToken is the first refresh token of the session, its payload ID is the ID of the session
+	RefreshToken string
+}
+
+type CreateSessionTxResult struct {
+	Session      Session
+	RefreshToken RefreshToken
+}
+
+// CreateSessionTx creates a session with its first refresh token, only the hash of the token is stored
+func (store *PgStore) CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error) {
+	var result CreateSessionTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			result.Session, err = q.CreateSession(ctx, arg.CreateSessionParams)
+			if err != nil {
+				return err
+			}
+
+			result.RefreshToken, err = q.CreateRefreshToken(ctx, CreateRefreshTokenParams{
+				ID:        result.Session.ID,
+				SessionID: result.Session.ID,
+				TokenHash: util.HashToken(arg.RefreshToken),
+				ExpiresAt: result.Session.ExpiresAt,
+			})
+
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// IssuedRefreshToken is a refresh token created to replace a renewed one
+type IssuedRefreshToken struct {
+	// ID is the ID of the refresh token payload
+	ID        pgtype.UUID
+	Token     string
+	ExpiresAt time.Time
+}
+
+type RotateRefreshTokenTxParams struct {
+	// ID is the ID of the payload of the presented refresh token
+	ID           pgtype.UUID
+	RefreshToken string
+	Username     string
+	// IssueRefreshToken creates the refresh token replacing the presented one, it must not outlive the session.
+	// The user is read again, so the new token carries their current role rather than the one of the presented token.
+	IssueRefreshToken func(session Session, user User) (IssuedRefreshToken, error)
+}
+
+type RotateRefreshTokenTxResult struct {
+	Session      Session
+	RefreshToken RefreshToken
+	// User is the user of the session as it is now, access tokens are issued with their current role
+	User User
+}
+
+// RotateRefreshTokenTx renews a refresh token: the presented token can no longer be used and a new one
+// is stored for the same session. Presenting a token that was renewed already means it has leaked,
+// so the whole session is blocked and ErrRefreshTokenReused is returned. ErrRefreshTokenMismatch,
+// ErrSessionBlocked or ErrSessionExpired are returned when the token cannot be renewed.
+func (store *PgStore) RotateRefreshTokenTx(ctx context.Context, arg RotateRefreshTokenTxParams) (RotateRefreshTokenTxResult, error) {
+	var result RotateRefreshTokenTxResult
+	var reused bool
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			refreshToken, err := q.GetRefreshTokenForUpdate(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+
+			if !util.CheckToken(arg.RefreshToken, refreshToken.TokenHash) {
+				return ErrRefreshTokenMismatch
+			}
+
+			result.Session, err = q.GetSession(ctx, refreshToken.SessionID)
+			if err != nil {
+				return err
+			}
+
+			if result.Session.Username != arg.Username {
+				return ErrRefreshTokenMismatch
+			}
+
+			// the block has to be committed, so the error is only returned after the transaction
+			if refreshToken.RotatedAt.Valid {
+				reused = true
+				result.Session, err = q.BlockSession(ctx, BlockSessionParams{
+					ID:       result.Session.ID,
+					Username: result.Session.Username,
+				})
+				return err
+			}
+
+			if result.Session.IsBlocked {
+				return ErrSessionBlocked
+			}
+
+			if time.Now().After(result.Session.ExpiresAt.Time) {
+				return ErrSessionExpired
+			}
+
+			result.User, err = q.GetUser(ctx, result.Session.Username)
+			if err != nil {
+				return err
+			}
+
+			issued, err := arg.IssueRefreshToken(result.Session, result.User)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.RotateRefreshToken(ctx, refreshToken.ID)
+			if err != nil {
+				return err
+			}
+
+			result.RefreshToken, err = q.CreateRefreshToken(ctx, CreateRefreshTokenParams{
+				ID:        issued.ID,
+				SessionID: result.Session.ID,
+				TokenHash: util.HashToken(issued.Token),
+				ExpiresAt: pgtype.Timestamptz{Time: issued.ExpiresAt, Valid: true},
+			})
+
+			return err
+		},
+	)
+	if err == nil && reused {
+		err = ErrRefreshTokenReused
+	}
+
+	return result, err
+}
@@ -2,13 +2,21 @@ package persistence
 
 import (
 	"context"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // TransferTxParams defines the input parameters for the transfer transaction
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64      `json:"from_account_id"`
+	ToAccountID   int64      `json:"to_account_id"`
+	Amount        util.Money `json:"amount"`
+	Reference     string     `json:"reference"`
+	// SentBy is the user making the transfer. While the receiving account is one of their beneficiaries
+	// in its cooling-off period, the transfer is subject to BeneficiaryLimit.
+	SentBy           string           `json:"sent_by"`
+	BeneficiaryLimit BeneficiaryLimit `json:"beneficiary_limit"`
 }
 
 // TransferTxResult defines the output result for the transfer transaction
@@ -29,54 +37,114 @@ func (store *PgStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tra
 		ctx,
 		func(q *Queries) error {
 			var err error
+			result, err = transfer(ctx, q, arg)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// transfer moves money between two accounts using the queries of an already open transaction.
+// Money set aside in pockets cannot be sent: ErrInsufficientFunds is returned and nothing is changed
+// when the available balance of the sending account does not cover the amount, and likewise
+// ErrBeneficiaryLimitExceeded when the transfer is over the limit of a new beneficiary of the sender.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	// lock both accounts in the order they are updated in, so the balance
+	// cannot change between the check and the transfer
+	fromAccount, err := lockAccountsForTransfer(ctx, q, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	pocketed, err := q.GetPocketedBalance(ctx, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if fromAccount.Balance-pocketed < arg.Amount.Amount {
+		return result, ErrInsufficientFunds
+	}
+
+	if arg.SentBy != "" {
+		err = checkBeneficiaryLimit(ctx, q, arg)
+		if err != nil {
+			return result, err
+		}
+	}
 
-			result.Transfer, err = q.CreateTransfer(
-				ctx,
-				CreateTransferParams(arg),
-			)
-			if err != nil {
-				return err
-			}
-
-			result.FromEntry, err = q.CreateEntry(
-				ctx,
-				CreateEntryParams{
-					AccountID: arg.FromAccountID,
-					Amount:    -arg.Amount,
-				},
-			)
-			if err != nil {
-				return err
-			}
-
-			result.ToEntry, err = q.CreateEntry(
-				ctx,
-				CreateEntryParams{
-					AccountID: arg.ToAccountID,
-					Amount:    arg.Amount,
-				},
-			)
-			if err != nil {
-				return err
-			}
-
-			if arg.FromAccountID < arg.ToAccountID {
-				result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-
-			} else {
-				result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-			}
-			if err != nil {
-				return err
-			}
-
-			return nil
+	result.Transfer, err = q.CreateTransfer(
+		ctx,
+		CreateTransferParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount.Amount,
+			Reference:     arg.Reference,
 		},
 	)
+	if err != nil {
+		return result, err
+	}
+
+	result.FromEntry, err = q.CreateEntry(
+		ctx,
+		CreateEntryParams{
+			AccountID:  arg.FromAccountID,
+			Amount:     -arg.Amount.Amount,
+			TransferID: pgtype.Int8{Int64: result.Transfer.ID, Valid: true},
+		},
+	)
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntry(
+		ctx,
+		CreateEntryParams{
+			AccountID:  arg.ToAccountID,
+			Amount:     arg.Amount.Amount,
+			TransferID: pgtype.Int8{Int64: result.Transfer.ID, Valid: true},
+		},
+	)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount.Amount, arg.ToAccountID, arg.Amount.Amount)
+
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount.Amount, arg.FromAccountID, -arg.Amount.Amount)
+	}
 
 	return result, err
 }
 
+// lockAccountsForTransfer locks the two accounts of a transfer in ID order and returns the one money is sent from
+func lockAccountsForTransfer(ctx context.Context, q *Queries, fromAccountID, toAccountID int64) (Account, error) {
+	firstID, secondID := fromAccountID, toAccountID
+	if secondID < firstID {
+		firstID, secondID = secondID, firstID
+	}
+
+	first, err := q.GetAccountForUpdate(ctx, firstID)
+	if err != nil {
+		return first, err
+	}
+
+	second, err := q.GetAccountForUpdate(ctx, secondID)
+	if err != nil {
+		return second, err
+	}
+
+	if first.ID == fromAccountID {
+		return first, nil
+	}
+	return second, nil
+}
+
 func addMoney(ctx context.Context, q *Queries, accountID1, amount1, accountID2, amount2 int64) (account1, account2 Account, err error) {
 	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
 		ID:     accountID1,
@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTransferNotHeld    = errors.New("transfer is not held for review")
+	ErrTransferSelfReview = errors.New("held transfer must be approved by someone other than its sender")
+)
+
+// TransferAssessment is the risk assessment a transfer is recorded with
+type TransferAssessment struct {
+	RequestedBy string
+	ClientIp    string
+	Decision    string
+	Findings    []byte
+}
+
+type AssessedTransferTxParams struct {
+	TransferTxParams
+	TransferAssessment
+	// Hold records the transfer as held for review instead of making it
+	Hold bool
+}
+
+type AssessedTransferTxResult struct {
+	Assessment TransferRiskAssessment
+	// Transfer is empty when the transfer was held
+	Transfer TransferTxResult
+}
+
+// AssessedTransferTx makes a transfer together with the record of its risk assessment,
+// or only records the assessment when the transfer is held for review
+func (store *PgStore) AssessedTransferTx(ctx context.Context, arg AssessedTransferTxParams) (AssessedTransferTxResult, error) {
+	var result AssessedTransferTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			var err error
+
+			if arg.Hold {
+				// the limit is checked again when the transfer is approved, rejecting it now spares the review
+				if arg.SentBy != "" {
+					err = checkBeneficiaryLimit(ctx, q, arg.TransferTxParams)
+					if err != nil {
+						return err
+					}
+				}
+
+				result.Assessment, err = createTransferAssessment(ctx, q, arg.TransferTxParams, arg.TransferAssessment, pgtype.Int8{})
+				return err
+			}
+
+			result.Transfer, err = transfer(ctx, q, arg.TransferTxParams)
+			if err != nil {
+				return err
+			}
+
+			result.Assessment, err = createTransferAssessment(ctx, q, arg.TransferTxParams, arg.TransferAssessment, pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true})
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// createTransferAssessment records the assessment of a transfer, as executed when the transfer has been made
+// and as held for review otherwise
+func createTransferAssessment(ctx context.Context, q *Queries, arg TransferTxParams, assessment TransferAssessment, transferID pgtype.Int8) (TransferRiskAssessment, error) {
+	status := util.TransferRiskHeld
+	if transferID.Valid {
+		status = util.TransferRiskExecuted
+	}
+
+	return q.CreateTransferRiskAssessment(ctx, CreateTransferRiskAssessmentParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount.Amount,
+		Currency:      arg.Amount.Currency,
+		Reference:     arg.Reference,
+		RequestedBy:   assessment.RequestedBy,
+		ClientIp:      assessment.ClientIp,
+		Decision:      assessment.Decision,
+		Findings:      assessment.Findings,
+		Status:        status,
+		TransferID:    transferID,
+	})
+}
+
+type ApproveHeldTransferTxParams struct {
+	AssessmentID int64
+	ReviewedBy   string
+	// BeneficiaryLimit applies when the receiving account is a new beneficiary of the user who made the transfer
+	BeneficiaryLimit BeneficiaryLimit
+}
+
+type ApproveHeldTransferTxResult struct {
+	Assessment TransferRiskAssessment
+	Transfer   TransferTxResult
+}
+
+// ApproveHeldTransferTx makes a held transfer on behalf of the banker who approved it.
+// ErrTransferNotHeld is returned when it has already been approved or rejected, and ErrTransferSelfReview
+// when the banker is the user who requested the transfer.
+func (store *PgStore) ApproveHeldTransferTx(ctx context.Context, arg ApproveHeldTransferTxParams) (ApproveHeldTransferTxResult, error) {
+	var result ApproveHeldTransferTxResult
+
+	err := store.execTx(
+		ctx,
+		func(q *Queries) error {
+			assessment, err := q.GetTransferRiskAssessmentForUpdate(ctx, arg.AssessmentID)
+			if err != nil {
+				return err
+			}
+
+			if assessment.Status != util.TransferRiskHeld {
+				return ErrTransferNotHeld
+			}
+
+			if assessment.RequestedBy == arg.ReviewedBy {
+				return ErrTransferSelfReview
+			}
+
+			result.Transfer, err = transfer(ctx, q, TransferTxParams{
+				FromAccountID:    assessment.FromAccountID,
+				ToAccountID:      assessment.ToAccountID,
+				Amount:           util.Money{Amount: assessment.Amount, Currency: assessment.Currency},
+				Reference:        assessment.Reference,
+				SentBy:           assessment.RequestedBy,
+				BeneficiaryLimit: arg.BeneficiaryLimit,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Assessment, err = q.ReviewTransferRiskAssessment(ctx, ReviewTransferRiskAssessmentParams{
+				ID:         assessment.ID,
+				Status:     util.TransferRiskApproved,
+				TransferID: pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true},
+				ReviewedBy: pgtype.Text{String: arg.ReviewedBy, Valid: true},
+			})
+
+			return err
+		},
+	)
+
+	return result, err
+}
@@ -19,7 +19,7 @@ INSERT INTO users (
     email
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, handle, email_changed_at
 `
 
 type CreateUserParams struct {
@@ -46,12 +46,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.Handle,
+		&i.EmailChangedAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role FROM users 
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, handle, email_changed_at FROM users 
 WHERE username = $1 LIMIT 1
 `
 
@@ -67,10 +69,83 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.Handle,
+		&i.EmailChangedAt,
 	)
 	return i, err
 }
 
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, handle, email_changed_at FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.Handle,
+		&i.EmailChangedAt,
+	)
+	return i, err
+}
+
+const getUserByHandle = `-- name: GetUserByHandle :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, handle, email_changed_at FROM users
+WHERE handle = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByHandle(ctx context.Context, handle pgtype.Text) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByHandle, handle)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.Handle,
+		&i.EmailChangedAt,
+	)
+	return i, err
+}
+
+const rehashPassword = `-- name: RehashPassword :execrows
+UPDATE users
+SET
+    hashed_password = $1
+WHERE
+    username = $2
+    AND hashed_password = $3
+`
+
+type RehashPasswordParams struct {
+	NewHashedPassword string `json:"new_hashed_password"`
+	Username          string `json:"username"`
+	HashedPassword    string `json:"hashed_password"`
+}
+
+// RehashPassword replaces the hash of a password with a new hash of the same password,
+// unless the password was changed in the meantime
+func (q *Queries) RehashPassword(ctx context.Context, arg RehashPasswordParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rehashPassword, arg.NewHashedPassword, arg.Username, arg.HashedPassword)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET
@@ -78,10 +153,12 @@ SET
     password_changed_at = COALESCE($2, password_changed_at),
     full_name = COALESCE($3, full_name),
     email = COALESCE($4, email),
-    is_email_verified = COALESCE($5, is_email_verified)
+    email_changed_at = COALESCE($5, email_changed_at),
+    is_email_verified = COALESCE($6, is_email_verified),
+    handle = COALESCE($7, handle)
 WHERE
-    username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+    username = $8
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, handle, email_changed_at
 `
 
 type UpdateUserParams struct {
@@ -89,7 +166,9 @@ type UpdateUserParams struct {
 	PasswordChangedAt pgtype.Timestamptz `json:"password_changed_at"`
 	FullName          pgtype.Text        `json:"full_name"`
 	Email             pgtype.Text        `json:"email"`
+	EmailChangedAt    pgtype.Timestamptz `json:"email_changed_at"`
 	IsEmailVerified   pgtype.Bool        `json:"is_email_verified"`
+	Handle            pgtype.Text        `json:"handle"`
 	Username          string             `json:"username"`
 }
 
@@ -99,7 +178,9 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.PasswordChangedAt,
 		arg.FullName,
 		arg.Email,
+		arg.EmailChangedAt,
 		arg.IsEmailVerified,
+		arg.Handle,
 		arg.Username,
 	)
 	var i User
@@ -112,6 +193,38 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.Handle,
+		&i.EmailChangedAt,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, handle, email_changed_at
+`
+
+type UpdateUserRoleParams struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.Username, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.Handle,
+		&i.EmailChangedAt,
 	)
 	return i, err
 }
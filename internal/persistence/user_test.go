@@ -11,7 +11,7 @@ import (
 )
 
 func createRandomUser(t *testing.T) User {
-	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	hashedPassword, err := testPasswordHasher.HashPassword(util.RandomString(6))
 	require.NoError(t, err)
 
 	arg := CreateUserParams{
@@ -93,6 +93,10 @@ func TestUpdateUserOnlyEmail(t *testing.T) {
 				String: newEmail,
 				Valid:  true,
 			},
+			EmailChangedAt: pgtype.Timestamptz{
+				Time:  time.Now(),
+				Valid: true,
+			},
 		},
 	)
 
@@ -105,6 +109,8 @@ func TestUpdateUserOnlyEmail(t *testing.T) {
 	require.Equal(t, newEmail, updatedUser.Email)
 	require.WithinDuration(t, oldUser.CreatedAt.Time, updatedUser.CreatedAt.Time, time.Second)
 	require.WithinDuration(t, oldUser.PasswordChangedAt.Time, updatedUser.PasswordChangedAt.Time, time.Second)
+	require.True(t, oldUser.EmailChangedAt.Time.IsZero())
+	require.WithinDuration(t, time.Now(), updatedUser.EmailChangedAt.Time, time.Second)
 
 	require.NotEqual(t, oldUser.Email, updatedUser.Email)
 }
@@ -113,7 +119,7 @@ func TestUpdateUserOnlyPassword(t *testing.T) {
 	oldUser := createRandomUser(t)
 
 	newPassword := util.RandomString(6)
-	newHashedPassword, err := util.HashPassword(newPassword)
+	newHashedPassword, err := testPasswordHasher.HashPassword(newPassword)
 	require.NoError(t, err)
 
 	updatedUser, err := testQueries.UpdateUser(context.Background(),
@@ -144,7 +150,7 @@ func TestUpdateUserAllFields(t *testing.T) {
 	newFullName := util.RandomOwner()
 	newEmail := util.RandomEmail()
 	newPassword := util.RandomString(6)
-	newHashedPassword, err := util.HashPassword(newPassword)
+	newHashedPassword, err := testPasswordHasher.HashPassword(newPassword)
 	require.NoError(t, err)
 
 	updatedUser, err := testQueries.UpdateUser(context.Background(),
@@ -178,3 +184,32 @@ func TestUpdateUserAllFields(t *testing.T) {
 	require.NotEqual(t, oldUser.Email, updatedUser.Email)
 	require.NotEqual(t, oldUser.HashedPassword, updatedUser.HashedPassword)
 }
+
+func TestRehashPassword(t *testing.T) {
+	user := createRandomUser(t)
+
+	newHashedPassword, err := testPasswordHasher.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	rows, err := testQueries.RehashPassword(context.Background(), RehashPasswordParams{
+		NewHashedPassword: newHashedPassword,
+		Username:          user.Username,
+		HashedPassword:    user.HashedPassword,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	rehashedUser, err := testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, newHashedPassword, rehashedUser.HashedPassword)
+	require.WithinDuration(t, user.PasswordChangedAt.Time, rehashedUser.PasswordChangedAt.Time, time.Second)
+
+	// the hash was replaced since it was read, so it is left alone
+	rows, err = testQueries.RehashPassword(context.Background(), RehashPasswordParams{
+		NewHashedPassword: user.HashedPassword,
+		Username:          user.Username,
+		HashedPassword:    user.HashedPassword,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
@@ -0,0 +1,143 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWebhook(t *testing.T, owner string, eventTypes []string) Webhook {
+	arg := CreateWebhookParams{
+		Owner:      owner,
+		Url:        "https://example.com/" + util.RandomString(8),
+		Secret:     util.RandomString(32),
+		EventTypes: eventTypes,
+	}
+
+	webhook, err := testQueries.CreateWebhook(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook)
+
+	require.NotZero(t, webhook.ID)
+	require.Equal(t, arg.Owner, webhook.Owner)
+	require.Equal(t, arg.Url, webhook.Url)
+	require.Equal(t, arg.Secret, webhook.Secret)
+	require.Equal(t, arg.EventTypes, webhook.EventTypes)
+	require.True(t, webhook.IsActive)
+	require.NotZero(t, webhook.CreatedAt)
+
+	return webhook
+}
+
+func TestListWebhooksForEvent(t *testing.T) {
+	account := createRandomAccount(t)
+	member := createRandomAccountMember(t, account, util.AccountViewOnlyRole)
+
+	subscribed := createRandomWebhook(t, member.Username, []string{"account.balance_added", "transfer.created"})
+	createRandomWebhook(t, member.Username, []string{"account.created"})
+	createRandomWebhook(t, createRandomUser(t).Username, []string{"transfer.created"})
+
+	webhooks, err := testQueries.ListWebhooksForEvent(context.Background(), ListWebhooksForEventParams{
+		EventType:  "transfer.created",
+		AccountIds: []int64{account.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, subscribed.ID, webhooks[0].ID)
+
+	rows, err := testQueries.DeactivateWebhook(context.Background(), DeactivateWebhookParams{
+		ID:    subscribed.ID,
+		Owner: member.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	webhooks, err = testQueries.ListWebhooksForEvent(context.Background(), ListWebhooksForEventParams{
+		EventType:  "transfer.created",
+		AccountIds: []int64{account.ID},
+	})
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+}
+
+func TestDeactivateWebhookWrongOwner(t *testing.T) {
+	webhook := createRandomWebhook(t, createRandomUser(t).Username, []string{"account.created"})
+
+	rows, err := testQueries.DeactivateWebhook(context.Background(), DeactivateWebhookParams{
+		ID:    webhook.ID,
+		Owner: createRandomUser(t).Username,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
+
+func TestRecordWebhookDeliveryAttempt(t *testing.T) {
+	webhook := createRandomWebhook(t, createRandomUser(t).Username, []string{"account.created"})
+
+	delivery, err := testQueries.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		WebhookID: webhook.ID,
+		EventID:   util.RandomString(16),
+		EventType: "account.created",
+		Payload:   []byte(`{"type": "account.created"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.WebhookDeliveryPending, delivery.Status)
+	require.Zero(t, delivery.Attempts)
+	require.False(t, delivery.ResponseCode.Valid)
+	require.False(t, delivery.LastAttemptAt.Valid)
+
+	delivery, err = testQueries.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		ID:           delivery.ID,
+		Status:       util.WebhookDeliveryPending,
+		ResponseCode: pgtype.Int4{Int32: 503, Valid: true},
+		LastError:    "service unavailable",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), delivery.Attempts)
+	require.Equal(t, int32(503), delivery.ResponseCode.Int32)
+
+	delivery, err = testQueries.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		ID:           delivery.ID,
+		Status:       util.WebhookDeliverySucceeded,
+		ResponseCode: pgtype.Int4{Int32: 200, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.WebhookDeliverySucceeded, delivery.Status)
+	require.Equal(t, int32(2), delivery.Attempts)
+	require.Empty(t, delivery.LastError)
+	require.True(t, delivery.LastAttemptAt.Valid)
+
+	again, err := testQueries.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		WebhookID: webhook.ID,
+		EventID:   delivery.EventID,
+		EventType: delivery.EventType,
+		Payload:   delivery.Payload,
+	})
+	require.NoError(t, err)
+	require.Equal(t, delivery.ID, again.ID)
+	require.Equal(t, util.WebhookDeliverySucceeded, again.Status)
+
+	replay, err := testQueries.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		WebhookID: webhook.ID,
+		EventID:   delivery.EventID,
+		EventType: delivery.EventType,
+		Payload:   delivery.Payload,
+		ReplayOf:  pgtype.Int8{Int64: delivery.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, delivery.ID, replay.ID)
+	require.Equal(t, util.WebhookDeliveryPending, replay.Status)
+
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		WebhookID: webhook.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, replay.ID, deliveries[0].ID)
+	require.JSONEq(t, `{"type": "account.created"}`, string(deliveries[1].Payload))
+}
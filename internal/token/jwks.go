@@ -0,0 +1,68 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/base64"
+	"time"
+)
+
+// JSONWebKey is the public part of a signing key as described by RFC 7517
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y,omitempty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// JSONWebKeySet is served at /.well-known/jwks.json for services that verify our tokens
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys of the unexpired keys of the set, including keys that are not active yet
+func (keys *KeySet) JWKS() JSONWebKeySet {
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+
+	jwks := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range keys.keys {
+		if !time.Now().Before(key.ExpiresAt) {
+			continue
+		}
+
+		jwk := JSONWebKey{
+			KeyID:     key.ID,
+			Algorithm: key.Algorithm,
+			Use:       "sig",
+		}
+
+		switch publicKey := key.PrivateKey.Public().(type) {
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		case *ecdsa.PublicKey:
+			ecdhKey, err := publicKey.ECDH()
+			if err != nil {
+				continue
+			}
+
+			// the uncompressed point is 0x04 followed by the coordinates
+			point := ecdhKey.Bytes()[1:]
+			jwk.KeyType = "EC"
+			jwk.Curve = publicKey.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(point[:len(point)/2])
+			jwk.Y = base64.RawURLEncoding.EncodeToString(point[len(point)/2:])
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
@@ -12,19 +12,21 @@ const minSecretKeySize = 32
 // JWTMaker is a JSON Web Token maker
 type JWTMaker struct {
 	secretKey string
+	issuer    string
+	audience  string
 }
 
-func NewJWTMaker(secretKey string) (Maker, error) {
+func NewJWTMaker(secretKey string, issuer string, audience string) (Maker, error) {
 	if len(secretKey) < minSecretKeySize {
 		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minSecretKeySize)
 	}
 
-	return &JWTMaker{secretKey}, nil
+	return &JWTMaker{secretKey: secretKey, issuer: issuer, audience: audience}, nil
 }
 
 // CreateToken creates a new token with a specific username, role and duration
 func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, role, duration)
+	payload, err := NewPayload(username, role, maker.issuer, maker.audience, duration)
 	if err != nil {
 		return "", payload, err
 	}
@@ -45,7 +47,81 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 		return []byte(maker.secretKey), nil
 	}
 
-	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	return parseJWT(token, keyFunc, maker.issuer, maker.audience)
+}
+
+// PublicKeys returns no keys, tokens can only be verified with the secret key
+func (maker *JWTMaker) PublicKeys() JSONWebKeySet {
+	return JSONWebKeySet{Keys: []JSONWebKey{}}
+}
+
+// AsymmetricJWTMaker is a JSON Web Token maker that signs with the active key of a KeySet,
+// so other services can verify its tokens with the published public keys
+type AsymmetricJWTMaker struct {
+	keys     *KeySet
+	method   jwt.SigningMethod
+	issuer   string
+	audience string
+}
+
+// NewAsymmetricJWTMaker creates a new AsymmetricJWTMaker signing with EdDSAAlgorithm or ES256Algorithm
+func NewAsymmetricJWTMaker(keys *KeySet, algorithm string, issuer string, audience string) (Maker, error) {
+	var method jwt.SigningMethod
+	switch algorithm {
+	case EdDSAAlgorithm:
+		method = jwt.SigningMethodEdDSA
+	case ES256Algorithm:
+		method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	return &AsymmetricJWTMaker{keys: keys, method: method, issuer: issuer, audience: audience}, nil
+}
+
+// CreateToken creates a new token with a specific username, role and duration
+func (maker *AsymmetricJWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, maker.issuer, maker.audience, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	key, err := maker.keys.signingKey(maker.method.Alg())
+	if err != nil {
+		return "", payload, err
+	}
+
+	jwtToken := jwt.NewWithClaims(maker.method, payload)
+	jwtToken.Header["kid"] = key.ID
+	token, err := jwtToken.SignedString(key.PrivateKey)
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *AsymmetricJWTMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != maker.method.Alg() {
+			return nil, ErrInvalidToken
+		}
+
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+
+		return maker.keys.publicKey(kid, maker.method.Alg())
+	}
+
+	return parseJWT(token, keyFunc, maker.issuer, maker.audience)
+}
+
+// PublicKeys returns the public keys of the KeySet
+func (maker *AsymmetricJWTMaker) PublicKeys() JSONWebKeySet {
+	return maker.keys.JWKS()
+}
+
+func parseJWT(token string, keyFunc jwt.Keyfunc, issuer string, audience string) (*Payload, error) {
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc, jwt.WithIssuer(issuer), jwt.WithAudience(audience))
 	if err != nil {
 		return nil, err
 	}
@@ -11,7 +11,7 @@ import (
 )
 
 func TestJWTMaker(t *testing.T) {
-	maker, err := NewJWTMaker(util.RandomString(32))
+	maker, err := NewJWTMaker(util.RandomString(32), testIssuer, testAudience)
 	require.NoError(t, err)
 
 	username := util.RandomOwner()
@@ -37,7 +37,7 @@ func TestJWTMaker(t *testing.T) {
 }
 
 func TestExpiredJWT(t *testing.T) {
-	maker, err := NewJWTMaker(util.RandomString(32))
+	maker, err := NewJWTMaker(util.RandomString(32), testIssuer, testAudience)
 	require.NoError(t, err)
 
 	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
@@ -52,14 +52,14 @@ func TestExpiredJWT(t *testing.T) {
 }
 
 func TestInvalidJWT(t *testing.T) {
-	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, time.Minute)
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, testIssuer, testAudience, time.Minute)
 	require.NoError(t, err)
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
 	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
 	require.NoError(t, err)
 
-	maker, err := NewJWTMaker(util.RandomString(32))
+	maker, err := NewJWTMaker(util.RandomString(32), testIssuer, testAudience)
 	require.NoError(t, err)
 
 	payload, err = maker.VerifyToken(token)
@@ -67,3 +67,106 @@ func TestInvalidJWT(t *testing.T) {
 	require.EqualError(t, err, fmt.Sprintf("token is unverifiable: error while executing keyfunc: %s", ErrInvalidToken.Error()))
 	require.Nil(t, payload)
 }
+
+func TestJWTMakerWrongAudience(t *testing.T) {
+	secretKey := util.RandomString(32)
+
+	maker, err := NewJWTMaker(secretKey, testIssuer, "another-service")
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	maker, err = NewJWTMaker(secretKey, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	require.Nil(t, payload)
+
+	maker, err = NewJWTMaker(secretKey, "another-issuer", "another-service")
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricJWTMaker(t *testing.T) {
+	for _, algorithm := range []string{EdDSAAlgorithm, ES256Algorithm} {
+		t.Run(algorithm, func(t *testing.T) {
+			keys := newTestKeySet(t, algorithm)
+			maker, err := NewAsymmetricJWTMaker(keys, algorithm, testIssuer, testAudience)
+			require.NoError(t, err)
+
+			username := util.RandomOwner()
+			role := util.DepositorRole
+			duration := time.Minute
+
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			token, payload, err := maker.CreateToken(username, role, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			jwtToken, _, err := jwt.NewParser().ParseUnverified(token, &Payload{})
+			require.NoError(t, err)
+			require.Equal(t, algorithm, jwtToken.Header["alg"])
+			require.Equal(t, keys.Keys()[0].ID, jwtToken.Header["kid"])
+
+			payload, err = maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.NotEmpty(t, payload)
+
+			require.NotZero(t, payload.ID)
+			require.Equal(t, username, payload.Username)
+			require.Equal(t, role, payload.Role)
+			require.Equal(t, testIssuer, payload.Issuer)
+			require.Equal(t, jwt.ClaimStrings{testAudience}, payload.Audience)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+			require.Equal(t, keys.JWKS(), maker.PublicKeys())
+		})
+	}
+}
+
+func TestAsymmetricJWTMakerUnknownKey(t *testing.T) {
+	maker, err := NewAsymmetricJWTMaker(newTestKeySet(t, EdDSAAlgorithm), EdDSAAlgorithm, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	otherMaker, err := NewAsymmetricJWTMaker(newTestKeySet(t, EdDSAAlgorithm), EdDSAAlgorithm, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	payload, err := otherMaker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricJWTMakerWrongAlgorithm(t *testing.T) {
+	keys := newTestKeySet(t, EdDSAAlgorithm)
+
+	maker, err := NewAsymmetricJWTMaker(keys, EdDSAAlgorithm, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	es256Maker, err := NewAsymmetricJWTMaker(keys, ES256Algorithm, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	payload, err := es256Maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+
+	_, _, err = es256Maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.ErrorIs(t, err, ErrNoSigningKey)
+
+	_, err = NewAsymmetricJWTMaker(keys, "HS256", testIssuer, testAudience)
+	require.Error(t, err)
+}
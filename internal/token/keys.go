@@ -0,0 +1,200 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// EdDSAAlgorithm signs tokens with Ed25519 keys
+	EdDSAAlgorithm = "EdDSA"
+	// ES256Algorithm signs tokens with ECDSA P-256 keys and SHA-256
+	ES256Algorithm = "ES256"
+)
+
+var ErrNoSigningKey = errors.New("no signing key")
+
+// SigningKey is a private key tokens are signed with, identified by the kid of the tokens it signs
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+	// ExpiresAt is when the last token the key signed has expired, so the key is no longer published
+	ExpiresAt time.Time
+}
+
+// GenerateSigningKey creates a new random key for the algorithm
+func GenerateSigningKey(algorithm string) (SigningKey, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch algorithm {
+	case EdDSAAlgorithm:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	case ES256Algorithm:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	return SigningKey{
+		ID:         uuid.NewString(),
+		Algorithm:  algorithm,
+		PrivateKey: privateKey,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// SealPrivateKey encrypts the private key of a signing key with a 32 byte key, so it can be stored
+func SealPrivateKey(encryptionKey []byte, key SigningKey) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(privateKey)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// the key ID is authenticated, so a sealed key cannot be stored under another ID
+	return aead.Seal(nonce, nonce, privateKey, []byte(key.ID)), nil
+}
+
+// OpenPrivateKey decrypts a private key sealed by SealPrivateKey
+func OpenPrivateKey(encryptionKey []byte, id string, algorithm string, sealedPrivateKey []byte) (crypto.Signer, error) {
+	aead, err := chacha20poly1305.NewX(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealedPrivateKey) < aead.NonceSize() {
+		return nil, fmt.Errorf("sealed private key %s is too short", id)
+	}
+
+	nonce, ciphertext := sealedPrivateKey[:aead.NonceSize()], sealedPrivateKey[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key %s: %w", id, err)
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", id, err)
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok || !matchesAlgorithm(signer.Public(), algorithm) {
+		return nil, fmt.Errorf("private key %s is not an %s key", id, algorithm)
+	}
+
+	return signer, nil
+}
+
+func matchesAlgorithm(publicKey crypto.PublicKey, algorithm string) bool {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return algorithm == EdDSAAlgorithm
+	case *ecdsa.PublicKey:
+		return algorithm == ES256Algorithm && key.Curve == elliptic.P256()
+	default:
+		return false
+	}
+}
+
+// KeySet holds the keys tokens are signed and verified with. It is shared by every maker of an
+// instance and replaced as keys are rotated.
+type KeySet struct {
+	mu   sync.RWMutex
+	keys []SigningKey
+	// activationDelay is how long a new key is only published before it signs tokens,
+	// so other instances and verifiers have picked it up by then
+	activationDelay time.Duration
+}
+
+// NewKeySet creates an empty KeySet
+func NewKeySet(activationDelay time.Duration) *KeySet {
+	return &KeySet{activationDelay: activationDelay}
+}
+
+// Set replaces the keys of the set
+func (keys *KeySet) Set(signingKeys []SigningKey) {
+	keys.mu.Lock()
+	defer keys.mu.Unlock()
+
+	keys.keys = append([]SigningKey(nil), signingKeys...)
+}
+
+// Keys returns the keys of the set
+func (keys *KeySet) Keys() []SigningKey {
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+
+	return append([]SigningKey(nil), keys.keys...)
+}
+
+// signingKey returns the newest active key of the algorithm. A key only becomes active activationDelay
+// after it was created, unless there is no older key to sign with.
+func (keys *KeySet) signingKey(algorithm string) (SigningKey, error) {
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+
+	now := time.Now()
+	var newest, active *SigningKey
+	for i := range keys.keys {
+		key := &keys.keys[i]
+		if key.Algorithm != algorithm || !now.Before(key.ExpiresAt) {
+			continue
+		}
+
+		if newest == nil || key.CreatedAt.After(newest.CreatedAt) {
+			newest = key
+		}
+
+		if !now.Before(key.CreatedAt.Add(keys.activationDelay)) && (active == nil || key.CreatedAt.After(active.CreatedAt)) {
+			active = key
+		}
+	}
+
+	switch {
+	case active != nil:
+		return *active, nil
+	case newest != nil:
+		return *newest, nil
+	default:
+		return SigningKey{}, ErrNoSigningKey
+	}
+}
+
+// publicKey returns the public key of the unexpired key with the ID, if it is of the algorithm
+func (keys *KeySet) publicKey(id string, algorithm string) (crypto.PublicKey, error) {
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+
+	for _, key := range keys.keys {
+		if key.ID == id && key.Algorithm == algorithm && time.Now().Before(key.ExpiresAt) {
+			return key.PrivateKey.Public(), nil
+		}
+	}
+
+	return nil, ErrInvalidToken
+}
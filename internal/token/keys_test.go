@@ -0,0 +1,157 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "simplebank"
+	testAudience = "simplebank"
+)
+
+// newTestKeySet creates a KeySet with one fresh key of the algorithm
+func newTestKeySet(t *testing.T, algorithm string) *KeySet {
+	key, err := GenerateSigningKey(algorithm)
+	require.NoError(t, err)
+	key.ExpiresAt = time.Now().Add(time.Hour)
+
+	keys := NewKeySet(time.Minute)
+	keys.Set([]SigningKey{key})
+	return keys
+}
+
+func TestSealPrivateKey(t *testing.T) {
+	encryptionKey := []byte(util.RandomString(32))
+
+	for _, algorithm := range []string{EdDSAAlgorithm, ES256Algorithm} {
+		t.Run(algorithm, func(t *testing.T) {
+			key, err := GenerateSigningKey(algorithm)
+			require.NoError(t, err)
+			require.NotEmpty(t, key.ID)
+
+			sealedPrivateKey, err := SealPrivateKey(encryptionKey, key)
+			require.NoError(t, err)
+
+			privateKey, err := OpenPrivateKey(encryptionKey, key.ID, algorithm, sealedPrivateKey)
+			require.NoError(t, err)
+			require.Equal(t, key.PrivateKey, privateKey)
+
+			_, err = OpenPrivateKey([]byte(util.RandomString(32)), key.ID, algorithm, sealedPrivateKey)
+			require.Error(t, err)
+
+			// a sealed key cannot be moved to another ID
+			otherKey, err := GenerateSigningKey(algorithm)
+			require.NoError(t, err)
+			_, err = OpenPrivateKey(encryptionKey, otherKey.ID, algorithm, sealedPrivateKey)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestOpenPrivateKeyWrongAlgorithm(t *testing.T) {
+	encryptionKey := []byte(util.RandomString(32))
+
+	key, err := GenerateSigningKey(EdDSAAlgorithm)
+	require.NoError(t, err)
+
+	sealedPrivateKey, err := SealPrivateKey(encryptionKey, key)
+	require.NoError(t, err)
+
+	_, err = OpenPrivateKey(encryptionKey, key.ID, ES256Algorithm, sealedPrivateKey)
+	require.Error(t, err)
+}
+
+func TestKeySetRotation(t *testing.T) {
+	keys := NewKeySet(time.Minute)
+
+	_, err := keys.signingKey(EdDSAAlgorithm)
+	require.ErrorIs(t, err, ErrNoSigningKey)
+
+	oldKey, err := GenerateSigningKey(EdDSAAlgorithm)
+	require.NoError(t, err)
+	oldKey.CreatedAt = time.Now().Add(-time.Hour)
+	oldKey.ExpiresAt = time.Now().Add(time.Hour)
+
+	// a new key signs nothing until other instances had the time to load it
+	newKey, err := GenerateSigningKey(EdDSAAlgorithm)
+	require.NoError(t, err)
+	newKey.ExpiresAt = time.Now().Add(2 * time.Hour)
+
+	keys.Set([]SigningKey{oldKey, newKey})
+
+	signingKey, err := keys.signingKey(EdDSAAlgorithm)
+	require.NoError(t, err)
+	require.Equal(t, oldKey.ID, signingKey.ID)
+
+	_, err = keys.publicKey(newKey.ID, EdDSAAlgorithm)
+	require.NoError(t, err)
+
+	newKey.CreatedAt = time.Now().Add(-2 * time.Minute)
+	keys.Set([]SigningKey{oldKey, newKey})
+
+	signingKey, err = keys.signingKey(EdDSAAlgorithm)
+	require.NoError(t, err)
+	require.Equal(t, newKey.ID, signingKey.ID)
+
+	// the old key still verifies the tokens it signed until it expires
+	_, err = keys.publicKey(oldKey.ID, EdDSAAlgorithm)
+	require.NoError(t, err)
+
+	oldKey.ExpiresAt = time.Now().Add(-time.Second)
+	keys.Set([]SigningKey{oldKey, newKey})
+
+	_, err = keys.publicKey(oldKey.ID, EdDSAAlgorithm)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = keys.publicKey(newKey.ID, ES256Algorithm)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestKeySetNewestKeySignsWithoutActiveKey(t *testing.T) {
+	keys := newTestKeySet(t, ES256Algorithm)
+
+	_, err := keys.signingKey(EdDSAAlgorithm)
+	require.ErrorIs(t, err, ErrNoSigningKey)
+
+	key, err := keys.signingKey(ES256Algorithm)
+	require.NoError(t, err)
+	require.Equal(t, keys.Keys()[0].ID, key.ID)
+}
+
+func TestJWKS(t *testing.T) {
+	eddsaKey, err := GenerateSigningKey(EdDSAAlgorithm)
+	require.NoError(t, err)
+	eddsaKey.ExpiresAt = time.Now().Add(time.Hour)
+
+	es256Key, err := GenerateSigningKey(ES256Algorithm)
+	require.NoError(t, err)
+	es256Key.ExpiresAt = time.Now().Add(time.Hour)
+
+	expiredKey, err := GenerateSigningKey(EdDSAAlgorithm)
+	require.NoError(t, err)
+	expiredKey.ExpiresAt = time.Now().Add(-time.Second)
+
+	keys := NewKeySet(time.Minute)
+	keys.Set([]SigningKey{eddsaKey, es256Key, expiredKey})
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+
+	require.Equal(t, eddsaKey.ID, jwks.Keys[0].KeyID)
+	require.Equal(t, "OKP", jwks.Keys[0].KeyType)
+	require.Equal(t, "Ed25519", jwks.Keys[0].Curve)
+	require.Equal(t, EdDSAAlgorithm, jwks.Keys[0].Algorithm)
+	require.Len(t, jwks.Keys[0].X, 43)
+	require.Empty(t, jwks.Keys[0].Y)
+
+	require.Equal(t, es256Key.ID, jwks.Keys[1].KeyID)
+	require.Equal(t, "EC", jwks.Keys[1].KeyType)
+	require.Equal(t, "P-256", jwks.Keys[1].Curve)
+	require.Equal(t, ES256Algorithm, jwks.Keys[1].Algorithm)
+	require.Len(t, jwks.Keys[1].X, 43)
+	require.Len(t, jwks.Keys[1].Y, 43)
+}
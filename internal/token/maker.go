@@ -9,4 +9,7 @@ type Maker interface {
 
 	// VerifyToken checks if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
+
+	// PublicKeys returns the keys other services can verify tokens with, it is empty for symmetric makers
+	PublicKeys() JSONWebKeySet
 }
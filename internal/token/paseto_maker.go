@@ -1,6 +1,8 @@
 package token
 
 import (
+	"crypto/ed25519"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -12,10 +14,12 @@ import (
 type PasetoMaker struct {
 	paseto       *paseto.V2
 	symmetricKey []byte
+	issuer       string
+	audience     string
 }
 
 // NewPasetoMaker creates a new PasetoMaker
-func NewPasetoMaker(symmetricKey string) (Maker, error) {
+func NewPasetoMaker(symmetricKey string, issuer string, audience string) (Maker, error) {
 	if len(symmetricKey) != chacha20poly1305.KeySize {
 		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", chacha20poly1305.KeySize)
 	}
@@ -23,6 +27,8 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	maker := &PasetoMaker{
 		paseto:       paseto.NewV2(),
 		symmetricKey: []byte(symmetricKey),
+		issuer:       issuer,
+		audience:     audience,
 	}
 
 	return maker, nil
@@ -30,7 +36,7 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 
 // CreateToken creates a new token with a specific username, role and duration
 func (maker *PasetoMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, role, duration)
+	payload, err := NewPayload(username, role, maker.issuer, maker.audience, duration)
 	if err != nil {
 		return "", payload, err
 	}
@@ -53,5 +59,113 @@ func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 		return nil, err
 	}
 
+	err = payload.validFor(maker.issuer, maker.audience)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// PublicKeys returns no keys, tokens can only be verified with the symmetric key
+func (maker *PasetoMaker) PublicKeys() JSONWebKeySet {
+	return JSONWebKeySet{Keys: []JSONWebKey{}}
+}
+
+// AsymmetricPasetoMaker is a PASETO v4.public token maker that signs with the active Ed25519 key of a KeySet,
+// so other services can verify its tokens with the published public keys
+type AsymmetricPasetoMaker struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+}
+
+// pasetoFooter identifies the key a token was signed with
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// NewAsymmetricPasetoMaker creates a new AsymmetricPasetoMaker
+func NewAsymmetricPasetoMaker(keys *KeySet, issuer string, audience string) (Maker, error) {
+	return &AsymmetricPasetoMaker{keys: keys, issuer: issuer, audience: audience}, nil
+}
+
+// CreateToken creates a new token with a specific username, role and duration
+func (maker *AsymmetricPasetoMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, maker.issuer, maker.audience, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	key, err := maker.keys.signingKey(EdDSAAlgorithm)
+	if err != nil {
+		return "", payload, err
+	}
+
+	privateKey, ok := key.PrivateKey.(ed25519.PrivateKey)
+	if !ok {
+		return "", payload, fmt.Errorf("signing key %s is not an Ed25519 key", key.ID)
+	}
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+
+	footer, err := json.Marshal(pasetoFooter{KeyID: key.ID})
+	if err != nil {
+		return "", payload, err
+	}
+
+	return signPasetoV4Public(privateKey, message, footer), payload, nil
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *AsymmetricPasetoMaker) VerifyToken(token string) (*Payload, error) {
+	signed, encodedFooter, err := splitPasetoV4Public(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var footer pasetoFooter
+	if err := json.Unmarshal(encodedFooter, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, err := maker.keys.publicKey(footer.KeyID, EdDSAAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	ed25519Key, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	message, err := verifyPasetoV4Public(ed25519Key, signed, encodedFooter)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(message, payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	err = payload.validFor(maker.issuer, maker.audience)
+	if err != nil {
+		return nil, err
+	}
+
 	return payload, nil
 }
+
+// PublicKeys returns the public keys of the KeySet
+func (maker *AsymmetricPasetoMaker) PublicKeys() JSONWebKeySet {
+	return maker.keys.JWKS()
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -9,7 +10,7 @@ import (
 )
 
 func TestPasetoMaker(t *testing.T) {
-	maker, err := NewPasetoMaker(util.RandomString(32))
+	maker, err := NewPasetoMaker(util.RandomString(32), testIssuer, testAudience)
 	require.NoError(t, err)
 
 	username := util.RandomOwner()
@@ -36,7 +37,7 @@ func TestPasetoMaker(t *testing.T) {
 }
 
 func TestExpiredPaseto(t *testing.T) {
-	maker, err := NewPasetoMaker(util.RandomString(32))
+	maker, err := NewPasetoMaker(util.RandomString(32), testIssuer, testAudience)
 	require.NoError(t, err)
 
 	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
@@ -49,3 +50,99 @@ func TestExpiredPaseto(t *testing.T) {
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestPasetoMakerWrongIssuer(t *testing.T) {
+	symmetricKey := util.RandomString(32)
+
+	maker, err := NewPasetoMaker(symmetricKey, "another-issuer", testAudience)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	maker, err = NewPasetoMaker(symmetricKey, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidIssuer)
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricPasetoMaker(t *testing.T) {
+	keys := newTestKeySet(t, EdDSAAlgorithm)
+	maker, err := NewAsymmetricPasetoMaker(keys, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	role := util.DepositorRole
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, role, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+	require.True(t, strings.HasPrefix(token, "v4.public."))
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, role, payload.Role)
+	require.Equal(t, testIssuer, payload.Issuer)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+	require.Equal(t, keys.JWKS(), maker.PublicKeys())
+}
+
+func TestExpiredAsymmetricPaseto(t *testing.T) {
+	maker, err := NewAsymmetricPasetoMaker(newTestKeySet(t, EdDSAAlgorithm), testIssuer, testAudience)
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestInvalidAsymmetricPaseto(t *testing.T) {
+	keys := newTestKeySet(t, EdDSAAlgorithm)
+	maker, err := NewAsymmetricPasetoMaker(keys, testIssuer, testAudience)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// the footer is signed along with the payload
+	tamperedToken := token[:strings.LastIndex(token, ".")+1] + pasetoEncoding.EncodeToString([]byte(`{"kid":"`+keys.Keys()[0].ID+`" }`))
+
+	payload, err := maker.VerifyToken(tamperedToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+
+	otherMaker, err := NewAsymmetricPasetoMaker(newTestKeySet(t, EdDSAAlgorithm), testIssuer, testAudience)
+	require.NoError(t, err)
+
+	payload, err = otherMaker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+
+	symmetricMaker, err := NewPasetoMaker(util.RandomString(32), testIssuer, testAudience)
+	require.NoError(t, err)
+
+	symmetricToken, _, err := symmetricMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(symmetricToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+}
@@ -0,0 +1,71 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+)
+
+// PASETO v4.public tokens are signed with Ed25519 over the pre-authentication encoding of the header,
+// message and footer, see https://github.com/paseto-standard/paseto-spec/blob/master/docs/01-Protocol-Versions/Version4.md
+
+const pasetoV4PublicHeader = "v4.public."
+
+var pasetoEncoding = base64.RawURLEncoding
+
+// signPasetoV4Public signs the message into a token, the footer is sent as it is
+func signPasetoV4Public(privateKey ed25519.PrivateKey, message []byte, footer []byte) string {
+	signature := ed25519.Sign(privateKey, pasetoPAE([]byte(pasetoV4PublicHeader), message, footer, nil))
+
+	token := pasetoV4PublicHeader + pasetoEncoding.EncodeToString(append(message, signature...))
+	if len(footer) > 0 {
+		token += "." + pasetoEncoding.EncodeToString(footer)
+	}
+	return token
+}
+
+// splitPasetoV4Public returns the signed part and the footer of a token without verifying it,
+// the footer is needed first to find the key to verify with
+func splitPasetoV4Public(token string) (signed []byte, footer []byte, err error) {
+	body, ok := strings.CutPrefix(token, pasetoV4PublicHeader)
+	if !ok {
+		return nil, nil, ErrInvalidToken
+	}
+
+	body, encodedFooter, _ := strings.Cut(body, ".")
+	signed, err = pasetoEncoding.DecodeString(body)
+	if err != nil || len(signed) < ed25519.SignatureSize {
+		return nil, nil, ErrInvalidToken
+	}
+
+	footer, err = pasetoEncoding.DecodeString(encodedFooter)
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	return signed, footer, nil
+}
+
+// verifyPasetoV4Public checks the signature of a token split by splitPasetoV4Public and returns its message
+func verifyPasetoV4Public(publicKey ed25519.PublicKey, signed []byte, footer []byte) ([]byte, error) {
+	message := signed[:len(signed)-ed25519.SignatureSize]
+	signature := signed[len(signed)-ed25519.SignatureSize:]
+
+	if !ed25519.Verify(publicKey, pasetoPAE([]byte(pasetoV4PublicHeader), message, footer, nil), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	return message, nil
+}
+
+// pasetoPAE is the pre-authentication encoding: the number of pieces and the length of each piece
+// as unsigned 64-bit little-endian integers, each length followed by its piece
+func pasetoPAE(pieces ...[]byte) []byte {
+	encoded := binary.LittleEndian.AppendUint64(nil, uint64(len(pieces)))
+	for _, piece := range pieces {
+		encoded = binary.LittleEndian.AppendUint64(encoded, uint64(len(piece)))
+		encoded = append(encoded, piece...)
+	}
+	return encoded
+}
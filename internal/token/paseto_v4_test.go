@@ -0,0 +1,28 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPasetoV4PublicVector checks the signing against test vector 4-S-1 of the PASETO specification
+func TestPasetoV4PublicVector(t *testing.T) {
+	secretKey, err := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a37741eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+
+	message := []byte(`{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`)
+	token := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+
+	require.Equal(t, token, signPasetoV4Public(ed25519.PrivateKey(secretKey), message, nil))
+
+	signed, footer, err := splitPasetoV4Public(token)
+	require.NoError(t, err)
+	require.Empty(t, footer)
+
+	verified, err := verifyPasetoV4Public(ed25519.PrivateKey(secretKey).Public().(ed25519.PublicKey), signed, footer)
+	require.NoError(t, err)
+	require.Equal(t, message, verified)
+}
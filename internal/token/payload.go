@@ -2,6 +2,7 @@ package token
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,19 +12,25 @@ import (
 var (
 	ErrInvalidToken = errors.New("token is invalid")
 	ErrExpiredToken = errors.New("token is expired")
+	// ErrInvalidIssuer and ErrInvalidAudience are returned for tokens made by or for another service
+	ErrInvalidIssuer   = errors.New("token has invalid issuer")
+	ErrInvalidAudience = errors.New("token has invalid audience")
 )
 
 // Payload contains the payload data of the token
 type Payload struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
-	Role      string    `json:"role"`
-	IssuedAt  time.Time `json:"issued_at"`
-	ExpiredAt time.Time `json:"expired_at"`
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	Issuer   string    `json:"issuer"`
+	// Audience are the services the token is meant for
+	Audience  jwt.ClaimStrings `json:"audience"`
+	IssuedAt  time.Time        `json:"issued_at"`
+	ExpiredAt time.Time        `json:"expired_at"`
 }
 
-// NewPayload creates a new token payload with specific username and duration
-func NewPayload(username string, role string, duration time.Duration) (*Payload, error) {
+// NewPayload creates a new token payload with specific username and duration, issued by issuer for audience
+func NewPayload(username string, role string, issuer string, audience string, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -33,6 +40,8 @@ func NewPayload(username string, role string, duration time.Duration) (*Payload,
 		ID:        tokenID,
 		Username:  username,
 		Role:      role,
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{audience},
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
@@ -53,7 +62,7 @@ func (payload *Payload) GetNotBefore() (*jwt.NumericDate, error) {
 }
 
 func (payload *Payload) GetIssuer() (string, error) {
-	return "go-simplebank", nil
+	return payload.Issuer, nil
 }
 
 func (payload *Payload) GetSubject() (string, error) {
@@ -61,7 +70,7 @@ func (payload *Payload) GetSubject() (string, error) {
 }
 
 func (payload *Payload) GetAudience() (jwt.ClaimStrings, error) {
-	return jwt.ClaimStrings{}, nil
+	return payload.Audience, nil
 }
 
 func (payload *Payload) Valid() error {
@@ -72,3 +81,16 @@ func (payload *Payload) Valid() error {
 
 	return nil
 }
+
+// validFor checks that the token was issued by issuer for audience
+func (payload *Payload) validFor(issuer string, audience string) error {
+	if payload.Issuer != issuer {
+		return ErrInvalidIssuer
+	}
+
+	if !slices.Contains(payload.Audience, audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package pain
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// namespacePrefix is followed by the message definition, e.g. pain.001.001.09
+	namespacePrefix = "urn:iso:std:iso:20022:tech:xsd:"
+	// pain001NamespacePrefix is shared by every version of the customer credit transfer initiation
+	pain001NamespacePrefix = namespacePrefix + "pain.001."
+)
+
+// notProvided is the end to end ID the initiating party uses when it has none, it is not unique
+const notProvided = "NOTPROVIDED"
+
+var ErrInvalidDocument = errors.New("invalid pain.001 document")
+
+// Initiation is a parsed pain.001 customer credit transfer initiation
+type Initiation struct {
+	MessageID string
+	// MessageName is the message definition the document uses, e.g. pain.001.001.09
+	MessageName string
+	CreatedAt   time.Time
+	Transfers   []CreditTransfer
+}
+
+// CreditTransfer is one credit transfer of a payment information block. Its fields are taken from the
+// document as they are, so that each one can be checked and reported on by the bank.
+type CreditTransfer struct {
+	PaymentInformationID  string
+	InstructionID         string
+	EndToEndID            string
+	DebtorAccount         string
+	CreditorAccount       string
+	Amount                string
+	Currency              string
+	RemittanceInformation string
+}
+
+type painAccount struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+func (account painAccount) id() string {
+	if account.IBAN != "" {
+		return strings.TrimSpace(account.IBAN)
+	}
+	return strings.TrimSpace(account.Other)
+}
+
+type pain001Document struct {
+	XMLName    xml.Name `xml:"Document"`
+	Initiation struct {
+		MessageID          string `xml:"GrpHdr>MsgId"`
+		CreatedAt          string `xml:"GrpHdr>CreDtTm"`
+		NumberOfTxs        string `xml:"GrpHdr>NbOfTxs"`
+		PaymentInformation []struct {
+			ID            string      `xml:"PmtInfId"`
+			DebtorAccount painAccount `xml:"DbtrAcct"`
+			Transactions  []struct {
+				InstructionID string `xml:"PmtId>InstrId"`
+				EndToEndID    string `xml:"PmtId>EndToEndId"`
+				Amount        struct {
+					Currency string `xml:"Ccy,attr"`
+					Value    string `xml:",chardata"`
+				} `xml:"Amt>InstdAmt"`
+				CreditorAccount painAccount `xml:"CdtrAcct"`
+				Remittance      []string    `xml:"RmtInf>Ustrd"`
+			} `xml:"CdtTrfTxInf"`
+		} `xml:"PmtInf"`
+	} `xml:"CstmrCdtTrfInitn"`
+}
+
+// Parse reads a pain.001 document of any version. Only the structure of the document is checked here,
+// the transfers themselves are returned unvalidated.
+func Parse(r io.Reader) (*Initiation, error) {
+	var doc pain001Document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+	}
+
+	namespace := doc.XMLName.Space
+	if !strings.HasPrefix(namespace, pain001NamespacePrefix) {
+		return nil, fmt.Errorf("%w: unexpected namespace %q", ErrInvalidDocument, namespace)
+	}
+
+	header := doc.Initiation
+	initiation := &Initiation{
+		MessageID:   strings.TrimSpace(header.MessageID),
+		MessageName: strings.TrimPrefix(namespace, namespacePrefix),
+	}
+	if initiation.MessageID == "" {
+		return nil, fmt.Errorf("%w: GrpHdr/MsgId is missing", ErrInvalidDocument)
+	}
+
+	createdAt, err := parseDateTime(strings.TrimSpace(header.CreatedAt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: GrpHdr/CreDtTm: %s", ErrInvalidDocument, err)
+	}
+	initiation.CreatedAt = createdAt
+
+	for _, info := range header.PaymentInformation {
+		for _, tx := range info.Transactions {
+			endToEndID := strings.TrimSpace(tx.EndToEndID)
+			if endToEndID == "" {
+				endToEndID = notProvided
+			}
+
+			initiation.Transfers = append(initiation.Transfers, CreditTransfer{
+				PaymentInformationID:  strings.TrimSpace(info.ID),
+				InstructionID:         strings.TrimSpace(tx.InstructionID),
+				EndToEndID:            endToEndID,
+				DebtorAccount:         info.DebtorAccount.id(),
+				CreditorAccount:       tx.CreditorAccount.id(),
+				Amount:                strings.TrimSpace(tx.Amount.Value),
+				Currency:              strings.TrimSpace(tx.Amount.Currency),
+				RemittanceInformation: strings.TrimSpace(strings.Join(tx.Remittance, " ")),
+			})
+		}
+	}
+
+	if len(initiation.Transfers) == 0 {
+		return nil, fmt.Errorf("%w: no credit transfers", ErrInvalidDocument)
+	}
+
+	if n := strings.TrimSpace(header.NumberOfTxs); n != "" && n != fmt.Sprint(len(initiation.Transfers)) {
+		return nil, fmt.Errorf("%w: GrpHdr/NbOfTxs is %s but the document has %d credit transfers", ErrInvalidDocument, n, len(initiation.Transfers))
+	}
+
+	return initiation, nil
+}
+
+// HasEndToEndID reports whether the initiating party gave the transfer its own end to end ID
+func (transfer CreditTransfer) HasEndToEndID() bool {
+	return transfer.EndToEndID != notProvided
+}
+
+// parseDateTime parses an ISO 8601 date time, which may leave out the time zone
+func parseDateTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not an ISO 8601 date time", value)
+}
@@ -0,0 +1,161 @@
+package pain
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const pain002Namespace = namespacePrefix + "pain.002.001.10"
+
+// Transaction and group statuses of a payment status report, from the ISO 20022 external code sets
+const (
+	StatusPending  = "PDNG"
+	StatusSettled  = "ACSC"
+	StatusRejected = "RJCT"
+	// StatusPartiallyAccepted is only used for a group, when some of its transactions were rejected
+	StatusPartiallyAccepted = "PART"
+)
+
+// Reasons a transaction is rejected, from the ISO 20022 external status reason code set
+const (
+	ReasonIncorrectAccountNumber = "AC01"
+	ReasonTransactionForbidden   = "AG01"
+	ReasonNotAllowedAmount       = "AM02"
+	ReasonNotAllowedCurrency     = "AM03"
+	ReasonInsufficientFunds      = "AM04"
+	ReasonDuplication            = "AM05"
+	ReasonInvalidAmount          = "AM12"
+	// ReasonNarrative leaves the explanation to the additional information
+	ReasonNarrative = "NARR"
+)
+
+// StatusReport is a pain.002 customer payment status report on a pain.001 initiation
+type StatusReport struct {
+	MessageID           string
+	CreatedAt           time.Time
+	OriginalMessageID   string
+	OriginalMessageName string
+	Transactions        []TransactionStatus
+}
+
+// TransactionStatus is the status of one credit transfer of the original initiation
+type TransactionStatus struct {
+	OriginalPaymentInformationID string
+	OriginalInstructionID        string
+	OriginalEndToEndID           string
+	Status                       string
+	// ReasonCode and Reason are set when the transaction was rejected
+	ReasonCode string
+	Reason     string
+}
+
+type pain002Reason struct {
+	Code           string `xml:"Rsn>Cd"`
+	AdditionalInfo string `xml:"AddtlInf,omitempty"`
+}
+
+type pain002Transaction struct {
+	OriginalInstructionID string         `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID    string         `xml:"OrgnlEndToEndId"`
+	Status                string         `xml:"TxSts"`
+	Reason                *pain002Reason `xml:"StsRsnInf,omitempty"`
+}
+
+type pain002PaymentInformation struct {
+	OriginalID   string               `xml:"OrgnlPmtInfId"`
+	Transactions []pain002Transaction `xml:"TxInfAndSts"`
+}
+
+type pain002Document struct {
+	XMLName   xml.Name `xml:"Document"`
+	Namespace string   `xml:"xmlns,attr"`
+	Report    struct {
+		MessageID           string                      `xml:"GrpHdr>MsgId"`
+		CreatedAt           string                      `xml:"GrpHdr>CreDtTm"`
+		OriginalMessageID   string                      `xml:"OrgnlGrpInfAndSts>OrgnlMsgId"`
+		OriginalMessageName string                      `xml:"OrgnlGrpInfAndSts>OrgnlMsgNmId"`
+		OriginalNumberOfTxs string                      `xml:"OrgnlGrpInfAndSts>OrgnlNbOfTxs"`
+		GroupStatus         string                      `xml:"OrgnlGrpInfAndSts>GrpSts"`
+		PaymentInformation  []pain002PaymentInformation `xml:"OrgnlPmtInfAndSts"`
+	} `xml:"CstmrPmtStsRpt"`
+}
+
+// GroupStatus sums up the statuses of the transactions: settled or rejected when they all are,
+// partially accepted when some were rejected and the rest settled, and pending otherwise
+func (report StatusReport) GroupStatus() string {
+	counts := make(map[string]int)
+	for _, tx := range report.Transactions {
+		counts[tx.Status]++
+	}
+
+	switch n := len(report.Transactions); {
+	case counts[StatusSettled] == n:
+		return StatusSettled
+	case counts[StatusRejected] == n:
+		return StatusRejected
+	case counts[StatusSettled]+counts[StatusRejected] == n:
+		return StatusPartiallyAccepted
+	}
+	return StatusPending
+}
+
+// WriteStatusReport writes the report as a pain.002.001.10 document. Transactions are grouped by
+// their original payment information block, in the order the blocks first appear.
+func WriteStatusReport(w io.Writer, report StatusReport) error {
+	var doc pain002Document
+	doc.Namespace = pain002Namespace
+	doc.Report.MessageID = report.MessageID
+	doc.Report.CreatedAt = report.CreatedAt.UTC().Format(time.RFC3339)
+	doc.Report.OriginalMessageID = report.OriginalMessageID
+	doc.Report.OriginalMessageName = report.OriginalMessageName
+	doc.Report.OriginalNumberOfTxs = strconv.Itoa(len(report.Transactions))
+	doc.Report.GroupStatus = report.GroupStatus()
+
+	blocks := make(map[string]int)
+	for _, tx := range report.Transactions {
+		i, ok := blocks[tx.OriginalPaymentInformationID]
+		if !ok {
+			i = len(doc.Report.PaymentInformation)
+			blocks[tx.OriginalPaymentInformationID] = i
+			doc.Report.PaymentInformation = append(doc.Report.PaymentInformation, pain002PaymentInformation{
+				OriginalID: tx.OriginalPaymentInformationID,
+			})
+		}
+
+		transaction := pain002Transaction{
+			OriginalInstructionID: tx.OriginalInstructionID,
+			OriginalEndToEndID:    tx.OriginalEndToEndID,
+			Status:                tx.Status,
+		}
+		if tx.ReasonCode != "" {
+			transaction.Reason = &pain002Reason{Code: tx.ReasonCode, AdditionalInfo: truncate(tx.Reason, 105)}
+		}
+
+		doc.Report.PaymentInformation[i].Transactions = append(doc.Report.PaymentInformation[i].Transactions, transaction)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode status report: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// truncate shortens s to at most n runes, the length limits of ISO 20022 text fields count characters
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,168 @@
+package pain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testInitiation = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>MSG-2026-03-01</MsgId>
+      <CreDtTm>2026-03-01T09:30:00</CreDtTm>
+      <NbOfTxs>3</NbOfTxs>
+      <CtrlSum>1250.50</CtrlSum>
+      <InitgPty><Nm>Acme Ltd</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>PMT-1</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <DbtrAcct><Id><Othr><Id>SMPL491234567890</Id></Othr></Id></DbtrAcct>
+      <CdtTrfTxInf>
+        <PmtId><InstrId>INSTR-1</InstrId><EndToEndId>INV-1001</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">1000.50</InstdAmt></Amt>
+        <CdtrAcct><Id><IBAN>SMPL220000000001</IBAN></Id></CdtrAcct>
+        <RmtInf><Ustrd>Invoice 1001</Ustrd></RmtInf>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>NOTPROVIDED</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">200</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>SMPL 2200 0000 0002</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+    <PmtInf>
+      <PmtInfId>PMT-2</PmtInfId>
+      <DbtrAcct><Id><Othr><Id>SMPL491234567891</Id></Othr></Id></DbtrAcct>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>INV-1002</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="EUR">50</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>SMPL220000000003</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`
+
+func TestParse(t *testing.T) {
+	initiation, err := Parse(strings.NewReader(testInitiation))
+	require.NoError(t, err)
+
+	require.Equal(t, "MSG-2026-03-01", initiation.MessageID)
+	require.Equal(t, "pain.001.001.09", initiation.MessageName)
+	require.Equal(t, time.Date(2026, time.March, 1, 9, 30, 0, 0, time.UTC), initiation.CreatedAt)
+	require.Len(t, initiation.Transfers, 3)
+
+	require.Equal(t, CreditTransfer{
+		PaymentInformationID:  "PMT-1",
+		InstructionID:         "INSTR-1",
+		EndToEndID:            "INV-1001",
+		DebtorAccount:         "SMPL491234567890",
+		CreditorAccount:       "SMPL220000000001",
+		Amount:                "1000.50",
+		Currency:              "USD",
+		RemittanceInformation: "Invoice 1001",
+	}, initiation.Transfers[0])
+	require.True(t, initiation.Transfers[0].HasEndToEndID())
+
+	require.Equal(t, "SMPL 2200 0000 0002", initiation.Transfers[1].CreditorAccount)
+	require.False(t, initiation.Transfers[1].HasEndToEndID())
+
+	require.Equal(t, "PMT-2", initiation.Transfers[2].PaymentInformationID)
+	require.Equal(t, "SMPL491234567891", initiation.Transfers[2].DebtorAccount)
+	require.Equal(t, "EUR", initiation.Transfers[2].Currency)
+}
+
+func TestParseInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+	}{
+		{
+			name:     "NotXML",
+			document: "date,amount\n",
+		},
+		{
+			name:     "WrongMessage",
+			document: strings.ReplaceAll(testInitiation, "pain.001.001.09", "camt.053.001.08"),
+		},
+		{
+			name:     "NoMessageID",
+			document: strings.Replace(testInitiation, "<MsgId>MSG-2026-03-01</MsgId>", "", 1),
+		},
+		{
+			name:     "NumberOfTransactionsMismatch",
+			document: strings.Replace(testInitiation, "<NbOfTxs>3</NbOfTxs>", "<NbOfTxs>4</NbOfTxs>", 1),
+		},
+		{
+			name:     "NoTransfers",
+			document: `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"><CstmrCdtTrfInitn><GrpHdr><MsgId>M</MsgId><CreDtTm>2026-03-01T09:30:00Z</CreDtTm></GrpHdr></CstmrCdtTrfInitn></Document>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tc.document))
+			require.True(t, errors.Is(err, ErrInvalidDocument), err)
+		})
+	}
+}
+
+func TestWriteStatusReport(t *testing.T) {
+	report := StatusReport{
+		MessageID:           "STS-1",
+		CreatedAt:           time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC),
+		OriginalMessageID:   "MSG-2026-03-01",
+		OriginalMessageName: "pain.001.001.09",
+		Transactions: []TransactionStatus{
+			{OriginalPaymentInformationID: "PMT-1", OriginalInstructionID: "INSTR-1", OriginalEndToEndID: "INV-1001", Status: StatusSettled},
+			{OriginalPaymentInformationID: "PMT-2", OriginalEndToEndID: "INV-1002", Status: StatusRejected, ReasonCode: ReasonInsufficientFunds, Reason: "insufficient funds <in> account"},
+			{OriginalPaymentInformationID: "PMT-1", OriginalEndToEndID: "NOTPROVIDED", Status: StatusSettled},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatusReport(&buf, report))
+	require.True(t, strings.HasPrefix(buf.String(), xml.Header))
+	require.Contains(t, buf.String(), `xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.10"`)
+
+	var doc pain002Document
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	require.Equal(t, "STS-1", doc.Report.MessageID)
+	require.Equal(t, "2026-03-01T10:00:00Z", doc.Report.CreatedAt)
+	require.Equal(t, "MSG-2026-03-01", doc.Report.OriginalMessageID)
+	require.Equal(t, "pain.001.001.09", doc.Report.OriginalMessageName)
+	require.Equal(t, "3", doc.Report.OriginalNumberOfTxs)
+	require.Equal(t, StatusPartiallyAccepted, doc.Report.GroupStatus)
+
+	require.Len(t, doc.Report.PaymentInformation, 2)
+	require.Equal(t, "PMT-1", doc.Report.PaymentInformation[0].OriginalID)
+	require.Len(t, doc.Report.PaymentInformation[0].Transactions, 2)
+	require.Equal(t, "INSTR-1", doc.Report.PaymentInformation[0].Transactions[0].OriginalInstructionID)
+	require.Nil(t, doc.Report.PaymentInformation[0].Transactions[0].Reason)
+
+	rejected := doc.Report.PaymentInformation[1].Transactions[0]
+	require.Equal(t, StatusRejected, rejected.Status)
+	require.Equal(t, ReasonInsufficientFunds, rejected.Reason.Code)
+	require.Equal(t, "insufficient funds <in> account", rejected.Reason.AdditionalInfo)
+}
+
+func TestGroupStatus(t *testing.T) {
+	report := func(statuses ...string) StatusReport {
+		var r StatusReport
+		for _, s := range statuses {
+			r.Transactions = append(r.Transactions, TransactionStatus{Status: s})
+		}
+		return r
+	}
+
+	require.Equal(t, StatusSettled, report(StatusSettled, StatusSettled).GroupStatus())
+	require.Equal(t, StatusRejected, report(StatusRejected).GroupStatus())
+	require.Equal(t, StatusPartiallyAccepted, report(StatusSettled, StatusRejected).GroupStatus())
+	require.Equal(t, StatusPending, report(StatusPending, StatusRejected).GroupStatus())
+}
@@ -3,14 +3,22 @@ package validator
 import (
 	"fmt"
 	"net/mail"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
+	"github.com/google/uuid"
 )
 
 var (
 	isValidUsername = regexp.MustCompile(`^[a-zA-Z0-9_]+$`).MatchString
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidHandle   = regexp.MustCompile(`^@?[a-zA-Z0-9_]+$`).MatchString
+	isValidLabel    = regexp.MustCompile(`^[a-zA-Z0-9_\- ]+$`).MatchString
+	isValidCurrency = regexp.MustCompile(`^[A-Z]{3}$`).MatchString
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -79,3 +87,452 @@ func ValidateCurrency(currency string) error {
 	}
 	return nil
 }
+
+// ValidateAccountCurrency checks that a new account can be opened in the currency
+func ValidateAccountCurrency(currency string) error {
+	if err := ValidateCurrency(currency); err != nil {
+		return err
+	}
+
+	if !util.IsCurrencyEnabled(currency) {
+		return fmt.Errorf("currency is disabled for new accounts")
+	}
+	return nil
+}
+
+func ValidateCurrencyCode(code string) error {
+	if !isValidCurrency(code) {
+		return fmt.Errorf("must be a 3 letter uppercase ISO 4217 code")
+	}
+	return nil
+}
+
+func ValidateCurrencyName(name string) error {
+	return ValidateString(name, 2, 100)
+}
+
+func ValidateCurrencyExponent(exponent int32) error {
+	if exponent < 0 || exponent > 4 {
+		return fmt.Errorf("must be between 0 and 4")
+	}
+	return nil
+}
+
+func ValidateTransferReference(value string) error {
+	return ValidateString(value, 0, 140)
+}
+
+func ValidateAmount(amount util.Money) error {
+	if !amount.IsPositive() {
+		return fmt.Errorf("must be positive")
+	}
+	return nil
+}
+
+func ValidateAccountId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateAccountRole(role string) error {
+	if !util.IsAccountRoleSupported(role) {
+		return fmt.Errorf("must be one of %s, %s or %s", util.AccountOwnerRole, util.AccountCanTransferRole, util.AccountViewOnlyRole)
+	}
+	return nil
+}
+
+func ValidateInvitationId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateSecretCode(value string) error {
+	return ValidateString(value, 32, 128)
+}
+
+func ValidatePocketId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidatePocketName(value string) error {
+	if err := ValidateString(value, 1, 50); err != nil {
+		return err
+	}
+
+	if !isValidLabel(value) {
+		return fmt.Errorf("must contain only letters, numbers, spaces, dashes and underscores")
+	}
+	return nil
+}
+
+func ValidateBeneficiaryId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateNickname(value string) error {
+	if err := ValidateString(value, 1, 50); err != nil {
+		return err
+	}
+
+	if !isValidLabel(value) {
+		return fmt.Errorf("must contain only letters, numbers, spaces, dashes and underscores")
+	}
+	return nil
+}
+
+func ValidatePageId(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("must be between 5 and 10")
+	}
+	return nil
+}
+
+func ValidateHandle(value string) error {
+	if err := ValidateString(value, 3, 31); err != nil {
+		return err
+	}
+
+	if !isValidHandle(value) {
+		return fmt.Errorf("must contain only letters, numbers, and underscores, optionally prefixed with @")
+	}
+	return nil
+}
+
+// ValidateRecipient checks a transfer recipient given as a username, an email address or an @handle
+func ValidateRecipient(value string) error {
+	switch {
+	case len(value) > 0 && value[0] == '@':
+		return ValidateHandle(value)
+	case strings.Contains(value, "@"):
+		return ValidateEmail(value)
+	default:
+		return ValidateUsername(value)
+	}
+}
+
+func ValidatePaymentRequestId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateNote(value string) error {
+	return ValidateString(value, 0, 200)
+}
+
+func ValidateWebhookId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateWebhookDeliveryId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateWebhookURL(value string) error {
+	if err := ValidateString(value, 1, 2048); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("must be an absolute http or https URL")
+	}
+
+	if u.User != nil {
+		return fmt.Errorf("must not contain credentials")
+	}
+
+	// host names are checked again when the delivery is sent, after they are resolved
+	if webhook.IsLocalHostname(u.Hostname()) {
+		return fmt.Errorf("must not point at the local machine")
+	}
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && !webhook.IsPublicAddress(addr) {
+		return fmt.Errorf("must not point at a private, loopback or link-local address")
+	}
+	return nil
+}
+
+func ValidateWebhookEventTypes(values []string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("must subscribe to at least one event type")
+	}
+
+	for _, value := range values {
+		if !webhook.IsEventTypeSupported(value) {
+			return fmt.Errorf("unsupported event type %q, must be one of %s", value, strings.Join(webhook.EventTypes(), ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateAccountNumber checks the format and check digits of an account number, ignoring spaces and case
+func ValidateAccountNumber(value string) error {
+	return util.ValidateAccountNumber(util.NormalizeAccountNumber(value))
+}
+
+func ValidateCategoryId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateCategoryName(value string) error {
+	if err := ValidateString(value, 1, 50); err != nil {
+		return err
+	}
+
+	if !isValidLabel(value) {
+		return fmt.Errorf("must contain only letters, numbers, spaces, dashes and underscores")
+	}
+	return nil
+}
+
+func ValidateCategoryKeyword(value string) error {
+	return ValidateString(strings.TrimSpace(value), 2, 50)
+}
+
+func ValidateTransferId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidatePaymentBatchId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+// maxPaymentBatchDocumentSize is the largest pain.001 file that can be imported, 2 MiB
+const maxPaymentBatchDocumentSize = 2 << 20
+
+func ValidatePaymentBatchDocument(value []byte) error {
+	if len(value) == 0 {
+		return fmt.Errorf("is required")
+	}
+
+	if len(value) > maxPaymentBatchDocumentSize {
+		return fmt.Errorf("must be at most %d bytes", maxPaymentBatchDocumentSize)
+	}
+	return nil
+}
+
+func ValidateTransferRiskAssessmentId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateTransferRiskStatus(value string) error {
+	switch value {
+	case util.TransferRiskExecuted, util.TransferRiskHeld, util.TransferRiskApproved, util.TransferRiskRejected:
+		return nil
+	}
+	return fmt.Errorf("must be one of %s, %s, %s or %s", util.TransferRiskExecuted, util.TransferRiskHeld, util.TransferRiskApproved, util.TransferRiskRejected)
+}
+
+func ValidateDisputeId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateDisputeReason(value string) error {
+	return ValidateString(strings.TrimSpace(value), 10, 1000)
+}
+
+func ValidateDisputeStatus(value string) error {
+	if !util.IsDisputeStatusSupported(value) {
+		return fmt.Errorf("must be one of %s, %s, %s, %s or %s", util.DisputeOpen, util.DisputeInvestigating, util.DisputeProvisionalCredit, util.DisputeResolvedForCustomer, util.DisputeResolvedAgainstCustomer)
+	}
+	return nil
+}
+
+const (
+	// maxDisputeAttachments is how many files can be attached to a dispute
+	maxDisputeAttachments = 3
+	// maxDisputeAttachmentSize is the largest file that can be attached to a dispute, 1 MiB,
+	// so that all of them fit in a single gRPC message
+	maxDisputeAttachmentSize = 1 << 20
+)
+
+func ValidateDisputeAttachmentCount(value int) error {
+	if value > maxDisputeAttachments {
+		return fmt.Errorf("must contain at most %d files", maxDisputeAttachments)
+	}
+	return nil
+}
+
+func ValidateDisputeAttachmentName(value string) error {
+	if err := ValidateString(value, 1, 255); err != nil {
+		return err
+	}
+
+	if strings.ContainsAny(value, `/\`) {
+		return fmt.Errorf("must not contain path separators")
+	}
+	return nil
+}
+
+func ValidateDisputeAttachmentContentType(value string) error {
+	switch value {
+	case "application/pdf", "image/png", "image/jpeg", "text/plain":
+		return nil
+	}
+	return fmt.Errorf("must be one of application/pdf, image/png, image/jpeg or text/plain")
+}
+
+func ValidateDisputeAttachmentContent(value []byte) error {
+	if len(value) == 0 {
+		return fmt.Errorf("is required")
+	}
+
+	if len(value) > maxDisputeAttachmentSize {
+		return fmt.Errorf("must be at most %d bytes", maxDisputeAttachmentSize)
+	}
+	return nil
+}
+
+func ValidateApprovalRequestId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateApprovalStatus(value string) error {
+	switch value {
+	case util.ApprovalPending, util.ApprovalApproved, util.ApprovalRejected, util.ApprovalExpired:
+		return nil
+	}
+	return fmt.Errorf("must be one of %s, %s, %s or %s", util.ApprovalPending, util.ApprovalApproved, util.ApprovalRejected, util.ApprovalExpired)
+}
+
+func ValidateUserRole(value string) error {
+	if !util.IsRoleSupported(value) {
+		return fmt.Errorf("must be one of %s", strings.Join(util.Roles(), ", "))
+	}
+	return nil
+}
+
+func ValidateMaxTransfersPerHour(value int64) error {
+	if value <= 0 || value > 10000 {
+		return fmt.Errorf("must be between 1 and 10000")
+	}
+	return nil
+}
+
+func ValidateSessionId(value string) error {
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+	return nil
+}
+
+func ValidateRefreshToken(value string) error {
+	if len(value) == 0 {
+		return fmt.Errorf("must not be empty")
+	}
+	return nil
+}
+
+func ValidateTotpCode(value string) error {
+	if len(value) != util.TOTPDigits {
+		return fmt.Errorf("must contain %d digits", util.TOTPDigits)
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("must contain %d digits", util.TOTPDigits)
+		}
+	}
+	return nil
+}
+
+// ValidateMfaCode accepts a TOTP code or a recovery code, with or without its dashes
+func ValidateMfaCode(value string) error {
+	if err := ValidateTotpCode(value); err == nil {
+		return nil
+	}
+	if len(util.NormalizeRecoveryCode(value)) != 19 {
+		return fmt.Errorf("must be a %d digit code or a recovery code", util.TOTPDigits)
+	}
+	return nil
+}
+
+func ValidateMfaToken(value string) error {
+	return ValidateString(value, 32, 128)
+}
+
+func ValidatePasswordResetId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateApiKeyId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateApiKeyName(value string) error {
+	return ValidateString(value, 1, 100)
+}
+
+func ValidateApiKeyScopes(values []string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("must grant at least one scope")
+	}
+
+	for _, value := range values {
+		if !util.IsScopeSupported(value) {
+			return fmt.Errorf("unsupported scope %q", value)
+		}
+	}
+	return nil
+}
+
+// ValidateIPAllowlist checks that every entry is an IP address or a CIDR range
+func ValidateIPAllowlist(values []string) error {
+	if len(values) > 50 {
+		return fmt.Errorf("must contain at most 50 entries")
+	}
+
+	for _, value := range values {
+		if _, err := util.ParseIPAllowlistEntry(value); err != nil {
+			return fmt.Errorf("%q must be an IP address or a CIDR range", value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package risk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+// Decision is what happens to a transfer once its risk has been assessed
+type Decision string
+
+const (
+	// Allow makes the transfer
+	Allow Decision = "allow"
+	// Review makes the transfer but flags it for bankers to look at
+	Review Decision = "review"
+	// Block holds the transfer until a banker approves or rejects it
+	Block Decision = "block"
+)
+
+// severity orders decisions, the most severe decision of any rule is the decision for the transfer
+var severity = map[Decision]int{
+	Allow:  0,
+	Review: 1,
+	Block:  2,
+}
+
+// Login is a session the sender created by logging in
+type Login struct {
+	ClientIP  string
+	CreatedAt time.Time
+}
+
+// Signals are what is known about a transfer and its sender when the transfer is assessed
+type Signals struct {
+	Amount util.Money
+	// TransfersLastHour is the number of transfers made from the same account in the hour before this one
+	TransfersLastHour int64
+	// NewCounterparty is true when the account has never sent money to the recipient before
+	NewCounterparty   bool
+	PasswordChangedAt time.Time
+	EmailChangedAt    time.Time
+	// Logins are the most recent logins of the sender, newest first
+	Logins []Login
+	// Limits are the overrides a banker has set on the sending account
+	Limits Limits
+	Now    time.Time
+}
+
+// Limits override the configured limits of the rules for one account, zero keeps the configured limit
+type Limits struct {
+	MaxTransfersPerHour int64
+	// NewCounterpartyThreshold is in minor units of the currency of the account
+	NewCounterpartyThreshold int64
+}
+
+// Rule is a single check on a transfer. Rules are given to NewEngine, which makes the engine
+// pluggable: deployments can combine the built-in rules with their own.
+type Rule interface {
+	// Name identifies the rule in the recorded findings
+	Name() string
+	// Evaluate returns the decision of the rule and the reason for it. Allow means the rule has no objection.
+	Evaluate(signals Signals) (Decision, string, error)
+}
+
+// Finding is a rule that did not allow a transfer, and why
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Decision Decision `json:"decision"`
+	Reason   string   `json:"reason"`
+}
+
+// Assessment is the outcome of running every rule of an engine on a transfer
+type Assessment struct {
+	Decision Decision  `json:"decision"`
+	Findings []Finding `json:"findings"`
+}
+
+// Engine assesses transfers with a set of rules
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine creates an engine that runs the rules in the given order
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Assess runs every rule on the transfer. The transfer is allowed when no rule objects, otherwise
+// the most severe decision wins and every objection is kept as a finding.
+func (engine *Engine) Assess(signals Signals) (Assessment, error) {
+	assessment := Assessment{
+		Decision: Allow,
+		Findings: []Finding{},
+	}
+
+	for _, rule := range engine.rules {
+		decision, reason, err := rule.Evaluate(signals)
+		if err != nil {
+			return assessment, fmt.Errorf("rule %s: %w", rule.Name(), err)
+		}
+
+		if decision == Allow {
+			continue
+		}
+
+		if _, ok := severity[decision]; !ok {
+			return assessment, fmt.Errorf("rule %s: unknown decision %q", rule.Name(), decision)
+		}
+
+		assessment.Findings = append(assessment.Findings, Finding{
+			Rule:     rule.Name(),
+			Decision: decision,
+			Reason:   reason,
+		})
+
+		if severity[decision] > severity[assessment.Decision] {
+			assessment.Decision = decision
+		}
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,210 @@
+package risk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newSignals(t *testing.T, amount string) Signals {
+	money, err := util.ParseMoney(amount, util.USD)
+	require.NoError(t, err)
+
+	return Signals{
+		Amount: money,
+		Logins: []Login{
+			{ClientIP: "10.0.0.1:50312", CreatedAt: now.Add(-time.Hour)},
+			{ClientIP: "10.0.0.1:49822", CreatedAt: now.Add(-48 * time.Hour)},
+		},
+		Now: now,
+	}
+}
+
+func TestVelocity(t *testing.T) {
+	rule := Velocity{MaxTransfersPerHour: 5}
+	signals := newSignals(t, "10")
+
+	signals.TransfersLastHour = 4
+	decision, _, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	signals.TransfersLastHour = 5
+	decision, reason, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Block, decision)
+	require.Contains(t, reason, "5 transfers")
+
+	// a limit set on the account replaces the configured one
+	signals.Limits.MaxTransfersPerHour = 8
+	decision, _, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	signals.TransfersLastHour = 8
+	decision, _, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Block, decision)
+}
+
+func TestNewCounterparty(t *testing.T) {
+	rule := NewCounterparty{Threshold: 1000}
+
+	signals := newSignals(t, "1500")
+	decision, _, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	signals.NewCounterparty = true
+	decision, reason, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Review, decision)
+	require.Contains(t, reason, "1000.00")
+
+	signals = newSignals(t, "1000")
+	signals.NewCounterparty = true
+	decision, _, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	// a threshold set on the account replaces the configured one
+	signals.Limits.NewCounterpartyThreshold = 50000
+	decision, reason, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Review, decision)
+	require.Contains(t, reason, "500.00")
+
+	signals.Limits.NewCounterpartyThreshold = 100001
+	decision, _, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	signals.Amount.Currency = "XXX"
+	_, _, err = rule.Evaluate(signals)
+	require.ErrorIs(t, err, util.ErrUnsupportedCurrency)
+}
+
+func TestNewCounterpartyWithoutMinorUnits(t *testing.T) {
+	original := util.Currencies()
+	t.Cleanup(func() { util.SetCurrencies(original) })
+	util.PutCurrency(util.Currency{Code: "JPY", Name: "Yen", Exponent: 0, Enabled: true})
+
+	rule := NewCounterparty{Threshold: 1000}
+
+	amount, err := util.ParseMoney("1001", "JPY")
+	require.NoError(t, err)
+
+	signals := Signals{Amount: amount, NewCounterparty: true, Now: now}
+	decision, reason, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Review, decision)
+	require.Contains(t, reason, "1000")
+
+	signals.Amount.Amount = 1000
+	decision, _, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+}
+
+func TestCredentialChange(t *testing.T) {
+	rule := CredentialChange{Window: 24 * time.Hour}
+
+	signals := newSignals(t, "10")
+	decision, _, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	signals.PasswordChangedAt = now.Add(-2 * time.Hour)
+	decision, reason, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Block, decision)
+	require.Contains(t, reason, "password")
+
+	signals.PasswordChangedAt = now.Add(-25 * time.Hour)
+	signals.EmailChangedAt = now.Add(-time.Minute)
+	decision, reason, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Block, decision)
+	require.Contains(t, reason, "email")
+}
+
+func TestNewLoginIP(t *testing.T) {
+	rule := NewLoginIP{Window: 24 * time.Hour}
+
+	signals := newSignals(t, "10")
+	decision, _, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	signals.Logins[0].ClientIP = "192.0.2.7:50312"
+	decision, reason, err := rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Review, decision)
+	require.Contains(t, reason, "192.0.2.7")
+	require.NotContains(t, reason, "50312")
+
+	// a login from a new IP long ago is no longer a signal
+	signals.Logins[0].CreatedAt = now.Add(-36 * time.Hour)
+	decision, _, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+
+	// the first login of a user has nothing to be compared with
+	signals.Logins = signals.Logins[:1]
+	signals.Logins[0].CreatedAt = now
+	decision, _, err = rule.Evaluate(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision)
+}
+
+type ruleFunc func(signals Signals) (Decision, string, error)
+
+func (f ruleFunc) Name() string {
+	return "custom"
+}
+
+func (f ruleFunc) Evaluate(signals Signals) (Decision, string, error) {
+	return f(signals)
+}
+
+func TestEngineAssess(t *testing.T) {
+	signals := newSignals(t, "1500")
+	signals.NewCounterparty = true
+	signals.TransfersLastHour = 10
+
+	engine := NewEngine(Velocity{MaxTransfersPerHour: 100}, NewCounterparty{Threshold: 1000})
+	assessment, err := engine.Assess(signals)
+	require.NoError(t, err)
+	require.Equal(t, Review, assessment.Decision)
+	require.Len(t, assessment.Findings, 1)
+	require.Equal(t, "new_counterparty", assessment.Findings[0].Rule)
+
+	// the most severe decision wins, every objection is kept
+	engine = NewEngine(NewCounterparty{Threshold: 1000}, Velocity{MaxTransfersPerHour: 10})
+	assessment, err = engine.Assess(signals)
+	require.NoError(t, err)
+	require.Equal(t, Block, assessment.Decision)
+	require.Len(t, assessment.Findings, 2)
+
+	assessment, err = NewEngine().Assess(signals)
+	require.NoError(t, err)
+	require.Equal(t, Allow, assessment.Decision)
+	require.Empty(t, assessment.Findings)
+
+	failing := ruleFunc(func(signals Signals) (Decision, string, error) {
+		return Allow, "", errors.New("unavailable")
+	})
+	_, err = NewEngine(failing).Assess(signals)
+	require.ErrorContains(t, err, "custom")
+
+	unknown := ruleFunc(func(signals Signals) (Decision, string, error) {
+		return Decision("escalate"), "", nil
+	})
+	_, err = NewEngine(unknown).Assess(signals)
+	require.ErrorContains(t, err, "unknown decision")
+}
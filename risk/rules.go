@@ -0,0 +1,126 @@
+package risk
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+)
+
+// Velocity blocks a transfer when the account has already made MaxTransfersPerHour transfers in the last hour,
+// or as many as the limit set on the account
+type Velocity struct {
+	MaxTransfersPerHour int64
+}
+
+func (rule Velocity) Name() string {
+	return "velocity"
+}
+
+func (rule Velocity) Evaluate(signals Signals) (Decision, string, error) {
+	maxTransfers := rule.MaxTransfersPerHour
+	if signals.Limits.MaxTransfersPerHour > 0 {
+		maxTransfers = signals.Limits.MaxTransfersPerHour
+	}
+
+	if signals.TransfersLastHour < maxTransfers {
+		return Allow, "", nil
+	}
+
+	return Block, fmt.Sprintf("%d transfers were made from the account in the last hour", signals.TransfersLastHour), nil
+}
+
+// NewCounterparty flags the first transfer to a recipient when it is over Threshold, an amount
+// in whole units of the currency of the transfer, so it applies to currencies without minor units too.
+// A threshold set on the account is used instead when there is one.
+type NewCounterparty struct {
+	Threshold int64
+}
+
+func (rule NewCounterparty) Name() string {
+	return "new_counterparty"
+}
+
+func (rule NewCounterparty) Evaluate(signals Signals) (Decision, string, error) {
+	if !signals.NewCounterparty {
+		return Allow, "", nil
+	}
+
+	threshold, err := util.MoneyFromUnits(signals.Amount.Currency, rule.Threshold, 0)
+	if err != nil {
+		return Allow, "", err
+	}
+	if signals.Limits.NewCounterpartyThreshold > 0 {
+		threshold.Amount = signals.Limits.NewCounterpartyThreshold
+	}
+
+	if signals.Amount.Amount <= threshold.Amount {
+		return Allow, "", nil
+	}
+
+	return Review, fmt.Sprintf("first transfer to the recipient is over %s", threshold), nil
+}
+
+// CredentialChange blocks transfers made within Window of the password or email of the sender being changed,
+// which is what a stolen account looks like
+type CredentialChange struct {
+	Window time.Duration
+}
+
+func (rule CredentialChange) Name() string {
+	return "credential_change"
+}
+
+func (rule CredentialChange) Evaluate(signals Signals) (Decision, string, error) {
+	since := signals.Now.Add(-rule.Window)
+
+	if signals.PasswordChangedAt.After(since) {
+		return Block, fmt.Sprintf("password was changed at %s", signals.PasswordChangedAt.Format(time.RFC3339)), nil
+	}
+
+	if signals.EmailChangedAt.After(since) {
+		return Block, fmt.Sprintf("email was changed at %s", signals.EmailChangedAt.Format(time.RFC3339)), nil
+	}
+
+	return Allow, "", nil
+}
+
+// NewLoginIP flags transfers made within Window of a login from an IP address the sender
+// has not logged in from before. The first login of a user is not considered new.
+type NewLoginIP struct {
+	Window time.Duration
+}
+
+func (rule NewLoginIP) Name() string {
+	return "new_login_ip"
+}
+
+func (rule NewLoginIP) Evaluate(signals Signals) (Decision, string, error) {
+	if len(signals.Logins) < 2 {
+		return Allow, "", nil
+	}
+
+	latest := signals.Logins[0]
+	if latest.CreatedAt.Before(signals.Now.Add(-rule.Window)) {
+		return Allow, "", nil
+	}
+
+	ip := host(latest.ClientIP)
+	for _, login := range signals.Logins[1:] {
+		if host(login.ClientIP) == ip {
+			return Allow, "", nil
+		}
+	}
+
+	return Review, fmt.Sprintf("logged in from the new IP address %s at %s", ip, latest.CreatedAt.Format(time.RFC3339)), nil
+}
+
+// host strips the port from a client address, sessions record the remote address of the connection
+func host(addr string) string {
+	h, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return h
+}
@@ -0,0 +1,103 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// recentLogins is how many of the latest logins of the sender are compared to find a login from a new IP address
+const recentLogins = 20
+
+// Source is where the signals of a transfer are read from, persistence.Store is one
+type Source interface {
+	CountTransfersSince(ctx context.Context, arg persistence.CountTransfersSinceParams) (int64, error)
+	HasTransferredTo(ctx context.Context, arg persistence.HasTransferredToParams) (bool, error)
+	GetUser(ctx context.Context, username string) (persistence.User, error)
+	ListRecentLogins(ctx context.Context, arg persistence.ListRecentLoginsParams) ([]persistence.ListRecentLoginsRow, error)
+	GetAccountLimit(ctx context.Context, accountID int64) (persistence.AccountLimit, error)
+}
+
+// Transfer is a transfer about to be made by a user
+type Transfer struct {
+	Username      string
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        util.Money
+}
+
+// GatherSignals collects what is known about a transfer and the user sending it
+func GatherSignals(ctx context.Context, source Source, transfer Transfer, now time.Time) (Signals, error) {
+	signals := Signals{
+		Amount: transfer.Amount,
+		Now:    now,
+	}
+
+	var err error
+	signals.TransfersLastHour, err = source.CountTransfersSince(ctx, persistence.CountTransfersSinceParams{
+		FromAccountID: transfer.FromAccountID,
+		Since:         pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+	})
+	if err != nil {
+		return signals, fmt.Errorf("failed to count transfers: %w", err)
+	}
+
+	transferred, err := source.HasTransferredTo(ctx, persistence.HasTransferredToParams{
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+	})
+	if err != nil {
+		return signals, fmt.Errorf("failed to check earlier transfers: %w", err)
+	}
+	signals.NewCounterparty = !transferred
+
+	user, err := source.GetUser(ctx, transfer.Username)
+	if err != nil {
+		return signals, fmt.Errorf("failed to get user: %w", err)
+	}
+	signals.PasswordChangedAt = user.PasswordChangedAt.Time
+	signals.EmailChangedAt = user.EmailChangedAt.Time
+
+	sessions, err := source.ListRecentLogins(ctx, persistence.ListRecentLoginsParams{
+		Username: transfer.Username,
+		Limit:    recentLogins,
+	})
+	if err != nil {
+		return signals, fmt.Errorf("failed to list recent logins: %w", err)
+	}
+
+	signals.Logins = make([]Login, 0, len(sessions))
+	for _, session := range sessions {
+		signals.Logins = append(signals.Logins, Login{
+			ClientIP:  session.ClientIp,
+			CreatedAt: session.CreatedAt.Time,
+		})
+	}
+
+	limit, err := source.GetAccountLimit(ctx, transfer.FromAccountID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return signals, fmt.Errorf("failed to get account limits: %w", err)
+	}
+	signals.Limits = Limits{
+		MaxTransfersPerHour:      limit.MaxTransfersPerHour.Int64,
+		NewCounterpartyThreshold: limit.NewCounterpartyThreshold.Int64,
+	}
+
+	return signals, nil
+}
+
+// AssessTransfer gathers the signals of a transfer and runs every rule of the engine on them
+func (engine *Engine) AssessTransfer(ctx context.Context, source Source, transfer Transfer) (Assessment, error) {
+	signals, err := GatherSignals(ctx, source, transfer, time.Now())
+	if err != nil {
+		return Assessment{}, err
+	}
+
+	return engine.Assess(signals)
+}
@@ -0,0 +1,88 @@
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/internal/persistence"
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	transfersSince int64
+	transferredTo  bool
+	user           persistence.User
+	logins         []persistence.ListRecentLoginsRow
+	limit          *persistence.AccountLimit
+}
+
+func (source fakeSource) CountTransfersSince(ctx context.Context, arg persistence.CountTransfersSinceParams) (int64, error) {
+	return source.transfersSince, nil
+}
+
+func (source fakeSource) HasTransferredTo(ctx context.Context, arg persistence.HasTransferredToParams) (bool, error) {
+	return source.transferredTo, nil
+}
+
+func (source fakeSource) GetUser(ctx context.Context, username string) (persistence.User, error) {
+	return source.user, nil
+}
+
+func (source fakeSource) ListRecentLogins(ctx context.Context, arg persistence.ListRecentLoginsParams) ([]persistence.ListRecentLoginsRow, error) {
+	return source.logins, nil
+}
+
+func (source fakeSource) GetAccountLimit(ctx context.Context, accountID int64) (persistence.AccountLimit, error) {
+	if source.limit == nil {
+		return persistence.AccountLimit{}, pgx.ErrNoRows
+	}
+	return *source.limit, nil
+}
+
+func TestGatherSignals(t *testing.T) {
+	source := fakeSource{
+		transfersSince: 3,
+		user: persistence.User{
+			PasswordChangedAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+		},
+		logins: []persistence.ListRecentLoginsRow{
+			{ClientIp: "10.0.0.1:50312", CreatedAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}},
+		},
+	}
+
+	transfer := Transfer{
+		Username:      util.RandomOwner(),
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        util.Money{Amount: 1000, Currency: util.USD},
+	}
+
+	signals, err := GatherSignals(context.Background(), source, transfer, now)
+	require.NoError(t, err)
+	require.Equal(t, transfer.Amount, signals.Amount)
+	require.Equal(t, int64(3), signals.TransfersLastHour)
+	require.True(t, signals.NewCounterparty)
+	require.Equal(t, now.Add(-time.Hour), signals.PasswordChangedAt)
+	require.Len(t, signals.Logins, 1)
+	require.Equal(t, "10.0.0.1:50312", signals.Logins[0].ClientIP)
+	require.Zero(t, signals.Limits)
+
+	source.limit = &persistence.AccountLimit{
+		AccountID:           transfer.FromAccountID,
+		MaxTransfersPerHour: pgtype.Int8{Int64: 20, Valid: true},
+	}
+	signals, err = GatherSignals(context.Background(), source, transfer, now)
+	require.NoError(t, err)
+	require.Equal(t, Limits{MaxTransfersPerHour: 20}, signals.Limits)
+	source.limit = nil
+
+	// a blocking rule decides the transfer
+	source.transfersSince = 10
+	assessment, err := NewEngine(Velocity{MaxTransfersPerHour: 10}).AssessTransfer(context.Background(), source, transfer)
+	require.NoError(t, err)
+	require.Equal(t, Block, assessment.Decision)
+}
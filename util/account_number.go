@@ -0,0 +1,106 @@
+package util
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var ErrInvalidAccountNumber = errors.New("invalid account number")
+
+const (
+	// BankCodeLength is the length of the bank code that prefixes every account number
+	BankCodeLength          = 4
+	accountNumberBodyLength = 10
+	// AccountNumberLength is the length of an account number without separators
+	AccountNumberLength = BankCodeLength + 2 + accountNumberBodyLength
+)
+
+// NewAccountNumber generates a random account number laid out like an IBAN:
+// the bank code, two mod-97 check digits and a 10 digit body, e.g. "SMPL470123456789"
+func NewAccountNumber(bankCode string) (string, error) {
+	if !isBankCode(bankCode) {
+		return "", fmt.Errorf("bank code must be %d uppercase letters, got %q", BankCodeLength, bankCode)
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(10_000_000_000))
+	if err != nil {
+		return "", err
+	}
+
+	body := fmt.Sprintf("%0*d", accountNumberBodyLength, n)
+	return bankCode + fmt.Sprintf("%02d", 98-mod97(body+bankCode+"00")) + body, nil
+}
+
+// ValidateAccountNumber checks the format and check digits of a normalized account number,
+// so that a mistyped number is rejected without looking it up
+func ValidateAccountNumber(number string) error {
+	if len(number) != AccountNumberLength {
+		return fmt.Errorf("%w: must be %d characters long", ErrInvalidAccountNumber, AccountNumberLength)
+	}
+
+	bankCode, check, body := number[:BankCodeLength], number[BankCodeLength:BankCodeLength+2], number[BankCodeLength+2:]
+	if !isBankCode(bankCode) || !isDigits(check) || !isDigits(body) {
+		return fmt.Errorf("%w: must be a %d letter bank code followed by %d digits", ErrInvalidAccountNumber, BankCodeLength, AccountNumberLength-BankCodeLength)
+	}
+
+	if mod97(body+bankCode+check) != 1 {
+		return fmt.Errorf("%w: check digits do not match", ErrInvalidAccountNumber)
+	}
+
+	return nil
+}
+
+// NormalizeAccountNumber removes spaces and dashes and upper-cases the account number
+func NormalizeAccountNumber(number string) string {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(number)
+	return strings.ToUpper(number)
+}
+
+// FormatAccountNumber groups an account number in blocks of four for display, e.g. "SMPL 4701 2345 6789"
+func FormatAccountNumber(number string) string {
+	var b strings.Builder
+	for i, r := range number {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// MaskAccountNumber hides all but the last 4 digits of an account number
+func MaskAccountNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return "xxxx" + number[len(number)-4:]
+}
+
+// mod97 computes the ISO 7064 MOD 97-10 remainder of s, reading letters as A=10 ... Z=35
+func mod97(s string) int {
+	r := 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			r = (r*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			r = (r*100 + int(c-'A') + 10) % 97
+		}
+	}
+	return r
+}
+
+func isBankCode(s string) bool {
+	if len(s) != BankCodeLength {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
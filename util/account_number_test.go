@@ -0,0 +1,47 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountNumber(t *testing.T) {
+	for range 100 {
+		number, err := NewAccountNumber("SMPL")
+		require.NoError(t, err)
+		require.Len(t, number, AccountNumberLength)
+		require.Equal(t, "SMPL", number[:4])
+		require.NoError(t, ValidateAccountNumber(number))
+	}
+
+	_, err := NewAccountNumber("smpl")
+	require.Error(t, err)
+
+	_, err = NewAccountNumber("BANK1")
+	require.Error(t, err)
+}
+
+func TestValidateAccountNumber(t *testing.T) {
+	require.NoError(t, ValidateAccountNumber("SMPL810123456789"))
+
+	// a single mistyped digit
+	require.ErrorIs(t, ValidateAccountNumber("SMPL810123456788"), ErrInvalidAccountNumber)
+	// two adjacent digits swapped
+	require.ErrorIs(t, ValidateAccountNumber("SMPL811023456789"), ErrInvalidAccountNumber)
+	// wrong check digits
+	require.ErrorIs(t, ValidateAccountNumber("SMPL180123456789"), ErrInvalidAccountNumber)
+
+	require.ErrorIs(t, ValidateAccountNumber("SMPL81012345678"), ErrInvalidAccountNumber)
+	require.ErrorIs(t, ValidateAccountNumber("SMP1810123456789"), ErrInvalidAccountNumber)
+	require.ErrorIs(t, ValidateAccountNumber("SMPL81012345678X"), ErrInvalidAccountNumber)
+	require.ErrorIs(t, ValidateAccountNumber("smpl810123456789"), ErrInvalidAccountNumber)
+	require.ErrorIs(t, ValidateAccountNumber(""), ErrInvalidAccountNumber)
+}
+
+func TestFormatAccountNumber(t *testing.T) {
+	number := NormalizeAccountNumber("smpl 8101-2345 6789")
+	require.Equal(t, "SMPL810123456789", number)
+	require.Equal(t, "SMPL 8101 2345 6789", FormatAccountNumber(number))
+	require.Equal(t, "xxxx6789", MaskAccountNumber(number))
+}
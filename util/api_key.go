@@ -0,0 +1,142 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// APIKeyPrefix starts every API key, so API keys can be told apart from access tokens and spotted in leaked text
+const APIKeyPrefix = "sbk_"
+
+// Scopes an API key can be restricted to. Calls without a scope, such as managing sessions,
+// MFA or API keys themselves, cannot be made with an API key.
+const (
+	ScopeAccountsRead       = "accounts:read"
+	ScopeAccountsWrite      = "accounts:write"
+	ScopeTransfersRead      = "transfers:read"
+	ScopeTransfersWrite     = "transfers:write"
+	ScopeBeneficiariesRead  = "beneficiaries:read"
+	ScopeBeneficiariesWrite = "beneficiaries:write"
+	ScopePaymentsRead       = "payments:read"
+	ScopePaymentsWrite      = "payments:write"
+	ScopeCategoriesRead     = "categories:read"
+	ScopeCategoriesWrite    = "categories:write"
+	ScopeDisputesRead       = "disputes:read"
+	ScopeDisputesWrite      = "disputes:write"
+	ScopeWebhooksRead       = "webhooks:read"
+	ScopeWebhooksWrite      = "webhooks:write"
+	ScopeCurrenciesRead     = "currencies:read"
+	ScopeCurrenciesWrite    = "currencies:write"
+	ScopeApprovalsRead      = "approvals:read"
+)
+
+var supportedScopes = map[string]bool{
+	ScopeAccountsRead:       true,
+	ScopeAccountsWrite:      true,
+	ScopeTransfersRead:      true,
+	ScopeTransfersWrite:     true,
+	ScopeBeneficiariesRead:  true,
+	ScopeBeneficiariesWrite: true,
+	ScopePaymentsRead:       true,
+	ScopePaymentsWrite:      true,
+	ScopeCategoriesRead:     true,
+	ScopeCategoriesWrite:    true,
+	ScopeDisputesRead:       true,
+	ScopeDisputesWrite:      true,
+	ScopeWebhooksRead:       true,
+	ScopeWebhooksWrite:      true,
+	ScopeCurrenciesRead:     true,
+	ScopeCurrenciesWrite:    true,
+	ScopeApprovalsRead:      true,
+}
+
+// IsScopeSupported checks if the API key scope is supported
+func IsScopeSupported(scope string) bool {
+	return supportedScopes[scope]
+}
+
+// GenerateAPIKey generates a new API key. The prefix identifies the key and can be shown,
+// the key itself is only shown once and stored hashed with HashToken.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	secret, err := GenerateToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix = APIKeyPrefix + hex.EncodeToString(id)
+	return prefix + "_" + secret, prefix, nil
+}
+
+// IsAPIKey checks if a bearer token is an API key rather than an access token
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// ParseAPIKeyPrefix returns the prefix of an API key
+func ParseAPIKeyPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, APIKeyPrefix)
+	if !ok {
+		return "", false
+	}
+
+	// the ID is hex encoded, so the first underscore separates it from the secret
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(id) != 16 || len(secret) == 0 {
+		return "", false
+	}
+
+	return APIKeyPrefix + id, true
+}
+
+// ParseIPAllowlistEntry parses an IP address or a CIDR range of an API key allowlist
+func ParseIPAllowlistEntry(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// IPAllowed checks if a client address, with or without its port, is in the allowlist.
+// An empty allowlist allows every address.
+func IPAllowed(allowlist []string, clientAddr string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(clientAddr)
+	if err != nil {
+		host = clientAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, entry := range allowlist {
+		prefix, err := ParseIPAllowlistEntry(entry)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
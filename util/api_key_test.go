@@ -0,0 +1,66 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.True(t, IsAPIKey(key))
+	require.Len(t, prefix, len(APIKeyPrefix)+16)
+
+	parsedPrefix, ok := ParseAPIKeyPrefix(key)
+	require.True(t, ok)
+	require.Equal(t, prefix, parsedPrefix)
+
+	otherKey, otherPrefix, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherKey)
+	require.NotEqual(t, prefix, otherPrefix)
+}
+
+func TestParseAPIKeyPrefixInvalid(t *testing.T) {
+	for _, key := range []string{
+		"",
+		"v4.public.eyJ1c2VybmFtZSI6InJvYmluIn0",
+		APIKeyPrefix,
+		APIKeyPrefix + "0123456789abcdef",
+		APIKeyPrefix + "0123456789abcdef_",
+		APIKeyPrefix + "0123_456789abcdef",
+	} {
+		_, ok := ParseAPIKeyPrefix(key)
+		require.False(t, ok, key)
+	}
+}
+
+func TestIPAllowed(t *testing.T) {
+	require.True(t, IPAllowed(nil, "203.0.113.7:52114"))
+
+	allowlist := []string{"10.0.0.0/8", "203.0.113.7", "2001:db8::/32"}
+	require.True(t, IPAllowed(allowlist, "10.1.2.3"))
+	require.True(t, IPAllowed(allowlist, "203.0.113.7:52114"))
+	require.True(t, IPAllowed(allowlist, "[2001:db8::1]:443"))
+	require.True(t, IPAllowed(allowlist, "::ffff:10.1.2.3"))
+	require.False(t, IPAllowed(allowlist, "203.0.113.8"))
+	require.False(t, IPAllowed(allowlist, "192.168.0.1:80"))
+	require.False(t, IPAllowed(allowlist, "not an address"))
+}
+
+func TestParseIPAllowlistEntry(t *testing.T) {
+	prefix, err := ParseIPAllowlistEntry("10.1.2.3/8")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.0/8", prefix.String())
+
+	prefix, err = ParseIPAllowlistEntry("2001:db8::1")
+	require.NoError(t, err)
+	require.Equal(t, "2001:db8::1/128", prefix.String())
+
+	_, err = ParseIPAllowlistEntry("10.0.0.0/33")
+	require.Error(t, err)
+
+	_, err = ParseIPAllowlistEntry("localhost")
+	require.Error(t, err)
+}
@@ -0,0 +1,18 @@
+package util
+
+// Operations that are held for a second banker to approve
+const (
+	ApprovalAddAccountBalance   = "add_account_balance"
+	ApprovalUpdateDisputeStatus = "update_dispute_status"
+	ApprovalUpdateUserRole      = "update_user_role"
+	ApprovalUpdateAccountLimits = "update_account_limits"
+)
+
+// States of an approval request. A pending request is executed when a banker other than the requester
+// approves it, and expires when nobody acts on it in time.
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+	ApprovalExpired  = "expired"
+)
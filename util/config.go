@@ -16,11 +16,81 @@ type Config struct {
 	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS" validate:"required"`
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY" validate:"required"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION" validate:"required"`
-	TokenType            string        `mapstructure:"TOKEN_TYPE" validate:"required,oneof=paseto jwt"`
+	TokenType            string        `mapstructure:"TOKEN_TYPE" validate:"required,oneof=paseto jwt paseto_public jwt_eddsa jwt_es256"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION" validate:"required"`
 	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME" validate:"required"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS" validate:"required"`
 	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD" validate:"required"`
+	// BankCode prefixes the account numbers of new accounts
+	BankCode string `mapstructure:"BANK_CODE" validate:"required,len=4,alpha,uppercase"`
+	// BeneficiaryCoolingOffPeriod is how long a newly added beneficiary is subject to NewBeneficiaryTransferLimit
+	BeneficiaryCoolingOffPeriod time.Duration `mapstructure:"BENEFICIARY_COOLING_OFF_PERIOD" validate:"required"`
+	// NewBeneficiaryTransferLimit is the amount, in whole units of the transfer currency, that can be sent
+	// to a beneficiary in total during its cooling-off period
+	NewBeneficiaryTransferLimit int64 `mapstructure:"NEW_BENEFICIARY_TRANSFER_LIMIT" validate:"required,gt=0"`
+	// WebhookTimeout bounds a single webhook delivery attempt
+	WebhookTimeout time.Duration `mapstructure:"WEBHOOK_TIMEOUT" validate:"required"`
+	// CurrencyRefreshInterval is how often the currency catalogue is reloaded from the database
+	// to pick up changes made through other instances
+	CurrencyRefreshInterval time.Duration `mapstructure:"CURRENCY_REFRESH_INTERVAL" validate:"required"`
+	// SpendingSummaryRefreshInterval is how often transfers are auto-categorized and the spending analytics recomputed
+	SpendingSummaryRefreshInterval time.Duration `mapstructure:"SPENDING_SUMMARY_REFRESH_INTERVAL" validate:"required"`
+	// RiskMaxTransfersPerHour is how many transfers an account can make in an hour before further transfers are held for review
+	RiskMaxTransfersPerHour int64 `mapstructure:"RISK_MAX_TRANSFERS_PER_HOUR" validate:"required,gt=0"`
+	// RiskNewCounterpartyThreshold is the amount, in whole units of the transfer currency, over which the first
+	// transfer to a recipient is flagged for review
+	RiskNewCounterpartyThreshold int64 `mapstructure:"RISK_NEW_COUNTERPARTY_THRESHOLD" validate:"required,gt=0"`
+	// RiskCredentialChangeHold is how long transfers are held for review after a password or email change
+	RiskCredentialChangeHold time.Duration `mapstructure:"RISK_CREDENTIAL_CHANGE_HOLD" validate:"required"`
+	// RiskNewLoginIPWindow is how long transfers are flagged for review after a login from a new IP address
+	RiskNewLoginIPWindow time.Duration `mapstructure:"RISK_NEW_LOGIN_IP_WINDOW" validate:"required"`
+	// ApprovalDepositThreshold is the amount, in whole units of the account currency, over which adding balance
+	// to an account has to be approved by a second banker
+	ApprovalDepositThreshold int64 `mapstructure:"APPROVAL_DEPOSIT_THRESHOLD" validate:"required,gt=0"`
+	// ApprovalDisputeThreshold is the amount, in whole units of the dispute currency, over which a dispute move that
+	// books a credit, chargeback or reversal has to be approved by a second banker
+	ApprovalDisputeThreshold int64 `mapstructure:"APPROVAL_DISPUTE_THRESHOLD" validate:"required,gt=0"`
+	// ApprovalRequestTTL is how long an operation waits for approval before it expires
+	ApprovalRequestTTL time.Duration `mapstructure:"APPROVAL_REQUEST_TTL" validate:"required"`
+	// MFAChallengeDuration is how long a user has to enter their TOTP code after their password
+	MFAChallengeDuration time.Duration `mapstructure:"MFA_CHALLENGE_DURATION" validate:"required"`
+	// TOTPIssuer is the name authenticator apps show next to the codes
+	TOTPIssuer string `mapstructure:"TOTP_ISSUER" validate:"required"`
+	// LoginMaxFailedAttempts is how many consecutive failed logins lock a username for LoginLockoutDuration
+	LoginMaxFailedAttempts int32 `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS" validate:"required,gt=0"`
+	// LoginMaxFailedAttemptsPerIP is how many failed logins a client address can make within LoginFailureWindow
+	LoginMaxFailedAttemptsPerIP int64 `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS_PER_IP" validate:"required,gt=0"`
+	// LoginFailureWindow is how long failed logins are remembered
+	LoginFailureWindow time.Duration `mapstructure:"LOGIN_FAILURE_WINDOW" validate:"required"`
+	// LoginBaseDelay is how long to wait after a failed login before trying again, it doubles with every further failure
+	LoginBaseDelay time.Duration `mapstructure:"LOGIN_BASE_DELAY" validate:"required"`
+	// LoginLockoutDuration is how long a username is locked after too many failed logins
+	LoginLockoutDuration time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION" validate:"required"`
+	// PasswordHashAlgorithm is the algorithm new password hashes are made with, older hashes are
+	// replaced on the next successful login
+	PasswordHashAlgorithm string `mapstructure:"PASSWORD_HASH_ALGORITHM" validate:"required,oneof=argon2id bcrypt"`
+	// Argon2Memory is the memory argon2id uses per hash, in KiB
+	Argon2Memory uint32 `mapstructure:"ARGON2_MEMORY" validate:"required"`
+	// Argon2Time is the number of passes argon2id makes over its memory
+	Argon2Time uint32 `mapstructure:"ARGON2_TIME" validate:"required"`
+	// Argon2Parallelism is the number of threads argon2id uses
+	Argon2Parallelism uint8 `mapstructure:"ARGON2_PARALLELISM" validate:"required"`
+	// BcryptCost is the cost bcrypt hashes are made with
+	BcryptCost int `mapstructure:"BCRYPT_COST" validate:"required"`
+	// TokenIssuer and TokenAudience are set in the tokens made and checked in the tokens verified
+	TokenIssuer   string `mapstructure:"TOKEN_ISSUER" validate:"required"`
+	TokenAudience string `mapstructure:"TOKEN_AUDIENCE" validate:"required"`
+	// TokenKeyRotationInterval is how long a key signs paseto_public, jwt_eddsa and jwt_es256 tokens before
+	// a new key is made. The private keys are stored sealed with TokenSymmetricKey.
+	TokenKeyRotationInterval time.Duration `mapstructure:"TOKEN_KEY_ROTATION_INTERVAL" validate:"required"`
+	// TokenKeyRefreshInterval is how often the keys are reloaded from the database to pick up keys
+	// made by other instances. A new key only signs tokens two intervals after it was made.
+	TokenKeyRefreshInterval time.Duration `mapstructure:"TOKEN_KEY_REFRESH_INTERVAL" validate:"required"`
+	// RoleRefreshInterval is how often the permissions of each role are reloaded from the database
+	RoleRefreshInterval time.Duration `mapstructure:"ROLE_REFRESH_INTERVAL" validate:"required"`
+	// TrustedProxies are the addresses and CIDR ranges of the proxies in front of the servers. The client
+	// address is read from X-Forwarded-For only as far as these proxies appended to it, empty trusts none.
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES" validate:"dive,cidr|ip"`
 }
 
 // LoadConfig loads the configuration from the file specified by the path.
@@ -1,5 +1,10 @@
 package util
 
+import (
+	"sort"
+	"sync"
+)
+
 const (
 	USD = "USD"
 	EUR = "EUR"
@@ -7,11 +12,80 @@ const (
 	BDT = "BDT"
 )
 
-// IsCurrencySupported checks if the currency is supported
+// Currency describes an ISO 4217 currency
+type Currency struct {
+	Code string
+	Name string
+	// Exponent is the number of decimal places of the minor unit, e.g. 2 for cents
+	Exponent int
+	// Enabled currencies can be used to open new accounts.
+	// Accounts already held in a disabled currency keep working.
+	Enabled bool
+}
+
+// currencies caches the currency catalogue stored in the database.
+// It starts with the built-in currencies so the package works before the catalogue is loaded.
+var (
+	currenciesMu sync.RWMutex
+	currencies   = map[string]Currency{
+		USD: {Code: USD, Name: "US Dollar", Exponent: 2, Enabled: true},
+		EUR: {Code: EUR, Name: "Euro", Exponent: 2, Enabled: true},
+		GBP: {Code: GBP, Name: "Pound Sterling", Exponent: 2, Enabled: true},
+		BDT: {Code: BDT, Name: "Bangladeshi Taka", Exponent: 2, Enabled: true},
+	}
+)
+
+// LookupCurrency returns the metadata of a supported currency
+func LookupCurrency(code string) (Currency, bool) {
+	currenciesMu.RLock()
+	defer currenciesMu.RUnlock()
+
+	currency, ok := currencies[code]
+	return currency, ok
+}
+
+// IsCurrencySupported checks if the currency is in the catalogue, whether it is enabled or not
 func IsCurrencySupported(currency string) bool {
-	switch currency {
-	case USD, EUR, GBP, BDT:
-		return true
+	_, ok := LookupCurrency(currency)
+	return ok
+}
+
+// IsCurrencyEnabled checks if new accounts can be opened in the currency
+func IsCurrencyEnabled(currency string) bool {
+	c, ok := LookupCurrency(currency)
+	return ok && c.Enabled
+}
+
+// Currencies returns the cached catalogue ordered by code
+func Currencies() []Currency {
+	currenciesMu.RLock()
+	defer currenciesMu.RUnlock()
+
+	result := make([]Currency, 0, len(currencies))
+	for _, currency := range currencies {
+		result = append(result, currency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// SetCurrencies replaces the cached catalogue
+func SetCurrencies(catalogue []Currency) {
+	next := make(map[string]Currency, len(catalogue))
+	for _, currency := range catalogue {
+		next[currency.Code] = currency
 	}
-	return false
+
+	currenciesMu.Lock()
+	defer currenciesMu.Unlock()
+	currencies = next
+}
+
+// PutCurrency adds or replaces a single currency in the cached catalogue
+func PutCurrency(currency Currency) {
+	currenciesMu.Lock()
+	defer currenciesMu.Unlock()
+	currencies[currency.Code] = currency
 }
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyCatalogue(t *testing.T) {
+	original := Currencies()
+	t.Cleanup(func() { SetCurrencies(original) })
+
+	require.True(t, IsCurrencySupported(USD))
+	require.True(t, IsCurrencyEnabled(USD))
+	require.False(t, IsCurrencySupported("JPY"))
+
+	SetCurrencies([]Currency{
+		{Code: USD, Name: "US Dollar", Exponent: 2, Enabled: false},
+		{Code: "JPY", Name: "Yen", Exponent: 0, Enabled: true},
+	})
+
+	require.True(t, IsCurrencySupported(USD))
+	require.False(t, IsCurrencyEnabled(USD))
+	require.False(t, IsCurrencySupported(EUR))
+
+	money, err := ParseMoney("1500", "JPY")
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), money.Amount)
+	require.Equal(t, "1500", money.Decimal())
+
+	PutCurrency(Currency{Code: "KWD", Name: "Kuwaiti Dinar", Exponent: 3, Enabled: true})
+	money, err = ParseMoney("1.005", "KWD")
+	require.NoError(t, err)
+	require.Equal(t, int64(1005), money.Amount)
+
+	codes := []string{}
+	for _, currency := range Currencies() {
+		codes = append(codes, currency.Code)
+	}
+	require.Equal(t, []string{"JPY", "KWD", USD}, codes)
+}
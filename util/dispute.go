@@ -0,0 +1,42 @@
+package util
+
+// States of a dispute. A banker investigates an open dispute, may credit the customer provisionally
+// while doing so, and resolves it for or against the customer.
+const (
+	DisputeOpen                    = "open"
+	DisputeInvestigating           = "investigating"
+	DisputeProvisionalCredit       = "provisional_credit"
+	DisputeResolvedForCustomer     = "resolved_for_customer"
+	DisputeResolvedAgainstCustomer = "resolved_against_customer"
+)
+
+// disputeTransitions lists the states a dispute can move to from each state
+var disputeTransitions = map[string][]string{
+	DisputeOpen:              {DisputeInvestigating},
+	DisputeInvestigating:     {DisputeProvisionalCredit, DisputeResolvedForCustomer, DisputeResolvedAgainstCustomer},
+	DisputeProvisionalCredit: {DisputeResolvedForCustomer, DisputeResolvedAgainstCustomer},
+}
+
+// IsDisputeStatusSupported checks if the dispute status is supported
+func IsDisputeStatusSupported(status string) bool {
+	switch status {
+	case DisputeOpen, DisputeInvestigating, DisputeProvisionalCredit, DisputeResolvedForCustomer, DisputeResolvedAgainstCustomer:
+		return true
+	}
+	return false
+}
+
+// CanMoveDispute checks if a dispute can move from one state to the other. Resolved disputes are final.
+func CanMoveDispute(from string, to string) bool {
+	for _, status := range disputeTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDisputeResolved checks if the dispute status is one of the final states
+func IsDisputeResolved(status string) bool {
+	return status == DisputeResolvedForCustomer || status == DisputeResolvedAgainstCustomer
+}
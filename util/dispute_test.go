@@ -0,0 +1,18 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanMoveDispute(t *testing.T) {
+	require.True(t, CanMoveDispute(DisputeOpen, DisputeInvestigating))
+	require.True(t, CanMoveDispute(DisputeInvestigating, DisputeProvisionalCredit))
+	require.True(t, CanMoveDispute(DisputeInvestigating, DisputeResolvedAgainstCustomer))
+	require.True(t, CanMoveDispute(DisputeProvisionalCredit, DisputeResolvedForCustomer))
+	require.False(t, CanMoveDispute(DisputeOpen, DisputeResolvedForCustomer))
+	require.False(t, CanMoveDispute(DisputeProvisionalCredit, DisputeProvisionalCredit))
+	require.False(t, CanMoveDispute(DisputeResolvedForCustomer, DisputeInvestigating))
+	require.False(t, CanMoveDispute(DisputeInvestigating, "unknown"))
+}
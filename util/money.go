@@ -0,0 +1,228 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+	ErrAmountOverflow      = errors.New("amount is out of range")
+	ErrInvalidAmount       = errors.New("invalid amount")
+)
+
+const nanosPerUnit = 1_000_000_000
+
+// Money is an amount expressed in the minor unit of its currency,
+// e.g. Money{Amount: 1050, Currency: "USD"} is 10.50 USD
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney creates a new Money from an amount in minor units
+func NewMoney(amount int64, currency string) (Money, error) {
+	if !IsCurrencySupported(currency) {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// ParseMoney parses a decimal string such as "10.50" into a Money of the given currency.
+// It rejects amounts more precise than the currency's minor unit.
+func ParseMoney(amount string, currency string) (Money, error) {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+
+	s := amount
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if whole == "" {
+		whole = "0"
+	}
+	if (hasPoint && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, amount)
+	}
+
+	if len(frac) > c.Exponent {
+		return Money{}, fmt.Errorf("%w: %s allows at most %d decimal places", ErrInvalidAmount, c.Code, c.Exponent)
+	}
+	frac += strings.Repeat("0", c.Exponent-len(frac))
+
+	value, err := strconv.ParseUint(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %s", ErrAmountOverflow, amount)
+	}
+
+	if negative {
+		if value > math.MaxInt64+1 {
+			return Money{}, fmt.Errorf("%w: %s", ErrAmountOverflow, amount)
+		}
+		return Money{Amount: int64(-value), Currency: c.Code}, nil
+	}
+
+	if value > math.MaxInt64 {
+		return Money{}, fmt.Errorf("%w: %s", ErrAmountOverflow, amount)
+	}
+	return Money{Amount: int64(value), Currency: c.Code}, nil
+}
+
+// MoneyFromUnits creates a Money from whole units and nano units, the representation used by google.type.Money
+func MoneyFromUnits(currency string, units int64, nanos int32) (Money, error) {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit || (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+		return Money{}, fmt.Errorf("%w: nanos %d out of range for units %d", ErrInvalidAmount, nanos, units)
+	}
+
+	nanoScale := int32(pow10(9 - c.Exponent))
+	if nanos%nanoScale != 0 {
+		return Money{}, fmt.Errorf("%w: %s allows at most %d decimal places", ErrInvalidAmount, c.Code, c.Exponent)
+	}
+
+	scale := pow10(c.Exponent)
+	if units > math.MaxInt64/scale || units < math.MinInt64/scale {
+		return Money{}, ErrAmountOverflow
+	}
+
+	return Money{Amount: units * scale, Currency: c.Code}.Add(Money{Amount: int64(nanos / nanoScale), Currency: c.Code})
+}
+
+// Units splits the amount into whole units and nano units, the representation used by google.type.Money
+func (m Money) Units() (units int64, nanos int32) {
+	exponent := m.exponent()
+	scale := pow10(exponent)
+	return m.Amount / scale, int32((m.Amount % scale) * pow10(9-exponent))
+}
+
+// Add returns the sum of two amounts of the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrAmountOverflow
+	}
+
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of two amounts of the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	diff := m.Amount - other.Amount
+	if (other.Amount > 0 && diff > m.Amount) || (other.Amount < 0 && diff < m.Amount) {
+		return Money{}, ErrAmountOverflow
+	}
+
+	return Money{Amount: diff, Currency: m.Currency}, nil
+}
+
+// Neg returns the amount with its sign flipped
+func (m Money) Neg() (Money, error) {
+	if m.Amount == math.MinInt64 {
+		return Money{}, ErrAmountOverflow
+	}
+
+	return Money{Amount: -m.Amount, Currency: m.Currency}, nil
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Decimal formats the amount as a decimal string in major units, e.g. "10.50"
+func (m Money) Decimal() string {
+	exponent := m.exponent()
+	if exponent == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+
+	sign := ""
+	amount := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	scale := uint64(pow10(exponent))
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, exponent, amount%scale)
+}
+
+// String formats the money for humans, e.g. "10.50 USD"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the money as {"amount": "10.50", "currency": "USD"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+// UnmarshalJSON decodes the money from {"amount": "10.50", "currency": "USD"}
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	money, err := ParseMoney(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
+
+// exponent returns the minor unit exponent of the currency, or 0 if it is unknown
+func (m Money) exponent() int {
+	c, _ := LookupCurrency(m.Currency)
+	return c.Exponent
+}
+
+func pow10(n int) int64 {
+	result := int64(1)
+	for range n {
+		result *= 10
+	}
+	return result
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
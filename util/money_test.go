@@ -0,0 +1,110 @@
+package util
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	money, err := ParseMoney("10.5", USD)
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 1050, Currency: USD}, money)
+
+	money, err = ParseMoney("-0.07", EUR)
+	require.NoError(t, err)
+	require.Equal(t, int64(-7), money.Amount)
+
+	money, err = ParseMoney("42", GBP)
+	require.NoError(t, err)
+	require.Equal(t, int64(4200), money.Amount)
+
+	_, err = ParseMoney("1.234", USD)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = ParseMoney("1.2.3", USD)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = ParseMoney("abc", USD)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = ParseMoney("10", "XYZ")
+	require.ErrorIs(t, err, ErrUnsupportedCurrency)
+
+	_, err = ParseMoney("92233720368547758.08", USD)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+
+	money, err = ParseMoney("-92233720368547758.08", USD)
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MinInt64), money.Amount)
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	require.Equal(t, "10.50", Money{Amount: 1050, Currency: USD}.Decimal())
+	require.Equal(t, "-0.07", Money{Amount: -7, Currency: USD}.Decimal())
+	require.Equal(t, "0.00", Money{Amount: 0, Currency: USD}.Decimal())
+	require.Equal(t, "-92233720368547758.08", Money{Amount: math.MinInt64, Currency: USD}.Decimal())
+	require.Equal(t, "12.34 BDT", Money{Amount: 1234, Currency: BDT}.String())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := Money{Amount: 1000, Currency: USD}
+	b := Money{Amount: 250, Currency: USD}
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, int64(1250), sum.Amount)
+
+	diff, err := b.Sub(a)
+	require.NoError(t, err)
+	require.Equal(t, int64(-750), diff.Amount)
+
+	_, err = a.Add(Money{Amount: 1, Currency: EUR})
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = Money{Amount: math.MaxInt64, Currency: USD}.Add(Money{Amount: 1, Currency: USD})
+	require.ErrorIs(t, err, ErrAmountOverflow)
+
+	_, err = Money{Amount: math.MinInt64, Currency: USD}.Sub(Money{Amount: 1, Currency: USD})
+	require.ErrorIs(t, err, ErrAmountOverflow)
+
+	_, err = Money{Amount: math.MinInt64, Currency: USD}.Neg()
+	require.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestMoneyUnits(t *testing.T) {
+	units, nanos := Money{Amount: -1050, Currency: USD}.Units()
+	require.Equal(t, int64(-10), units)
+	require.Equal(t, int32(-500_000_000), nanos)
+
+	money, err := MoneyFromUnits(USD, units, nanos)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1050), money.Amount)
+
+	_, err = MoneyFromUnits(USD, 1, 1)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = MoneyFromUnits(USD, 1, -500_000_000)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = MoneyFromUnits(USD, math.MaxInt64, 0)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestMoneyJSON(t *testing.T) {
+	money := Money{Amount: 1050, Currency: USD}
+
+	data, err := json.Marshal(money)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"10.50","currency":"USD"}`, string(data))
+
+	var decoded Money
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, money, decoded)
+
+	err = json.Unmarshal([]byte(`{"amount":"1.001","currency":"USD"}`), &decoded)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
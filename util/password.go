@@ -1,14 +1,120 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword hashes the password using bcrypt
-func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+const (
+	// Argon2idAlgorithm hashes passwords with argon2id, as recommended by RFC 9106
+	Argon2idAlgorithm = "argon2id"
+	// BcryptAlgorithm hashes passwords with bcrypt
+	BcryptAlgorithm = "bcrypt"
+)
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+var (
+	ErrMismatchedPassword      = errors.New("password does not match")
+	ErrUnsupportedPasswordHash = errors.New("unsupported password hash")
+)
+
+var argon2Encoding = base64.RawStdEncoding
+
+// PasswordHasher hashes passwords into self-describing strings in the PHC string format, so a hash
+// records the algorithm and parameters it was made with and can still be checked after they change
+type PasswordHasher interface {
+	// HashPassword hashes the password with a random salt
+	HashPassword(password string) (string, error)
+	// CheckPassword checks a password against a hash made with any supported algorithm
+	CheckPassword(password string, hashedPassword string) error
+	// NeedsRehash reports whether a hash was made with another algorithm or other parameters than HashPassword uses
+	NeedsRehash(hashedPassword string) bool
+}
+
+// Argon2idHasher hashes passwords with argon2id
+type Argon2idHasher struct {
+	// memory is in KiB
+	memory      uint32
+	time        uint32
+	parallelism uint8
+}
+
+// NewArgon2idHasher creates a new Argon2idHasher, memory is in KiB
+func NewArgon2idHasher(memory uint32, time uint32, parallelism uint8) (PasswordHasher, error) {
+	if time < 1 || parallelism < 1 {
+		return nil, fmt.Errorf("argon2id time and parallelism must be at least 1")
+	}
+
+	if memory < 8*uint32(parallelism) {
+		return nil, fmt.Errorf("argon2id memory must be at least %d KiB", 8*uint32(parallelism))
+	}
+
+	return &Argon2idHasher{memory: memory, time: time, parallelism: parallelism}, nil
+}
+
+func (hasher *Argon2idHasher) HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	params := argon2Params{
+		version:     argon2.Version,
+		memory:      hasher.memory,
+		time:        hasher.time,
+		parallelism: hasher.parallelism,
+		salt:        salt,
+	}
+	params.key = argon2.IDKey([]byte(password), salt, params.time, params.memory, params.parallelism, argon2KeyLength)
+
+	return params.String(), nil
+}
+
+func (hasher *Argon2idHasher) CheckPassword(password string, hashedPassword string) error {
+	return CheckPassword(password, hashedPassword)
+}
+
+func (hasher *Argon2idHasher) NeedsRehash(hashedPassword string) bool {
+	params, err := parseArgon2Hash(hashedPassword)
+	if err != nil {
+		return true
+	}
+
+	return params.version != argon2.Version ||
+		params.memory != hasher.memory ||
+		params.time != hasher.time ||
+		params.parallelism != hasher.parallelism ||
+		len(params.key) != argon2KeyLength
+}
+
+// BcryptHasher hashes passwords with bcrypt. Its hashes keep the modular crypt format bcrypt has always
+// used, which the PHC string format extends.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates a new BcryptHasher
+func NewBcryptHasher(cost int) (PasswordHasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return &BcryptHasher{cost: cost}, nil
+}
+
+func (hasher *BcryptHasher) HashPassword(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), hasher.cost)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate password: %w", err)
 	}
@@ -16,7 +122,104 @@ func HashPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
-// CheckPassword checks if the provided password is correct
-func CheckPassword(password, hashedPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+func (hasher *BcryptHasher) CheckPassword(password string, hashedPassword string) error {
+	return CheckPassword(password, hashedPassword)
+}
+
+func (hasher *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	if !isBcryptHash(hashedPassword) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != hasher.cost
+}
+
+// CheckPassword checks a password against an argon2id or bcrypt hash. It returns ErrMismatchedPassword
+// when the password is wrong.
+func CheckPassword(password string, hashedPassword string) error {
+	switch {
+	case strings.HasPrefix(hashedPassword, "$"+Argon2idAlgorithm+"$"):
+		params, err := parseArgon2Hash(hashedPassword)
+		if err != nil {
+			return err
+		}
+
+		key := argon2.IDKey([]byte(password), params.salt, params.time, params.memory, params.parallelism, uint32(len(params.key)))
+		if subtle.ConstantTimeCompare(key, params.key) != 1 {
+			return ErrMismatchedPassword
+		}
+		return nil
+	case isBcryptHash(hashedPassword):
+		err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrMismatchedPassword
+		}
+		return err
+	default:
+		return ErrUnsupportedPasswordHash
+	}
+}
+
+func isBcryptHash(hashedPassword string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hashedPassword, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// argon2Params are the parts of an argon2id hash in the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
+type argon2Params struct {
+	version     int
+	memory      uint32
+	time        uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func (params argon2Params) String() string {
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2idAlgorithm,
+		params.version,
+		params.memory,
+		params.time,
+		params.parallelism,
+		argon2Encoding.EncodeToString(params.salt),
+		argon2Encoding.EncodeToString(params.key),
+	)
+}
+
+func parseArgon2Hash(hashedPassword string) (argon2Params, error) {
+	var params argon2Params
+
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != Argon2idAlgorithm {
+		return params, ErrUnsupportedPasswordHash
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &params.version); err != nil {
+		return params, fmt.Errorf("%w: invalid version", ErrUnsupportedPasswordHash)
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.parallelism)
+	if err != nil || params.time < 1 || params.parallelism < 1 {
+		return params, fmt.Errorf("%w: invalid parameters", ErrUnsupportedPasswordHash)
+	}
+
+	params.salt, err = argon2Encoding.DecodeString(parts[4])
+	if err != nil {
+		return params, fmt.Errorf("%w: invalid salt", ErrUnsupportedPasswordHash)
+	}
+
+	params.key, err = argon2Encoding.DecodeString(parts[5])
+	if err != nil || len(params.key) == 0 {
+		return params, fmt.Errorf("%w: invalid key", ErrUnsupportedPasswordHash)
+	}
+
+	return params, nil
 }
@@ -1,29 +1,121 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+func newTestHashers(t *testing.T) map[string]PasswordHasher {
+	argon2idHasher, err := NewArgon2idHasher(64, 1, 1)
+	require.NoError(t, err)
+
+	bcryptHasher, err := NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return map[string]PasswordHasher{
+		Argon2idAlgorithm: argon2idHasher,
+		BcryptAlgorithm:   bcryptHasher,
+	}
+}
+
 func TestPassword(t *testing.T) {
-	password := RandomString(6)
+	for algorithm, hasher := range newTestHashers(t) {
+		t.Run(algorithm, func(t *testing.T) {
+			password := RandomString(6)
+
+			hashedPassword1, err := hasher.HashPassword(password)
+			require.NoError(t, err)
+			require.NotEmpty(t, hashedPassword1)
+			require.False(t, hasher.NeedsRehash(hashedPassword1))
+
+			err = hasher.CheckPassword(password, hashedPassword1)
+			require.NoError(t, err)
 
-	hashedPassword1, err := HashPassword(password)
+			wrongPassword := RandomString(6)
+			err = hasher.CheckPassword(wrongPassword, hashedPassword1)
+			require.ErrorIs(t, err, ErrMismatchedPassword)
+
+			hashedPassword2, err := hasher.HashPassword(password)
+			require.NoError(t, err)
+			require.NotEmpty(t, hashedPassword2)
+
+			require.NotEqual(t, hashedPassword1, hashedPassword2)
+		})
+	}
+}
+
+func TestArgon2idHashFormat(t *testing.T) {
+	hasher, err := NewArgon2idHasher(64, 2, 1)
 	require.NoError(t, err)
-	require.NotEmpty(t, hashedPassword1)
 
-	err = CheckPassword(password, hashedPassword1)
+	hashedPassword, err := hasher.HashPassword(RandomString(6))
 	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=64,t=2,p=1$"))
+	require.Len(t, strings.Split(hashedPassword, "$"), 6)
+}
 
-	wrongPassword := RandomString(6)
-	err = CheckPassword(wrongPassword, hashedPassword1)
-	require.EqualError(t, err, bcrypt.ErrMismatchedHashAndPassword.Error())
+func TestCheckPasswordPHCString(t *testing.T) {
+	// a hash made elsewhere is checked with the parameters it records
+	salt := []byte("somesalt")
+	key := argon2.IDKey([]byte("password"), salt, 2, 64, 1, 24)
+	hashedPassword := "$argon2id$v=19$m=64,t=2,p=1$" + argon2Encoding.EncodeToString(salt) + "$" + argon2Encoding.EncodeToString(key)
 
-	hashedPassword2, err := HashPassword(password)
+	hasher, err := NewArgon2idHasher(64, 2, 1)
 	require.NoError(t, err)
-	require.NotEmpty(t, hashedPassword2)
 
-	require.NotEqual(t, hashedPassword1, hashedPassword2)
+	require.NoError(t, hasher.CheckPassword("password", hashedPassword))
+	require.ErrorIs(t, hasher.CheckPassword("drowssap", hashedPassword), ErrMismatchedPassword)
+
+	// the key is shorter than the ones the hasher makes
+	require.True(t, hasher.NeedsRehash(hashedPassword))
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hashers := newTestHashers(t)
+	argon2idHasher := hashers[Argon2idAlgorithm]
+	bcryptHasher := hashers[BcryptAlgorithm]
+
+	password := RandomString(6)
+	argon2idHash, err := argon2idHasher.HashPassword(password)
+	require.NoError(t, err)
+	bcryptHash, err := bcryptHasher.HashPassword(password)
+	require.NoError(t, err)
+
+	// either hasher checks hashes of the other algorithm, so they can be replaced after a login
+	require.NoError(t, argon2idHasher.CheckPassword(password, bcryptHash))
+	require.NoError(t, bcryptHasher.CheckPassword(password, argon2idHash))
+	require.True(t, argon2idHasher.NeedsRehash(bcryptHash))
+	require.True(t, bcryptHasher.NeedsRehash(argon2idHash))
+
+	// as are hashes made with other parameters
+	strongerArgon2idHasher, err := NewArgon2idHasher(128, 1, 1)
+	require.NoError(t, err)
+	require.True(t, strongerArgon2idHasher.NeedsRehash(argon2idHash))
+
+	strongerBcryptHasher, err := NewBcryptHasher(bcrypt.MinCost + 1)
+	require.NoError(t, err)
+	require.True(t, strongerBcryptHasher.NeedsRehash(bcryptHash))
+}
+
+func TestCheckPasswordUnsupportedHash(t *testing.T) {
+	err := CheckPassword(RandomString(6), "$md5$"+RandomString(22))
+	require.ErrorIs(t, err, ErrUnsupportedPasswordHash)
+
+	err = CheckPassword(RandomString(6), "$argon2id$v=19$m=64,t=1$c29tZXNhbHQ$c29tZWtleQ")
+	require.ErrorIs(t, err, ErrUnsupportedPasswordHash)
+}
+
+func TestNewPasswordHasherInvalidParameters(t *testing.T) {
+	_, err := NewArgon2idHasher(64, 0, 1)
+	require.Error(t, err)
+
+	_, err = NewArgon2idHasher(4, 1, 1)
+	require.Error(t, err)
+
+	_, err = NewBcryptHasher(bcrypt.MaxCost + 1)
+	require.Error(t, err)
 }
@@ -0,0 +1,17 @@
+package util
+
+// States of a payment batch. A batch is previewed while pending and executed by the worker once confirmed.
+const (
+	PaymentBatchPending   = "pending"
+	PaymentBatchConfirmed = "confirmed"
+	PaymentBatchCompleted = "completed"
+)
+
+// States of a payment batch item. Items are valid or invalid once imported, and valid items
+// become executed or failed when the batch is executed.
+const (
+	PaymentBatchItemValid    = "valid"
+	PaymentBatchItemInvalid  = "invalid"
+	PaymentBatchItemExecuted = "executed"
+	PaymentBatchItemFailed   = "failed"
+)
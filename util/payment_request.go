@@ -0,0 +1,9 @@
+package util
+
+// States of a payment request. A request starts pending and moves to exactly one of the other states.
+const (
+	PaymentRequestPending  = "pending"
+	PaymentRequestAccepted = "accepted"
+	PaymentRequestDeclined = "declined"
+	PaymentRequestExpired  = "expired"
+)
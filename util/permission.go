@@ -0,0 +1,188 @@
+package util
+
+import (
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Permissions granted to roles. Permissions ending in .any extend the permission without it
+// from the user's own resources to those of every user.
+const (
+	PermissionAccountCreate     = "account.create"
+	PermissionAccountCreateAny  = "account.create.any"
+	PermissionAccountRead       = "account.read"
+	PermissionAccountReadAny    = "account.read.any"
+	PermissionAccountDeposit    = "account.deposit"
+	PermissionAccountDepositAny = "account.deposit.any"
+	PermissionAccountManage     = "account.manage"
+	PermissionTransferCreate    = "transfer.create"
+	PermissionBeneficiaryManage = "beneficiary.manage"
+	PermissionPaymentManage     = "payment.manage"
+	PermissionCategoryManage    = "category.manage"
+	PermissionWebhookManage     = "webhook.manage"
+	PermissionDisputeOpen       = "dispute.open"
+	PermissionDisputeRead       = "dispute.read"
+	PermissionDisputeReadAny    = "dispute.read.any"
+	PermissionDisputeResolve    = "dispute.resolve"
+	PermissionCurrencyManage    = "currency.manage"
+	PermissionRiskReview        = "risk.review"
+	PermissionApprovalReview    = "approval.review"
+	// PermissionAuditRead reads the approval requests and transfer risk assessments of every user
+	PermissionAuditRead       = "audit.read"
+	PermissionUserUpdate      = "user.update"
+	PermissionUserUpdateAny   = "user.update.any"
+	PermissionUserRoleAssign  = "user.role.assign"
+	PermissionUserUnlock      = "user.unlock"
+	PermissionSessionBlock    = "session.block.any"
+	PermissionApiKeyManage    = "api_key.manage"
+	PermissionApiKeyRevokeAny = "api_key.revoke.any"
+	PermissionRoleRead        = "role.read"
+	// PermissionAccountLimitsUpdate requests limit overrides on any account, which another banker approves
+	PermissionAccountLimitsUpdate = "account.limits.update"
+	// PermissionMfaOptional lets users of the role login without two-factor authentication,
+	// users of other roles have to enroll before they can login
+	PermissionMfaOptional = "mfa.optional"
+)
+
+var depositorPermissions = []string{
+	PermissionAccountCreate,
+	PermissionAccountRead,
+	PermissionAccountDeposit,
+	PermissionAccountManage,
+	PermissionTransferCreate,
+	PermissionBeneficiaryManage,
+	PermissionPaymentManage,
+	PermissionCategoryManage,
+	PermissionWebhookManage,
+	PermissionDisputeOpen,
+	PermissionDisputeRead,
+	PermissionUserUpdate,
+	PermissionApiKeyManage,
+	PermissionMfaOptional,
+}
+
+var bankerPermissions = []string{
+	PermissionAccountCreate,
+	PermissionAccountCreateAny,
+	PermissionAccountRead,
+	PermissionAccountReadAny,
+	PermissionAccountDeposit,
+	PermissionAccountDepositAny,
+	PermissionAccountManage,
+	PermissionTransferCreate,
+	PermissionBeneficiaryManage,
+	PermissionPaymentManage,
+	PermissionCategoryManage,
+	PermissionWebhookManage,
+	PermissionDisputeOpen,
+	PermissionDisputeRead,
+	PermissionDisputeReadAny,
+	PermissionDisputeResolve,
+	PermissionCurrencyManage,
+	PermissionRiskReview,
+	PermissionApprovalReview,
+	PermissionAuditRead,
+	PermissionUserUpdate,
+	PermissionUserUpdateAny,
+	PermissionUserRoleAssign,
+	PermissionUserUnlock,
+	PermissionSessionBlock,
+	PermissionApiKeyManage,
+	PermissionRoleRead,
+	PermissionAccountLimitsUpdate,
+}
+
+var auditorPermissions = []string{
+	PermissionAccountRead,
+	PermissionAccountReadAny,
+	PermissionDisputeRead,
+	PermissionDisputeReadAny,
+	PermissionAuditRead,
+	PermissionRoleRead,
+}
+
+// rolePermissions caches the permissions of each role stored in the database.
+// It starts with the built-in roles so the package works before the roles are loaded.
+var (
+	rolePermissionsMu sync.RWMutex
+	rolePermissions   = map[string][]string{
+		DepositorRole: depositorPermissions,
+		BankerRole:    bankerPermissions,
+		AdminRole:     append(slices.Clone(bankerPermissions), PermissionApiKeyRevokeAny),
+		AuditorRole:   auditorPermissions,
+	}
+)
+
+// IsRoleSupported checks if the role exists
+func IsRoleSupported(role string) bool {
+	rolePermissionsMu.RLock()
+	defer rolePermissionsMu.RUnlock()
+
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Roles returns the names of the cached roles in order
+func Roles() []string {
+	rolePermissionsMu.RLock()
+	defer rolePermissionsMu.RUnlock()
+
+	roles := make([]string, 0, len(rolePermissions))
+	for role := range rolePermissions {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// RolePermissions returns the permissions of the role in order
+func RolePermissions(role string) []string {
+	rolePermissionsMu.RLock()
+	defer rolePermissionsMu.RUnlock()
+
+	permissions := slices.Clone(rolePermissions[role])
+	sort.Strings(permissions)
+	return permissions
+}
+
+// RoleHasPermission checks if the role grants the permission, unknown roles grant nothing
+func RoleHasPermission(role string, permission string) bool {
+	rolePermissionsMu.RLock()
+	defer rolePermissionsMu.RUnlock()
+
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// RoleIncludes checks if the role grants every permission of the other role, so users of the role
+// cannot give others more than they can do themselves. PermissionMfaOptional is left out,
+// it relaxes a requirement rather than granting access.
+func RoleIncludes(role string, other string) bool {
+	rolePermissionsMu.RLock()
+	defer rolePermissionsMu.RUnlock()
+
+	permissions, ok := rolePermissions[role]
+	if !ok {
+		return false
+	}
+
+	for _, permission := range rolePermissions[other] {
+		if permission != PermissionMfaOptional && !slices.Contains(permissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// RoleCanChangeRole checks if users of the role can move a user from one role to another. Both roles
+// must be included in theirs, so nobody can be promoted past them or demoted from above them.
+func RoleCanChangeRole(role string, from string, to string) bool {
+	return RoleIncludes(role, from) && RoleIncludes(role, to)
+}
+
+// SetRolePermissions replaces the cached roles with the permissions of each role
+func SetRolePermissions(roles map[string][]string) {
+	rolePermissionsMu.Lock()
+	defer rolePermissionsMu.Unlock()
+	rolePermissions = roles
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolePermissions(t *testing.T) {
+	original := map[string][]string{}
+	for _, role := range Roles() {
+		original[role] = RolePermissions(role)
+	}
+	t.Cleanup(func() { SetRolePermissions(original) })
+
+	require.Equal(t, []string{AdminRole, AuditorRole, BankerRole, DepositorRole}, Roles())
+
+	require.True(t, RoleHasPermission(DepositorRole, PermissionAccountDeposit))
+	require.False(t, RoleHasPermission(DepositorRole, PermissionAccountDepositAny))
+	require.True(t, RoleHasPermission(BankerRole, PermissionAccountDepositAny))
+	require.False(t, RoleHasPermission(AuditorRole, PermissionAccountDeposit))
+	require.True(t, RoleHasPermission(AuditorRole, PermissionAuditRead))
+	require.False(t, RoleHasPermission("teller", PermissionAccountRead))
+
+	// bankers cannot make admins, admins can make bankers
+	require.True(t, RoleIncludes(AdminRole, BankerRole))
+	require.False(t, RoleIncludes(BankerRole, AdminRole))
+	require.True(t, RoleIncludes(BankerRole, AuditorRole))
+	require.True(t, RoleIncludes(BankerRole, DepositorRole))
+	require.False(t, RoleIncludes(AuditorRole, DepositorRole))
+	require.False(t, RoleIncludes("teller", DepositorRole))
+
+	// bankers can neither demote admins nor promote users to admin
+	require.False(t, RoleCanChangeRole(BankerRole, AdminRole, DepositorRole))
+	require.False(t, RoleCanChangeRole(BankerRole, DepositorRole, AdminRole))
+	require.True(t, RoleCanChangeRole(BankerRole, DepositorRole, BankerRole))
+	require.True(t, RoleCanChangeRole(AdminRole, AdminRole, BankerRole))
+
+	SetRolePermissions(map[string][]string{
+		"teller":      {PermissionAccountRead, PermissionAccountDepositAny},
+		DepositorRole: {PermissionAccountRead},
+	})
+
+	require.True(t, IsRoleSupported("teller"))
+	require.False(t, IsRoleSupported(BankerRole))
+	require.True(t, RoleHasPermission("teller", PermissionAccountDepositAny))
+	require.True(t, RoleIncludes("teller", DepositorRole))
+	require.Equal(t, []string{PermissionAccountDepositAny, PermissionAccountRead}, RolePermissions("teller"))
+}
@@ -38,3 +38,9 @@ func RandomCurrency() string {
 func RandomEmail() string {
 	return RandomString(6) + "@gmail.com"
 }
+
+// RandomAccountNumber returns a random account number with valid check digits
+func RandomAccountNumber() string {
+	number, _ := NewAccountNumber("TEST")
+	return number
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// NormalizeHandle returns the form a payment handle is stored in: lowercase, without the leading @
+func NormalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(handle, "@"))
+}
+
+// MaskName hides most of a full name so a sender can confirm a recipient without learning who they are,
+// e.g. "John Ronald Smith" becomes "John R. S." and "Cher" becomes "C***"
+func MaskName(fullName string) string {
+	words := strings.Fields(fullName)
+	if len(words) == 0 {
+		return ""
+	}
+
+	if len(words) == 1 {
+		first, _ := utf8.DecodeRuneInString(words[0])
+		return string(first) + "***"
+	}
+
+	masked := []string{words[0]}
+	for _, word := range words[1:] {
+		initial, _ := utf8.DecodeRuneInString(word)
+		masked = append(masked, string(initial)+".")
+	}
+
+	return strings.Join(masked, " ")
+}
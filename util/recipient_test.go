@@ -0,0 +1,19 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeHandle(t *testing.T) {
+	require.Equal(t, "robin_hood", NormalizeHandle("@Robin_Hood"))
+	require.Equal(t, "robin", NormalizeHandle("robin"))
+}
+
+func TestMaskName(t *testing.T) {
+	require.Equal(t, "John R. S.", MaskName("John Ronald Smith"))
+	require.Equal(t, "John S.", MaskName("  John   Smith "))
+	require.Equal(t, "C***", MaskName("Cher"))
+	require.Equal(t, "", MaskName(" "))
+}
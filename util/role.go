@@ -1,6 +1,35 @@
 package util
 
+// Built-in roles, what each of them can do is stored in the database, see RoleHasPermission
 const (
 	DepositorRole = "depositor"
 	BankerRole    = "banker"
+	AdminRole     = "admin"
+	AuditorRole   = "auditor"
 )
+
+// Roles a member can hold on a shared account
+const (
+	AccountOwnerRole       = "owner"
+	AccountCanTransferRole = "can-transfer"
+	AccountViewOnlyRole    = "view-only"
+)
+
+var accountRoleRank = map[string]int{
+	AccountViewOnlyRole:    1,
+	AccountCanTransferRole: 2,
+	AccountOwnerRole:       3,
+}
+
+// IsAccountRoleSupported checks if the account member role is supported
+func IsAccountRoleSupported(role string) bool {
+	_, ok := accountRoleRank[role]
+	return ok
+}
+
+// AccountRoleAllows checks if an account member role grants at least the required role.
+// Owners can do everything members who can transfer can do, who in turn can view the account.
+func AccountRoleAllows(role string, required string) bool {
+	rank, ok := accountRoleRank[role]
+	return ok && rank >= accountRoleRank[required]
+}
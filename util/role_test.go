@@ -0,0 +1,16 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountRoleAllows(t *testing.T) {
+	require.True(t, AccountRoleAllows(AccountOwnerRole, AccountCanTransferRole))
+	require.True(t, AccountRoleAllows(AccountCanTransferRole, AccountCanTransferRole))
+	require.True(t, AccountRoleAllows(AccountViewOnlyRole, AccountViewOnlyRole))
+	require.False(t, AccountRoleAllows(AccountViewOnlyRole, AccountCanTransferRole))
+	require.False(t, AccountRoleAllows(AccountCanTransferRole, AccountOwnerRole))
+	require.False(t, AccountRoleAllows("unknown", AccountViewOnlyRole))
+}
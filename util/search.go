@@ -0,0 +1,16 @@
+package util
+
+// Directions a transfer or entry search can be narrowed to, seen from the searched accounts.
+// An empty direction matches both.
+const (
+	SearchDirectionIncoming = "incoming"
+	SearchDirectionOutgoing = "outgoing"
+)
+
+// Orders search results can be sorted in. An empty order sorts newest first.
+const (
+	SearchSortNewestFirst = "newest"
+	SearchSortOldestFirst = "oldest"
+	SearchSortAmountDesc  = "amount_desc"
+	SearchSortAmountAsc   = "amount_asc"
+)
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken(t *testing.T) {
+	token := RandomString(32)
+
+	tokenHash := HashToken(token)
+	require.Len(t, tokenHash, 64)
+	require.NotEqual(t, token, tokenHash)
+	require.Equal(t, tokenHash, HashToken(token))
+
+	require.True(t, CheckToken(token, tokenHash))
+	require.False(t, CheckToken(RandomString(32), tokenHash))
+	require.False(t, CheckToken(token, ""))
+}
+
+func TestGenerateToken(t *testing.T) {
+	token1, err := GenerateToken()
+	require.NoError(t, err)
+	require.Len(t, token1, 43)
+
+	token2, err := GenerateToken()
+	require.NoError(t, err)
+	require.NotEqual(t, token1, token2)
+}
@@ -0,0 +1,91 @@
+package util
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA1 secret of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// the RFC 6238 SHA1 test vectors, truncated to six digits
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+
+	_, err := TOTPCode("not base32!", 1)
+	require.Error(t, err)
+}
+
+func TestCheckTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := TOTPCode(secret, TOTPStep(now))
+	require.NoError(t, err)
+
+	step, ok := CheckTOTP(secret, code, now, 0)
+	require.True(t, ok)
+	require.Equal(t, TOTPStep(now), step)
+
+	// a code cannot be used twice
+	_, ok = CheckTOTP(secret, code, now, step)
+	require.False(t, ok)
+
+	// a code of the previous period is accepted for clock drift, older ones are not
+	_, ok = CheckTOTP(secret, code, now.Add(TOTPPeriod), 0)
+	require.True(t, ok)
+
+	_, ok = CheckTOTP(secret, code, now.Add(3*TOTPPeriod), 0)
+	require.False(t, ok)
+
+	_, ok = CheckTOTP(secret, "12345", now, 0)
+	require.False(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(TOTPProvisioningURI("Simple Bank", "robin", rfc6238Secret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Simple Bank:robin", uri.Path)
+	require.Equal(t, rfc6238Secret, uri.Query().Get("secret"))
+	require.Equal(t, "Simple Bank", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+	require.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		require.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+
+	require.Equal(t, codes[0], NormalizeRecoveryCode(" "+codes[0]+" "))
+	require.Equal(t, codes[0], NormalizeRecoveryCode(strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))))
+}
@@ -0,0 +1,10 @@
+package util
+
+// States of a transfer risk assessment. Allowed transfers and transfers flagged for review are executed
+// right away, blocked transfers are held until a banker approves or rejects them.
+const (
+	TransferRiskExecuted = "executed"
+	TransferRiskHeld     = "held"
+	TransferRiskApproved = "approved"
+	TransferRiskRejected = "rejected"
+)
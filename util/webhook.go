@@ -0,0 +1,8 @@
+package util
+
+// States of a webhook delivery. A delivery stays pending while it is being retried.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
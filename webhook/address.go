@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+var ErrAddressNotAllowed = errors.New("webhook endpoint resolves to a non-public address")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which net/netip does not treat as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicAddress reports whether deliveries may be sent to addr. Loopback, private (RFC 1918 and RFC 4193),
+// link-local (such as the 169.254.169.254 metadata service), multicast and unspecified addresses are refused,
+// so a webhook cannot be used to reach the bank's own network.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// IsLocalHostname reports whether host names the local machine without being an IP address
+func IsLocalHostname(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	return host == "localhost" || strings.HasSuffix(host, ".localhost")
+}
+
+// refuseNonPublicAddress is a net.Dialer Control hook. It runs after the host name has been resolved,
+// so it also catches public names pointing at internal addresses and names rebound after registration.
+func refuseNonPublicAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, address)
+	}
+
+	if !IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, addrPort.Addr())
+	}
+
+	return nil
+}
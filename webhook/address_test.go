@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	testCases := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			require.Equal(t, tc.public, IsPublicAddress(netip.MustParseAddr(tc.addr)))
+		})
+	}
+}
+
+func TestIsLocalHostname(t *testing.T) {
+	require.True(t, IsLocalHostname("localhost"))
+	require.True(t, IsLocalHostname("LOCALHOST."))
+	require.True(t, IsLocalHostname("api.localhost"))
+	require.False(t, IsLocalHostname("example.com"))
+	require.False(t, IsLocalHostname("localhost.example.com"))
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/google/uuid"
+)
+
+const (
+	EventAccountCreated      = "account.created"
+	EventAccountBalanceAdded = "account.balance_added"
+	EventTransferCreated     = "transfer.created"
+)
+
+var eventTypes = []string{
+	EventAccountCreated,
+	EventAccountBalanceAdded,
+	EventTransferCreated,
+}
+
+// EventTypes returns every event type a webhook can subscribe to
+func EventTypes() []string {
+	return append([]string(nil), eventTypes...)
+}
+
+// IsEventTypeSupported checks if a webhook can subscribe to the event type
+func IsEventTypeSupported(eventType string) bool {
+	for _, t := range eventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the JSON document posted to a webhook
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewEvent creates an event with a fresh ID that carries data as its JSON payload
+func NewEvent(eventType string, data any) (Event, error) {
+	if !IsEventTypeSupported(eventType) {
+		return Event{}, fmt.Errorf("unsupported event type %q", eventType)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return Event{}, err
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	return Event{
+		ID:        id.String(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      raw,
+	}, nil
+}
+
+// AccountData is the payload of account.created
+type AccountData struct {
+	AccountNumber string     `json:"account_number"`
+	Owner         string     `json:"owner"`
+	Balance       util.Money `json:"balance"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// BalanceAddedData is the payload of account.balance_added
+type BalanceAddedData struct {
+	AccountNumber string     `json:"account_number"`
+	Amount        util.Money `json:"amount"`
+	Balance       util.Money `json:"balance"`
+}
+
+// TransferData is the payload of transfer.created
+type TransferData struct {
+	ID                int64      `json:"id"`
+	FromAccountNumber string     `json:"from_account_number"`
+	ToAccountNumber   string     `json:"to_account_number"`
+	Amount            util.Money `json:"amount"`
+	Reference         string     `json:"reference"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
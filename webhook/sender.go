@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+var ErrUnexpectedStatus = errors.New("webhook endpoint returned a non-2xx status")
+
+type Sender interface {
+	// Send posts a signed event body to url and returns the HTTP status code of the response, or 0 if there was none
+	Send(
+		ctx context.Context,
+		url, secret string,
+		deliveryID int64,
+		eventType string,
+		body []byte,
+	) (int, error)
+}
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender returns a Sender that only connects to public addresses
+func NewHTTPSender(timeout time.Duration) Sender {
+	return newHTTPSender(timeout, refuseNonPublicAddress)
+}
+
+func newHTTPSender(timeout time.Duration, control func(network, address string, c syscall.RawConn) error) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would be the only address the dialer checks
+	transport.Proxy = nil
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// a redirect would send the signed body to a host the owner never registered
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (sender *HTTPSender) Send(
+	ctx context.Context,
+	url, secret string,
+	deliveryID int64,
+	eventType string,
+	body []byte,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SimpleBank-Webhooks/1.0")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(deliveryID, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now(), body))
+
+	res, err := sender.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("%w: %d", ErrUnexpectedStatus, res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
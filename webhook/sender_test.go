@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSenderSignsDelivery(t *testing.T) {
+	secret := util.RandomString(32)
+	body := []byte(`{"id":"1","type":"transfer.created","data":{}}`)
+
+	var received *http.Request
+	var receivedBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	sender := newHTTPSender(5*time.Second, nil)
+	code, err := sender.Send(context.Background(), receiver.URL, secret, 42, EventTransferCreated, body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, code)
+
+	require.NotNil(t, received)
+	require.Equal(t, http.MethodPost, received.Method)
+	require.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	require.Equal(t, EventTransferCreated, received.Header.Get(EventHeader))
+	require.Equal(t, "42", received.Header.Get(DeliveryHeader))
+	require.Equal(t, body, receivedBody)
+
+	err = Verify(secret, received.Header.Get(SignatureHeader), receivedBody, time.Minute, time.Now())
+	require.NoError(t, err)
+}
+
+func TestHTTPSenderNon2xx(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	sender := newHTTPSender(5*time.Second, nil)
+	code, err := sender.Send(context.Background(), receiver.URL, util.RandomString(32), 1, EventAccountCreated, []byte(`{}`))
+	require.ErrorIs(t, err, ErrUnexpectedStatus)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestHTTPSenderDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	sender := newHTTPSender(5*time.Second, nil)
+	code, err := sender.Send(context.Background(), receiver.URL, util.RandomString(32), 1, EventAccountCreated, []byte(`{}`))
+	require.ErrorIs(t, err, ErrUnexpectedStatus)
+	require.Equal(t, http.StatusTemporaryRedirect, code)
+	require.False(t, followed)
+}
+
+func TestHTTPSenderUnreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	receiver.Close()
+
+	sender := newHTTPSender(time.Second, nil)
+	code, err := sender.Send(context.Background(), receiver.URL, util.RandomString(32), 1, EventAccountCreated, []byte(`{}`))
+	require.Error(t, err)
+	require.Zero(t, code)
+}
+
+func TestHTTPSenderRefusesNonPublicAddresses(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	sender := NewHTTPSender(time.Second)
+	code, err := sender.Send(context.Background(), receiver.URL, util.RandomString(32), 1, EventAccountCreated, []byte(`{}`))
+	require.ErrorIs(t, err, ErrAddressNotAllowed)
+	require.Zero(t, code)
+	require.False(t, called)
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := util.RandomString(32)
+	body := []byte(`{"id":"1","type":"account.created"}`)
+	now := time.Now()
+
+	header := Sign(secret, now, body)
+	require.Regexp(t, `^t=\d+,v1=[0-9a-f]{64}$`, header)
+
+	err := Verify(secret, header, body, 5*time.Minute, now)
+	require.NoError(t, err)
+
+	err = Verify(util.RandomString(32), header, body, 5*time.Minute, now)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	err = Verify(secret, header, []byte(`{"id":"2","type":"account.created"}`), 5*time.Minute, now)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	err = Verify(secret, header, body, 5*time.Minute, now.Add(10*time.Minute))
+	require.ErrorIs(t, err, ErrSignatureExpired)
+}
+
+func TestVerifyFutureTimestamp(t *testing.T) {
+	secret := util.RandomString(32)
+	body := []byte(`{"id":"1","type":"account.created"}`)
+	now := time.Now()
+
+	err := Verify(secret, Sign(secret, now.Add(4*time.Minute), body), body, 5*time.Minute, now)
+	require.NoError(t, err)
+
+	err = Verify(secret, Sign(secret, now.Add(10*time.Minute), body), body, 5*time.Minute, now)
+	require.ErrorIs(t, err, ErrSignatureExpired)
+}
+
+func TestNewSecret(t *testing.T) {
+	secret1, err := NewSecret()
+	require.NoError(t, err)
+	require.Regexp(t, `^whsec_[0-9a-f]{64}$`, secret1)
+
+	secret2, err := NewSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret1, secret2)
+}
+
+func TestVerifyMalformedHeader(t *testing.T) {
+	secret := util.RandomString(32)
+	body := []byte(`{}`)
+	now := time.Now()
+
+	for _, header := range []string{"", "t=abc,v1=00", "t=1", "v1=00", "t=1,v1=zz", "garbage"} {
+		err := Verify(secret, header, body, time.Minute, now)
+		require.ErrorIs(t, err, ErrInvalidSignature, header)
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	event, err := NewEvent(EventTransferCreated, TransferData{ID: 1, Amount: util.Money{Amount: 1050, Currency: util.USD}})
+	require.NoError(t, err)
+	require.NotEmpty(t, event.ID)
+	require.Equal(t, EventTransferCreated, event.Type)
+	require.Contains(t, string(event.Data), `"amount":{"amount":"10.50","currency":"USD"}`)
+
+	_, err = NewEvent("account.deleted", nil)
+	require.Error(t, err)
+}
@@ -3,9 +3,11 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 
+	"github.com/RobinHood3082/simplebank/webhook"
 	"github.com/hibiken/asynq"
 )
 
@@ -16,6 +18,19 @@ type TaskDistributor interface {
 		payload any,
 		opts ...asynq.Option,
 	) error
+	// DistributeWebhookEvent publishes an event to the webhooks of the members of the accounts it concerns
+	DistributeWebhookEvent(
+		ctx context.Context,
+		eventType string,
+		data any,
+		accountIDs ...int64,
+	) error
+	// DistributeWebhookDelivery queues a webhook delivery, retrying it with backoff until it succeeds.
+	// Queueing a delivery that is already queued is a no-op.
+	DistributeWebhookDelivery(ctx context.Context, deliveryID int64) error
+	// DistributeSpendingSummaryRefresh queues a run of the auto-categorization rules followed by a refresh
+	// of the spending summaries. Requests made while a refresh is already queued are merged into it.
+	DistributeSpendingSummaryRefresh(ctx context.Context) error
 }
 
 type RedisTaskDistributor struct {
@@ -48,3 +63,57 @@ func (distributor *RedisTaskDistributor) DistributeTask(
 
 	return nil
 }
+
+func (distributor *RedisTaskDistributor) DistributeWebhookEvent(
+	ctx context.Context,
+	eventType string,
+	data any,
+	accountIDs ...int64,
+) error {
+	event, err := webhook.NewEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+
+	payload := PayloadDispatchWebhookEvent{
+		Event:      event,
+		AccountIDs: accountIDs,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(QueueWebhooks),
+	}
+
+	return distributor.DistributeTask(ctx, TaskDispatchWebhookEvent, payload, opts...)
+}
+
+func (distributor *RedisTaskDistributor) DistributeWebhookDelivery(ctx context.Context, deliveryID int64) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(webhookMaxRetry),
+		asynq.Queue(QueueWebhooks),
+		asynq.TaskID(fmt.Sprintf("webhook_delivery:%d", deliveryID)),
+	}
+
+	err := distributor.DistributeTask(ctx, TaskDeliverWebhook, PayloadDeliverWebhook{DeliveryID: deliveryID}, opts...)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return err
+	}
+
+	return nil
+}
+
+func (distributor *RedisTaskDistributor) DistributeSpendingSummaryRefresh(ctx context.Context) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(3),
+		asynq.Queue(QueueDefault),
+		asynq.Unique(spendingSummaryRefreshUniqueness),
+	}
+
+	err := distributor.DistributeTask(ctx, TaskRefreshSpendingSummaries, PayloadRefreshSpendingSummaries{}, opts...)
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		return err
+	}
+
+	return nil
+}
@@ -3,21 +3,39 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"log/slog"
+	"time"
 
 	"github.com/RobinHood3082/simplebank/internal/persistence"
 	"github.com/RobinHood3082/simplebank/mail"
+	"github.com/RobinHood3082/simplebank/pain"
+	"github.com/RobinHood3082/simplebank/risk"
 	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const (
 	QueueCritical = "critical"
 	QueueDefault  = "default"
+	// QueueWebhooks keeps slow or failing webhook endpoints from delaying emails
+	QueueWebhooks = "webhooks"
 )
 
+const (
+	webhookMaxRetry       = 10
+	webhookBaseRetryDelay = 30 * time.Second
+	webhookMaxRetryDelay  = 6 * time.Hour
+)
+
+// spendingSummaryRefreshUniqueness is how long a queued spending summary refresh absorbs further requests
+const spendingSummaryRefreshUniqueness = time.Minute
+
 type TaskProcessor interface {
 	Start() error
 	Shutdown()
@@ -25,19 +43,34 @@ type TaskProcessor interface {
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  persistence.Store
-	mailer mail.EmailSender
+	server          *asynq.Server
+	store           persistence.Store
+	mailer          mail.EmailSender
+	webhookSender   webhook.Sender
+	taskDistributor TaskDistributor
+	riskEngine      *risk.Engine
+	// beneficiaryLimit applies to payment batch items sent to a new beneficiary of the batch owner
+	beneficiaryLimit persistence.BeneficiaryLimit
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store persistence.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store persistence.Store,
+	mailer mail.EmailSender,
+	webhookSender webhook.Sender,
+	taskDistributor TaskDistributor,
+	riskEngine *risk.Engine,
+	beneficiaryLimit persistence.BeneficiaryLimit,
+) TaskProcessor {
 	server := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
 			Queues: map[string]int{
 				QueueCritical: 10,
 				QueueDefault:  5,
+				QueueWebhooks: 3,
 			},
+			RetryDelayFunc: retryDelay,
 			ErrorHandler: asynq.ErrorHandlerFunc(
 				func(ctx context.Context, task *asynq.Task, err error) {
 					slog.Error("error processing task", "type", task.Type(), "error", err, "payload", task.Payload())
@@ -47,10 +80,31 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store persistence.Stor
 	)
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:           server,
+		store:            store,
+		mailer:           mailer,
+		webhookSender:    webhookSender,
+		taskDistributor:  taskDistributor,
+		riskEngine:       riskEngine,
+		beneficiaryLimit: beneficiaryLimit,
+	}
+}
+
+// retryDelay backs webhook deliveries off exponentially, from webhookBaseRetryDelay up to webhookMaxRetryDelay,
+// so an endpoint that is down for hours still receives its events. Other tasks use the asynq default.
+func retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	if task.Type() != TaskDeliverWebhook {
+		return asynq.DefaultRetryDelayFunc(n, err, task)
+	}
+
+	delay := webhookBaseRetryDelay
+	for range n {
+		delay *= 2
+		if delay >= webhookMaxRetryDelay {
+			return webhookMaxRetryDelay
+		}
 	}
+	return delay
 }
 
 func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error {
@@ -103,6 +157,100 @@ func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Cont
 	return nil
 }
 
+// ProcessTaskSendPasswordResetEmail emails a single use code that lets the user choose a new password.
+// Only the hash of the code is stored.
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPasswordResetEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secretCode, err := util.GenerateToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate reset code: %w", err)
+	}
+
+	passwordReset, err := processor.store.CreatePasswordReset(
+		ctx,
+		persistence.CreatePasswordResetParams{
+			Username:       user.Username,
+			Email:          user.Email,
+			SecretCodeHash: util.HashToken(secretCode),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	subject := "Simple Bank: Reset your password"
+	resetUrl := fmt.Sprintf("http://localhost:8080/reset_password?reset_id=%d&secret_code=%s",
+		passwordReset.ID,
+		secretCode,
+	)
+	content := fmt.Sprintf(
+		`Hello %s, <br/>
+		We received a request to reset your password. <br/>
+		Please <a href="%s">click here</a> to choose a new one. The link can be used once and expires in %s. <br/>
+		If you did not ask for this, you can ignore this email, your password has not been changed.`,
+		user.Username,
+		resetUrl,
+		time.Until(passwordReset.ExpiresAt.Time).Round(time.Minute),
+	)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "user", user.Username)
+
+	return nil
+}
+
+// ProcessTaskSendLoginLockedEmail tells a user their account was locked after too many failed logins
+func (processor *RedisTaskProcessor) ProcessTaskSendLoginLockedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendLoginLockedEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Simple Bank: Your account has been locked"
+	content := fmt.Sprintf(
+		`Hello %s, <br/>
+		Someone entered a wrong password for your account too many times, so logins are locked until %s. <br/>
+		If this was not you, please reset your password or contact your bank.`,
+		user.Username,
+		payload.LockedUntil.Format(time.RFC1123),
+	)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "user", user.Username)
+
+	return nil
+}
+
 func (processor *RedisTaskProcessor) ProcessTaskSendAccountCreatedEmail(ctx context.Context, task *asynq.Task) error {
 	var payload PayloadSendAccountCreatedEmail
 	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
@@ -121,11 +269,10 @@ func (processor *RedisTaskProcessor) ProcessTaskSendAccountCreatedEmail(ctx cont
 	content := fmt.Sprintf(
 		`Hello %s, <br/>
 		Your new account with ID: %s has been created. <br/>
-		Current balance: %d %s.`,
+		Current balance: %s.`,
 		payload.Username,
 		payload.AccountID,
 		payload.Balance,
-		payload.Currency,
 	)
 	to := []string{user.Email}
 
@@ -156,14 +303,12 @@ func (processor *RedisTaskProcessor) ProcessTaskSendBalanceAddedEmail(ctx contex
 	subject := "Simple Bank: Balance Added"
 	content := fmt.Sprintf(
 		`Hello %s, <br/>
-		Your account with ID: %s has been credited with %d %s. <br/>
-		Current balance: %d %s.`,
+		Your account with ID: %s has been credited with %s. <br/>
+		Current balance: %s.`,
 		payload.Username,
 		payload.AccountID,
 		payload.AddedBalance,
-		payload.Currency,
 		payload.NewBalance,
-		payload.Currency,
 	)
 	to := []string{user.Email}
 
@@ -177,12 +322,653 @@ func (processor *RedisTaskProcessor) ProcessTaskSendBalanceAddedEmail(ctx contex
 	return nil
 }
 
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountInvitationEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountInvitationEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	invitation, err := processor.store.GetAccountInvitation(ctx, payload.InvitationID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("invitation not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, invitation.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secretCode, err := util.GenerateToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate invitation code: %w", err)
+	}
+
+	// only the hash is stored, a retried task replaces the code sent by an earlier attempt
+	invitation, err = processor.store.SetAccountInvitationSecret(
+		ctx,
+		persistence.SetAccountInvitationSecretParams{
+			ID:             invitation.ID,
+			SecretCodeHash: util.HashToken(secretCode),
+		},
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("invitation already accepted: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to set invitation code: %w", err)
+	}
+
+	subject := "Simple Bank: You have been invited to a joint account"
+	acceptUrl := fmt.Sprintf("http://localhost:8080/api/v1/accept_account_invitation?invitation_id=%d&secret_code=%s",
+		invitation.ID,
+		secretCode,
+	)
+	content := fmt.Sprintf(
+		`Hello %s, <br/>
+		%s has invited you to join their account as %s. <br/>
+		Please <a href="%s">click here</a> to accept the invitation.`,
+		user.Username,
+		invitation.InvitedBy,
+		invitation.Role,
+		acceptUrl,
+	)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "user", user.Username)
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountMemberRemovedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountMemberRemovedEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Simple Bank: Removed from joint account"
+	content := fmt.Sprintf(
+		`Hello %s, <br/>
+		You have been removed from the account with ID: %s by %s.`,
+		payload.Username,
+		payload.AccountID,
+		payload.RemovedBy,
+	)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "user", user.Username)
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendBeneficiaryAddedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendBeneficiaryAddedEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Simple Bank: New beneficiary added"
+	content := fmt.Sprintf(
+		`Hello %s, <br/>
+		%s (account ID: %s) has been added to your beneficiaries. <br/>
+		Until %s, you can send at most %s in total to this beneficiary. <br/>
+		If you did not add this beneficiary, please contact us immediately.`,
+		payload.Username,
+		payload.Nickname,
+		payload.AccountID,
+		payload.CoolingOffEndsAt.Format(time.RFC1123),
+		payload.CoolingOffLimit,
+	)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "user", user.Username)
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendPaymentRequestEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPaymentRequestEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	paymentRequest, err := processor.store.GetPaymentRequest(ctx, payload.PaymentRequestID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("payment request not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get payment request: %w", err)
+	}
+
+	err = processor.sendPaymentRequestEmail(ctx, paymentRequest)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", paymentRequest.Status)
+
+	return nil
+}
+
+// ProcessTaskExpirePaymentRequest runs when a payment request is due to expire
+// and expires it unless it has been accepted or declined in the meantime
+func (processor *RedisTaskProcessor) ProcessTaskExpirePaymentRequest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExpirePaymentRequest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	paymentRequest, err := processor.store.ExpirePaymentRequest(ctx, payload.PaymentRequestID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", "already resolved")
+			return nil
+		}
+		return fmt.Errorf("failed to expire payment request: %w", err)
+	}
+
+	err = processor.sendPaymentRequestEmail(ctx, paymentRequest)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", paymentRequest.Status)
+
+	return nil
+}
+
+// sendPaymentRequestEmail tells the payer about a new request and the requester about what became of it
+func (processor *RedisTaskProcessor) sendPaymentRequestEmail(ctx context.Context, paymentRequest persistence.PaymentRequest) error {
+	amount := util.Money{Amount: paymentRequest.Amount, Currency: paymentRequest.Currency}
+
+	recipient := paymentRequest.Requester
+	var subject, content string
+	switch paymentRequest.Status {
+	case util.PaymentRequestPending:
+		recipient = paymentRequest.Payer
+		subject = "Simple Bank: You have a new payment request"
+		content = fmt.Sprintf(
+			`Hello %s, <br/>
+			%s has requested %s from you: %s <br/>
+			The request expires on %s.`,
+			paymentRequest.Payer,
+			paymentRequest.Requester,
+			amount,
+			html.EscapeString(paymentRequest.Note),
+			paymentRequest.ExpiresAt.Time.Format(time.RFC1123),
+		)
+	case util.PaymentRequestAccepted:
+		subject = "Simple Bank: Your payment request was paid"
+		content = fmt.Sprintf(
+			`Hello %s, <br/>
+			%s has paid your request for %s.`,
+			paymentRequest.Requester,
+			paymentRequest.Payer,
+			amount,
+		)
+	case util.PaymentRequestDeclined:
+		subject = "Simple Bank: Your payment request was declined"
+		content = fmt.Sprintf(
+			`Hello %s, <br/>
+			%s has declined your request for %s.`,
+			paymentRequest.Requester,
+			paymentRequest.Payer,
+			amount,
+		)
+	case util.PaymentRequestExpired:
+		subject = "Simple Bank: Your payment request has expired"
+		content = fmt.Sprintf(
+			`Hello %s, <br/>
+			Your request for %s from %s has expired without being paid.`,
+			paymentRequest.Requester,
+			amount,
+			paymentRequest.Payer,
+		)
+	default:
+		return fmt.Errorf("unknown payment request status %q: %w", paymentRequest.Status, asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, recipient)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// ProcessTaskDispatchWebhookEvent records a delivery of the event for every subscribed webhook and queues it.
+// It is safe to retry: each webhook gets at most one original delivery per event.
+func (processor *RedisTaskProcessor) ProcessTaskDispatchWebhookEvent(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDispatchWebhookEvent
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	body, err := json.Marshal(payload.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", asynq.SkipRetry)
+	}
+
+	webhooks, err := processor.store.ListWebhooksForEvent(ctx, persistence.ListWebhooksForEventParams{
+		EventType:  payload.Event.Type,
+		AccountIds: payload.AccountIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	for _, hook := range webhooks {
+		delivery, err := processor.store.CreateWebhookDelivery(ctx, persistence.CreateWebhookDeliveryParams{
+			WebhookID: hook.ID,
+			EventID:   payload.Event.ID,
+			EventType: payload.Event.Type,
+			Payload:   body,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+
+		if delivery.Status != util.WebhookDeliveryPending {
+			continue
+		}
+
+		err = processor.taskDistributor.DistributeWebhookDelivery(ctx, delivery.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "webhooks", len(webhooks))
+
+	return nil
+}
+
+// ProcessTaskDeliverWebhook posts a delivery to its webhook and records the outcome of the attempt.
+// A failed attempt is retried with exponential backoff until the retries run out.
+func (processor *RedisTaskProcessor) ProcessTaskDeliverWebhook(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDeliverWebhook
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	delivery, err := processor.store.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("webhook delivery not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if delivery.Status == util.WebhookDeliverySucceeded {
+		return nil
+	}
+
+	hook, err := processor.store.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	arg := persistence.RecordWebhookDeliveryAttemptParams{
+		ID:     delivery.ID,
+		Status: util.WebhookDeliverySucceeded,
+	}
+
+	var sendErr error
+	if !hook.IsActive {
+		sendErr = fmt.Errorf("webhook has been deleted: %w", asynq.SkipRetry)
+	} else {
+		var code int
+		code, sendErr = processor.webhookSender.Send(ctx, hook.Url, hook.Secret, delivery.ID, delivery.EventType, delivery.Payload)
+		if code != 0 {
+			arg.ResponseCode = pgtype.Int4{Int32: int32(code), Valid: true}
+		}
+	}
+
+	if sendErr != nil {
+		arg.Status = util.WebhookDeliveryPending
+		arg.LastError = sendErr.Error()
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if retried >= maxRetry || !hook.IsActive {
+			arg.Status = util.WebhookDeliveryFailed
+		}
+	}
+
+	_, err = processor.store.RecordWebhookDeliveryAttempt(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", sendErr)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", arg.Status)
+
+	return nil
+}
+
+// ProcessTaskRefreshSpendingSummaries applies the category rules to new transfers
+// and then recomputes the spending summaries behind the analytics
+func (processor *RedisTaskProcessor) ProcessTaskRefreshSpendingSummaries(ctx context.Context, task *asynq.Task) error {
+	categorized, err := processor.store.ApplyCategoryRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to apply category rules: %w", err)
+	}
+
+	err = processor.store.RefreshSpendingSummaries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to refresh spending summaries: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "categorized", categorized)
+
+	return nil
+}
+
+// ProcessTaskExecutePaymentBatch makes the transfers of the valid items of a confirmed batch one at a time and then
+// completes the batch. Items executed by an earlier attempt are skipped, so it is safe to retry.
+func (processor *RedisTaskProcessor) ProcessTaskExecutePaymentBatch(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExecutePaymentBatch
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	paymentBatch, err := processor.store.GetPaymentBatch(ctx, payload.PaymentBatchID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("payment batch not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get payment batch: %w", err)
+	}
+
+	if paymentBatch.Status != util.PaymentBatchConfirmed {
+		slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", paymentBatch.Status)
+		return nil
+	}
+
+	items, err := processor.store.ListPaymentBatchItems(ctx, paymentBatch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list payment batch items: %w", err)
+	}
+
+	executed := 0
+	for _, item := range items {
+		if item.Status != util.PaymentBatchItemValid {
+			continue
+		}
+
+		assessment, err := processor.riskEngine.AssessTransfer(ctx, processor.store, risk.Transfer{
+			Username:      paymentBatch.Owner,
+			FromAccountID: item.FromAccountID.Int64,
+			ToAccountID:   item.ToAccountID.Int64,
+			Amount:        util.Money{Amount: item.Amount, Currency: item.Currency},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assess payment batch item: %w", err)
+		}
+
+		// only direct transfers can be held for review, a blocked item is rejected instead
+		if assessment.Decision == risk.Block {
+			slog.Info("type", "recieved task", task.Type(), "blocked item", item.ID, "assessment", assessment)
+
+			_, err = processor.store.ResolvePaymentBatchItem(ctx, persistence.ResolvePaymentBatchItemParams{
+				ID:         item.ID,
+				Status:     util.PaymentBatchItemFailed,
+				ReasonCode: pain.ReasonTransactionForbidden,
+				Reason:     "blocked by risk checks",
+			})
+			if err != nil && err != pgx.ErrNoRows {
+				return fmt.Errorf("failed to resolve payment batch item: %w", err)
+			}
+			continue
+		}
+
+		findings, err := json.Marshal(assessment.Findings)
+		if err != nil {
+			return fmt.Errorf("failed to marshal findings: %w", err)
+		}
+
+		result, err := processor.store.ExecutePaymentBatchItemTx(ctx, persistence.ExecutePaymentBatchItemTxParams{
+			ItemID: item.ID,
+			Assessment: persistence.TransferAssessment{
+				RequestedBy: paymentBatch.Owner,
+				Decision:    string(assessment.Decision),
+				Findings:    findings,
+			},
+			BeneficiaryLimit: processor.beneficiaryLimit,
+		})
+		if errors.Is(err, persistence.ErrBeneficiaryLimitExceeded) {
+			_, err = processor.store.ResolvePaymentBatchItem(ctx, persistence.ResolvePaymentBatchItemParams{
+				ID:         item.ID,
+				Status:     util.PaymentBatchItemFailed,
+				ReasonCode: pain.ReasonNotAllowedAmount,
+				Reason:     "over the transfer limit of a new beneficiary",
+			})
+			if err != nil && err != pgx.ErrNoRows {
+				return fmt.Errorf("failed to resolve payment batch item: %w", err)
+			}
+			continue
+		}
+		if errors.Is(err, persistence.ErrInsufficientFunds) {
+			_, err = processor.store.ResolvePaymentBatchItem(ctx, persistence.ResolvePaymentBatchItemParams{
+				ID:         item.ID,
+				Status:     util.PaymentBatchItemFailed,
+				ReasonCode: pain.ReasonInsufficientFunds,
+				Reason:     "insufficient funds when the batch was executed",
+			})
+			if err != nil && err != pgx.ErrNoRows {
+				return fmt.Errorf("failed to resolve payment batch item: %w", err)
+			}
+			continue
+		}
+		if errors.Is(err, persistence.ErrPaymentBatchItemNotExecutable) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to execute payment batch item: %w", err)
+		}
+
+		executed++
+
+		transfer := result.Transfer.Transfer
+		data := webhook.TransferData{
+			ID:                transfer.ID,
+			FromAccountNumber: result.Transfer.FromAccount.AccountNumber,
+			ToAccountNumber:   result.Transfer.ToAccount.AccountNumber,
+			Amount:            util.Money{Amount: transfer.Amount, Currency: item.Currency},
+			Reference:         transfer.Reference,
+			CreatedAt:         transfer.CreatedAt.Time,
+		}
+
+		err = processor.taskDistributor.DistributeWebhookEvent(ctx, webhook.EventTransferCreated, data, transfer.FromAccountID, transfer.ToAccountID)
+		if err != nil {
+			slog.Error("failed to distribute webhook event", "error", err)
+		}
+	}
+
+	_, err = processor.store.CompletePaymentBatch(ctx, paymentBatch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to complete payment batch: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "executed", executed)
+
+	return nil
+}
+
+// ProcessTaskSendDisputeEmail tells the customer who opened a dispute and the owner of the account
+// that received the disputed transfer about a step in the case
+func (processor *RedisTaskProcessor) ProcessTaskSendDisputeEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendDisputeEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	event, err := processor.store.GetDisputeEvent(ctx, payload.DisputeEventID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("dispute event not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get dispute event: %w", err)
+	}
+
+	dispute, err := processor.store.GetDispute(ctx, event.DisputeID)
+	if err != nil {
+		return fmt.Errorf("failed to get dispute: %w", err)
+	}
+
+	transfer, err := processor.store.GetTransfer(ctx, dispute.TransferID)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	toAccount, err := processor.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	amount := util.Money{Amount: dispute.Amount, Currency: dispute.Currency}
+
+	var customerContent, recipientContent string
+	switch event.Status {
+	case util.DisputeOpen:
+		customerContent = fmt.Sprintf("We have received your dispute #%d of a transfer of %s and will look into it.", dispute.ID, amount)
+		recipientContent = fmt.Sprintf("A transfer of %s you received has been disputed by the sender (dispute #%d).", amount, dispute.ID)
+	case util.DisputeInvestigating:
+		customerContent = fmt.Sprintf("We are investigating your dispute #%d.", dispute.ID)
+		recipientContent = fmt.Sprintf("We are investigating dispute #%d of a transfer of %s you received.", dispute.ID, amount)
+	case util.DisputeProvisionalCredit:
+		customerContent = fmt.Sprintf("We have provisionally credited %s to your account while dispute #%d is being investigated.", amount, dispute.ID)
+		recipientContent = fmt.Sprintf("We are still investigating dispute #%d of a transfer of %s you received.", dispute.ID, amount)
+	case util.DisputeResolvedForCustomer:
+		customerContent = fmt.Sprintf("Dispute #%d was resolved in your favour and %s has been credited to your account.", dispute.ID, amount)
+		recipientContent = fmt.Sprintf("Dispute #%d was resolved in favour of the sender and %s has been charged back from your account.", dispute.ID, amount)
+	case util.DisputeResolvedAgainstCustomer:
+		customerContent = fmt.Sprintf("Dispute #%d was resolved against you. Any provisional credit of %s has been reversed.", dispute.ID, amount)
+		recipientContent = fmt.Sprintf("Dispute #%d of a transfer of %s you received was resolved in your favour.", dispute.ID, amount)
+	default:
+		return fmt.Errorf("unknown dispute status %q: %w", event.Status, asynq.SkipRetry)
+	}
+
+	subject := fmt.Sprintf("Simple Bank: Update on dispute #%d", dispute.ID)
+	for username, text := range map[string]string{
+		dispute.OpenedBy: customerContent,
+		toAccount.Owner:  recipientContent,
+	} {
+		user, err := processor.store.GetUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		content := fmt.Sprintf(
+			`Hello %s, <br/>
+			%s <br/>
+			%s`,
+			user.Username,
+			text,
+			html.EscapeString(event.Note),
+		)
+
+		err = processor.mailer.SendEmail(subject, content, []string{user.Email}, nil, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", event.Status)
+
+	return nil
+}
+
+// ProcessTaskExpireApprovalRequest runs when an approval request is due to expire
+// and expires it unless it has been approved or rejected in the meantime
+func (processor *RedisTaskProcessor) ProcessTaskExpireApprovalRequest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExpireApprovalRequest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	request, err := processor.store.ExpireApprovalRequest(ctx, payload.ApprovalRequestID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", "already resolved")
+			return nil
+		}
+		return fmt.Errorf("failed to expire approval request: %w", err)
+	}
+
+	slog.Info("type", "recieved task", task.Type(), "payload", task.Payload(), "status", request.Status)
+
+	return nil
+}
+
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskSendPasswordResetEmail, processor.ProcessTaskSendPasswordResetEmail)
+	mux.HandleFunc(TaskSendLoginLockedEmail, processor.ProcessTaskSendLoginLockedEmail)
 	mux.HandleFunc(TaskSendAccountCreatedEmail, processor.ProcessTaskSendAccountCreatedEmail)
 	mux.HandleFunc(TaskSendBalanceAddedEmail, processor.ProcessTaskSendBalanceAddedEmail)
+	mux.HandleFunc(TaskSendAccountInvitationEmail, processor.ProcessTaskSendAccountInvitationEmail)
+	mux.HandleFunc(TaskSendAccountMemberRemovedEmail, processor.ProcessTaskSendAccountMemberRemovedEmail)
+	mux.HandleFunc(TaskSendBeneficiaryAddedEmail, processor.ProcessTaskSendBeneficiaryAddedEmail)
+	mux.HandleFunc(TaskSendPaymentRequestEmail, processor.ProcessTaskSendPaymentRequestEmail)
+	mux.HandleFunc(TaskExpirePaymentRequest, processor.ProcessTaskExpirePaymentRequest)
+	mux.HandleFunc(TaskDispatchWebhookEvent, processor.ProcessTaskDispatchWebhookEvent)
+	mux.HandleFunc(TaskDeliverWebhook, processor.ProcessTaskDeliverWebhook)
+	mux.HandleFunc(TaskRefreshSpendingSummaries, processor.ProcessTaskRefreshSpendingSummaries)
+	mux.HandleFunc(TaskExecutePaymentBatch, processor.ProcessTaskExecutePaymentBatch)
+	mux.HandleFunc(TaskSendDisputeEmail, processor.ProcessTaskSendDisputeEmail)
+	mux.HandleFunc(TaskExpireApprovalRequest, processor.ProcessTaskExpireApprovalRequest)
 
 	return processor.server.Start(mux)
 }
@@ -1,26 +1,112 @@
 package worker
 
+import (
+	"time"
+
+	"github.com/RobinHood3082/simplebank/util"
+	"github.com/RobinHood3082/simplebank/webhook"
+)
+
 const (
 	TaskSendAccountCreatedEmail = "task:send_account_created_email"
 	TaskSendVerifyEmail         = "task:send_verify_email"
 	TaskSendBalanceAddedEmail   = "task:send_balance_added_email"
+	TaskSendPasswordResetEmail  = "task:send_password_reset_email"
+	TaskSendLoginLockedEmail    = "task:send_login_locked_email"
+
+	TaskSendAccountInvitationEmail    = "task:send_account_invitation_email"
+	TaskSendAccountMemberRemovedEmail = "task:send_account_member_removed_email"
+
+	TaskSendBeneficiaryAddedEmail = "task:send_beneficiary_added_email"
+
+	TaskSendPaymentRequestEmail = "task:send_payment_request_email"
+	TaskExpirePaymentRequest    = "task:expire_payment_request"
+
+	TaskDispatchWebhookEvent = "task:dispatch_webhook_event"
+	TaskDeliverWebhook       = "task:deliver_webhook"
+
+	TaskRefreshSpendingSummaries = "task:refresh_spending_summaries"
+
+	TaskExecutePaymentBatch = "task:execute_payment_batch"
+
+	TaskSendDisputeEmail = "task:send_dispute_email"
+
+	TaskExpireApprovalRequest = "task:expire_approval_request"
 )
 
 type PayloadSendAccountCreatedEmail struct {
-	Username  string `json:"username"`
-	AccountID string `json:"account_id"`
-	Balance   int64  `json:"balance"`
-	Currency  string `json:"currency"`
+	Username  string     `json:"username"`
+	AccountID string     `json:"account_id"`
+	Balance   util.Money `json:"balance"`
 }
 
 type PayloadSendVerifyEmail struct {
 	Username string `json:"username"`
 }
 
+type PayloadSendPasswordResetEmail struct {
+	Username string `json:"username"`
+}
+
+type PayloadSendLoginLockedEmail struct {
+	Username    string    `json:"username"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
 type PayloadSendBalanceAddedEmail struct {
-	Username     string `json:"username"`
-	AccountID    string `json:"account_id"`
-	AddedBalance int64  `json:"added_balance"`
-	Currency     string `json:"currency"`
-	NewBalance   int64  `json:"new_balance"`
+	Username     string     `json:"username"`
+	AccountID    string     `json:"account_id"`
+	AddedBalance util.Money `json:"added_balance"`
+	NewBalance   util.Money `json:"new_balance"`
+}
+
+type PayloadSendAccountInvitationEmail struct {
+	InvitationID int64 `json:"invitation_id"`
+}
+
+type PayloadSendAccountMemberRemovedEmail struct {
+	Username  string `json:"username"`
+	AccountID string `json:"account_id"`
+	RemovedBy string `json:"removed_by"`
+}
+
+type PayloadSendBeneficiaryAddedEmail struct {
+	Username         string     `json:"username"`
+	Nickname         string     `json:"nickname"`
+	AccountID        string     `json:"account_id"`
+	CoolingOffEndsAt time.Time  `json:"cooling_off_ends_at"`
+	CoolingOffLimit  util.Money `json:"cooling_off_limit"`
+}
+
+type PayloadSendPaymentRequestEmail struct {
+	PaymentRequestID int64 `json:"payment_request_id"`
+}
+
+type PayloadExpirePaymentRequest struct {
+	PaymentRequestID int64 `json:"payment_request_id"`
+}
+
+// PayloadDispatchWebhookEvent carries an event to every webhook subscribed to it
+// whose owner is a member of one of the accounts
+type PayloadDispatchWebhookEvent struct {
+	Event      webhook.Event `json:"event"`
+	AccountIDs []int64       `json:"account_ids"`
+}
+
+type PayloadDeliverWebhook struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+type PayloadRefreshSpendingSummaries struct{}
+
+type PayloadExecutePaymentBatch struct {
+	PaymentBatchID int64 `json:"payment_batch_id"`
+}
+
+type PayloadSendDisputeEmail struct {
+	DisputeEventID int64 `json:"dispute_event_id"`
+}
+
+type PayloadExpireApprovalRequest struct {
+	ApprovalRequestID int64 `json:"approval_request_id"`
 }